@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -33,6 +34,32 @@ const (
 	TargetTypeIP       TargetType = "ip"
 )
 
+// +kubebuilder:validation:Enum=Refuse;Truncate
+// MaxTargetsPolicy is the policy when the targets to register exceed maxTargets.
+//
+// * with `Refuse` policy, the targets won't be reconciled until they are within maxTargets
+// * with `Truncate` policy, only up to maxTargets targets are registered
+type MaxTargetsPolicy string
+
+const (
+	MaxTargetsPolicyRefuse   MaxTargetsPolicy = "Refuse"
+	MaxTargetsPolicyTruncate MaxTargetsPolicy = "Truncate"
+)
+
+// +kubebuilder:validation:Enum=ipv4;ipv6;dualstack
+// TargetGroupIPAddressType is the IP address type of your ELBV2 TargetGroup with ip TargetType.
+//
+// * with `ipv4` TargetGroupIPAddressType, the IPv4 address of Pods will be registered as targets
+// * with `ipv6` TargetGroupIPAddressType, the IPv6 address of Pods will be registered as targets
+// * with `dualstack` TargetGroupIPAddressType, both the IPv4 and IPv6 address of Pods will be registered as targets
+type TargetGroupIPAddressType string
+
+const (
+	TargetGroupIPAddressTypeIPV4      TargetGroupIPAddressType = "ipv4"
+	TargetGroupIPAddressTypeIPV6      TargetGroupIPAddressType = "ipv6"
+	TargetGroupIPAddressTypeDualStack TargetGroupIPAddressType = "dualstack"
+)
+
 // ServiceReference defines reference to a Kubernetes Service and its ServicePort.
 type ServiceReference struct {
 	// Name is the name of the Service.
@@ -112,6 +139,69 @@ type TargetGroupBindingNetworking struct {
 	Ingress []NetworkingIngressRule `json:"ingress,omitempty"`
 }
 
+// TargetGroupPortMapping maps a ServicePort to an additional TargetGroup.
+type TargetGroupPortMapping struct {
+	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
+	TargetGroupARN string `json:"targetGroupARN"`
+
+	// port is the port of the ServicePort.
+	Port intstr.IntOrString `json:"port"`
+}
+
+// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP
+// TargetGroupHealthCheckProtocol is the protocol used for health checks on targets.
+type TargetGroupHealthCheckProtocol string
+
+const (
+	TargetGroupHealthCheckProtocolHTTP  TargetGroupHealthCheckProtocol = "HTTP"
+	TargetGroupHealthCheckProtocolHTTPS TargetGroupHealthCheckProtocol = "HTTPS"
+	TargetGroupHealthCheckProtocolTCP   TargetGroupHealthCheckProtocol = "TCP"
+)
+
+// TargetGroupHealthCheck defines the health check configuration of TargetGroup.
+// Fields that are unspecified are left untouched on the TargetGroup.
+type TargetGroupHealthCheck struct {
+	// port is the port used for health checks on targets, either numerical port or "traffic-port".
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+
+	// protocol is the protocol used for health checks on targets.
+	// +optional
+	Protocol *TargetGroupHealthCheckProtocol `json:"protocol,omitempty"`
+
+	// path is the destination path on targets for HTTP/HTTPS health checks.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// httpCode is the HTTP codes indicating a successful response for HTTP/HTTPS health checks, e.g. "200" or "200-399".
+	// +optional
+	HTTPCode *string `json:"httpCode,omitempty"`
+
+	// intervalSeconds is the approximate amount of time between health checks of an individual target.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty"`
+
+	// timeoutSeconds is the amount of time during which no response from a target means a failed health check.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// healthyThresholdCount is the number of consecutive successful health checks before an unhealthy target is considered healthy.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	HealthyThresholdCount *int64 `json:"healthyThresholdCount,omitempty"`
+
+	// unhealthyThresholdCount is the number of consecutive failed health checks before a target is considered unhealthy.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	UnhealthyThresholdCount *int64 `json:"unhealthyThresholdCount,omitempty"`
+}
+
 // TargetGroupBindingSpec defines the desired state of TargetGroupBinding
 type TargetGroupBindingSpec struct {
 	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
@@ -131,6 +221,94 @@ type TargetGroupBindingSpec struct {
 	// node selector for instance type target groups to only register certain nodes
 	// +optional
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// podSelector for ip type target groups to only register certain pods, in addition to the selector of the Service.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// targetPort overrides the port registered for ip type target groups, instead of the targetPort of the ServicePort.
+	// It can be either numerical or named container port on pods.
+	// +optional
+	TargetPort *intstr.IntOrString `json:"targetPort,omitempty"`
+
+	// podReadinessConditionType is the pod condition type gating the registration of pods for ip type target groups.
+	// Pods are registered once this condition is True instead of once they are Ready. If unspecified, it defaults to Ready.
+	// +optional
+	PodReadinessConditionType *corev1.PodConditionType `json:"podReadinessConditionType,omitempty"`
+
+	// ipAddressType is the IP address type of TargetGroups with ip TargetType, which decides the IP family of Pod IPs registered.
+	// If unspecified, the IP of Pods from the Endpoints of Service is registered.
+	// +optional
+	IPAddressType *TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
+	// additionalTargetGroups maps additional ServicePorts of the Service to additional TargetGroups.
+	// The targetHealth pod condition only reflects the targets within the TargetGroup of targetGroupARN.
+	// +optional
+	AdditionalTargetGroups []TargetGroupPortMapping `json:"additionalTargetGroups,omitempty"`
+
+	// maxTargets limits the number of targets registered into each TargetGroup.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTargets *int64 `json:"maxTargets,omitempty"`
+
+	// maxTargetsPolicy is the policy when the targets to register exceed maxTargets. If unspecified, it defaults to Refuse.
+	// +optional
+	MaxTargetsPolicy *MaxTargetsPolicy `json:"maxTargetsPolicy,omitempty"`
+
+	// deregistrationRateLimit limits the number of targets deregistered from each TargetGroup per reconcile,
+	// the remaining targets are deregistered in following reconciles to smooth connection draining.
+	// It doesn't apply when TargetGroupBinding is deleted. If unspecified, targets are deregistered without limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DeregistrationRateLimit *int64 `json:"deregistrationRateLimit,omitempty"`
+
+	// healthCheckReconcile specifies whether the health check configuration of TargetGroups is reconciled to healthCheck.
+	// If unspecified, it defaults to false and the health check configuration of TargetGroups is left untouched.
+	// +optional
+	HealthCheckReconcile *bool `json:"healthCheckReconcile,omitempty"`
+
+	// healthCheck is the health check configuration of TargetGroups, including additionalTargetGroups.
+	// It's only applied when healthCheckReconcile is true.
+	// +optional
+	HealthCheck *TargetGroupHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// ZoneEndpoints defines the number of endpoints within an availability zone.
+type ZoneEndpoints struct {
+	// zone is the availability zone of endpoints, empty if the zone cannot be determined.
+	Zone string `json:"zone"`
+
+	// count is the number of endpoints within the availability zone.
+	Count int32 `json:"count"`
+}
+
+// TargetGroupBindingConditionType is the type of TargetGroupBinding condition.
+type TargetGroupBindingConditionType string
+
+const (
+	// TargetGroupBindingConditionMaxTargetsExceeded indicates whether the targets to register exceed maxTargets.
+	TargetGroupBindingConditionMaxTargetsExceeded TargetGroupBindingConditionType = "MaxTargetsExceeded"
+)
+
+// TargetGroupBindingCondition describes the state of TargetGroupBinding at a certain point.
+type TargetGroupBindingCondition struct {
+	// type of the condition.
+	Type TargetGroupBindingConditionType `json:"type"`
+
+	// status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// reason is a brief machine readable explanation for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// message is a human readable description of the condition's last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// lastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // TargetGroupBindingStatus defines the observed state of TargetGroupBinding
@@ -138,6 +316,15 @@ type TargetGroupBindingStatus struct {
 	// The generation observed by the TargetGroupBinding controller.
 	// +optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// zoneEndpoints is the distribution of endpoints across availability zones.
+	// It's only populated when the experimental endpoint zone status feature is enabled.
+	// +optional
+	ZoneEndpoints []ZoneEndpoints `json:"zoneEndpoints,omitempty"`
+
+	// conditions are the current conditions of TargetGroupBinding.
+	// +optional
+	Conditions []TargetGroupBindingCondition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -286,6 +287,25 @@ func (in *TargetGroupBinding) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupBindingCondition) DeepCopyInto(out *TargetGroupBindingCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingCondition.
+func (in *TargetGroupBindingCondition) DeepCopy() *TargetGroupBindingCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupBindingCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupBindingList) DeepCopyInto(out *TargetGroupBindingList) {
 	*out = *in
@@ -359,6 +379,56 @@ func (in *TargetGroupBindingSpec) DeepCopyInto(out *TargetGroupBindingSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetPort != nil {
+		in, out := &in.TargetPort, &out.TargetPort
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PodReadinessConditionType != nil {
+		in, out := &in.PodReadinessConditionType, &out.PodReadinessConditionType
+		*out = new(corev1.PodConditionType)
+		**out = **in
+	}
+	if in.IPAddressType != nil {
+		in, out := &in.IPAddressType, &out.IPAddressType
+		*out = new(TargetGroupIPAddressType)
+		**out = **in
+	}
+	if in.AdditionalTargetGroups != nil {
+		in, out := &in.AdditionalTargetGroups, &out.AdditionalTargetGroups
+		*out = make([]TargetGroupPortMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxTargets != nil {
+		in, out := &in.MaxTargets, &out.MaxTargets
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTargetsPolicy != nil {
+		in, out := &in.MaxTargetsPolicy, &out.MaxTargetsPolicy
+		*out = new(MaxTargetsPolicy)
+		**out = **in
+	}
+	if in.DeregistrationRateLimit != nil {
+		in, out := &in.DeregistrationRateLimit, &out.DeregistrationRateLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthCheckReconcile != nil {
+		in, out := &in.HealthCheckReconcile, &out.HealthCheckReconcile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(TargetGroupHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingSpec.
@@ -379,6 +449,18 @@ func (in *TargetGroupBindingStatus) DeepCopyInto(out *TargetGroupBindingStatus)
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ZoneEndpoints != nil {
+		in, out := &in.ZoneEndpoints, &out.ZoneEndpoints
+		*out = make([]ZoneEndpoints, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TargetGroupBindingCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingStatus.
@@ -390,3 +472,89 @@ func (in *TargetGroupBindingStatus) DeepCopy() *TargetGroupBindingStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupHealthCheck) DeepCopyInto(out *TargetGroupHealthCheck) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(TargetGroupHealthCheckProtocol)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPCode != nil {
+		in, out := &in.HTTPCode, &out.HTTPCode
+		*out = new(string)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthyThresholdCount != nil {
+		in, out := &in.HealthyThresholdCount, &out.HealthyThresholdCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnhealthyThresholdCount != nil {
+		in, out := &in.UnhealthyThresholdCount, &out.UnhealthyThresholdCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupHealthCheck.
+func (in *TargetGroupHealthCheck) DeepCopy() *TargetGroupHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupPortMapping) DeepCopyInto(out *TargetGroupPortMapping) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupPortMapping.
+func (in *TargetGroupPortMapping) DeepCopy() *TargetGroupPortMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupPortMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneEndpoints) DeepCopyInto(out *ZoneEndpoints) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneEndpoints.
+func (in *ZoneEndpoints) DeepCopy() *ZoneEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
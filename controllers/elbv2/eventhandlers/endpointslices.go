@@ -0,0 +1,91 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	discovery "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForEndpointSlicesEvent constructs new enqueueRequestsForEndpointSlicesEvent.
+func NewEnqueueRequestsForEndpointSlicesEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForEndpointSlicesEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForEndpointSlicesEvent)(nil)
+
+type enqueueRequestsForEndpointSlicesEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *enqueueRequestsForEndpointSlicesEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	epsliceNew := e.Object.(*discovery.EndpointSlice)
+	h.enqueueImpactedTargetGroupBindings(queue, epsliceNew)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *enqueueRequestsForEndpointSlicesEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	epsliceOld := e.ObjectOld.(*discovery.EndpointSlice)
+	epsliceNew := e.ObjectNew.(*discovery.EndpointSlice)
+	if !equality.Semantic.DeepEqual(epsliceOld.Endpoints, epsliceNew.Endpoints) ||
+		!equality.Semantic.DeepEqual(epsliceOld.Ports, epsliceNew.Ports) {
+		h.enqueueImpactedTargetGroupBindings(queue, epsliceNew)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *enqueueRequestsForEndpointSlicesEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	epsliceOld := e.Object.(*discovery.EndpointSlice)
+	h.enqueueImpactedTargetGroupBindings(queue, epsliceOld)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForEndpointSlicesEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForEndpointSlicesEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, epslice *discovery.EndpointSlice) {
+	svcName, ok := epslice.Labels[discovery.LabelServiceName]
+	if !ok || svcName == "" {
+		return
+	}
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := h.k8sClient.List(context.Background(), tgbList,
+		client.InNamespace(epslice.Namespace),
+		client.MatchingFields{targetgroupbinding.IndexKeyServiceRefName: svcName}); err != nil {
+		h.logger.Error(err, "failed to fetch targetGroupBindings")
+		return
+	}
+
+	epsliceKey := k8s.NamespacedName(epslice)
+	for _, tgb := range tgbList.Items {
+		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+			continue
+		}
+
+		h.logger.V(1).Info("enqueue targetGroupBinding for endpointSlice event",
+			"endpointSlice", epsliceKey,
+			"targetGroupBinding", k8s.NamespacedName(&tgb),
+		)
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: tgb.Namespace,
+				Name:      tgb.Name,
+			},
+		})
+	}
+}
@@ -18,16 +18,18 @@ import (
 )
 
 // NewEnqueueRequestsForNodeEvent constructs new enqueueRequestsForNodeEvent.
-func NewEnqueueRequestsForNodeEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+func NewEnqueueRequestsForNodeEvent(k8sClient client.Client, nodeDrainConditions k8s.NodeDrainConditions, logger logr.Logger) handler.EventHandler {
 	return &enqueueRequestsForNodeEvent{
-		k8sClient: k8sClient,
-		logger:    logger,
+		k8sClient:           k8sClient,
+		nodeDrainConditions: nodeDrainConditions,
+		logger:              logger,
 	}
 }
 
 type enqueueRequestsForNodeEvent struct {
-	k8sClient client.Client
-	logger    logr.Logger
+	k8sClient           client.Client
+	nodeDrainConditions k8s.NodeDrainConditions
+	logger              logr.Logger
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -62,11 +64,11 @@ func (h *enqueueRequestsForNodeEvent) enqueueImpactedTargetGroupBindings(queue w
 	nodeNewIsReady := false
 	if nodeOld != nil {
 		nodeKey = k8s.NamespacedName(nodeOld)
-		nodeOldIsReady = k8s.IsNodeSuitableAsTrafficProxy(nodeOld)
+		nodeOldIsReady = k8s.IsNodeSuitableAsTrafficProxy(nodeOld) && !k8s.IsNodeDraining(nodeOld, h.nodeDrainConditions)
 	}
 	if nodeNew != nil {
 		nodeKey = k8s.NamespacedName(nodeNew)
-		nodeNewIsReady = k8s.IsNodeSuitableAsTrafficProxy(nodeNew)
+		nodeNewIsReady = k8s.IsNodeSuitableAsTrafficProxy(nodeNew) && !k8s.IsNodeDraining(nodeNew, h.nodeDrainConditions)
 	}
 
 	tgbList := &elbv2api.TargetGroupBindingList{}
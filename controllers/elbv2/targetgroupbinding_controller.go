@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
@@ -30,6 +31,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,35 +41,50 @@ import (
 )
 
 const (
-	targetGroupBindingFinalizer = "elbv2.k8s.aws/resources"
-	controllerName              = "targetGroupBinding"
+	controllerName = "targetGroupBinding"
 )
 
 // NewTargetGroupBindingReconciler constructs new targetGroupBindingReconciler
 func NewTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, finalizerManager k8s.FinalizerManager,
-	tgbResourceManager targetgroupbinding.ResourceManager, config config.ControllerConfig,
-	logger logr.Logger) *targetGroupBindingReconciler {
+	tgbResourceManager targetgroupbinding.ResourceManager, targetHealthMetricsCollector targetgroupbinding.TargetHealthMetricsCollector,
+	useEndpointSlices bool, config config.ControllerConfig, logger logr.Logger) *targetGroupBindingReconciler {
 
 	return &targetGroupBindingReconciler{
-		k8sClient:          k8sClient,
-		eventRecorder:      eventRecorder,
-		finalizerManager:   finalizerManager,
-		tgbResourceManager: tgbResourceManager,
-		logger:             logger,
-
-		maxConcurrentReconciles: config.TargetGroupBindingMaxConcurrentReconciles,
+		k8sClient:                    k8sClient,
+		eventRecorder:                eventRecorder,
+		finalizerManager:             finalizerManager,
+		tgbResourceManager:           tgbResourceManager,
+		targetHealthMetricsCollector: targetHealthMetricsCollector,
+		logger:                       logger,
+
+		useEndpointSlices:                     useEndpointSlices,
+		finalizer:                             config.TargetGroupBindingFinalizer,
+		nodeDrainConditions:                   config.NodeDrainConditions(),
+		maxConcurrentReconciles:               config.TargetGroupBindingMaxConcurrentReconciles,
+		reconcileMaxBackoff:                   config.RuntimeConfig.ReconcileMaxBackoff,
+		reconcileTerminalErrorRequeueInterval: config.RuntimeConfig.ReconcileTerminalErrorRequeueInterval,
+		reconcileTimeout:                      config.RuntimeConfig.ReconcileTimeout,
+		targetHealthPollInterval:              config.TargetGroupBindingTargetHealthPollInterval,
 	}
 }
 
 // targetGroupBindingReconciler reconciles a TargetGroupBinding object
 type targetGroupBindingReconciler struct {
-	k8sClient          client.Client
-	eventRecorder      record.EventRecorder
-	finalizerManager   k8s.FinalizerManager
-	tgbResourceManager targetgroupbinding.ResourceManager
-	logger             logr.Logger
-
-	maxConcurrentReconciles int
+	k8sClient                    client.Client
+	eventRecorder                record.EventRecorder
+	finalizerManager             k8s.FinalizerManager
+	tgbResourceManager           targetgroupbinding.ResourceManager
+	targetHealthMetricsCollector targetgroupbinding.TargetHealthMetricsCollector
+	logger                       logr.Logger
+
+	useEndpointSlices                     bool
+	finalizer                             string
+	nodeDrainConditions                   k8s.NodeDrainConditions
+	maxConcurrentReconciles               int
+	reconcileMaxBackoff                   time.Duration
+	reconcileTerminalErrorRequeueInterval time.Duration
+	reconcileTimeout                      time.Duration
+	targetHealthPollInterval              time.Duration
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=get;list;watch;update;patch;create;delete
@@ -76,16 +93,19 @@ type targetGroupBindingReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *targetGroupBindingReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	err := runtime.ReconcileWithTimeout(r.reconcileTimeout, func(ctx context.Context) error {
+		return r.reconcile(ctx, req)
+	})
+	return runtime.HandleReconcileError(err, r.reconcileTerminalErrorRequeueInterval, r.logger)
 }
 
-func (r *targetGroupBindingReconciler) reconcile(req ctrl.Request) error {
-	ctx := context.Background()
+func (r *targetGroupBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	tgb := &elbv2api.TargetGroupBinding{}
 	if err := r.k8sClient.Get(ctx, req.NamespacedName, tgb); err != nil {
 		return client.IgnoreNotFound(err)
@@ -98,33 +118,52 @@ func (r *targetGroupBindingReconciler) reconcile(req ctrl.Request) error {
 }
 
 func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	if err := r.finalizerManager.AddFinalizers(ctx, tgb, targetGroupBindingFinalizer); err != nil {
+	if err := r.finalizerManager.AddFinalizers(ctx, tgb, r.finalizer); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
-	if err := r.tgbResourceManager.Reconcile(ctx, tgb); err != nil {
+	err := r.tgbResourceManager.Reconcile(ctx, tgb)
+	// target health metrics are observed regardless of reconcile result, so that they don't go stale while reconcile is requeued or failing.
+	if r.targetHealthPollInterval > 0 {
+		if err := r.targetHealthMetricsCollector.Observe(ctx, tgb); err != nil {
+			r.logger.Error(err, "failed to observe target health metrics", "targetGroupBinding", k8s.NamespacedName(tgb))
+		}
+	}
+	if err != nil {
 		return err
 	}
+	generationChanged := aws.Int64Value(tgb.Status.ObservedGeneration) != tgb.Generation
 	if err := r.updateTargetGroupBindingStatus(ctx, tgb); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
 
+	if r.targetHealthPollInterval > 0 {
+		// with target health polling, only reconciles of new generations are reported to avoid recording an event on every poll.
+		if generationChanged {
+			r.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
+		}
+		return runtime.NewRequeueNeededAfter("poll target health", r.targetHealthPollInterval)
+	}
 	r.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
 	return nil
 }
 
 func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	if k8s.HasFinalizer(tgb, targetGroupBindingFinalizer) {
+	if k8s.HasFinalizer(tgb, r.finalizer) {
 		if err := r.tgbResourceManager.Cleanup(ctx, tgb); err != nil {
-			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+			// cleanup waiting for other TargetGroupBindings is requeued without being reported as failure.
+			if !runtime.IsRequeueNeeded(err) {
+				r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+			}
 			return err
 		}
-		if err := r.finalizerManager.RemoveFinalizers(ctx, tgb, targetGroupBindingFinalizer); err != nil {
+		if err := r.finalizerManager.RemoveFinalizers(ctx, tgb, r.finalizer); err != nil {
 			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
 			return err
 		}
 	}
+	r.targetHealthMetricsCollector.Forget(k8s.NamespacedName(tgb))
 	return nil
 }
 
@@ -147,17 +186,27 @@ func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr
 
 	svcEventHandler := eventhandlers.NewEnqueueRequestsForServiceEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("service"))
+	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient, r.nodeDrainConditions,
+		r.logger.WithName("eventHandlers").WithName("node"))
 	epsEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("endpoints"))
-	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient,
-		r.logger.WithName("eventHandlers").WithName("node"))
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&elbv2api.TargetGroupBinding{}).
 		Named(controllerName).
 		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler).
+		// Endpoints are watched even if EndpointSlices are used, since services without any EndpointSlice fall back to their Endpoints.
 		Watches(&source.Kind{Type: &corev1.Endpoints{}}, epsEventsHandler).
-		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
-		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles}).
+		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler)
+	if r.useEndpointSlices {
+		epslicesEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointSlicesEvent(r.k8sClient,
+			r.logger.WithName("eventHandlers").WithName("endpointSlices"))
+		builder = builder.Watches(&source.Kind{Type: &discovery.EndpointSlice{}}, epslicesEventsHandler)
+	}
+	return builder.
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.maxConcurrentReconciles,
+			RateLimiter:             runtime.NewReconcileRateLimiter(r.reconcileMaxBackoff),
+		}).
 		Complete(r)
 }
 
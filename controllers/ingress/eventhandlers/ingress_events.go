@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -33,7 +34,7 @@ type enqueueRequestsForIngressEvent struct {
 }
 
 func (h *enqueueRequestsForIngressEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueIfBelongsToGroup(queue, e.Object.(*networking.Ingress))
+	h.enqueueIfBelongsToGroup(queue, e.Object.(*networking.Ingress), true)
 }
 
 func (h *enqueueRequestsForIngressEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
@@ -50,7 +51,7 @@ func (h *enqueueRequestsForIngressEvent) Update(e event.UpdateEvent, queue workq
 		return
 	}
 
-	h.enqueueIfBelongsToGroup(queue, ingNew)
+	h.enqueueIfBelongsToGroup(queue, ingNew, true)
 }
 
 func (h *enqueueRequestsForIngressEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
@@ -61,10 +62,12 @@ func (h *enqueueRequestsForIngressEvent) Delete(e event.DeleteEvent, queue workq
 }
 
 func (h *enqueueRequestsForIngressEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueIfBelongsToGroup(queue, e.Object.(*networking.Ingress))
+	h.enqueueIfBelongsToGroup(queue, e.Object.(*networking.Ingress), false)
 }
 
-func (h *enqueueRequestsForIngressEvent) enqueueIfBelongsToGroup(queue workqueue.RateLimitingInterface, ing *networking.Ingress) {
+// enqueueIfBelongsToGroup enqueues the IngressGroups of ingress.
+// Ingresses ignored due to missing explicit opt-in are notified via event only if notifySkipped is set, i.e. when the Ingress itself changed.
+func (h *enqueueRequestsForIngressEvent) enqueueIfBelongsToGroup(queue workqueue.RateLimitingInterface, ing *networking.Ingress, notifySkipped bool) {
 	ctx := context.Background()
 	ingKey := k8s.NamespacedName(ing)
 	groupIDsSet := make(map[ingress.GroupID]struct{})
@@ -75,7 +78,14 @@ func (h *enqueueRequestsForIngressEvent) enqueueIfBelongsToGroup(queue workqueue
 	}
 
 	if groupID, err := h.groupLoader.LoadGroupIDIfAny(ctx, ing); err != nil {
-		h.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonFailedLoadGroupID, fmt.Sprintf("failed load groupID due to %v", err))
+		if errors.Is(err, ingress.ErrMissingExplicitOptIn) {
+			if notifySkipped {
+				h.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonSkippedWithoutOptIn,
+					fmt.Sprintf("ingress is ignored since it's not opted in via annotation %v", k8s.AnnotationExplicitOptIn))
+			}
+		} else {
+			h.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonFailedLoadGroupID, fmt.Sprintf("failed load groupID due to %v", err))
+		}
 	} else if groupID != nil {
 		groupIDsSet[*groupID] = struct{}{}
 	}
@@ -25,36 +25,52 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 const (
 	ingressTagPrefix = "ingress.k8s.aws"
 	controllerName   = "ingress"
+
+	// annotation on Ingress to expose the canonical hosted zone ID of LoadBalancer, e.g. for alias records.
+	annotationLoadBalancerHostedZoneID = "ingress.k8s.aws/load-balancer-hosted-zone-id"
 )
 
 // NewGroupReconciler constructs new GroupReconciler
 func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networkingpkg.SecurityGroupManager,
 	networkingSGReconciler networkingpkg.SecurityGroupReconciler, subnetsResolver networkingpkg.SubnetsResolver,
+	sslPolicyValidator networkingpkg.SSLPolicyValidator, stackMetricsCollector deploy.StackMetricsCollector,
 	config config.ControllerConfig, logger logr.Logger) *groupReconciler {
 
 	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
-	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser)
+	userPoolDomainResolver := ingress.NewCognitoUserPoolDomainResolver(cloud.CognitoIDP(), logger)
+	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser, userPoolDomainResolver)
 	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(annotationParser)
-	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, logger)
+	// the reference indexer only needs secret references, so it won't resolve user pool domains.
+	referenceAuthConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser, nil)
+	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, referenceAuthConfigBuilder, logger)
 	modelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
 		cloud.EC2(), cloud.ACM(),
-		annotationParser, subnetsResolver,
+		annotationParser, subnetsResolver, sslPolicyValidator,
 		authConfigBuilder, enhancedBackendBuilder,
 		cloud.VpcID(), config.ClusterName, config.DefaultTags,
-		config.DefaultSSLPolicy, logger)
+		config.DefaultSSLPolicy, elbv2model.TargetType(config.DefaultTargetType), config.ResourceNamespaceTagKey, config.ResourceNameTagKey,
+		config.IngressConfig.LoadBalancerAttributesMergeStrategy, config.IngressConfig.ManageBackendSecurityGroupRules,
+		config.IngressConfig.RejectListenersWithoutRules, config.IngressConfig.SkipListenersWithoutRules,
+		config.IngressConfig.DefaultCertificateStrategy, config.IngressConfig.MissingBackendServicePolicy, logger)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler,
 		config, ingressTagPrefix, logger)
 	classLoader := ingress.NewDefaultClassLoader(k8sClient)
 	classAnnotationMatcher := ingress.NewDefaultClassAnnotationMatcher(config.IngressConfig.IngressClass)
-	manageIngressesWithoutIngressClass := config.IngressConfig.IngressClass == ""
-	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, classLoader, classAnnotationMatcher, manageIngressesWithoutIngressClass)
+	ignoreIngressClassAnnotation := config.IngressConfig.StrictIngressClass
+	manageIngressesWithoutIngressClass := config.IngressConfig.IngressClass == "" && !config.IngressConfig.StrictIngressClass
+	rejectCrossNamespaceGroups := len(config.RuntimeConfig.WatchNamespaces) != 0
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, classLoader, classAnnotationMatcher,
+		ignoreIngressClassAnnotation, manageIngressesWithoutIngressClass, rejectCrossNamespaceGroups, config.IngressConfig.AllowCrossNamespaceIngressGroups,
+		config.IngressConfig.GroupAllowedNamespaces(),
+		config.RequireExplicitOptIn)
 	groupFinalizerManager := ingress.NewDefaultFinalizerManager(finalizerManager)
 
 	return &groupReconciler{
@@ -65,11 +81,17 @@ func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder
 		stackMarshaller:  stackMarshaller,
 		stackDeployer:    stackDeployer,
 
+		stackMetricsCollector: stackMetricsCollector,
 		groupLoader:           groupLoader,
 		groupFinalizerManager: groupFinalizerManager,
+		suspensionTracker:     k8s.NewDefaultSuspensionTracker(),
 		logger:                logger,
+		requireExplicitOptIn:  config.RequireExplicitOptIn,
 
-		maxConcurrentReconciles: config.IngressConfig.MaxConcurrentReconciles,
+		maxConcurrentReconciles:               config.IngressConfig.MaxConcurrentReconciles,
+		reconcileMaxBackoff:                   config.RuntimeConfig.ReconcileMaxBackoff,
+		reconcileTerminalErrorRequeueInterval: config.RuntimeConfig.ReconcileTerminalErrorRequeueInterval,
+		reconcileTimeout:                      config.RuntimeConfig.ReconcileTimeout,
 	}
 }
 
@@ -82,11 +104,17 @@ type groupReconciler struct {
 	stackMarshaller  deploy.StackMarshaller
 	stackDeployer    deploy.StackDeployer
 
+	stackMetricsCollector deploy.StackMetricsCollector
 	groupLoader           ingress.GroupLoader
 	groupFinalizerManager ingress.FinalizerManager
+	suspensionTracker     k8s.SuspensionTracker
 	logger                logr.Logger
+	requireExplicitOptIn  bool
 
-	maxConcurrentReconciles int
+	maxConcurrentReconciles               int
+	reconcileMaxBackoff                   time.Duration
+	reconcileTerminalErrorRequeueInterval time.Duration
+	reconcileTimeout                      time.Duration
 }
 
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
@@ -100,39 +128,66 @@ type groupReconciler struct {
 
 // Reconcile
 func (r *groupReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	err := runtime.ReconcileWithTimeout(r.reconcileTimeout, func(ctx context.Context) error {
+		return r.reconcile(ctx, req)
+	})
+	return runtime.HandleReconcileError(err, r.reconcileTerminalErrorRequeueInterval, r.logger)
 }
 
-func (r *groupReconciler) reconcile(req ctrl.Request) error {
-	ctx := context.Background()
+func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
 	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
 	if err != nil {
 		return err
 	}
+	// AWS resources are shared by the whole IngressGroup, so it's suspended if any of its Ingresses are suspended.
+	// the suspension is only reported once suspended, rather than on every reconcile.
+	if r.isIngressGroupSuspended(ingGroup) {
+		if r.suspensionTracker.MarkSuspended(ingGroupID.String()) {
+			r.recordIngressGroupProgressEvent(ctx, ingGroup, k8s.IngressEventReasonSuspended,
+				fmt.Sprintf("Reconcile suspended by annotation %v", k8s.AnnotationSuspend))
+		}
+		r.logger.Info("skipping reconcile of suspended ingressGroup", "ingressGroup", ingGroupID)
+		return nil
+	}
+	r.suspensionTracker.MarkResumed(ingGroupID.String())
+	// Ingresses managed before explicit opt-in is required are kept in IngressGroup, so the IngressGroup is skipped
+	// until they're opted in, rather than deleting their resources.
+	if membersWithoutOptIn := r.findMembersWithoutOptIn(ingGroup); len(membersWithoutOptIn) > 0 {
+		for _, ing := range membersWithoutOptIn {
+			r.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonSkippedWithoutOptIn,
+				fmt.Sprintf("ingressGroup is ignored since ingress is not opted in via annotation %v", k8s.AnnotationExplicitOptIn))
+		}
+		r.logger.Info("skipping reconcile of ingressGroup with ingresses not opted in", "ingressGroup", ingGroupID)
+		return nil
+	}
 
 	if err := r.groupFinalizerManager.AddGroupFinalizer(ctx, ingGroupID, ingGroup.Members); err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
 
-	_, lb, err := r.buildAndDeployModel(ctx, ingGroup)
-	if err != nil {
-		return err
-	}
-
-	if len(ingGroup.Members) > 0 && lb != nil {
-		lbDNS, err := lb.DNSName().Resolve(ctx)
-		if err != nil {
-			return err
-		}
-		if err := r.updateIngressGroupStatus(ctx, ingGroup, lbDNS); err != nil {
+	stack, lb, deployErr := r.buildAndDeployModel(ctx, ingGroup)
+	// LoadBalancer status is fulfilled from the create response, so Ingress status is updated as soon as the LoadBalancer exists,
+	// even if the deployment of other resources failed.
+	if len(ingGroup.Members) > 0 && lb != nil && (deployErr == nil || lb.Status != nil) {
+		if err := r.updateIngressGroupStatus(ctx, ingGroup, lb); err != nil {
 			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
-			return err
+			if deployErr == nil {
+				return err
+			}
 		}
 	}
+	if deployErr != nil {
+		return deployErr
+	}
+	r.recordRecreatedTargetGroupBindingEvents(ctx, ingGroup, stack)
+	r.observeStackMetrics(ingGroup, stack)
 
 	if len(ingGroup.InactiveMembers) > 0 {
+		if err := r.cleanupIngressHostedZoneIDs(ctx, ingGroup.InactiveMembers); err != nil {
+			return err
+		}
 		if err := r.groupFinalizerManager.RemoveGroupFinalizer(ctx, ingGroupID, ingGroup.InactiveMembers); err != nil {
 			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
 			return err
@@ -157,29 +212,153 @@ func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingr
 	r.logger.Info("successfully built model", "model", stackJSON)
 
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
+		if reason, ok := runtime.RequeueNeededReason(err); ok {
+			// AWS resources pending deletion or traffic switching are retried in later reconciles, the group finalizer is kept until then.
+			// the LoadBalancer is still returned so that Ingress status is updated, e.g. replaced LoadBalancers are only deleted after that.
+			r.logger.Info("deploy in progress", "ingressGroup", ingGroup.ID, "reason", reason)
+			r.recordIngressGroupProgressEvent(ctx, ingGroup, k8s.IngressEventReasonDeployInProgress, fmt.Sprintf("Deploy in progress: %v", reason))
+			return stack, lb, err
+		}
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
-		return nil, nil, err
+		return stack, lb, err
 	}
 	r.logger.Info("successfully deployed model", "ingressGroup", ingGroup.ID)
 	return stack, lb, err
 }
 
+// isIngressGroupSuspended checks whether any active or inactive member of IngressGroup is suspended.
+func (r *groupReconciler) isIngressGroupSuspended(ingGroup ingress.Group) bool {
+	for _, member := range ingGroup.Members {
+		if k8s.IsReconcileSuspended(member.Ing) {
+			return true
+		}
+	}
+	for _, inactiveMember := range ingGroup.InactiveMembers {
+		if k8s.IsReconcileSuspended(inactiveMember) {
+			return true
+		}
+	}
+	return false
+}
+
+// findMembersWithoutOptIn returns the active members of IngressGroup that are not explicitly opted in while explicit opt-in is required.
+func (r *groupReconciler) findMembersWithoutOptIn(ingGroup ingress.Group) []*networking.Ingress {
+	if !r.requireExplicitOptIn {
+		return nil
+	}
+	var membersWithoutOptIn []*networking.Ingress
+	for _, member := range ingGroup.Members {
+		if !k8s.HasExplicitOptIn(member.Ing) {
+			membersWithoutOptIn = append(membersWithoutOptIn, member.Ing)
+		}
+	}
+	return membersWithoutOptIn
+}
+
 func (r *groupReconciler) recordIngressGroupEvent(_ context.Context, ingGroup ingress.Group, eventType string, reason string, message string) {
 	for _, member := range ingGroup.Members {
 		r.eventRecorder.Event(member.Ing, eventType, reason, message)
 	}
 }
 
-func (r *groupReconciler) updateIngressGroupStatus(ctx context.Context, ingGroup ingress.Group, lbDNS string) error {
+// recordIngressGroupProgressEvent records event on both active and inactive members, so that Ingresses pending deletion can observe the progress.
+func (r *groupReconciler) recordIngressGroupProgressEvent(_ context.Context, ingGroup ingress.Group, reason string, message string) {
 	for _, member := range ingGroup.Members {
-		if err := r.updateIngressStatus(ctx, lbDNS, member.Ing); err != nil {
+		r.eventRecorder.Event(member.Ing, corev1.EventTypeNormal, reason, message)
+	}
+	for _, ing := range ingGroup.InactiveMembers {
+		r.eventRecorder.Event(ing, corev1.EventTypeNormal, reason, message)
+	}
+}
+
+// recordRecreatedTargetGroupBindingEvents records event for TargetGroupBindings recreated since they were missing, e.g. deleted manually.
+func (r *groupReconciler) recordRecreatedTargetGroupBindingEvents(ctx context.Context, ingGroup ingress.Group, stack core.Stack) {
+	var resTGBs []*elbv2model.TargetGroupBindingResource
+	stack.ListResources(&resTGBs)
+	for _, resTGB := range resTGBs {
+		if resTGB.Status == nil || !resTGB.Status.Recreated {
+			continue
+		}
+		tgbRef := resTGB.Status.TargetGroupBindingRef
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonTGBRecreated,
+			fmt.Sprintf("Recreated missing TargetGroupBinding %v/%v", tgbRef.Namespace, tgbRef.Name))
+	}
+}
+
+// observeStackMetrics records the count of AWS resources managed for IngressGroup, or forgets them once all its members are gone.
+func (r *groupReconciler) observeStackMetrics(ingGroup ingress.Group, stack core.Stack) {
+	if len(ingGroup.Members) == 0 {
+		r.stackMetricsCollector.Forget(controllerName, stack.StackID())
+		return
+	}
+	if err := r.stackMetricsCollector.Observe(controllerName, stack); err != nil {
+		r.logger.Error(err, "failed to observe stack metrics", "ingressGroup", ingGroup.ID)
+	}
+}
+
+func (r *groupReconciler) updateIngressGroupStatus(ctx context.Context, ingGroup ingress.Group, lb *elbv2model.LoadBalancer) error {
+	lbARN, err := lb.LoadBalancerARN().Resolve(ctx)
+	if err != nil {
+		return err
+	}
+	lbDNS, err := lb.DNSName().Resolve(ctx)
+	if err != nil {
+		return err
+	}
+	lbHostedZoneID, err := lb.CanonicalHostedZoneID().Resolve(ctx)
+	if err != nil {
+		return err
+	}
+	for _, member := range ingGroup.Members {
+		if err := r.updateIngressStatus(ctx, lbARN, lbDNS, member.Ing); err != nil {
+			return err
+		}
+		if err := r.updateIngressHostedZoneID(ctx, lbHostedZoneID, member.Ing); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbDNS string, ing *networking.Ingress) error {
+// updateIngressHostedZoneID records the LoadBalancer's canonical hosted zone ID as annotation,
+// since Ingress status doesn't have a field for it.
+func (r *groupReconciler) updateIngressHostedZoneID(ctx context.Context, lbHostedZoneID string, ing *networking.Ingress) error {
+	if lbHostedZoneID == "" || ing.Annotations[annotationLoadBalancerHostedZoneID] == lbHostedZoneID {
+		return nil
+	}
+	ingOld := ing.DeepCopy()
+	if ing.Annotations == nil {
+		ing.Annotations = make(map[string]string)
+	}
+	ing.Annotations[annotationLoadBalancerHostedZoneID] = lbHostedZoneID
+	if err := r.k8sClient.Patch(ctx, ing, client.MergeFrom(ingOld)); err != nil {
+		return errors.Wrapf(err, "failed to update ingress hosted zone ID: %v", k8s.NamespacedName(ing))
+	}
+	return nil
+}
+
+// cleanupIngressHostedZoneIDs removes the hosted zone ID annotation from Ingresses that no longer belong to the group,
+// so that stale alias records won't be created for them.
+func (r *groupReconciler) cleanupIngressHostedZoneIDs(ctx context.Context, ings []*networking.Ingress) error {
+	for _, ing := range ings {
+		if !ing.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if _, exists := ing.Annotations[annotationLoadBalancerHostedZoneID]; !exists {
+			continue
+		}
+		ingOld := ing.DeepCopy()
+		delete(ing.Annotations, annotationLoadBalancerHostedZoneID)
+		if err := r.k8sClient.Patch(ctx, ing, client.MergeFrom(ingOld)); err != nil {
+			return errors.Wrapf(err, "failed to remove ingress hosted zone ID: %v", k8s.NamespacedName(ing))
+		}
+	}
+	return nil
+}
+
+// updateIngressStatus updates the Ingress status with LoadBalancer's DNS name,
+// and records an event with the LoadBalancer's ARN once it's provisioned or changed for the Ingress.
+func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbARN string, lbDNS string, ing *networking.Ingress) error {
 	if len(ing.Status.LoadBalancer.Ingress) != 1 ||
 		ing.Status.LoadBalancer.Ingress[0].IP != "" ||
 		ing.Status.LoadBalancer.Ingress[0].Hostname != lbDNS {
@@ -192,6 +371,8 @@ func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbDNS string,
 		if err := r.k8sClient.Status().Patch(ctx, ing, client.MergeFrom(ingOld)); err != nil {
 			return errors.Wrapf(err, "failed to update ingress status: %v", k8s.NamespacedName(ing))
 		}
+		r.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonLBProvisioned,
+			fmt.Sprintf("Provisioned load balancer %v with DNS name %v", lbARN, lbDNS))
 	}
 	return nil
 }
@@ -199,6 +380,7 @@ func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbDNS string,
 func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
+		RateLimiter:             runtime.NewReconcileRateLimiter(r.reconcileMaxBackoff),
 		Reconciler:              r,
 	})
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	svcpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/service"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -15,10 +16,11 @@ import (
 )
 
 // NewEnqueueRequestForServiceEvent constructs new enqueueRequestsForServiceEvent.
-func NewEnqueueRequestForServiceEvent(eventRecorder record.EventRecorder, annotationParser annotations.Parser, logger logr.Logger) *enqueueRequestsForServiceEvent {
+func NewEnqueueRequestForServiceEvent(eventRecorder record.EventRecorder, annotationParser annotations.Parser, serviceFinalizer string, logger logr.Logger) *enqueueRequestsForServiceEvent {
 	return &enqueueRequestsForServiceEvent{
 		eventRecorder:    eventRecorder,
 		annotationParser: annotationParser,
+		serviceFinalizer: serviceFinalizer,
 		logger:           logger,
 	}
 }
@@ -28,6 +30,7 @@ var _ handler.EventHandler = (*enqueueRequestsForServiceEvent)(nil)
 type enqueueRequestsForServiceEvent struct {
 	eventRecorder    record.EventRecorder
 	annotationParser annotations.Parser
+	serviceFinalizer string
 	logger           logr.Logger
 }
 
@@ -57,24 +60,17 @@ func (h *enqueueRequestsForServiceEvent) Delete(e event.DeleteEvent, queue workq
 func (h *enqueueRequestsForServiceEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
 }
 
-func (h *enqueueRequestsForServiceEvent) isServiceSupported(service *corev1.Service) bool {
-	lbType := ""
-	_ = h.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, service.Annotations)
-	if lbType == svcpkg.LoadBalancerTypeNLBIP {
-		return true
-	}
-	var lbTargetType string
-	_ = h.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixTargetType, &lbTargetType, service.Annotations)
-	if lbType == svcpkg.LoadBalancerTypeExternal && (lbTargetType == svcpkg.LoadBalancerTargetTypeIP ||
-		lbTargetType == svcpkg.LoadBalancerTargetTypeInstance) {
-		return true
-	}
-	return false
-}
-
 func (h *enqueueRequestsForServiceEvent) enqueueManagedService(queue workqueue.RateLimitingInterface, service *corev1.Service) {
-	// Check if the svc needs to be handled
-	if !h.isServiceSupported(service) {
+	// Check if the svc needs to be handled, services that are no longer supported are enqueued if they hold our finalizer,
+	// so that reconcile can notify users and clean up on deletion.
+	// services not opted in are enqueued as well, so that reconcile can notify users why they're skipped.
+	if !svcpkg.IsServiceSupported(service, h.annotationParser) && !k8s.HasFinalizer(service, h.serviceFinalizer) {
+		// LoadBalancer services are left to the in-tree controller, other services are irrelevant to load balancers.
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			h.logger.Info("ignoring unsupported service", "service", k8s.NamespacedName(service))
+		} else {
+			h.logger.V(1).Info("ignoring unsupported service", "service", k8s.NamespacedName(service))
+		}
 		return
 	}
 	queue.Add(reconcile.Request{
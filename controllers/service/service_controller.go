@@ -6,6 +6,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/service/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
@@ -22,10 +23,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 const (
-	serviceFinalizer        = "service.k8s.aws/resources"
 	serviceTagPrefix        = "service.k8s.aws"
 	serviceAnnotationPrefix = "service.beta.kubernetes.io"
 	controllerName          = "service"
@@ -34,10 +35,12 @@ const (
 func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networking.SecurityGroupManager,
 	networkingSGReconciler networking.SecurityGroupReconciler, subnetsResolver networking.SubnetsResolver,
-	config config.ControllerConfig, logger logr.Logger) *serviceReconciler {
+	stackMetricsCollector deploy.StackMetricsCollector, config config.ControllerConfig, logger logr.Logger) *serviceReconciler {
 
 	annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
-	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, config.ClusterName, config.DefaultTags, config.DefaultSSLPolicy)
+	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, config.ClusterName, config.DefaultTags, config.DefaultSSLPolicy,
+		elbv2model.TargetType(config.DefaultTargetType), config.ResourceNamespaceTagKey, config.ResourceNameTagKey, config.RequireExplicitOptIn,
+		config.PreserveUnmanagedTargetGroupAttributes())
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler, config, serviceTagPrefix, logger)
 	return &serviceReconciler{
@@ -51,7 +54,15 @@ func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorde
 		stackDeployer:   stackDeployer,
 		logger:          logger,
 
-		maxConcurrentReconciles: config.ServiceMaxConcurrentReconciles,
+		stackMetricsCollector: stackMetricsCollector,
+		suspensionTracker:     k8s.NewDefaultSuspensionTracker(),
+
+		finalizer:                             config.ServiceFinalizer,
+		maxConcurrentReconciles:               config.ServiceMaxConcurrentReconciles,
+		reconcileMaxBackoff:                   config.RuntimeConfig.ReconcileMaxBackoff,
+		reconcileTerminalErrorRequeueInterval: config.RuntimeConfig.ReconcileTerminalErrorRequeueInterval,
+		reconcileTimeout:                      config.RuntimeConfig.ReconcileTimeout,
+		requireExplicitOptIn:                  config.RequireExplicitOptIn,
 	}
 }
 
@@ -66,7 +77,15 @@ type serviceReconciler struct {
 	stackDeployer   deploy.StackDeployer
 	logger          logr.Logger
 
-	maxConcurrentReconciles int
+	stackMetricsCollector deploy.StackMetricsCollector
+	suspensionTracker     k8s.SuspensionTracker
+
+	finalizer                             string
+	maxConcurrentReconciles               int
+	reconcileMaxBackoff                   time.Duration
+	reconcileTerminalErrorRequeueInterval time.Duration
+	reconcileTimeout                      time.Duration
+	requireExplicitOptIn                  bool
 }
 
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
@@ -74,18 +93,48 @@ type serviceReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *serviceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	err := runtime.ReconcileWithTimeout(r.reconcileTimeout, func(ctx context.Context) error {
+		return r.reconcile(ctx, req)
+	})
+	return runtime.HandleReconcileError(err, r.reconcileTerminalErrorRequeueInterval, r.logger)
 }
 
-func (r *serviceReconciler) reconcile(req ctrl.Request) error {
-	ctx := context.Background()
+func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	svc := &corev1.Service{}
 	if err := r.k8sClient.Get(ctx, req.NamespacedName, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.suspensionTracker.MarkResumed(req.NamespacedName.String())
+		}
 		return client.IgnoreNotFound(err)
 	}
+	// the suspension is only reported once suspended, rather than on every reconcile.
+	if k8s.IsReconcileSuspended(svc) {
+		if r.suspensionTracker.MarkSuspended(req.NamespacedName.String()) {
+			r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuspended, fmt.Sprintf("Reconcile suspended by annotation %v", k8s.AnnotationSuspend))
+		}
+		r.logger.Info("skipping reconcile of suspended service", "service", k8s.NamespacedName(svc))
+		return nil
+	}
+	r.suspensionTracker.MarkResumed(req.NamespacedName.String())
 	if !svc.DeletionTimestamp.IsZero() {
 		return r.cleanupLoadBalancerResources(ctx, svc)
 	}
+	// services no longer supported are skipped, the resources are kept and only cleaned up once the service is deleted.
+	if !service.IsServiceSupported(svc, r.annotationParser) {
+		if k8s.HasFinalizer(svc, r.finalizer) {
+			r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSkippedUnsupported,
+				"service is ignored since it's no longer supported, the load balancer is kept until the service is deleted")
+		}
+		r.logger.Info("skipping reconcile of unsupported service", "service", k8s.NamespacedName(svc))
+		return nil
+	}
+	// services not opted in are skipped while explicit opt-in is required, existing resources are kept until deletion.
+	if !service.IsServiceOptedIn(svc, r.requireExplicitOptIn) {
+		r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSkippedWithoutOptIn,
+			fmt.Sprintf("service is ignored since it's not opted in via annotation %v", k8s.AnnotationExplicitOptIn))
+		r.logger.Info("skipping reconcile of service not opted in", "service", k8s.NamespacedName(svc))
+		return nil
+	}
 	return r.reconcileLoadBalancerResources(ctx, svc)
 }
 
@@ -103,6 +152,11 @@ func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svc *corev1
 	r.logger.Info("successfully built model", "model", stackJSON)
 
 	if err = r.stackDeployer.Deploy(ctx, stack); err != nil {
+		if reason, ok := runtime.RequeueNeededReason(err); ok {
+			r.logger.Info("deploy in progress", "service", k8s.NamespacedName(svc), "reason", reason)
+			r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonDeployInProgress, fmt.Sprintf("Deploy in progress: %v", reason))
+			return stack, lb, err
+		}
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
 		return nil, nil, err
 	}
@@ -112,11 +166,24 @@ func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svc *corev1
 }
 
 func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context, svc *corev1.Service) error {
-	if err := r.finalizerManager.AddFinalizers(ctx, svc, serviceFinalizer); err != nil {
+	if err := r.finalizerManager.AddFinalizers(ctx, svc, r.finalizer); err != nil {
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
-	_, lb, err := r.buildAndDeployModel(ctx, svc)
+	stack, lb, err := r.buildAndDeployModel(ctx, svc)
+	if err != nil {
+		// Service status is updated while deploy is in progress, e.g. replaced LoadBalancers are only deleted after that.
+		if _, ok := runtime.RequeueNeededReason(err); ok && lb != nil && lb.Status != nil {
+			if err := r.updateServiceStatus(ctx, lb.Status.LoadBalancerARN, lb.Status.DNSName, svc); err != nil {
+				r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+			}
+		}
+		return err
+	}
+	if err := r.stackMetricsCollector.Observe(controllerName, stack); err != nil {
+		r.logger.Error(err, "failed to observe stack metrics", "service", k8s.NamespacedName(svc))
+	}
+	lbARN, err := lb.LoadBalancerARN().Resolve(ctx)
 	if err != nil {
 		return err
 	}
@@ -125,7 +192,7 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		return err
 	}
 
-	if err = r.updateServiceStatus(ctx, lbDNS, svc); err != nil {
+	if err = r.updateServiceStatus(ctx, lbARN, lbDNS, svc); err != nil {
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
@@ -134,12 +201,13 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 }
 
 func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, svc *corev1.Service) error {
-	if k8s.HasFinalizer(svc, serviceFinalizer) {
-		_, _, err := r.buildAndDeployModel(ctx, svc)
+	if k8s.HasFinalizer(svc, r.finalizer) {
+		stack, _, err := r.buildAndDeployModel(ctx, svc)
 		if err != nil {
 			return err
 		}
-		if err := r.finalizerManager.RemoveFinalizers(ctx, svc, serviceFinalizer); err != nil {
+		r.stackMetricsCollector.Forget(controllerName, stack.StackID())
+		if err := r.finalizerManager.RemoveFinalizers(ctx, svc, r.finalizer); err != nil {
 			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
 			return err
 		}
@@ -147,7 +215,9 @@ func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, sv
 	return nil
 }
 
-func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS string, svc *corev1.Service) error {
+// updateServiceStatus updates the Service status with LoadBalancer's DNS name,
+// and records an event with the LoadBalancer's ARN once it's provisioned or changed for the Service.
+func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbARN string, lbDNS string, svc *corev1.Service) error {
 	if len(svc.Status.LoadBalancer.Ingress) != 1 ||
 		svc.Status.LoadBalancer.Ingress[0].IP != "" ||
 		svc.Status.LoadBalancer.Ingress[0].Hostname != lbDNS {
@@ -160,6 +230,8 @@ func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS strin
 		if err := r.k8sClient.Status().Patch(ctx, svc, client.MergeFrom(svcOld)); err != nil {
 			return errors.Wrapf(err, "failed to update service status: %v", k8s.NamespacedName(svc))
 		}
+		r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonLBProvisioned,
+			fmt.Sprintf("Provisioned load balancer %v with DNS name %v", lbARN, lbDNS))
 	}
 	return nil
 }
@@ -167,6 +239,7 @@ func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS strin
 func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
+		RateLimiter:             runtime.NewReconcileRateLimiter(r.reconcileMaxBackoff),
 		Reconciler:              r,
 	})
 	if err != nil {
@@ -179,7 +252,7 @@ func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 }
 
 func (r *serviceReconciler) setupWatches(_ context.Context, c controller.Controller) error {
-	svcEventHandler := eventhandlers.NewEnqueueRequestForServiceEvent(r.eventRecorder, r.annotationParser,
+	svcEventHandler := eventhandlers.NewEnqueueRequestForServiceEvent(r.eventRecorder, r.annotationParser, r.finalizer,
 		r.logger.WithName("eventHandlers").WithName("service"))
 	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, svcEventHandler); err != nil {
 		return err
@@ -18,9 +18,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
 	zapraw "go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -33,6 +35,8 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
@@ -97,23 +101,70 @@ func main() {
 		os.Exit(1)
 	}
 	podInfoRepo := k8s.NewDefaultPodInfoRepo(clientSet.CoreV1().RESTClient(), rtOpts.Namespace, ctrl.Log)
+	useEndpointSlices, err := controllerCFG.UseEndpointSlices(clientSet.Discovery())
+	if err != nil {
+		setupLog.Error(err, "unable to detect EndpointSlices support")
+		os.Exit(1)
+	}
+	var endpointsRepo k8s.EndpointsRepo
+	if useEndpointSlices {
+		setupLog.Info("resolving endpoints of services from EndpointSlices", "enableEndpointSlices", controllerCFG.EnableEndpointSlices)
+		endpointsRepo = k8s.NewEndpointSlicesEndpointsRepo(mgr.GetClient())
+	} else {
+		setupLog.Info("resolving endpoints of services from Endpoints", "enableEndpointSlices", controllerCFG.EnableEndpointSlices)
+		endpointsInformer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Endpoints{})
+		if err != nil {
+			setupLog.Error(err, "unable to obtain endpoints informer")
+			os.Exit(1)
+		}
+		defaultEndpointsRepo, err := k8s.NewDefaultEndpointsRepo(mgr.GetClient(), endpointsInformer, metrics.Registry, ctrl.Log.WithName("endpoints-repo"))
+		if err != nil {
+			setupLog.Error(err, "unable to initialize endpoints repo")
+			os.Exit(1)
+		}
+		endpointsRepo = defaultEndpointsRepo
+	}
 	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient(), ctrl.Log)
 	podENIResolver := networking.NewDefaultPodENIInfoResolver(cloud.EC2(), cloud.VpcID(), ctrl.Log)
 	nodeENIResolver := networking.NewDefaultNodeENIInfoResolver(cloud.EC2(), ctrl.Log)
+	instanceStateResolver := networking.NewDefaultInstanceStateResolver(cloud.EC2(), ctrl.Log)
 	sgManager := networking.NewDefaultSecurityGroupManager(cloud.EC2(), ctrl.Log)
 	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, ctrl.Log)
 	azInfoProvider := networking.NewDefaultAZInfoProvider(cloud.EC2(), ctrl.Log.WithName("az-info-provider"))
 	subnetResolver := networking.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log.WithName("subnets-resolver"))
+	sslPolicyValidator := networking.NewDefaultSSLPolicyValidator(cloud.ELBV2(), ctrl.Log.WithName("ssl-policy-validator"))
+	if err := sslPolicyValidator.Validate(context.Background(), controllerCFG.DefaultSSLPolicy); err != nil {
+		var unknownSSLPolicyErr *networking.UnknownSSLPolicyError
+		if errors.As(err, &unknownSSLPolicyErr) {
+			setupLog.Error(err, "invalid default SSL policy", "policy", controllerCFG.DefaultSSLPolicy)
+			os.Exit(1)
+		}
+		// failures to describe SSL policies(e.g. throttling) shouldn't block the controller from starting.
+		setupLog.Error(err, "unable to validate default SSL policy", "policy", controllerCFG.DefaultSSLPolicy)
+	}
 	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
-		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log)
+		podInfoRepo, endpointsRepo, podENIResolver, nodeENIResolver, instanceStateResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, controllerCFG.EnableEndpointZoneStatus,
+		controllerCFG.TargetGroupBindingTagLabelPrefix, controllerCFG.TargetGroupBindingUnhealthyTargetsRequeueInterval,
+		controllerCFG.TargetGroupBindingHealthyTargetsRequeueInterval, controllerCFG.TargetGroupBindingNodeStartupGracePeriod,
+		controllerCFG.NodeDrainConditions(), controllerCFG.TargetGroupBindingRegisterRunningInstancesOnly, mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log)
+	stackMetricsCollector, err := deploy.NewDefaultStackMetricsCollector(metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize stack metrics collector")
+		os.Exit(1)
+	}
 	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("ingress"),
-		finalizerManager, sgManager, sgReconciler, subnetResolver,
+		finalizerManager, sgManager, sgReconciler, subnetResolver, sslPolicyValidator, stackMetricsCollector,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("ingress"))
 	svcReconciler := service.NewServiceReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("service"),
-		finalizerManager, sgManager, sgReconciler, subnetResolver,
+		finalizerManager, sgManager, sgReconciler, subnetResolver, stackMetricsCollector,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("service"))
+	targetHealthMetricsCollector, err := targetgroupbinding.NewDefaultTargetHealthMetricsCollector(cloud.ELBV2(), metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize target health metrics collector")
+		os.Exit(1)
+	}
 	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"),
-		finalizerManager, tgbResManager,
+		finalizerManager, tgbResManager, targetHealthMetricsCollector, useEndpointSlices,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("targetGroupBinding"))
 	ctx := context.Background()
 	if err = ingGroupReconciler.SetupWithManager(ctx, mgr); err != nil {
@@ -128,6 +179,14 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "TargetGroupBinding")
 		os.Exit(1)
 	}
+	if controllerCFG.EnableOrphanedResourcesGC {
+		orphanedResourcesCollector := gc.NewDefaultOrphanedResourcesCollector(cloud.ELBV2(), cloud.EC2(), mgr.GetAPIReader(),
+			cloud.VpcID(), controllerCFG, controllerCFG.OrphanedResourcesGCDryRun, ctrl.Log.WithName("orphaned-resources-collector"))
+		if err := mgr.Add(orphanedResourcesCollector); err != nil {
+			setupLog.Error(err, "unable to add orphaned resources collector")
+			os.Exit(1)
+		}
+	}
 
 	// Add liveness probe
 	err = mgr.AddHealthzCheck("health-ping", healthz.Ping)
@@ -136,12 +195,21 @@ func main() {
 		setupLog.Error(err, "unable add a health check")
 		os.Exit(1)
 	}
+	// Add readiness probe reflecting connectivity to AWS APIs
+	if connectivityChecker := cloud.ConnectivityChecker(); connectivityChecker != nil {
+		setupLog.Info("adding readiness check for AWS connectivity")
+		if err := mgr.AddReadyzCheck("aws-connectivity", connectivityChecker); err != nil {
+			setupLog.Error(err, "unable add a readiness check")
+			os.Exit(1)
+		}
+	}
 
 	podReadinessGateInjector := inject.NewPodReadinessGate(controllerCFG.PodWebhookConfig,
 		mgr.GetClient(), ctrl.Log.WithName("pod-readiness-gate-injector"))
 	corewebhook.NewPodMutator(podReadinessGateInjector).SetupWithManager(mgr)
-	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), ctrl.Log).SetupWithManager(mgr)
-	elbv2webhook.NewTargetGroupBindingValidator(ctrl.Log).SetupWithManager(mgr)
+	tgTargetTypeResolver := elbv2webhook.NewDefaultTargetTypeResolver(cloud.ELBV2())
+	elbv2webhook.NewTargetGroupBindingMutator(tgTargetTypeResolver, ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.NewTargetGroupBindingValidator(mgr.GetClient(), tgTargetTypeResolver, controllerCFG.WebhookFailClosedOnAWSErrors, ctrl.Log).SetupWithManager(mgr)
 	networkingwebhook.NewIngressValidator(mgr.GetClient(), controllerCFG.IngressConfig, ctrl.Log).SetupWithManager(mgr)
 	//+kubebuilder:scaffold:builder
 
@@ -9,7 +9,9 @@ const (
 	IngressSuffixLoadBalancerName             = "load-balancer-name"
 	IngressSuffixGroupName                    = "group.name"
 	IngressSuffixGroupOrder                   = "group.order"
+	IngressSuffixGroupPriorityOffset          = "group.priority-offset"
 	IngressSuffixTags                         = "tags"
+	IngressSuffixRuleTags                     = "rule-tags"
 	IngressSuffixIPAddressType                = "ip-address-type"
 	IngressSuffixScheme                       = "scheme"
 	IngressSuffixSubnets                      = "subnets"
@@ -20,14 +22,18 @@ const (
 	IngressSuffixWebACLID                     = "web-acl-id" // deprecated, use "waf-acl-id" instead.
 	IngressSuffixShieldAdvancedProtection     = "shield-advanced-protection"
 	IngressSuffixSecurityGroups               = "security-groups"
+	IngressSuffixSecurityGroupRuleDescription = "security-group-rules-description"
+	IngressSuffixManageBackendSGRules         = "manage-backend-security-group-rules"
 	IngressSuffixListenPorts                  = "listen-ports"
 	IngressSuffixSSLRedirect                  = "ssl-redirect"
 	IngressSuffixInboundCIDRs                 = "inbound-cidrs"
 	IngressSuffixCertificateARN               = "certificate-arn"
+	IngressSuffixDefaultCertificateARN        = "default-certificate-arn"
 	IngressSuffixSSLPolicy                    = "ssl-policy"
 	IngressSuffixTargetType                   = "target-type"
 	IngressSuffixBackendProtocol              = "backend-protocol"
 	IngressSuffixBackendProtocolVersion       = "backend-protocol-version"
+	IngressSuffixTargetGroupProtocol          = "target-group-protocol"
 	IngressSuffixTargetGroupAttributes        = "target-group-attributes"
 	IngressSuffixHealthCheckPort              = "healthcheck-port"
 	IngressSuffixHealthCheckProtocol          = "healthcheck-protocol"
@@ -42,15 +48,20 @@ const (
 	IngressSuffixAuthIDPOIDC                  = "auth-idp-oidc"
 	IngressSuffixAuthOnUnauthenticatedRequest = "auth-on-unauthenticated-request"
 	IngressSuffixAuthScope                    = "auth-scope"
+	IngressSuffixAuthRequestExtraParams       = "auth-request-extra-params"
 	IngressSuffixAuthSessionCookie            = "auth-session-cookie"
 	IngressSuffixAuthSessionTimeout           = "auth-session-timeout"
 	IngressSuffixTargetNodeLabels             = "target-node-labels"
+	IngressSuffixTargetPodLabels              = "target-pod-labels"
+	IngressSuffixRecreateTargetGroupBinding   = "recreate-target-group-binding"
+	IngressSuffixPathExpansion                = "path-expansion"
 
 	// NLB annotation suffixes
 	// prefixes service.beta.kubernetes.io, service.kubernetes.io
 	SvcLBSuffixSourceRanges                  = "load-balancer-source-ranges"
 	SvcLBSuffixLoadBalancerType              = "aws-load-balancer-type"
 	SvcLBSuffixTargetType                    = "aws-load-balancer-nlb-target-type"
+	SvcLBSuffixTargetTypePerPort             = "aws-load-balancer-nlb-target-type-per-port"
 	SvcLBSuffixLoadBalancerName              = "aws-load-balancer-name"
 	SvcLBSuffixInternal                      = "aws-load-balancer-internal"
 	SvcLBSuffixProxyProtocol                 = "aws-load-balancer-proxy-protocol"
@@ -71,10 +82,15 @@ const (
 	SvcLBSuffixHCProtocol                    = "aws-load-balancer-healthcheck-protocol"
 	SvcLBSuffixHCPort                        = "aws-load-balancer-healthcheck-port"
 	SvcLBSuffixHCPath                        = "aws-load-balancer-healthcheck-path"
+	SvcLBSuffixHCSuccessCodes                = "aws-load-balancer-healthcheck-success-codes"
 	SvcLBSuffixEIPAllocations                = "aws-load-balancer-eip-allocations"
 	SvcLBSuffixPrivateIpv4Addresses          = "aws-load-balancer-private-ipv4-addresses"
 	SvcLBSuffixTargetGroupAttributes         = "aws-load-balancer-target-group-attributes"
+	SvcLBSuffixTargetGroupCrossZoneEnabled   = "aws-load-balancer-target-group-cross-zone-enabled"
+	SvcLBSuffixTGFailoverOnDeregistration    = "aws-load-balancer-target-failover-on-deregistration"
+	SvcLBSuffixTGFailoverOnUnhealthy         = "aws-load-balancer-target-failover-on-unhealthy"
 	SvcLBSuffixSubnets                       = "aws-load-balancer-subnets"
 	SvcLBSuffixALPNPolicy                    = "aws-load-balancer-alpn-policy"
 	SvcLBSuffixTargetNodeLabels              = "aws-load-balancer-target-node-labels"
+	SvcLBSuffixShareTargetGroups             = "aws-load-balancer-share-target-groups"
 )
@@ -6,9 +6,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/connectivity"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/metrics"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 )
 
 type Cloud interface {
@@ -33,11 +36,17 @@ type Cloud interface {
 	// RGT provides API to AWS RGT
 	RGT() services.RGT
 
+	// CognitoIDP provides API to AWS Cognito Identity Provider
+	CognitoIDP() services.CognitoIdentityProvider
+
 	// Region for the kubernetes cluster
 	Region() string
 
 	// VPC ID for the the kubernetes cluster
 	VpcID() string
+
+	// ConnectivityChecker checks connectivity to AWS APIs, nil if connectivity check is disabled
+	ConnectivityChecker() healthz.Checker
 }
 
 // NewCloud constructs new Cloud implementation.
@@ -60,7 +69,8 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		cfg.VpcID = vpcId
 	}
 
-	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.MaxRetries)
+	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.MaxRetries).
+		WithHTTPClient(buildHTTPClient(cfg))
 	sess := session.Must(session.NewSession(awsCFG))
 	injectUserAgent(&sess.Handlers)
 
@@ -68,6 +78,13 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		throttler := throttle.NewThrottler(cfg.ThrottleConfig)
 		throttler.InjectHandlers(&sess.Handlers)
 	}
+	if cfg.MutationRateLimit > 0 {
+		mutationThrottler, err := throttle.NewMutationThrottler(rate.Limit(cfg.MutationRateLimit), cfg.MutationRateLimitBurst, metricsRegisterer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize mutation throttler")
+		}
+		mutationThrottler.InjectHandlers(&sess.Handlers)
+	}
 	if metricsRegisterer != nil {
 		metricsCollector, err := metrics.NewCollector(metricsRegisterer)
 		if err != nil {
@@ -75,16 +92,32 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		}
 		metricsCollector.InjectHandlers(&sess.Handlers)
 	}
+	var connectivityChecker healthz.Checker
+	if cfg.ConnectivityCheckTimeout > 0 {
+		checker := connectivity.NewChecker(cfg.ConnectivityCheckTimeout)
+		checker.InjectHandlers(&sess.Handlers)
+		connectivityChecker = checker.Check
+	}
+	elbv2Client := services.NewELBV2(sess)
+	if cfg.ELBV2DescribeCacheTTL > 0 {
+		cachedELBV2Client, err := services.NewCachedELBV2(elbv2Client, cfg.ELBV2DescribeCacheTTL, metricsRegisterer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize elbv2 describe cache")
+		}
+		elbv2Client = cachedELBV2Client
+	}
 
 	return &defaultCloud{
-		cfg:         cfg,
-		ec2:         services.NewEC2(sess),
-		elbv2:       services.NewELBV2(sess),
-		acm:         services.NewACM(sess),
-		wafv2:       services.NewWAFv2(sess),
-		wafRegional: services.NewWAFRegional(sess, cfg.Region),
-		shield:      services.NewShield(sess),
-		rgt:         services.NewRGT(sess),
+		cfg:                 cfg,
+		connectivityChecker: connectivityChecker,
+		ec2:                 services.NewEC2(sess),
+		elbv2:               elbv2Client,
+		acm:                 services.NewACM(sess),
+		wafv2:               services.NewWAFv2(sess),
+		wafRegional:         services.NewWAFRegional(sess, cfg.Region),
+		shield:              services.NewShield(sess),
+		rgt:                 services.NewRGT(sess),
+		cognitoIDP:          services.NewCognitoIdentityProvider(sess),
 	}, nil
 }
 
@@ -101,6 +134,9 @@ type defaultCloud struct {
 	wafRegional services.WAFRegional
 	shield      services.Shield
 	rgt         services.RGT
+	cognitoIDP  services.CognitoIdentityProvider
+
+	connectivityChecker healthz.Checker
 }
 
 func (c *defaultCloud) EC2() services.EC2 {
@@ -131,6 +167,10 @@ func (c *defaultCloud) RGT() services.RGT {
 	return c.rgt
 }
 
+func (c *defaultCloud) CognitoIDP() services.CognitoIdentityProvider {
+	return c.cognitoIDP
+}
+
 func (c *defaultCloud) Region() string {
 	return c.cfg.Region
 }
@@ -138,3 +178,7 @@ func (c *defaultCloud) Region() string {
 func (c *defaultCloud) VpcID() string {
 	return c.cfg.VpcID
 }
+
+func (c *defaultCloud) ConnectivityChecker() healthz.Checker {
+	return c.connectivityChecker
+}
@@ -1,18 +1,38 @@
 package aws
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"time"
 )
 
 const (
-	flagAWSRegion        = "aws-region"
-	flagAWSAPIThrottle   = "aws-api-throttle"
-	flagAWSVpcID         = "aws-vpc-id"
-	flagAWSMaxRetries    = "aws-max-retries"
-	defaultVpcID         = ""
-	defaultRegion        = ""
-	defaultAPIMaxRetries = 10
+	flagAWSRegion                   = "aws-region"
+	flagAWSAPIThrottle              = "aws-api-throttle"
+	flagAWSVpcID                    = "aws-vpc-id"
+	flagAWSMaxRetries               = "aws-max-retries"
+	flagAWSConnectivityCheckTimeout = "aws-connectivity-check-timeout"
+	flagAWSHTTPMaxIdleConns         = "aws-http-max-idle-conns"
+	flagAWSHTTPMaxIdleConnsPerHost  = "aws-http-max-idle-conns-per-host"
+	flagAWSHTTPMaxConnsPerHost      = "aws-http-max-conns-per-host"
+	flagAWSHTTPRequestTimeout       = "aws-http-request-timeout"
+	flagAWSELBV2DescribeCacheTTL    = "aws-elbv2-describe-cache-ttl"
+	flagAWSMutationRateLimit        = "aws-mutation-rate-limit"
+	flagAWSMutationRateLimitBurst   = "aws-mutation-rate-limit-burst"
+	defaultVpcID                    = ""
+	defaultRegion                   = ""
+	defaultAPIMaxRetries            = 10
+	defaultConnectivityCheckTimeout = 0
+	defaultELBV2DescribeCacheTTL    = 0
+	defaultMutationRateLimit        = 0
+	defaultMutationRateLimitBurst   = 10
+	// reconcile loops call the same few AWS API endpoints concurrently,
+	// so idle connections per host are kept well above the Go default of 2 to reuse connections instead of reconnecting.
+	defaultHTTPMaxIdleConns        = 100
+	defaultHTTPMaxIdleConnsPerHost = 50
+	defaultHTTPMaxConnsPerHost     = 0
+	defaultHTTPRequestTimeout      = 60 * time.Second
 )
 
 type CloudConfig struct {
@@ -27,6 +47,30 @@ type CloudConfig struct {
 
 	// Max retries configuration for AWS APIs
 	MaxRetries int
+
+	// Duration AWS APIs must stay unreachable under API calls before the readiness check fails, zero means disabled
+	ConnectivityCheckTimeout time.Duration
+
+	// Max idle connections across all hosts for the HTTP client of AWS APIs, zero means no limit
+	HTTPMaxIdleConns int
+
+	// Max idle connections per host for the HTTP client of AWS APIs
+	HTTPMaxIdleConnsPerHost int
+
+	// Max connections per host for the HTTP client of AWS APIs, zero means no limit
+	HTTPMaxConnsPerHost int
+
+	// Timeout of each HTTP request attempt to AWS APIs, zero means no timeout
+	HTTPRequestTimeout time.Duration
+
+	// TTL of cached results of ELBV2 DescribeLoadBalancers, DescribeTargetGroups and DescribeTags APIs, zero means disabled
+	ELBV2DescribeCacheTTL time.Duration
+
+	// Rate limit of mutating AWS API operations across all services per second, zero means disabled
+	MutationRateLimit float64
+
+	// Burst of mutating AWS API operations allowed by the rate limit
+	MutationRateLimitBurst int
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
@@ -34,4 +78,49 @@ func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.Var(cfg.ThrottleConfig, flagAWSAPIThrottle, "throttle settings for AWS APIs, format: serviceID1:operationRegex1=rate:burst,serviceID2:operationRegex2=rate:burst")
 	fs.StringVar(&cfg.VpcID, flagAWSVpcID, defaultVpcID, "AWS VPC ID for the Kubernetes cluster")
 	fs.IntVar(&cfg.MaxRetries, flagAWSMaxRetries, defaultAPIMaxRetries, "Maximum retries for AWS APIs")
+	fs.DurationVar(&cfg.ConnectivityCheckTimeout, flagAWSConnectivityCheckTimeout, defaultConnectivityCheckTimeout,
+		"Duration AWS API calls must keep failing before the readiness check fails, zero disables the check")
+	fs.IntVar(&cfg.HTTPMaxIdleConns, flagAWSHTTPMaxIdleConns, defaultHTTPMaxIdleConns,
+		"Maximum idle connections across all hosts for AWS APIs, zero means no limit")
+	fs.IntVar(&cfg.HTTPMaxIdleConnsPerHost, flagAWSHTTPMaxIdleConnsPerHost, defaultHTTPMaxIdleConnsPerHost,
+		"Maximum idle connections per host for AWS APIs")
+	fs.IntVar(&cfg.HTTPMaxConnsPerHost, flagAWSHTTPMaxConnsPerHost, defaultHTTPMaxConnsPerHost,
+		"Maximum connections per host for AWS APIs, zero means no limit")
+	fs.DurationVar(&cfg.HTTPRequestTimeout, flagAWSHTTPRequestTimeout, defaultHTTPRequestTimeout,
+		"Timeout of each HTTP request to AWS APIs, zero means no timeout")
+	fs.DurationVar(&cfg.ELBV2DescribeCacheTTL, flagAWSELBV2DescribeCacheTTL, defaultELBV2DescribeCacheTTL,
+		"TTL of cached results of ELBV2 DescribeLoadBalancers, DescribeTargetGroups and DescribeTags APIs, zero disables the cache")
+	fs.Float64Var(&cfg.MutationRateLimit, flagAWSMutationRateLimit, defaultMutationRateLimit,
+		"Rate limit of mutating AWS API operations, e.g. Create, Modify and Delete, across all services per second, zero disables the limit")
+	fs.IntVar(&cfg.MutationRateLimitBurst, flagAWSMutationRateLimitBurst, defaultMutationRateLimitBurst,
+		"Burst of mutating AWS API operations allowed by the rate limit")
+}
+
+// Validate the cloud configuration
+func (cfg *CloudConfig) Validate() error {
+	if cfg.HTTPMaxIdleConns < 0 {
+		return errors.Errorf("%v must not be negative", flagAWSHTTPMaxIdleConns)
+	}
+	if cfg.HTTPMaxIdleConnsPerHost <= 0 {
+		return errors.Errorf("%v must be positive", flagAWSHTTPMaxIdleConnsPerHost)
+	}
+	if cfg.HTTPMaxConnsPerHost < 0 {
+		return errors.Errorf("%v must not be negative", flagAWSHTTPMaxConnsPerHost)
+	}
+	if cfg.HTTPMaxConnsPerHost > 0 && cfg.HTTPMaxIdleConnsPerHost > cfg.HTTPMaxConnsPerHost {
+		return errors.Errorf("%v must not be greater than %v", flagAWSHTTPMaxIdleConnsPerHost, flagAWSHTTPMaxConnsPerHost)
+	}
+	if cfg.HTTPRequestTimeout < 0 {
+		return errors.Errorf("%v must not be negative", flagAWSHTTPRequestTimeout)
+	}
+	if cfg.ELBV2DescribeCacheTTL < 0 {
+		return errors.Errorf("%v must not be negative", flagAWSELBV2DescribeCacheTTL)
+	}
+	if cfg.MutationRateLimit < 0 {
+		return errors.Errorf("%v must not be negative", flagAWSMutationRateLimit)
+	}
+	if cfg.MutationRateLimit > 0 && cfg.MutationRateLimitBurst <= 0 {
+		return errors.Errorf("%v must be positive", flagAWSMutationRateLimitBurst)
+	}
+	return nil
 }
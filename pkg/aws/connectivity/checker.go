@@ -0,0 +1,96 @@
+package connectivity
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	sdkHandlerTrackConnectivity = "trackConnectivity"
+)
+
+// Checker tracks the connectivity to AWS APIs based on the outcome of API requests issued by the controller.
+// It never calls AWS APIs itself, so checks are cheap and won't be affected by API throttling.
+// AWS APIs are only considered unreachable while request attempts keep failing, so the check never fails without traffic.
+type Checker struct {
+	// the duration AWS APIs must stay unreachable before check fails.
+	unreachableTimeout time.Duration
+
+	mutex sync.RWMutex
+	// the time of the first failed request attempt since AWS APIs were last reachable, zero if AWS APIs are reachable.
+	unreachableSince time.Time
+	// the time of the last failed request attempt since AWS APIs were last reachable, zero if AWS APIs are reachable.
+	lastUnreachableAt time.Time
+
+	now func() time.Time
+}
+
+// NewChecker constructs new Checker.
+func NewChecker(unreachableTimeout time.Duration) *Checker {
+	return &Checker{
+		unreachableTimeout: unreachableTimeout,
+		now:                time.Now,
+	}
+}
+
+// InjectHandlers injects the handlers to track connectivity into AWS SDK request handlers.
+func (c *Checker) InjectHandlers(handlers *request.Handlers) {
+	handlers.CompleteAttempt.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerTrackConnectivity,
+		Fn:   c.trackConnectivity,
+	})
+}
+
+// Check fails if request attempts to AWS APIs have kept failing for longer than unreachableTimeout,
+// and the last failed attempt is within unreachableTimeout.
+// It's compatible with controller-runtime's healthz.Checker.
+func (c *Checker) Check(_ *http.Request) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.unreachableSince.IsZero() {
+		return nil
+	}
+	// without recent request attempts, the connectivity is unknown rather than broken.
+	if c.now().Sub(c.lastUnreachableAt) > c.unreachableTimeout {
+		return nil
+	}
+	if unreachableDuration := c.lastUnreachableAt.Sub(c.unreachableSince); unreachableDuration > c.unreachableTimeout {
+		return errors.Errorf("AWS APIs have been unreachable for %v", unreachableDuration)
+	}
+	return nil
+}
+
+func (c *Checker) trackConnectivity(r *request.Request) {
+	if isRequestCanceled(r) {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// any response from AWS APIs means they are reachable, including throttling and server errors.
+	if r.HTTPResponse != nil && r.HTTPResponse.StatusCode != 0 {
+		c.unreachableSince = time.Time{}
+		c.lastUnreachableAt = time.Time{}
+		return
+	}
+	if r.Error == nil {
+		return
+	}
+	now := c.now()
+	if c.unreachableSince.IsZero() {
+		c.unreachableSince = now
+	}
+	c.lastUnreachableAt = now
+}
+
+// isRequestCanceled checks whether the request is canceled by the controller.
+func isRequestCanceled(r *request.Request) bool {
+	var awsErr awserr.Error
+	if errors.As(r.Error, &awsErr) {
+		return awsErr.Code() == request.CanceledErrorCode
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package connectivity
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChecker_Check(t *testing.T) {
+	startTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	responded := &request.Request{HTTPResponse: &http.Response{StatusCode: 200}}
+	throttled := &request.Request{
+		HTTPResponse: &http.Response{StatusCode: 400},
+		Error:        awserr.New("Throttling", "Rate exceeded", nil),
+	}
+	unreachable := &request.Request{
+		HTTPResponse: &http.Response{StatusCode: 0},
+		Error:        awserr.New(request.ErrCodeRequestError, "send request failed", nil),
+	}
+	canceled := &request.Request{
+		HTTPResponse: &http.Response{StatusCode: 0},
+		Error:        awserr.New(request.CanceledErrorCode, "request context canceled", nil),
+	}
+
+	type requestAttempt struct {
+		elapsed time.Duration
+		req     *request.Request
+	}
+	tests := []struct {
+		name            string
+		requestAttempts []requestAttempt
+		checkElapsed    time.Duration
+		wantErr         error
+	}{
+		{
+			name:         "no requests attempted",
+			checkElapsed: 10 * time.Minute,
+		},
+		{
+			name: "requests succeeded",
+			requestAttempts: []requestAttempt{
+				{elapsed: 0, req: responded},
+			},
+			checkElapsed: 10 * time.Minute,
+		},
+		{
+			name: "requests throttled",
+			requestAttempts: []requestAttempt{
+				{elapsed: 0, req: throttled},
+				{elapsed: 5 * time.Minute, req: throttled},
+			},
+			checkElapsed: 10 * time.Minute,
+		},
+		{
+			name: "requests unreachable within timeout",
+			requestAttempts: []requestAttempt{
+				{elapsed: 0, req: responded},
+				{elapsed: 1 * time.Minute, req: unreachable},
+			},
+			checkElapsed: 3 * time.Minute,
+		},
+		{
+			name: "requests unreachable beyond timeout",
+			requestAttempts: []requestAttempt{
+				{elapsed: 0, req: responded},
+				{elapsed: 1 * time.Minute, req: unreachable},
+				{elapsed: 3 * time.Minute, req: unreachable},
+				{elapsed: 5 * time.Minute, req: unreachable},
+			},
+			checkElapsed: 6 * time.Minute,
+			wantErr:      errors.New("AWS APIs have been unreachable for 4m0s"),
+		},
+		{
+			name: "no requests attempted after requests unreachable",
+			requestAttempts: []requestAttempt{
+				{elapsed: 0, req: responded},
+				{elapsed: 1 * time.Minute, req: unreachable},
+			},
+			checkElapsed: 30 * time.Minute,
+		},
+		{
+			name: "no requests attempted recently after requests unreachable beyond timeout",
+			requestAttempts: []requestAttempt{
+				{elapsed: 1 * time.Minute, req: unreachable},
+				{elapsed: 5 * time.Minute, req: unreachable},
+			},
+			checkElapsed: 30 * time.Minute,
+		},
+		{
+			name: "requests reachable again",
+			requestAttempts: []requestAttempt{
+				{elapsed: 1 * time.Minute, req: unreachable},
+				{elapsed: 4 * time.Minute, req: responded},
+			},
+			checkElapsed: 5 * time.Minute,
+		},
+		{
+			name: "requests canceled",
+			requestAttempts: []requestAttempt{
+				{elapsed: 1 * time.Minute, req: canceled},
+			},
+			checkElapsed: 5 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(3 * time.Minute)
+			for _, attempt := range tt.requestAttempts {
+				attemptTime := startTime.Add(attempt.elapsed)
+				c.now = func() time.Time { return attemptTime }
+				c.trackConnectivity(attempt.req)
+			}
+			c.now = func() time.Time { return startTime.Add(tt.checkElapsed) }
+			err := c.Check(nil)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
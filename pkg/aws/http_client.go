@@ -0,0 +1,18 @@
+package aws
+
+import (
+	"net/http"
+)
+
+// buildHTTPClient builds the HTTP client for AWS SDK with connection pool and timeout settings from CloudConfig.
+// It's based on the default transport, so that proxy settings from environment variables and TLS verification are preserved.
+func buildHTTPClient(cfg CloudConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = cfg.HTTPMaxIdleConns
+	transport.MaxIdleConnsPerHost = cfg.HTTPMaxIdleConnsPerHost
+	transport.MaxConnsPerHost = cfg.HTTPMaxConnsPerHost
+	return &http.Client{
+		Transport: transport,
+		Timeout:   cfg.HTTPRequestTimeout,
+	}
+}
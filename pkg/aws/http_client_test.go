@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildHTTPClient(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CloudConfig
+	}{
+		{
+			name: "default settings",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        defaultHTTPMaxIdleConns,
+				HTTPMaxIdleConnsPerHost: defaultHTTPMaxIdleConnsPerHost,
+				HTTPMaxConnsPerHost:     defaultHTTPMaxConnsPerHost,
+				HTTPRequestTimeout:      defaultHTTPRequestTimeout,
+			},
+		},
+		{
+			name: "custom settings",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        200,
+				HTTPMaxIdleConnsPerHost: 20,
+				HTTPMaxConnsPerHost:     40,
+				HTTPRequestTimeout:      10 * time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildHTTPClient(tt.cfg)
+			assert.Equal(t, tt.cfg.HTTPRequestTimeout, got.Timeout)
+			transport, ok := got.Transport.(*http.Transport)
+			assert.True(t, ok)
+			assert.Equal(t, tt.cfg.HTTPMaxIdleConns, transport.MaxIdleConns)
+			assert.Equal(t, tt.cfg.HTTPMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+			assert.Equal(t, tt.cfg.HTTPMaxConnsPerHost, transport.MaxConnsPerHost)
+			assert.True(t, transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify)
+			assert.NotNil(t, transport.Proxy)
+			assert.NotSame(t, http.DefaultTransport, transport)
+		})
+	}
+}
+
+func TestCloudConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     CloudConfig
+		wantErr string
+	}{
+		{
+			name: "default settings",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        defaultHTTPMaxIdleConns,
+				HTTPMaxIdleConnsPerHost: defaultHTTPMaxIdleConnsPerHost,
+				HTTPMaxConnsPerHost:     defaultHTTPMaxConnsPerHost,
+				HTTPRequestTimeout:      defaultHTTPRequestTimeout,
+			},
+		},
+		{
+			name: "negative max idle conns",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        -1,
+				HTTPMaxIdleConnsPerHost: defaultHTTPMaxIdleConnsPerHost,
+			},
+			wantErr: "aws-http-max-idle-conns must not be negative",
+		},
+		{
+			name: "zero max idle conns per host",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        defaultHTTPMaxIdleConns,
+				HTTPMaxIdleConnsPerHost: 0,
+			},
+			wantErr: "aws-http-max-idle-conns-per-host must be positive",
+		},
+		{
+			name: "max idle conns per host exceeds max conns per host",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        defaultHTTPMaxIdleConns,
+				HTTPMaxIdleConnsPerHost: 50,
+				HTTPMaxConnsPerHost:     20,
+			},
+			wantErr: "aws-http-max-idle-conns-per-host must not be greater than aws-http-max-conns-per-host",
+		},
+		{
+			name: "negative request timeout",
+			cfg: CloudConfig{
+				HTTPMaxIdleConns:        defaultHTTPMaxIdleConns,
+				HTTPMaxIdleConnsPerHost: defaultHTTPMaxIdleConnsPerHost,
+				HTTPRequestTimeout:      -time.Second,
+			},
+			wantErr: "aws-http-request-timeout must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+)
+
+type CognitoIdentityProvider interface {
+	cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
+
+// NewCognitoIdentityProvider constructs new CognitoIdentityProvider implementation.
+func NewCognitoIdentityProvider(session *session.Session) CognitoIdentityProvider {
+	return &defaultCognitoIdentityProvider{
+		CognitoIdentityProviderAPI: cognitoidentityprovider.New(session),
+	}
+}
+
+// default implementation for CognitoIdentityProvider.
+type defaultCognitoIdentityProvider struct {
+	cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	metricDescribeCacheHitsTotal   = "aws_describe_cache_hits_total"
+	metricDescribeCacheMissesTotal = "aws_describe_cache_misses_total"
+	labelDescribeCacheOperation    = "operation"
+
+	describeCacheOperationDescribeLoadBalancers = "DescribeLoadBalancers"
+	describeCacheOperationDescribeTargetGroups  = "DescribeTargetGroups"
+	describeCacheOperationDescribeTags          = "DescribeTags"
+)
+
+// NewCachedELBV2 constructs new ELBV2 implementation that caches results of DescribeLoadBalancers, DescribeTargetGroups and DescribeTags APIs.
+// Cached results are invalidated when the resources are mutated via this client, and expire after ttl to pick up changes made outside of it.
+// Cache metrics are registered to metricsRegisterer if it's not nil.
+func NewCachedELBV2(elbv2Client ELBV2, ttl time.Duration, metricsRegisterer prometheus.Registerer) (ELBV2, error) {
+	hitsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricDescribeCacheHitsTotal,
+		Help: "Total number of AWS describe API calls served from cache",
+	}, []string{labelDescribeCacheOperation})
+	missesTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricDescribeCacheMissesTotal,
+		Help: "Total number of AWS describe API calls not served from cache",
+	}, []string{labelDescribeCacheOperation})
+	if metricsRegisterer != nil {
+		if err := metricsRegisterer.Register(hitsTotal); err != nil {
+			return nil, err
+		}
+		if err := metricsRegisterer.Register(missesTotal); err != nil {
+			return nil, err
+		}
+	}
+	clk := clock.RealClock{}
+	return &cachedELBV2{
+		ELBV2:       elbv2Client,
+		lbsCache:    newDescribeCache(clk, ttl),
+		tgsCache:    newDescribeCache(clk, ttl),
+		tagsCache:   newDescribeCache(clk, ttl),
+		hitsTotal:   hitsTotal,
+		missesTotal: missesTotal,
+	}, nil
+}
+
+// cachedELBV2 caches describe results of LoadBalancers and TargetGroups keyed by request, and their tags keyed by resource ARN.
+// Only mutations via the WithContext APIs invalidate the cache.
+type cachedELBV2 struct {
+	ELBV2
+
+	lbsCache  *describeCache
+	tgsCache  *describeCache
+	tagsCache *describeCache
+
+	hitsTotal   *prometheus.CounterVec
+	missesTotal *prometheus.CounterVec
+}
+
+func (c *cachedELBV2) DescribeLoadBalancersAsList(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) ([]*elbv2.LoadBalancer, error) {
+	key := awsutil.Prettify(input)
+	cachedLBs, generation, exists := c.lbsCache.get(key)
+	c.observe(describeCacheOperationDescribeLoadBalancers, exists)
+	if exists {
+		return copyLoadBalancers(cachedLBs.([]*elbv2.LoadBalancer)), nil
+	}
+	lbs, err := c.ELBV2.DescribeLoadBalancersAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.lbsCache.set(key, copyLoadBalancers(lbs), generation)
+	return lbs, nil
+}
+
+func (c *cachedELBV2) DescribeTargetGroupsAsList(ctx context.Context, input *elbv2.DescribeTargetGroupsInput) ([]*elbv2.TargetGroup, error) {
+	key := awsutil.Prettify(input)
+	cachedTGs, generation, exists := c.tgsCache.get(key)
+	c.observe(describeCacheOperationDescribeTargetGroups, exists)
+	if exists {
+		return copyTargetGroups(cachedTGs.([]*elbv2.TargetGroup)), nil
+	}
+	tgs, err := c.ELBV2.DescribeTargetGroupsAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	c.tgsCache.set(key, copyTargetGroups(tgs), generation)
+	return tgs, nil
+}
+
+// DescribeTagsWithContext only describes tags for resources not in cache.
+func (c *cachedELBV2) DescribeTagsWithContext(ctx context.Context, input *elbv2.DescribeTagsInput, opts ...request.Option) (*elbv2.DescribeTagsOutput, error) {
+	var tagDescriptions []*elbv2.TagDescription
+	var uncachedARNs []string
+	var generation int64
+	for _, arn := range awssdk.StringValueSlice(input.ResourceArns) {
+		cachedTagDescription, arnGeneration, exists := c.tagsCache.get(arn)
+		generation = arnGeneration
+		if exists {
+			tagDescriptions = append(tagDescriptions, awsutil.CopyOf(cachedTagDescription).(*elbv2.TagDescription))
+		} else {
+			uncachedARNs = append(uncachedARNs, arn)
+		}
+	}
+	c.observe(describeCacheOperationDescribeTags, len(uncachedARNs) == 0)
+	if len(uncachedARNs) == 0 {
+		return &elbv2.DescribeTagsOutput{TagDescriptions: tagDescriptions}, nil
+	}
+
+	resp, err := c.ELBV2.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: awssdk.StringSlice(uncachedARNs),
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, tagDescription := range resp.TagDescriptions {
+		c.tagsCache.set(awssdk.StringValue(tagDescription.ResourceArn), awsutil.CopyOf(tagDescription), generation)
+		tagDescriptions = append(tagDescriptions, tagDescription)
+	}
+	return &elbv2.DescribeTagsOutput{TagDescriptions: tagDescriptions}, nil
+}
+
+func (c *cachedELBV2) CreateLoadBalancerWithContext(ctx context.Context, input *elbv2.CreateLoadBalancerInput, opts ...request.Option) (*elbv2.CreateLoadBalancerOutput, error) {
+	defer c.lbsCache.invalidate()
+	return c.ELBV2.CreateLoadBalancerWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) DeleteLoadBalancerWithContext(ctx context.Context, input *elbv2.DeleteLoadBalancerInput, opts ...request.Option) (*elbv2.DeleteLoadBalancerOutput, error) {
+	// targetGroups are no longer associated with the deleted loadBalancer.
+	defer c.tgsCache.invalidate()
+	defer c.lbsCache.invalidate()
+	defer c.tagsCache.invalidate(awssdk.StringValue(input.LoadBalancerArn))
+	return c.ELBV2.DeleteLoadBalancerWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) SetIpAddressTypeWithContext(ctx context.Context, input *elbv2.SetIpAddressTypeInput, opts ...request.Option) (*elbv2.SetIpAddressTypeOutput, error) {
+	defer c.lbsCache.invalidate()
+	return c.ELBV2.SetIpAddressTypeWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) SetSecurityGroupsWithContext(ctx context.Context, input *elbv2.SetSecurityGroupsInput, opts ...request.Option) (*elbv2.SetSecurityGroupsOutput, error) {
+	defer c.lbsCache.invalidate()
+	return c.ELBV2.SetSecurityGroupsWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) SetSubnetsWithContext(ctx context.Context, input *elbv2.SetSubnetsInput, opts ...request.Option) (*elbv2.SetSubnetsOutput, error) {
+	defer c.lbsCache.invalidate()
+	return c.ELBV2.SetSubnetsWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) CreateTargetGroupWithContext(ctx context.Context, input *elbv2.CreateTargetGroupInput, opts ...request.Option) (*elbv2.CreateTargetGroupOutput, error) {
+	defer c.tgsCache.invalidate()
+	return c.ELBV2.CreateTargetGroupWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) ModifyTargetGroupWithContext(ctx context.Context, input *elbv2.ModifyTargetGroupInput, opts ...request.Option) (*elbv2.ModifyTargetGroupOutput, error) {
+	defer c.tgsCache.invalidate()
+	return c.ELBV2.ModifyTargetGroupWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) DeleteTargetGroupWithContext(ctx context.Context, input *elbv2.DeleteTargetGroupInput, opts ...request.Option) (*elbv2.DeleteTargetGroupOutput, error) {
+	defer c.tgsCache.invalidate()
+	defer c.tagsCache.invalidate(awssdk.StringValue(input.TargetGroupArn))
+	return c.ELBV2.DeleteTargetGroupWithContext(ctx, input, opts...)
+}
+
+// CreateListenerWithContext invalidates targetGroups, as listeners and rules change the loadBalancers associated with targetGroups they forward to.
+func (c *cachedELBV2) CreateListenerWithContext(ctx context.Context, input *elbv2.CreateListenerInput, opts ...request.Option) (*elbv2.CreateListenerOutput, error) {
+	defer c.tgsCache.invalidate()
+	return c.ELBV2.CreateListenerWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) ModifyListenerWithContext(ctx context.Context, input *elbv2.ModifyListenerInput, opts ...request.Option) (*elbv2.ModifyListenerOutput, error) {
+	defer c.tgsCache.invalidate()
+	return c.ELBV2.ModifyListenerWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) DeleteListenerWithContext(ctx context.Context, input *elbv2.DeleteListenerInput, opts ...request.Option) (*elbv2.DeleteListenerOutput, error) {
+	defer c.tgsCache.invalidate()
+	defer c.tagsCache.invalidate(awssdk.StringValue(input.ListenerArn))
+	return c.ELBV2.DeleteListenerWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) CreateRuleWithContext(ctx context.Context, input *elbv2.CreateRuleInput, opts ...request.Option) (*elbv2.CreateRuleOutput, error) {
+	defer c.tgsCache.invalidate()
+	return c.ELBV2.CreateRuleWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) ModifyRuleWithContext(ctx context.Context, input *elbv2.ModifyRuleInput, opts ...request.Option) (*elbv2.ModifyRuleOutput, error) {
+	defer c.tgsCache.invalidate()
+	return c.ELBV2.ModifyRuleWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) DeleteRuleWithContext(ctx context.Context, input *elbv2.DeleteRuleInput, opts ...request.Option) (*elbv2.DeleteRuleOutput, error) {
+	defer c.tgsCache.invalidate()
+	defer c.tagsCache.invalidate(awssdk.StringValue(input.RuleArn))
+	return c.ELBV2.DeleteRuleWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) AddTagsWithContext(ctx context.Context, input *elbv2.AddTagsInput, opts ...request.Option) (*elbv2.AddTagsOutput, error) {
+	defer c.tagsCache.invalidate(awssdk.StringValueSlice(input.ResourceArns)...)
+	return c.ELBV2.AddTagsWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) RemoveTagsWithContext(ctx context.Context, input *elbv2.RemoveTagsInput, opts ...request.Option) (*elbv2.RemoveTagsOutput, error) {
+	defer c.tagsCache.invalidate(awssdk.StringValueSlice(input.ResourceArns)...)
+	return c.ELBV2.RemoveTagsWithContext(ctx, input, opts...)
+}
+
+func (c *cachedELBV2) observe(operation string, hit bool) {
+	if hit {
+		c.hitsTotal.WithLabelValues(operation).Inc()
+	} else {
+		c.missesTotal.WithLabelValues(operation).Inc()
+	}
+}
+
+// copyLoadBalancers deep copies loadBalancers, so that callers cannot mutate cached results.
+func copyLoadBalancers(lbs []*elbv2.LoadBalancer) []*elbv2.LoadBalancer {
+	return *awsutil.CopyOf(&lbs).(*[]*elbv2.LoadBalancer)
+}
+
+// copyTargetGroups deep copies targetGroups, so that callers cannot mutate cached results.
+func copyTargetGroups(tgs []*elbv2.TargetGroup) []*elbv2.TargetGroup {
+	return *awsutil.CopyOf(&tgs).(*[]*elbv2.TargetGroup)
+}
+
+// describeCache caches describe results with TTL.
+// Each invalidation bumps the generation, so that results of describe calls in flight during invalidation are not cached.
+type describeCache struct {
+	mutex      sync.Mutex
+	clock      clock.Clock
+	ttl        time.Duration
+	items      map[string]describeCacheItem
+	generation int64
+}
+
+type describeCacheItem struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newDescribeCache(clock clock.Clock, ttl time.Duration) *describeCache {
+	return &describeCache{
+		clock: clock,
+		ttl:   ttl,
+		items: make(map[string]describeCacheItem),
+	}
+}
+
+// get returns the cached value if exists, along with current generation to set value for the key later.
+func (c *describeCache) get(key string) (interface{}, int64, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	item, exists := c.items[key]
+	if !exists {
+		return nil, c.generation, false
+	}
+	if !c.clock.Now().Before(item.expiresAt) {
+		delete(c.items, key)
+		return nil, c.generation, false
+	}
+	return item.value, c.generation, true
+}
+
+// set caches value for the key, unless the cache is invalidated since the generation.
+func (c *describeCache) set(key string, value interface{}, generation int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.items[key] = describeCacheItem{
+		value:     value,
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+}
+
+// invalidate removes cached values for keys, or all cached values if no key is specified.
+func (c *describeCache) invalidate(keys ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	if len(keys) == 0 {
+		c.items = make(map[string]describeCacheItem)
+		return
+	}
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+}
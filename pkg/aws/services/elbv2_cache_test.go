@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func newTestCachedELBV2(elbv2Client ELBV2, clk clock.Clock, ttl time.Duration) *cachedELBV2 {
+	return &cachedELBV2{
+		ELBV2:     elbv2Client,
+		lbsCache:  newDescribeCache(clk, ttl),
+		tgsCache:  newDescribeCache(clk, ttl),
+		tagsCache: newDescribeCache(clk, ttl),
+		hitsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricDescribeCacheHitsTotal,
+		}, []string{labelDescribeCacheOperation}),
+		missesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricDescribeCacheMissesTotal,
+		}, []string{labelDescribeCacheOperation}),
+	}
+}
+
+func Test_cachedELBV2_DescribeLoadBalancersAsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lbs := []*elbv2.LoadBalancer{
+		{
+			LoadBalancerArn: awssdk.String("lb-1"),
+			SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+		},
+	}
+	elbv2Client := NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(lbs, nil).Times(3)
+	elbv2Client.EXPECT().SetSecurityGroupsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2.SetSecurityGroupsOutput{}, nil)
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	c := newTestCachedELBV2(elbv2Client, fakeClock, time.Minute)
+	ctx := context.Background()
+
+	// first call misses the cache.
+	got, err := c.DescribeLoadBalancersAsList(ctx, &elbv2.DescribeLoadBalancersInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, lbs, got)
+
+	// results are served from cache within ttl, and callers cannot mutate cached results.
+	got, err = c.DescribeLoadBalancersAsList(ctx, &elbv2.DescribeLoadBalancersInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, lbs, got)
+	got[0].SecurityGroups = nil
+	got, err = c.DescribeLoadBalancersAsList(ctx, &elbv2.DescribeLoadBalancersInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, lbs, got)
+
+	// results expire after ttl.
+	fakeClock.Step(time.Minute)
+	_, err = c.DescribeLoadBalancersAsList(ctx, &elbv2.DescribeLoadBalancersInput{})
+	assert.NoError(t, err)
+
+	// results are invalidated on mutations.
+	_, err = c.SetSecurityGroupsWithContext(ctx, &elbv2.SetSecurityGroupsInput{LoadBalancerArn: awssdk.String("lb-1")})
+	assert.NoError(t, err)
+	_, err = c.DescribeLoadBalancersAsList(ctx, &elbv2.DescribeLoadBalancersInput{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.hitsTotal.WithLabelValues(describeCacheOperationDescribeLoadBalancers)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(c.missesTotal.WithLabelValues(describeCacheOperationDescribeLoadBalancers)))
+}
+
+func Test_cachedELBV2_DescribeTargetGroupsAsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tgs := []*elbv2.TargetGroup{
+		{
+			TargetGroupArn: awssdk.String("tg-1"),
+		},
+	}
+	allTGsInput := &elbv2.DescribeTargetGroupsInput{}
+	tg1Input := &elbv2.DescribeTargetGroupsInput{TargetGroupArns: awssdk.StringSlice([]string{"tg-1"})}
+	elbv2Client := NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), allTGsInput).Return(tgs, nil).Times(2)
+	elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), tg1Input).Return(tgs, nil).Times(1)
+	elbv2Client.EXPECT().DeleteListenerWithContext(gomock.Any(), gomock.Any()).Return(&elbv2.DeleteListenerOutput{}, nil)
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	c := newTestCachedELBV2(elbv2Client, fakeClock, time.Minute)
+	ctx := context.Background()
+
+	// results are cached per request.
+	for i := 0; i < 2; i++ {
+		got, err := c.DescribeTargetGroupsAsList(ctx, &elbv2.DescribeTargetGroupsInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, tgs, got)
+		got, err = c.DescribeTargetGroupsAsList(ctx, &elbv2.DescribeTargetGroupsInput{TargetGroupArns: awssdk.StringSlice([]string{"tg-1"})})
+		assert.NoError(t, err)
+		assert.Equal(t, tgs, got)
+	}
+
+	// listeners change the loadBalancers associated with targetGroups.
+	_, err := c.DeleteListenerWithContext(ctx, &elbv2.DeleteListenerInput{ListenerArn: awssdk.String("ls-1")})
+	assert.NoError(t, err)
+	_, err = c.DescribeTargetGroupsAsList(ctx, &elbv2.DescribeTargetGroupsInput{})
+	assert.NoError(t, err)
+}
+
+func Test_cachedELBV2_DescribeTagsWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tagDescription1 := &elbv2.TagDescription{
+		ResourceArn: awssdk.String("arn-1"),
+		Tags:        []*elbv2.Tag{{Key: awssdk.String("key"), Value: awssdk.String("value-1")}},
+	}
+	tagDescription2 := &elbv2.TagDescription{
+		ResourceArn: awssdk.String("arn-2"),
+		Tags:        []*elbv2.Tag{{Key: awssdk.String("key"), Value: awssdk.String("value-2")}},
+	}
+	tagDescription3 := &elbv2.TagDescription{
+		ResourceArn: awssdk.String("arn-3"),
+		Tags:        []*elbv2.Tag{{Key: awssdk.String("key"), Value: awssdk.String("value-3")}},
+	}
+	elbv2Client := NewMockELBV2(ctrl)
+	gomock.InOrder(
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2.DescribeTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{"arn-1", "arn-2"}),
+		}).Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{tagDescription1, tagDescription2}}, nil),
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2.DescribeTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{"arn-3"}),
+		}).Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{tagDescription3}}, nil),
+		elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2.AddTagsOutput{}, nil),
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2.DescribeTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{"arn-1"}),
+		}).Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{tagDescription1}}, nil),
+	)
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	c := newTestCachedELBV2(elbv2Client, fakeClock, time.Minute)
+	ctx := context.Background()
+
+	got, err := c.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"arn-1", "arn-2"})})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*elbv2.TagDescription{tagDescription1, tagDescription2}, got.TagDescriptions)
+
+	// only tags of resources not in cache are described.
+	got, err = c.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"arn-2", "arn-3"})})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*elbv2.TagDescription{tagDescription2, tagDescription3}, got.TagDescriptions)
+
+	// tags are invalidated per resource on mutations.
+	_, err = c.AddTagsWithContext(ctx, &elbv2.AddTagsInput{ResourceArns: awssdk.StringSlice([]string{"arn-1"})})
+	assert.NoError(t, err)
+	got, err = c.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"arn-1", "arn-2", "arn-3"})})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*elbv2.TagDescription{tagDescription1, tagDescription2, tagDescription3}, got.TagDescriptions)
+}
+
+func Test_describeCache(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	c := newDescribeCache(fakeClock, time.Minute)
+
+	_, generation, exists := c.get("key")
+	assert.False(t, exists)
+	c.set("key", "value", generation)
+	value, _, exists := c.get("key")
+	assert.True(t, exists)
+	assert.Equal(t, "value", value)
+
+	// results of describe calls in flight during invalidation are not cached.
+	_, generation, _ = c.get("other-key")
+	c.invalidate("key")
+	c.set("other-key", "value", generation)
+	_, _, exists = c.get("other-key")
+	assert.False(t, exists)
+	_, _, exists = c.get("key")
+	assert.False(t, exists)
+
+	// values expire after ttl.
+	_, generation, _ = c.get("key")
+	c.set("key", "value", generation)
+	fakeClock.Step(time.Minute)
+	_, _, exists = c.get("key")
+	assert.False(t, exists)
+}
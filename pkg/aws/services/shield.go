@@ -11,7 +11,7 @@ import (
 type Shield interface {
 	shieldiface.ShieldAPI
 
-	Available() (bool, error)
+	Available(ctx context.Context) (bool, error)
 }
 
 // NewShield constructs new Shield implementation.
@@ -27,9 +27,9 @@ type defaultShield struct {
 	shieldiface.ShieldAPI
 }
 
-func (c *defaultShield) Available() (bool, error) {
+func (c *defaultShield) Available(ctx context.Context) (bool, error) {
 	req := &shield.GetSubscriptionStateInput{}
-	resp, err := c.GetSubscriptionStateWithContext(context.Background(), req)
+	resp, err := c.GetSubscriptionStateWithContext(ctx, req)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,108 @@
+package throttle
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"math"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	sdkHandlerMutationThrottle = "mutationThrottle"
+
+	metricMutationRateLimiterUtilization = "aws_mutation_rate_limiter_utilization"
+)
+
+// mutationOperationPtn matches AWS API operations that mutate resources.
+var mutationOperationPtn = regexp.MustCompile("^(Create|Modify|Delete|Set|Add|Remove|Register|Deregister|Associate|Disassociate|Authorize|Revoke)")
+
+// mutationThrottler throttles mutating AWS API operations across all services with a single token bucket,
+// while read operations are exempt.
+type mutationThrottler struct {
+	limiter *rate.Limiter
+
+	// tokens and tokensLastUpdated track the tokens available in limiter for the utilization metric,
+	// since they are not exposed by the limiter itself.
+	tokens            float64
+	tokensLastUpdated time.Time
+	tokensMutex       sync.Mutex
+}
+
+// NewMutationThrottler constructs new mutation throttler instance.
+// The limiter utilization is registered to metricsRegisterer if it's not nil.
+func NewMutationThrottler(r rate.Limit, burst int, metricsRegisterer prometheus.Registerer) (*mutationThrottler, error) {
+	t := &mutationThrottler{
+		limiter:           rate.NewLimiter(r, burst),
+		tokens:            float64(burst),
+		tokensLastUpdated: time.Now(),
+	}
+	if metricsRegisterer != nil {
+		utilization := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: metricMutationRateLimiterUtilization,
+			Help: "Fraction of the burst of mutating AWS API operations currently consumed, 1 means mutations are being throttled",
+		}, func() float64 {
+			return t.utilization(time.Now())
+		})
+		if err := metricsRegisterer.Register(utilization); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (t *mutationThrottler) InjectHandlers(handlers *request.Handlers) {
+	handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerMutationThrottle,
+		Fn:   t.beforeSign,
+	})
+}
+
+// beforeSign is added to the Sign chain; called before each request
+func (t *mutationThrottler) beforeSign(r *request.Request) {
+	if !isMutationRequest(r) {
+		return
+	}
+	if err := t.limiter.Wait(r.Context()); err != nil {
+		return
+	}
+	t.consumeToken(time.Now())
+}
+
+// consumeToken mirrors the token consumed from limiter at now.
+func (t *mutationThrottler) consumeToken(now time.Time) {
+	t.tokensMutex.Lock()
+	defer t.tokensMutex.Unlock()
+	t.tokens = t.availableTokens(now) - 1
+	t.tokensLastUpdated = now
+}
+
+// utilization returns the fraction of burst consumed at now.
+func (t *mutationThrottler) utilization(now time.Time) float64 {
+	t.tokensMutex.Lock()
+	defer t.tokensMutex.Unlock()
+	burst := float64(t.limiter.Burst())
+	if burst <= 0 {
+		return 1
+	}
+	return math.Max(0, math.Min(1, 1-t.availableTokens(now)/burst))
+}
+
+// availableTokens returns the tokens replenished until now, capped by burst.
+func (t *mutationThrottler) availableTokens(now time.Time) float64 {
+	elapsed := now.Sub(t.tokensLastUpdated)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	tokens := t.tokens + elapsed.Seconds()*float64(t.limiter.Limit())
+	return math.Min(tokens, float64(t.limiter.Burst()))
+}
+
+func isMutationRequest(r *request.Request) bool {
+	if r.Operation == nil {
+		return false
+	}
+	return mutationOperationPtn.MatchString(r.Operation.Name)
+}
@@ -0,0 +1,123 @@
+package throttle
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_mutationThrottler_InjectHandlers(t *testing.T) {
+	throttler, err := NewMutationThrottler(1, 1, nil)
+	assert.NoError(t, err)
+	handlers := request.Handlers{}
+	throttler.InjectHandlers(&handlers)
+	assert.Equal(t, 1, handlers.Sign.Len())
+}
+
+func Test_mutationThrottler_beforeSign(t *testing.T) {
+	tests := []struct {
+		name            string
+		operation       string
+		wantUtilization float64
+	}{
+		{
+			name:            "mutating operation consumes token",
+			operation:       "CreateTargetGroup",
+			wantUtilization: 0.5,
+		},
+		{
+			name:            "register targets consumes token",
+			operation:       "RegisterTargets",
+			wantUtilization: 0.5,
+		},
+		{
+			name:            "read operation is exempt",
+			operation:       "DescribeTargetGroups",
+			wantUtilization: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttler, err := NewMutationThrottler(rate.Limit(0.000001), 2, prometheus.NewRegistry())
+			assert.NoError(t, err)
+			r := &request.Request{
+				Operation:   &request.Operation{Name: tt.operation},
+				HTTPRequest: &http.Request{},
+			}
+			r.SetContext(context.Background())
+			throttler.beforeSign(r)
+			assert.InDelta(t, tt.wantUtilization, throttler.utilization(time.Now()), 0.01)
+		})
+	}
+}
+
+func Test_mutationThrottler_utilization(t *testing.T) {
+	now := time.Now()
+	throttler, err := NewMutationThrottler(10, 10, nil)
+	assert.NoError(t, err)
+	throttler.tokensLastUpdated = now
+
+	assert.Equal(t, float64(0), throttler.utilization(now))
+	for i := 0; i < 10; i++ {
+		throttler.consumeToken(now)
+	}
+	assert.Equal(t, float64(1), throttler.utilization(now))
+	// tokens are replenished at the rate limit.
+	assert.InDelta(t, 0.5, throttler.utilization(now.Add(500*time.Millisecond)), 0.0001)
+	assert.Equal(t, float64(0), throttler.utilization(now.Add(2*time.Second)))
+}
+
+func Test_isMutationRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation *request.Operation
+		want      bool
+	}{
+		{
+			name:      "create",
+			operation: &request.Operation{Name: "CreateLoadBalancer"},
+			want:      true,
+		},
+		{
+			name:      "modify",
+			operation: &request.Operation{Name: "ModifyListener"},
+			want:      true,
+		},
+		{
+			name:      "delete",
+			operation: &request.Operation{Name: "DeleteRule"},
+			want:      true,
+		},
+		{
+			name:      "deregister",
+			operation: &request.Operation{Name: "DeregisterTargets"},
+			want:      true,
+		},
+		{
+			name:      "describe",
+			operation: &request.Operation{Name: "DescribeListeners"},
+			want:      false,
+		},
+		{
+			name:      "get",
+			operation: &request.Operation{Name: "GetWebACLForResource"},
+			want:      false,
+		},
+		{
+			name:      "nil operation",
+			operation: nil,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isMutationRequest(&request.Request{Operation: tt.operation})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
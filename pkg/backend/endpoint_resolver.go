@@ -7,14 +7,19 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"net"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
 )
 
 var ErrNotFound = errors.New("backend not found")
 
+const resourceTypeEndpoints = "endpoints"
+
 // TODO: for pod endpoints, we currently rely on endpoints events, we might change to use pod events directly in the future.
 // under current implementation with pod readinessGate enabled, an unready endpoint but not match our inclusionCriteria won't be registered,
 // and it won't turn ready due to blocked by readinessGate, and no future endpoint events will trigger.
@@ -33,11 +38,13 @@ type EndpointResolver interface {
 }
 
 // NewDefaultEndpointResolver constructs new defaultEndpointResolver
-func NewDefaultEndpointResolver(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) *defaultEndpointResolver {
+// Endpoints are fetched from endpointsRepo if it's not nil, otherwise from k8sClient.
+func NewDefaultEndpointResolver(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, endpointsRepo k8s.EndpointsRepo, logger logr.Logger) *defaultEndpointResolver {
 	return &defaultEndpointResolver{
-		k8sClient:   k8sClient,
-		podInfoRepo: podInfoRepo,
-		logger:      logger,
+		k8sClient:     k8sClient,
+		podInfoRepo:   podInfoRepo,
+		endpointsRepo: endpointsRepo,
+		logger:        logger,
 	}
 }
 
@@ -45,9 +52,10 @@ var _ EndpointResolver = &defaultEndpointResolver{}
 
 // default implementation for EndpointResolver
 type defaultEndpointResolver struct {
-	k8sClient   client.Client
-	podInfoRepo k8s.PodInfoRepo
-	logger      logr.Logger
+	k8sClient     client.Client
+	podInfoRepo   k8s.PodInfoRepo
+	endpointsRepo k8s.EndpointsRepo
+	logger        logr.Logger
 }
 
 func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString,
@@ -60,15 +68,13 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 		return nil, false, err
 	}
 
-	epsKey := k8s.NamespacedName(svc) // k8s Endpoints have same name as k8s Service
-	eps := &corev1.Endpoints{}
-	if err := r.k8sClient.Get(ctx, epsKey, eps); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil, false, fmt.Errorf("%w: %v", ErrNotFound, err.Error())
-		}
+	eps, err := r.findEndpoints(ctx, svc)
+	if err != nil {
 		return nil, false, err
 	}
 
+	// with custom readiness condition, pods are registered based on that condition regardless of the readiness of addresses.
+	useReadinessCondition := resolveOpts.PodReadinessConditionType != corev1.PodReady
 	containsPotentialReadyEndpoints := false
 	var endpoints []PodEndpoint
 	for _, epSubset := range eps.Subsets {
@@ -89,6 +95,13 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 				if !exists {
 					return nil, false, errors.New("couldn't find podInfo for ready endpoint")
 				}
+				if !resolveOpts.PodSelector.Matches(labels.Set(pod.Labels)) {
+					continue
+				}
+				if useReadinessCondition && !pod.IsPodConditionTrue(resolveOpts.PodReadinessConditionType) {
+					containsPotentialReadyEndpoints = true
+					continue
+				}
                 //remap the endpoint
                 podTemp := &corev1.Pod{}
 				podRef := *epAddr.TargetRef
@@ -100,10 +113,10 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 						epAddr.IP = v
 					}
 				}
-				endpoints = append(endpoints, buildPodEndpoint(pod, epAddr, epPort))
+				endpoints = append(endpoints, buildPodEndpoints(pod, epAddr, epPort, resolveOpts.PodIPFamilies)...)
 			}
 
-			if len(resolveOpts.PodReadinessGates) != 0 {
+			if len(resolveOpts.PodReadinessGates) != 0 || useReadinessCondition {
 				for _, epAddr := range epSubset.NotReadyAddresses {
 					if epAddr.TargetRef == nil || epAddr.TargetRef.Kind != "Pod" {
 						continue
@@ -116,12 +129,22 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 						containsPotentialReadyEndpoints = true
 						continue
 					}
-					if !pod.HasAnyOfReadinessGates(resolveOpts.PodReadinessGates) {
+					if !resolveOpts.PodSelector.Matches(labels.Set(pod.Labels)) {
 						continue
 					}
-					if !pod.IsContainersReady() {
-						containsPotentialReadyEndpoints = true
-						continue
+					if useReadinessCondition {
+						if !pod.IsPodConditionTrue(resolveOpts.PodReadinessConditionType) {
+							containsPotentialReadyEndpoints = true
+							continue
+						}
+					} else {
+						if !pod.HasAnyOfReadinessGates(resolveOpts.PodReadinessGates) {
+							continue
+						}
+						if !pod.IsContainersReady() {
+							containsPotentialReadyEndpoints = true
+							continue
+						}
 					}
                     //remap the endpoint
                     podTemp := &corev1.Pod{}
@@ -134,7 +157,7 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 						    epAddr.IP = v
 					    }
 				    }
-					endpoints = append(endpoints, buildPodEndpoint(pod, epAddr, epPort))
+					endpoints = append(endpoints, buildPodEndpoints(pod, epAddr, epPort, resolveOpts.PodIPFamilies)...)
 				}
 			}
 		}
@@ -163,7 +186,10 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 	var endpoints []NodePortEndpoint
 	for i := range nodeList.Items {
 		node := &nodeList.Items[i]
-		if !k8s.IsNodeSuitableAsTrafficProxy(node) {
+		if k8s.IsNodeDraining(node, resolveOpts.NodeDrainConditions) {
+			continue
+		}
+		if !k8s.IsNodeSuitableAsTrafficProxy(node) && !isNodeWithinStartupGracePeriod(node, resolveOpts.NodeStartupGracePeriod) {
 			continue
 		}
 		instanceID, err := k8s.ExtractNodeInstanceID(node)
@@ -176,6 +202,31 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 	return endpoints, nil
 }
 
+// findEndpoints finds the k8s Endpoints of service, which have same name as k8s Service.
+func (r *defaultEndpointResolver) findEndpoints(ctx context.Context, svc *corev1.Service) (*corev1.Endpoints, error) {
+	epsKey := k8s.NamespacedName(svc)
+	if r.endpointsRepo != nil {
+		eps, exists, err := r.endpointsRepo.Get(ctx, epsKey)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			err := apierrors.NewNotFound(corev1.Resource(resourceTypeEndpoints), epsKey.Name)
+			return nil, fmt.Errorf("%w: %v", ErrNotFound, err.Error())
+		}
+		return eps, nil
+	}
+
+	eps := &corev1.Endpoints{}
+	if err := r.k8sClient.Get(ctx, epsKey, eps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %v", ErrNotFound, err.Error())
+		}
+		return nil, err
+	}
+	return eps, nil
+}
+
 func (r *defaultEndpointResolver) findServiceAndServicePort(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString) (*corev1.Service, corev1.ServicePort, error) {
 	svc := &corev1.Service{}
 	if err := r.k8sClient.Get(ctx, svcKey, svc); err != nil {
@@ -205,6 +256,56 @@ func buildPodEndpoint(pod k8s.PodInfo, epAddr corev1.EndpointAddress, epPort cor
 	}
 }
 
+// buildPodEndpoints will build podEndpoints for pod, one per pod IP of ipFamilies.
+// If ipFamilies is empty, the IP from Endpoints address is used.
+func buildPodEndpoints(pod k8s.PodInfo, epAddr corev1.EndpointAddress, epPort corev1.EndpointPort, ipFamilies []corev1.IPFamily) []PodEndpoint {
+	if len(ipFamilies) == 0 {
+		return []PodEndpoint{buildPodEndpoint(pod, epAddr, epPort)}
+	}
+	var endpoints []PodEndpoint
+	for _, ipFamily := range ipFamilies {
+		ip, exists := lookupPodIPOfFamily(pod, epAddr, ipFamily)
+		if !exists {
+			continue
+		}
+		epAddrOfFamily := epAddr
+		epAddrOfFamily.IP = ip
+		endpoints = append(endpoints, buildPodEndpoint(pod, epAddrOfFamily, epPort))
+	}
+	return endpoints
+}
+
+// lookupPodIPOfFamily returns the pod IP of ipFamily, the IP from Endpoints address is preferred if it's of ipFamily.
+func lookupPodIPOfFamily(pod k8s.PodInfo, epAddr corev1.EndpointAddress, ipFamily corev1.IPFamily) (string, bool) {
+	candidateIPs := append([]string{epAddr.IP}, pod.PodIPs...)
+	for _, ip := range candidateIPs {
+		if ipFamilyOf(ip) == ipFamily {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+// ipFamilyOf returns the IP family of ip, or empty if ip is invalid.
+func ipFamilyOf(ip string) corev1.IPFamily {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ""
+	}
+	if parsedIP.To4() != nil {
+		return corev1.IPv4Protocol
+	}
+	return corev1.IPv6Protocol
+}
+
+// isNodeWithinStartupGracePeriod checks whether node is created within the startup grace period, and not about to be removed.
+func isNodeWithinStartupGracePeriod(node *corev1.Node, gracePeriod time.Duration) bool {
+	if gracePeriod <= 0 || k8s.IsNodeToBeDeleted(node) {
+		return false
+	}
+	return time.Since(node.CreationTimestamp.Time) < gracePeriod
+}
+
 func buildNodePortEndpoint(node *corev1.Node, instanceID string, nodePort int32) NodePortEndpoint {
 	return NodePortEndpoint{
 		InstanceID: instanceID,
@@ -21,6 +21,7 @@ import (
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
@@ -379,6 +380,89 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		},
 	}
 
+	pod6 := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: testNS, Name: "pod-6"},
+		UID: "pod-uuid-6",
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionFalse,
+			},
+			{
+				Type:   "example.com/warmed-up",
+				Status: corev1.ConditionTrue,
+			},
+		},
+		PodIP: "192.168.1.6",
+	}
+	pod7 := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: testNS, Name: "pod-7"},
+		UID: "pod-uuid-7",
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   "example.com/warmed-up",
+				Status: corev1.ConditionTrue,
+			},
+		},
+		PodIP: "192.168.1.7",
+	}
+	ep1E := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Ports: []corev1.EndpointPort{
+					{
+						Name: "http",
+						Port: 8080,
+					},
+				},
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP: pod1.PodIP,
+						TargetRef: &corev1.ObjectReference{
+							Kind:      "Pod",
+							Namespace: pod1.Key.Namespace,
+							Name:      pod1.Key.Name,
+						},
+					},
+					{
+						IP: pod7.PodIP,
+						TargetRef: &corev1.ObjectReference{
+							Kind:      "Pod",
+							Namespace: pod7.Key.Namespace,
+							Name:      pod7.Key.Name,
+						},
+					},
+				},
+				NotReadyAddresses: []corev1.EndpointAddress{
+					{
+						IP: pod3.PodIP,
+						TargetRef: &corev1.ObjectReference{
+							Kind:      "Pod",
+							Namespace: pod3.Key.Namespace,
+							Name:      pod3.Key.Name,
+						},
+					},
+					{
+						IP: pod6.PodIP,
+						TargetRef: &corev1.ObjectReference{
+							Kind:      "Pod",
+							Namespace: pod6.Key.Namespace,
+							Name:      pod6.Key.Name,
+						},
+					},
+				},
+			},
+		},
+	}
+
 	type podInfoRepoGetCall struct {
 		key    types.NamespacedName
 		pod    k8s.PodInfo
@@ -499,6 +583,55 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			},
 			wantContainsPotentialReadyEndpoints: false,
 		},
+		{
+			name: "pods are included based on custom readiness condition regardless of address readiness",
+			env: env{
+				services:      []*corev1.Service{svc1},
+				endpointsList: []*corev1.Endpoints{ep1E},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+					{
+						key:    pod7.Key,
+						pod:    pod7,
+						exists: true,
+					},
+					{
+						key:    pod3.Key,
+						pod:    pod3,
+						exists: true,
+					},
+					{
+						key:    pod6.Key,
+						pod:    pod6,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithPodReadinessConditionType("example.com/warmed-up")},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.6",
+					Port: 8080,
+					Pod:  pod6,
+				},
+				{
+					IP:   "192.168.1.7",
+					Port: 8080,
+					Pod:  pod7,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: true,
+		},
 		{
 			name: "endpoints with multiple subsets should work as expected",
 			env: env{
@@ -894,6 +1027,63 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 		},
 	}
+	node5 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node-5",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Minute).Truncate(time.Second)),
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: "aws:///us-west-2b/i-abcdefg5",
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+	node6 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node-6",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Minute).Truncate(time.Second)),
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: "aws:///us-west-2b/i-abcdefg6",
+			Taints: []corev1.Taint{
+				{
+					Key:    "ToBeDeletedByClusterAutoscaler",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+	node7 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-7",
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID:    "aws:///us-west-2b/i-abcdefg7",
+			Unschedulable: true,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
 	svc1 := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
@@ -1024,6 +1214,79 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "choose every ready node and nodes not ready within startup grace period",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4, node5, node6},
+				services: []*corev1.Service{svc1},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything()), WithNodeStartupGracePeriod(5 * time.Minute)},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+				{
+					InstanceID: "i-abcdefg2",
+					Port:       18080,
+					Node:       node2,
+				},
+				{
+					InstanceID: "i-abcdefg5",
+					Port:       18080,
+					Node:       node5,
+				},
+			},
+		},
+		{
+			name: "cordoned nodes are included by default",
+			env: env{
+				nodes:    []*corev1.Node{node1, node7},
+				services: []*corev1.Service{svc1},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+				{
+					InstanceID: "i-abcdefg7",
+					Port:       18080,
+					Node:       node7,
+				},
+			},
+		},
+		{
+			name: "cordoned nodes are excluded when draining cordoned nodes",
+			env: env{
+				nodes:    []*corev1.Node{node1, node7},
+				services: []*corev1.Service{svc1},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts: []EndpointResolveOption{WithNodeSelector(labels.Everything()),
+					WithNodeDrainConditions(k8s.NodeDrainConditions{Cordoned: true})},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+			},
+		},
 		{
 			name: "clusterIP service is not supported",
 			env: env{
@@ -1099,3 +1362,232 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultEndpointResolver_findEndpoints(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-1",
+		},
+	}
+	eps := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-1",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP: "192.168.1.1",
+					},
+				},
+			},
+		},
+	}
+	type endpointsRepoGetCall struct {
+		key    types.NamespacedName
+		eps    *corev1.Endpoints
+		exists bool
+		err    error
+	}
+	tests := []struct {
+		name                  string
+		withEndpointsRepo     bool
+		endpointsRepoGetCalls []endpointsRepoGetCall
+		endpointsList         []*corev1.Endpoints
+		want                  *corev1.Endpoints
+		wantErr               error
+	}{
+		{
+			name:              "endpoints found in endpointsRepo",
+			withEndpointsRepo: true,
+			endpointsRepoGetCalls: []endpointsRepoGetCall{
+				{
+					key:    types.NamespacedName{Namespace: "ns-1", Name: "svc-1"},
+					eps:    eps,
+					exists: true,
+				},
+			},
+			want: eps,
+		},
+		{
+			name:              "endpoints not found in endpointsRepo",
+			withEndpointsRepo: true,
+			endpointsRepoGetCalls: []endpointsRepoGetCall{
+				{
+					key:    types.NamespacedName{Namespace: "ns-1", Name: "svc-1"},
+					exists: false,
+				},
+			},
+			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "endpoints \"svc-1\" not found"),
+		},
+		{
+			name:              "endpointsRepo failed",
+			withEndpointsRepo: true,
+			endpointsRepoGetCalls: []endpointsRepoGetCall{
+				{
+					key: types.NamespacedName{Namespace: "ns-1", Name: "svc-1"},
+					err: errors.New("endpoints cache not synced"),
+				},
+			},
+			wantErr: errors.New("endpoints cache not synced"),
+		},
+		{
+			name:          "endpoints found via k8sClient without endpointsRepo",
+			endpointsList: []*corev1.Endpoints{eps},
+			want:          eps,
+		},
+		{
+			name:    "endpoints not found via k8sClient without endpointsRepo",
+			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "endpoints \"svc-1\" not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, endpoints := range tt.endpointsList {
+				assert.NoError(t, k8sClient.Create(ctx, endpoints.DeepCopy()))
+			}
+
+			r := &defaultEndpointResolver{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+			if tt.withEndpointsRepo {
+				endpointsRepo := k8s.NewMockEndpointsRepo(ctrl)
+				for _, call := range tt.endpointsRepoGetCalls {
+					endpointsRepo.EXPECT().Get(gomock.Any(), call.key).Return(call.eps, call.exists, call.err)
+				}
+				r.endpointsRepo = endpointsRepo
+			}
+			got, err := r.findEndpoints(ctx, svc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opt := equality.IgnoreFakeClientPopulatedFields()
+				assert.True(t, cmp.Equal(tt.want, got, opt),
+					"diff: %v", cmp.Diff(tt.want, got, opt))
+			}
+		})
+	}
+}
+
+func Test_buildPodEndpoints(t *testing.T) {
+	ipv4Pod := k8s.PodInfo{
+		Key:    types.NamespacedName{Namespace: "default", Name: "pod-1"},
+		PodIP:  "192.168.1.1",
+		PodIPs: []string{"192.168.1.1"},
+	}
+	dualStackPod := k8s.PodInfo{
+		Key:    types.NamespacedName{Namespace: "default", Name: "pod-2"},
+		PodIP:  "192.168.1.2",
+		PodIPs: []string{"192.168.1.2", "2600:1f14:f8c:2700::2"},
+	}
+	ipv6Pod := k8s.PodInfo{
+		Key:    types.NamespacedName{Namespace: "default", Name: "pod-3"},
+		PodIP:  "2600:1f14:f8c:2700::3",
+		PodIPs: []string{"2600:1f14:f8c:2700::3"},
+	}
+	epPort := corev1.EndpointPort{Port: 8080}
+	type args struct {
+		pod        k8s.PodInfo
+		epAddr     corev1.EndpointAddress
+		ipFamilies []corev1.IPFamily
+	}
+	tests := []struct {
+		name string
+		args args
+		want []PodEndpoint
+	}{
+		{
+			name: "without ipFamilies",
+			args: args{
+				pod:    dualStackPod,
+				epAddr: corev1.EndpointAddress{IP: "192.168.1.2"},
+			},
+			want: []PodEndpoint{
+				{IP: "192.168.1.2", Port: 8080, Pod: dualStackPod},
+			},
+		},
+		{
+			name: "ipv4 family with ipv4 pod",
+			args: args{
+				pod:        ipv4Pod,
+				epAddr:     corev1.EndpointAddress{IP: "192.168.1.1"},
+				ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+			},
+			want: []PodEndpoint{
+				{IP: "192.168.1.1", Port: 8080, Pod: ipv4Pod},
+			},
+		},
+		{
+			name: "ipv6 family with ipv4 pod",
+			args: args{
+				pod:        ipv4Pod,
+				epAddr:     corev1.EndpointAddress{IP: "192.168.1.1"},
+				ipFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+			},
+			want: nil,
+		},
+		{
+			name: "ipv6 family with dualstack pod",
+			args: args{
+				pod:        dualStackPod,
+				epAddr:     corev1.EndpointAddress{IP: "192.168.1.2"},
+				ipFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+			},
+			want: []PodEndpoint{
+				{IP: "2600:1f14:f8c:2700::2", Port: 8080, Pod: dualStackPod},
+			},
+		},
+		{
+			name: "dualstack families with dualstack pod",
+			args: args{
+				pod:        dualStackPod,
+				epAddr:     corev1.EndpointAddress{IP: "192.168.1.2"},
+				ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			},
+			want: []PodEndpoint{
+				{IP: "192.168.1.2", Port: 8080, Pod: dualStackPod},
+				{IP: "2600:1f14:f8c:2700::2", Port: 8080, Pod: dualStackPod},
+			},
+		},
+		{
+			name: "dualstack families with ipv6 pod",
+			args: args{
+				pod:        ipv6Pod,
+				epAddr:     corev1.EndpointAddress{IP: "2600:1f14:f8c:2700::3"},
+				ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			},
+			want: []PodEndpoint{
+				{IP: "2600:1f14:f8c:2700::3", Port: 8080, Pod: ipv6Pod},
+			},
+		},
+		{
+			name: "dualstack families prefer IP from Endpoints address",
+			args: args{
+				pod:        dualStackPod,
+				epAddr:     corev1.EndpointAddress{IP: "10.0.0.2"},
+				ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			},
+			want: []PodEndpoint{
+				{IP: "10.0.0.2", Port: 8080, Pod: dualStackPod},
+				{IP: "2600:1f14:f8c:2700::2", Port: 8080, Pod: dualStackPod},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPodEndpoints(tt.args.pod, tt.args.epAddr, epPort, tt.args.ipFamilies)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package backend
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
@@ -32,9 +34,31 @@ type EndpointResolveOptions struct {
 	// By default, no node will be selected.
 	NodeSelector labels.Selector
 
+	// [NodePort Endpoint] If nodeStartupGracePeriod is specified, then nodes that are not ready but created within the grace period will be included as well.
+	// By default, no grace period is specified.
+	NodeStartupGracePeriod time.Duration
+
+	// [NodePort Endpoint] nodes draining under nodeDrainConditions will be excluded, so that their targets are deregistered.
+	// By default, no node is considered draining.
+	NodeDrainConditions k8s.NodeDrainConditions
+
 	// [Pod Endpoint] If pod readinessGates is defined, then pods from unready addresses with any of these readinessGates and containersReady condition will be included as well.
 	// By default, no readinessGate is specified.
 	PodReadinessGates []corev1.PodConditionType
+
+	// [Pod Endpoint] only pods matched by podSelector will be included.
+	// By default, all pods will be selected.
+	PodSelector labels.Selector
+
+	// [Pod Endpoint] If podReadinessConditionType is other than PodReady, then pods from both ready and unready addresses
+	// will be included once this condition is true, instead of relying on the readiness of addresses.
+	// By default, it's PodReady.
+	PodReadinessConditionType corev1.PodConditionType
+
+	// [Pod Endpoint] If podIPFamilies is specified, then a podEndpoint will be included per pod IP of these IP families,
+	// instead of the IP from Endpoints address. Pods without IP of an IP family won't have podEndpoint for that IP family.
+	// By default, no IP family is specified.
+	PodIPFamilies []corev1.IPFamily
 }
 
 func (opts *EndpointResolveOptions) ApplyOptions(options []EndpointResolveOption) {
@@ -52,6 +76,27 @@ func WithNodeSelector(nodeSelector labels.Selector) EndpointResolveOption {
 	}
 }
 
+// WithNodeStartupGracePeriod is a option that sets nodeStartupGracePeriod.
+func WithNodeStartupGracePeriod(gracePeriod time.Duration) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.NodeStartupGracePeriod = gracePeriod
+	}
+}
+
+// WithNodeDrainConditions is a option that sets nodeDrainConditions.
+func WithNodeDrainConditions(conditions k8s.NodeDrainConditions) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.NodeDrainConditions = conditions
+	}
+}
+
+// WithPodSelector is a option that sets podSelector.
+func WithPodSelector(podSelector labels.Selector) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.PodSelector = podSelector
+	}
+}
+
 // WithPodReadinessGate is a option that appends podReadinessGate into EndpointResolveOptions.
 func WithPodReadinessGate(cond corev1.PodConditionType) EndpointResolveOption {
 	return func(opts *EndpointResolveOptions) {
@@ -59,10 +104,26 @@ func WithPodReadinessGate(cond corev1.PodConditionType) EndpointResolveOption {
 	}
 }
 
+// WithPodReadinessConditionType is a option that sets podReadinessConditionType.
+func WithPodReadinessConditionType(conditionType corev1.PodConditionType) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.PodReadinessConditionType = conditionType
+	}
+}
+
+// WithPodIPFamilies is a option that sets podIPFamilies.
+func WithPodIPFamilies(ipFamilies []corev1.IPFamily) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.PodIPFamilies = ipFamilies
+	}
+}
+
 // defaultEndpointResolveOptions returns the default value for EndpointResolveOptions.
 func defaultEndpointResolveOptions() EndpointResolveOptions {
 	return EndpointResolveOptions{
-		NodeSelector:      labels.Nothing(),
-		PodReadinessGates: nil,
+		NodeSelector:              labels.Nothing(),
+		PodReadinessGates:         nil,
+		PodSelector:               labels.Everything(),
+		PodReadinessConditionType: corev1.PodReady,
 	}
 }
@@ -18,6 +18,7 @@ func TestEndpointResolveOptions_ApplyOptions(t *testing.T) {
 			opts: []EndpointResolveOption{WithNodeSelector(labels.Everything())},
 			want: EndpointResolveOptions{
 				NodeSelector: labels.Everything(),
+				PodSelector:  labels.Everything(),
 			},
 		},
 		{
@@ -28,12 +29,21 @@ func TestEndpointResolveOptions_ApplyOptions(t *testing.T) {
 			},
 			want: EndpointResolveOptions{
 				NodeSelector: labels.Nothing(),
+				PodSelector:  labels.Everything(),
 				PodReadinessGates: []corev1.PodConditionType{
 					"target-health.ingress.k8s.aws/some-tgb-1",
 					"target-health.ingress.k8s.aws/some-tgb-2",
 				},
 			},
 		},
+		{
+			name: "set podSelector",
+			opts: []EndpointResolveOption{WithPodSelector(labels.Set{"role": "web"}.AsSelectorPreValidated())},
+			want: EndpointResolveOptions{
+				NodeSelector: labels.Nothing(),
+				PodSelector:  labels.Set{"role": "web"}.AsSelectorPreValidated(),
+			},
+		},
 		{
 			name: "set labelSelector & readinessGate",
 			opts: []EndpointResolveOption{
@@ -43,6 +53,7 @@ func TestEndpointResolveOptions_ApplyOptions(t *testing.T) {
 			want: EndpointResolveOptions{
 				NodeSelector:      labels.Everything(),
 				PodReadinessGates: []corev1.PodConditionType{"target-health.ingress.k8s.aws/some-tgb"},
+				PodSelector:       labels.Everything(),
 			},
 		},
 	}
@@ -51,6 +62,7 @@ func TestEndpointResolveOptions_ApplyOptions(t *testing.T) {
 			opts := defaultEndpointResolveOptions()
 			opts.ApplyOptions(tt.opts)
 			assert.Equal(t, tt.want.NodeSelector, opts.NodeSelector)
+			assert.Equal(t, tt.want.PodSelector, opts.PodSelector)
 		})
 	}
 }
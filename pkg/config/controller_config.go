@@ -1,10 +1,16 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 )
 
 const (
@@ -14,9 +20,53 @@ const (
 	flagServiceMaxConcurrentReconciles            = "service-max-concurrent-reconciles"
 	flagTargetGroupBindingMaxConcurrentReconciles = "targetgroupbinding-max-concurrent-reconciles"
 	flagDefaultSSLPolicy                          = "default-ssl-policy"
+	flagResourceNamespaceTagKey                   = "resource-namespace-tag-key"
+	flagResourceNameTagKey                        = "resource-name-tag-key"
+	flagEnableEndpointZoneStatus                  = "enable-endpoint-zone-status"
+	flagWebhookFailClosedOnAWSErrors              = "webhook-fail-closed-on-aws-errors"
+	flagDefaultTargetType                         = "default-target-type"
+	flagManagedTagKeyPrefixes                     = "managed-tag-key-prefixes"
+	flagDisableDeletionProtectionOnCleanup        = "disable-deletion-protection-on-cleanup"
+	flagManagedLoadBalancerReplacement            = "managed-load-balancer-replacement"
+	flagRequireExplicitOptIn                      = "require-explicit-opt-in"
+	flagTargetGroupBindingTagLabelPrefix          = "targetgroupbinding-tag-label-prefix"
+	flagUnhealthyTargetsRequeueInterval           = "targetgroupbinding-unhealthy-targets-requeue-interval"
+	flagHealthyTargetsRequeueInterval             = "targetgroupbinding-healthy-targets-requeue-interval"
+	flagTargetHealthPollInterval                  = "targetgroupbinding-target-health-poll-interval"
+	flagTargetGroupBindingFinalizer               = "targetgroupbinding-finalizer"
+	flagNodeStartupGracePeriod                    = "targetgroupbinding-node-startup-grace-period"
+	flagDrainCordonedNodes                        = "targetgroupbinding-drain-cordoned-nodes"
+	flagDrainNodeTaints                           = "targetgroupbinding-drain-node-taints"
+	flagRegisterRunningInstancesOnly              = "targetgroupbinding-register-running-instances-only"
+	flagServiceFinalizer                          = "service-finalizer"
+	flagEnableOrphanedResourcesGC                 = "enable-orphaned-resources-gc"
+	flagOrphanedResourcesGCDryRun                 = "orphaned-resources-gc-dry-run"
+	flagEnableEndpointSlices                      = "enable-endpoint-slices"
+	flagTargetGroupAttributesPolicy               = "target-group-attributes-policy"
 	defaultLogLevel                               = "info"
 	defaultMaxConcurrentReconciles                = 3
 	defaultSSLPolicy                              = "ELBSecurityPolicy-2016-08"
+	defaultResourceNamespaceTagKey                = "elbv2.k8s.aws/namespace"
+	defaultResourceNameTagKey                     = "elbv2.k8s.aws/resource"
+	defaultTargetType                             = targetTypeInstance
+	defaultDisableDeletionProtectionOnCleanup     = true
+	defaultUnhealthyTargetsRequeueInterval        = 15 * time.Second
+	defaultHealthyTargetsRequeueInterval          = 5 * time.Minute
+	defaultTargetGroupBindingFinalizer            = "elbv2.k8s.aws/resources"
+	defaultServiceFinalizer                       = "service.k8s.aws/resources"
+	defaultOrphanedResourcesGCDryRun              = true
+	defaultEnableEndpointSlices                   = endpointSlicesAuto
+	defaultTargetGroupAttributesPolicy            = tgAttributesPolicyControllerOwned
+
+	targetTypeInstance = "instance"
+	targetTypeIP       = "ip"
+
+	endpointSlicesAuto     = "auto"
+	endpointSlicesEnabled  = "true"
+	endpointSlicesDisabled = "false"
+
+	tgAttributesPolicyControllerOwned   = "controller-owned"
+	tgAttributesPolicyPreserveUnmanaged = "preserve-unmanaged"
 )
 
 // ControllerConfig contains the controller configuration
@@ -39,14 +89,68 @@ type ControllerConfig struct {
 	// Default AWS Tags that will be applied to all AWS resources managed by this controller.
 	DefaultTags map[string]string
 
+	// AWS Tag key prefixes of tags managed by this controller.
+	// When specified, tags on ELBV2 resources whose keys don't match any of the prefixes are never removed.
+	ManagedTagKeyPrefixes []string
+
 	// Default SSL Policy that will be applied to all ingresses or services that do not have
 	// the SSL Policy annotation.
 	DefaultSSLPolicy string
 
+	// Default target type that will be applied to all ingresses or services that do not have
+	// the target type annotation.
+	DefaultTargetType string
+
+	// AWS Tag key used to record the namespace of the Kubernetes resource that owns an AWS resource.
+	// The tag is not applied if empty.
+	ResourceNamespaceTagKey string
+	// AWS Tag key used to record the name of the Kubernetes resource that owns an AWS resource.
+	// The tag is not applied if empty.
+	ResourceNameTagKey string
+
 	// Max concurrent reconcile loops for Service objects
 	ServiceMaxConcurrentReconciles int
 	// Max concurrent reconcile loops for TargetGroupBinding objects
 	TargetGroupBindingMaxConcurrentReconciles int
+	// Prefix of TargetGroupBinding labels that are propagated as tags on its TargetGroups, empty means disabled.
+	TargetGroupBindingTagLabelPrefix string
+	// Interval to requeue TargetGroupBindings while any pod with targetHealth readiness gate isn't healthy
+	TargetGroupBindingUnhealthyTargetsRequeueInterval time.Duration
+	// Interval to requeue TargetGroupBindings once all pods with targetHealth readiness gate are healthy, zero means no requeue
+	TargetGroupBindingHealthyTargetsRequeueInterval time.Duration
+	// Interval to poll the health of targets to export per TargetGroupBinding metrics, zero means disabled
+	TargetGroupBindingTargetHealthPollInterval time.Duration
+	// Grace period since node creation, within which instance targets of nodes not ready yet are not deregistered, zero means disabled
+	TargetGroupBindingNodeStartupGracePeriod time.Duration
+	// Whether instance targets of cordoned nodes are deregistered to drain connections before node termination
+	TargetGroupBindingDrainCordonedNodes bool
+	// Taint keys of nodes whose instance targets are deregistered to drain connections before node termination
+	TargetGroupBindingDrainNodeTaints []string
+	// Whether only nodes whose EC2 instances are in running state are registered as instance targets
+	TargetGroupBindingRegisterRunningInstancesOnly bool
+	// Finalizer added to TargetGroupBinding objects to cleanup targets before deletion
+	TargetGroupBindingFinalizer string
+	// Finalizer added to Service objects to cleanup load balancers before deletion
+	ServiceFinalizer string
+	// Experimental: populate the distribution of endpoints across availability zones in TargetGroupBinding status
+	EnableEndpointZoneStatus bool
+	// Whether webhooks reject objects when validations cannot be done due to AWS errors
+	WebhookFailClosedOnAWSErrors bool
+	// Whether to disable deletion protection of load balancers before deleting them during cleanup.
+	// If disabled, load balancers with deletion protection enabled must be deleted manually.
+	DisableDeletionProtectionOnCleanup bool
+	// Whether load balancers requiring replacement due to immutable changes, e.g. scheme, are only deleted after the replacement is created.
+	ManagedLoadBalancerReplacement bool
+	// Whether Ingresses and Services are only managed when explicitly opted in via the "elbv2.k8s.aws/managed: true" annotation.
+	RequireExplicitOptIn bool
+	// Whether AWS resources whose owning Ingress or Service no longer exists are collected on startup.
+	EnableOrphanedResourcesGC bool
+	// Whether orphaned AWS resources are only logged instead of deleted.
+	OrphanedResourcesGCDryRun bool
+	// Whether endpoints of services are resolved from EndpointSlices instead of Endpoints, "auto" means detected via API discovery.
+	EnableEndpointSlices string
+	// Whether target group attributes defaulted by the controller are managed, or only those explicitly specified via annotations.
+	TargetGroupAttributesPolicy string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -56,12 +160,58 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.ClusterName, flagK8sClusterName, "", "Kubernetes cluster name")
 	fs.StringToStringVar(&cfg.DefaultTags, flagDefaultTags, nil,
 		"Default AWS Tags that will be applied to all AWS resources managed by this controller")
+	fs.StringSliceVar(&cfg.ManagedTagKeyPrefixes, flagManagedTagKeyPrefixes, nil,
+		"AWS Tag key prefixes of tags managed by this controller, tags on ELBV2 resources not matching any of them are never removed. Empty to manage all tags")
 	fs.IntVar(&cfg.ServiceMaxConcurrentReconciles, flagServiceMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for service")
 	fs.IntVar(&cfg.TargetGroupBindingMaxConcurrentReconciles, flagTargetGroupBindingMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for targetGroupBinding")
+	fs.StringVar(&cfg.TargetGroupBindingTagLabelPrefix, flagTargetGroupBindingTagLabelPrefix, "",
+		"Prefix of TargetGroupBinding labels to propagate as AWS tags on its TargetGroups, empty to disable")
+	fs.DurationVar(&cfg.TargetGroupBindingUnhealthyTargetsRequeueInterval, flagUnhealthyTargetsRequeueInterval, defaultUnhealthyTargetsRequeueInterval,
+		"Interval to requeue targetGroupBinding to monitor targetHealth while any pod with the readiness gate isn't healthy")
+	fs.DurationVar(&cfg.TargetGroupBindingHealthyTargetsRequeueInterval, flagHealthyTargetsRequeueInterval, defaultHealthyTargetsRequeueInterval,
+		"Interval to requeue targetGroupBinding to monitor targetHealth once all pods with the readiness gate are healthy, 0 to disable")
+	fs.DurationVar(&cfg.TargetGroupBindingTargetHealthPollInterval, flagTargetHealthPollInterval, 0,
+		"Interval to poll the health of targets to export healthy and unhealthy target counts per targetGroupBinding as metrics, 0 to disable")
+	fs.DurationVar(&cfg.TargetGroupBindingNodeStartupGracePeriod, flagNodeStartupGracePeriod, 0,
+		"Grace period since node creation, within which instance targets of nodes not ready yet are not deregistered, 0 to disable")
+	fs.BoolVar(&cfg.TargetGroupBindingDrainCordonedNodes, flagDrainCordonedNodes, false,
+		"Deregister instance targets of cordoned nodes to drain connections before the nodes are terminated")
+	fs.StringSliceVar(&cfg.TargetGroupBindingDrainNodeTaints, flagDrainNodeTaints, nil,
+		"Taint keys of nodes to deregister instance targets of, to drain connections before the nodes are terminated")
+	fs.BoolVar(&cfg.TargetGroupBindingRegisterRunningInstancesOnly, flagRegisterRunningInstancesOnly, false,
+		"Only register nodes whose EC2 instances are in running state as instance targets, e.g. to skip stopped instances in Auto Scaling warm pools")
+	fs.StringVar(&cfg.TargetGroupBindingFinalizer, flagTargetGroupBindingFinalizer, defaultTargetGroupBindingFinalizer,
+		"Finalizer added to targetGroupBinding objects, must be distinct from other controllers managing targetGroupBindings")
+	fs.StringVar(&cfg.ServiceFinalizer, flagServiceFinalizer, defaultServiceFinalizer,
+		"Finalizer added to service objects, must be distinct from other controllers managing services")
+	fs.BoolVar(&cfg.EnableEndpointZoneStatus, flagEnableEndpointZoneStatus, false,
+		"[Experimental] Populate the distribution of endpoints across availability zones in TargetGroupBinding status")
+	fs.BoolVar(&cfg.WebhookFailClosedOnAWSErrors, flagWebhookFailClosedOnAWSErrors, false,
+		"Reject TargetGroupBindings in webhook if validations cannot be done due to AWS errors")
+	fs.BoolVar(&cfg.DisableDeletionProtectionOnCleanup, flagDisableDeletionProtectionOnCleanup, defaultDisableDeletionProtectionOnCleanup,
+		"Disable deletion protection of load balancers before deleting them, otherwise load balancers with deletion protection enabled must be deleted manually")
+	fs.BoolVar(&cfg.ManagedLoadBalancerReplacement, flagManagedLoadBalancerReplacement, false,
+		"Create the replacement of load balancers requiring replacement due to immutable changes, e.g. scheme, and migrate listeners before deleting them")
+	fs.BoolVar(&cfg.RequireExplicitOptIn, flagRequireExplicitOptIn, false,
+		"Only manage Ingresses and Services with the elbv2.k8s.aws/managed: \"true\" annotation, even if they match the class")
+	fs.BoolVar(&cfg.EnableOrphanedResourcesGC, flagEnableOrphanedResourcesGC, false,
+		"Collect AWS resources whose owning Ingress or Service no longer exists on startup")
+	fs.BoolVar(&cfg.OrphanedResourcesGCDryRun, flagOrphanedResourcesGCDryRun, defaultOrphanedResourcesGCDryRun,
+		"Only log orphaned AWS resources found on startup instead of deleting them")
+	fs.StringVar(&cfg.EnableEndpointSlices, flagEnableEndpointSlices, defaultEnableEndpointSlices,
+		"Resolve endpoints of services from EndpointSlices instead of Endpoints - auto(default), true, false. auto uses EndpointSlices if they're served by the API server")
+	fs.StringVar(&cfg.TargetGroupAttributesPolicy, flagTargetGroupAttributesPolicy, defaultTargetGroupAttributesPolicy,
+		"Policy for target group attributes not specified via annotations - controller-owned(default), preserve-unmanaged. controller-owned resets them to controller defaults, preserve-unmanaged leaves them at their current values")
 	fs.StringVar(&cfg.DefaultSSLPolicy, flagDefaultSSLPolicy, defaultSSLPolicy,
 		"Default SSL policy for load balancers listeners")
+	fs.StringVar(&cfg.DefaultTargetType, flagDefaultTargetType, defaultTargetType,
+		"Default target type for load balancers target groups - instance(default), ip")
+	fs.StringVar(&cfg.ResourceNamespaceTagKey, flagResourceNamespaceTagKey, defaultResourceNamespaceTagKey,
+		"AWS Tag key for the namespace of the Kubernetes resource owning load balancers and target groups, empty to disable")
+	fs.StringVar(&cfg.ResourceNameTagKey, flagResourceNameTagKey, defaultResourceNameTagKey,
+		"AWS Tag key for the name of the Kubernetes resource owning load balancers and target groups, empty to disable")
 
 	cfg.AWSConfig.BindFlags(fs)
 	cfg.RuntimeConfig.BindFlags(fs)
@@ -76,5 +226,91 @@ func (cfg *ControllerConfig) Validate() error {
 	if len(cfg.ClusterName) == 0 {
 		return errors.New("kubernetes cluster name must be specified")
 	}
+	if cfg.DefaultTargetType != targetTypeInstance && cfg.DefaultTargetType != targetTypeIP {
+		return errors.Errorf("invalid value %v for %v, must be %v or %v", cfg.DefaultTargetType, flagDefaultTargetType, targetTypeInstance, targetTypeIP)
+	}
+	if cfg.EnableEndpointSlices != endpointSlicesAuto && cfg.EnableEndpointSlices != endpointSlicesEnabled && cfg.EnableEndpointSlices != endpointSlicesDisabled {
+		return errors.Errorf("invalid value %v for %v, must be %v, %v or %v", cfg.EnableEndpointSlices, flagEnableEndpointSlices, endpointSlicesAuto, endpointSlicesEnabled, endpointSlicesDisabled)
+	}
+	if cfg.TargetGroupAttributesPolicy != tgAttributesPolicyControllerOwned && cfg.TargetGroupAttributesPolicy != tgAttributesPolicyPreserveUnmanaged {
+		return errors.Errorf("invalid value %v for %v, must be %v or %v", cfg.TargetGroupAttributesPolicy, flagTargetGroupAttributesPolicy, tgAttributesPolicyControllerOwned, tgAttributesPolicyPreserveUnmanaged)
+	}
+	if cfg.ResourceNamespaceTagKey != "" && cfg.ResourceNamespaceTagKey == cfg.ResourceNameTagKey {
+		return errors.New("resource namespace and name tag keys must be different")
+	}
+	for _, prefix := range cfg.ManagedTagKeyPrefixes {
+		if len(prefix) == 0 {
+			return errors.Errorf("invalid value for %v, prefix must not be empty", flagManagedTagKeyPrefixes)
+		}
+	}
+	if cfg.TargetGroupBindingUnhealthyTargetsRequeueInterval <= 0 {
+		return errors.Errorf("%v must be positive", flagUnhealthyTargetsRequeueInterval)
+	}
+	if cfg.TargetGroupBindingHealthyTargetsRequeueInterval < 0 {
+		return errors.Errorf("%v must not be negative", flagHealthyTargetsRequeueInterval)
+	}
+	if cfg.TargetGroupBindingTargetHealthPollInterval < 0 {
+		return errors.Errorf("%v must not be negative", flagTargetHealthPollInterval)
+	}
+	if cfg.TargetGroupBindingNodeStartupGracePeriod < 0 {
+		return errors.Errorf("%v must not be negative", flagNodeStartupGracePeriod)
+	}
+	for _, taintKey := range cfg.TargetGroupBindingDrainNodeTaints {
+		if len(taintKey) == 0 {
+			return errors.Errorf("invalid value for %v, taint key must not be empty", flagDrainNodeTaints)
+		}
+	}
+	if err := validateFinalizer(flagTargetGroupBindingFinalizer, cfg.TargetGroupBindingFinalizer); err != nil {
+		return err
+	}
+	if err := validateFinalizer(flagServiceFinalizer, cfg.ServiceFinalizer); err != nil {
+		return err
+	}
+	if err := cfg.AWSConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.RuntimeConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.IngressConfig.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NodeDrainConditions returns the conditions under which instance targets of nodes are deregistered to drain connections.
+func (cfg *ControllerConfig) NodeDrainConditions() k8s.NodeDrainConditions {
+	return k8s.NodeDrainConditions{
+		Cordoned:  cfg.TargetGroupBindingDrainCordonedNodes,
+		TaintKeys: cfg.TargetGroupBindingDrainNodeTaints,
+	}
+}
+
+// UseEndpointSlices returns whether endpoints of services are resolved from EndpointSlices.
+// With "auto", EndpointSlices are used if they're served by the API server.
+func (cfg *ControllerConfig) UseEndpointSlices(discoveryClient discovery.ServerGroupsInterface) (bool, error) {
+	switch cfg.EnableEndpointSlices {
+	case endpointSlicesEnabled:
+		return true, nil
+	case endpointSlicesDisabled:
+		return false, nil
+	default:
+		return k8s.IsEndpointSlicesSupported(discoveryClient)
+	}
+}
+
+// PreserveUnmanagedTargetGroupAttributes returns whether target group attributes not specified via annotations are left at their current values.
+func (cfg *ControllerConfig) PreserveUnmanagedTargetGroupAttributes() bool {
+	return cfg.TargetGroupAttributesPolicy == tgAttributesPolicyPreserveUnmanaged
+}
+
+// validateFinalizer checks the finalizer is a domain-qualified name, e.g. "elbv2.k8s.aws/resources".
+func validateFinalizer(flag string, finalizer string) error {
+	if !strings.Contains(finalizer, "/") {
+		return errors.Errorf("invalid value %v for %v, finalizer must be domain-qualified", finalizer, flag)
+	}
+	if msgs := validation.IsQualifiedName(finalizer); len(msgs) != 0 {
+		return errors.Errorf("invalid value %v for %v, %v", finalizer, flag, strings.Join(msgs, ", "))
+	}
 	return nil
 }
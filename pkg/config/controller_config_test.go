@@ -0,0 +1,99 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_validateFinalizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		finalizer string
+		wantErr   error
+	}{
+		{
+			name:      "default finalizer",
+			finalizer: "elbv2.k8s.aws/resources",
+		},
+		{
+			name:      "custom finalizer",
+			finalizer: "elbv2.example.com/resources",
+		},
+		{
+			name:      "finalizer without domain",
+			finalizer: "resources",
+			wantErr:   errors.New("invalid value resources for targetgroupbinding-finalizer, finalizer must be domain-qualified"),
+		},
+		{
+			name:      "invalid finalizer",
+			finalizer: "elbv2.k8s.aws/resources!",
+			wantErr:   errors.New("invalid value elbv2.k8s.aws/resources! for targetgroupbinding-finalizer, name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFinalizer(flagTargetGroupBindingFinalizer, tt.finalizer)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_UseEndpointSlices(t *testing.T) {
+	endpointSlicesResources := &metav1.APIResourceList{
+		GroupVersion: "discovery.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true}},
+	}
+	endpointsResources := &metav1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "endpoints", Kind: "Endpoints", Namespaced: true}},
+	}
+	tests := []struct {
+		name                 string
+		enableEndpointSlices string
+		resources            []*metav1.APIResourceList
+		want                 bool
+	}{
+		{
+			name:                 "auto with EndpointSlices served",
+			enableEndpointSlices: "auto",
+			resources:            []*metav1.APIResourceList{endpointsResources, endpointSlicesResources},
+			want:                 true,
+		},
+		{
+			name:                 "auto without EndpointSlices served",
+			enableEndpointSlices: "auto",
+			resources:            []*metav1.APIResourceList{endpointsResources},
+			want:                 false,
+		},
+		{
+			name:                 "enabled without EndpointSlices served",
+			enableEndpointSlices: "true",
+			resources:            []*metav1.APIResourceList{endpointsResources},
+			want:                 true,
+		},
+		{
+			name:                 "disabled with EndpointSlices served",
+			enableEndpointSlices: "false",
+			resources:            []*metav1.APIResourceList{endpointsResources, endpointSlicesResources},
+			want:                 false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{EnableEndpointSlices: tt.enableEndpointSlices}
+			discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: tt.resources}}
+			got, err := cfg.UseEndpointSlices(discoveryClient)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,16 +1,64 @@
 package config
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"strings"
+)
 
 const (
 	flagIngressClass                         = "ingress-class"
 	flagDisableIngressClassAnnotation        = "disable-ingress-class-annotation"
 	flagDisableIngressGroupNameAnnotation    = "disable-ingress-group-name-annotation"
 	flagIngressMaxConcurrentReconciles       = "ingress-max-concurrent-reconciles"
+	flagIngressGroupAllowedNamespaces        = "ingress-group-allowed-namespaces"
+	flagAllowCrossNamespaceIngressGroups     = "allow-cross-namespace-ingress-groups"
+	flagIngressLBAttributesMergeStrategy     = "ingress-load-balancer-attributes-merge-strategy"
+	flagIngressManageBackendSGRules          = "ingress-manage-backend-security-group-rules"
+	flagIngressRejectListenersWithoutRules   = "ingress-reject-listeners-without-rules"
+	flagIngressSkipListenersWithoutRules     = "ingress-skip-listeners-without-rules"
+	flagIngressDefaultCertificateStrategy    = "ingress-default-certificate-strategy"
+	flagStrictIngressClass                   = "strict-ingress-class"
+	flagIngressMissingBackendServicePolicy   = "ingress-missing-backend-service-policy"
 	defaultIngressClass                      = "alb"
 	defaultDisableIngressClassAnnotation     = false
 	defaultDisableIngressGroupNameAnnotation = false
 	defaultMaxIngressConcurrentReconciles    = 3
+	defaultIngressLBAttributesMergeStrategy  = LBAttributesMergeStrategyStrict
+	defaultIngressManageBackendSGRules       = true
+	defaultRejectListenersWithoutRules       = false
+	defaultSkipListenersWithoutRules         = false
+	defaultIngressDefaultCertificateStrategy = DefaultCertificateStrategyMostSpecificDomain
+	defaultStrictIngressClass                = false
+	defaultMissingBackendServicePolicy       = MissingBackendServicePolicyStrict
+
+	// separator between namespaces within the allowed namespaces of an IngressGroup
+	ingressGroupAllowedNamespacesSeparator = ":"
+)
+
+const (
+	// LBAttributesMergeStrategyStrict rejects conflicting load balancer attributes within IngressGroup.
+	LBAttributesMergeStrategyStrict = "strict"
+	// LBAttributesMergeStrategyOrdered resolves conflicting load balancer attributes within IngressGroup by group order,
+	// the Ingress with higher order wins.
+	LBAttributesMergeStrategyOrdered = "ordered"
+)
+
+const (
+	// DefaultCertificateStrategyMostSpecificDomain chooses the discovered certificate with the most specific domain matching the Ingress hosts
+	// as default certificate of listeners.
+	DefaultCertificateStrategyMostSpecificDomain = "most-specific-domain"
+	// DefaultCertificateStrategyLongestValidity chooses the discovered certificate that expires last as default certificate of listeners.
+	DefaultCertificateStrategyLongestValidity = "longest-validity"
+)
+
+const (
+	// MissingBackendServicePolicyStrict fails the reconcile of IngressGroup if any backend Service is missing.
+	MissingBackendServicePolicyStrict = "strict"
+	// MissingBackendServicePolicyLenient skips the paths whose backend Services are missing, and reconciles the rest of IngressGroup.
+	// default backend whose backend Services are missing serves 404 instead.
+	MissingBackendServicePolicyLenient = "lenient"
 )
 
 // IngressConfig contains the configurations for the Ingress controller
@@ -22,11 +70,42 @@ type IngressConfig struct {
 	// DisableIngressClassAnnotation specifies whether to disable new usage of kubernetes.io/ingress.class annotation.
 	DisableIngressClassAnnotation bool
 
+	// StrictIngressClass specifies whether to only manage Ingresses selected by IngressClass via spec.ingressClassName,
+	// the kubernetes.io/ingress.class annotation is ignored entirely when enabled.
+	StrictIngressClass bool
+
 	// DisableIngressGroupNameAnnotation specifies whether to disable new usage of alb.ingress.kubernetes.io/group.name annotation.
 	DisableIngressGroupNameAnnotation bool
 
 	// Max concurrent reconcile loops for Ingress objects
 	MaxConcurrentReconciles int
+
+	// IngressGroupAllowedNamespaces restricts the namespaces of Ingresses that are allowed to join explicit IngressGroups.
+	// the key is groupName, and the value is colon separated list of namespaces.
+	// IngressGroups without entry accept Ingresses from any namespace.
+	IngressGroupAllowedNamespaces map[string]string
+
+	// AllowCrossNamespaceIngressGroups specifies whether Ingresses are allowed to join explicit IngressGroups owned by other namespaces.
+	AllowCrossNamespaceIngressGroups bool
+
+	// LoadBalancerAttributesMergeStrategy specifies how conflicting load-balancer-attributes within IngressGroup are merged.
+	LoadBalancerAttributesMergeStrategy string
+
+	// ManageBackendSecurityGroupRules specifies whether to manage inbound rules on backend securityGroups by default,
+	// it can be overridden per Ingress by annotation.
+	ManageBackendSecurityGroupRules bool
+
+	// RejectListenersWithoutRules specifies whether to fail the model build if a listener would only have the default 404 action.
+	RejectListenersWithoutRules bool
+
+	// SkipListenersWithoutRules specifies whether to omit listeners that would have neither rules nor a configured default action.
+	SkipListenersWithoutRules bool
+
+	// DefaultCertificateStrategy specifies how the default certificate of listeners is chosen among discovered certificates.
+	DefaultCertificateStrategy string
+
+	// MissingBackendServicePolicy specifies how paths whose backend Services are missing are handled.
+	MissingBackendServicePolicy string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -35,8 +114,67 @@ func (cfg *IngressConfig) BindFlags(fs *pflag.FlagSet) {
 		"Name of the ingress class this controller satisfies")
 	fs.BoolVar(&cfg.DisableIngressClassAnnotation, flagDisableIngressClassAnnotation, defaultDisableIngressClassAnnotation,
 		"Disable new usage of kubernetes.io/ingress.class annotation")
+	fs.BoolVar(&cfg.StrictIngressClass, flagStrictIngressClass, defaultStrictIngressClass,
+		"Only manage Ingresses selected by IngressClass via spec.ingressClassName, and ignore the kubernetes.io/ingress.class annotation")
 	fs.BoolVar(&cfg.DisableIngressGroupNameAnnotation, flagDisableIngressGroupNameAnnotation, defaultDisableIngressGroupNameAnnotation,
 		"Disable new usage of alb.ingress.kubernetes.io/group.name annotation")
 	fs.IntVar(&cfg.MaxConcurrentReconciles, flagIngressMaxConcurrentReconciles, defaultMaxIngressConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for ingress")
+	fs.StringToStringVar(&cfg.IngressGroupAllowedNamespaces, flagIngressGroupAllowedNamespaces, nil,
+		"Namespaces of Ingresses allowed to join explicit IngressGroups, format: groupName1=namespace1:namespace2,groupName2=namespace3")
+	fs.BoolVar(&cfg.AllowCrossNamespaceIngressGroups, flagAllowCrossNamespaceIngressGroups, false,
+		"Allow Ingresses to join explicit IngressGroups owned by other namespaces")
+	fs.StringVar(&cfg.LoadBalancerAttributesMergeStrategy, flagIngressLBAttributesMergeStrategy, defaultIngressLBAttributesMergeStrategy,
+		"Strategy to merge conflicting load-balancer-attributes within IngressGroup - strict(default), ordered")
+	fs.BoolVar(&cfg.ManageBackendSecurityGroupRules, flagIngressManageBackendSGRules, defaultIngressManageBackendSGRules,
+		"Manage inbound rules on backend securityGroups of nodes or pods to allow traffic from the securityGroup created for ALB")
+	fs.BoolVar(&cfg.RejectListenersWithoutRules, flagIngressRejectListenersWithoutRules, defaultRejectListenersWithoutRules,
+		"Fail to reconcile IngressGroups with listeners that have neither rules nor default backend, instead of responding 404")
+	fs.BoolVar(&cfg.SkipListenersWithoutRules, flagIngressSkipListenersWithoutRules, defaultSkipListenersWithoutRules,
+		"Omit listeners that have neither rules nor default backend, instead of responding 404")
+	fs.StringVar(&cfg.DefaultCertificateStrategy, flagIngressDefaultCertificateStrategy, defaultIngressDefaultCertificateStrategy,
+		"Strategy to choose the default certificate of listeners among discovered certificates - most-specific-domain(default), longest-validity")
+	fs.StringVar(&cfg.MissingBackendServicePolicy, flagIngressMissingBackendServicePolicy, defaultMissingBackendServicePolicy,
+		"Policy to handle paths whose backend Services are missing - strict(default) fails the IngressGroup, lenient skips the paths")
+}
+
+// Validate the Ingress configuration
+func (cfg *IngressConfig) Validate() error {
+	switch cfg.LoadBalancerAttributesMergeStrategy {
+	case LBAttributesMergeStrategyStrict, LBAttributesMergeStrategyOrdered:
+	default:
+		return errors.Errorf("invalid value %v for %v, must be %v or %v", cfg.LoadBalancerAttributesMergeStrategy,
+			flagIngressLBAttributesMergeStrategy, LBAttributesMergeStrategyStrict, LBAttributesMergeStrategyOrdered)
+	}
+	if cfg.RejectListenersWithoutRules && cfg.SkipListenersWithoutRules {
+		return errors.Errorf("%v and %v are mutually exclusive", flagIngressRejectListenersWithoutRules, flagIngressSkipListenersWithoutRules)
+	}
+	switch cfg.DefaultCertificateStrategy {
+	case DefaultCertificateStrategyMostSpecificDomain, DefaultCertificateStrategyLongestValidity:
+	default:
+		return errors.Errorf("invalid value %v for %v, must be %v or %v", cfg.DefaultCertificateStrategy,
+			flagIngressDefaultCertificateStrategy, DefaultCertificateStrategyMostSpecificDomain, DefaultCertificateStrategyLongestValidity)
+	}
+	switch cfg.MissingBackendServicePolicy {
+	case MissingBackendServicePolicyStrict, MissingBackendServicePolicyLenient:
+	default:
+		return errors.Errorf("invalid value %v for %v, must be %v or %v", cfg.MissingBackendServicePolicy,
+			flagIngressMissingBackendServicePolicy, MissingBackendServicePolicyStrict, MissingBackendServicePolicyLenient)
+	}
+	return nil
+}
+
+// GroupAllowedNamespaces returns the allowed namespaces per explicit IngressGroup.
+func (cfg *IngressConfig) GroupAllowedNamespaces() map[string]sets.String {
+	groupAllowedNamespaces := make(map[string]sets.String, len(cfg.IngressGroupAllowedNamespaces))
+	for groupName, rawNamespaces := range cfg.IngressGroupAllowedNamespaces {
+		namespaces := sets.NewString()
+		for _, namespace := range strings.Split(rawNamespaces, ingressGroupAllowedNamespacesSeparator) {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				namespaces.Insert(namespace)
+			}
+		}
+		groupAllowedNamespaces[groupName] = namespaces
+	}
+	return groupAllowedNamespaces
 }
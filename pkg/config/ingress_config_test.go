@@ -0,0 +1,97 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIngressConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     IngressConfig
+		wantErr error
+	}{
+		{
+			name: "strict merge strategy",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyStrict,
+				DefaultCertificateStrategy:          DefaultCertificateStrategyMostSpecificDomain,
+				MissingBackendServicePolicy:         MissingBackendServicePolicyStrict,
+			},
+		},
+		{
+			name: "ordered merge strategy",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyOrdered,
+				DefaultCertificateStrategy:          DefaultCertificateStrategyLongestValidity,
+				MissingBackendServicePolicy:         MissingBackendServicePolicyStrict,
+			},
+		},
+		{
+			name: "invalid merge strategy",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: "lenient",
+				DefaultCertificateStrategy:          DefaultCertificateStrategyMostSpecificDomain,
+				MissingBackendServicePolicy:         MissingBackendServicePolicyStrict,
+			},
+			wantErr: errors.New("invalid value lenient for ingress-load-balancer-attributes-merge-strategy, must be strict or ordered"),
+		},
+		{
+			name: "skip listeners without rules",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyStrict,
+				DefaultCertificateStrategy:          DefaultCertificateStrategyMostSpecificDomain,
+				MissingBackendServicePolicy:         MissingBackendServicePolicyStrict,
+				SkipListenersWithoutRules:           true,
+			},
+		},
+		{
+			name: "both reject and skip listeners without rules",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyStrict,
+				DefaultCertificateStrategy:          DefaultCertificateStrategyMostSpecificDomain,
+				MissingBackendServicePolicy:         MissingBackendServicePolicyStrict,
+				RejectListenersWithoutRules:         true,
+				SkipListenersWithoutRules:           true,
+			},
+			wantErr: errors.New("ingress-reject-listeners-without-rules and ingress-skip-listeners-without-rules are mutually exclusive"),
+		},
+		{
+			name: "invalid default certificate strategy",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyStrict,
+				DefaultCertificateStrategy:          "newest",
+				MissingBackendServicePolicy:         MissingBackendServicePolicyStrict,
+			},
+			wantErr: errors.New("invalid value newest for ingress-default-certificate-strategy, must be most-specific-domain or longest-validity"),
+		},
+		{
+			name: "lenient missing backend service policy",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyStrict,
+				DefaultCertificateStrategy:          DefaultCertificateStrategyMostSpecificDomain,
+				MissingBackendServicePolicy:         MissingBackendServicePolicyLenient,
+			},
+		},
+		{
+			name: "invalid missing backend service policy",
+			cfg: IngressConfig{
+				LoadBalancerAttributesMergeStrategy: LBAttributesMergeStrategyStrict,
+				DefaultCertificateStrategy:          DefaultCertificateStrategyMostSpecificDomain,
+				MissingBackendServicePolicy:         "ignore",
+			},
+			wantErr: errors.New("invalid value ignore for ingress-missing-backend-service-policy, must be strict or lenient"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,34 +1,47 @@
 package config
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"time"
 )
 
 const (
-	flagMetricsBindAddr         = "metrics-bind-addr"
-	flagHealthProbeBindAddr     = "health-probe-bind-addr"
-	flagWebhookBindPort         = "webhook-bind-port"
-	flagEnableLeaderElection    = "enable-leader-election"
-	flagLeaderElectionID        = "leader-election-id"
-	flagLeaderElectionNamespace = "leader-election-namespace"
-	flagWatchNamespace          = "watch-namespace"
-	flagSyncPeriod              = "sync-period"
-	flagKubeconfig              = "kubeconfig"
+	flagMetricsBindAddr                       = "metrics-bind-addr"
+	flagHealthProbeBindAddr                   = "health-probe-bind-addr"
+	flagWebhookBindPort                       = "webhook-bind-port"
+	flagEnableLeaderElection                  = "enable-leader-election"
+	flagLeaderElectionID                      = "leader-election-id"
+	flagLeaderElectionNamespace               = "leader-election-namespace"
+	flagLeaderElectionLeaseDuration           = "leader-election-lease-duration"
+	flagLeaderElectionRenewDeadline           = "leader-election-renew-deadline"
+	flagLeaderElectionRetryPeriod             = "leader-election-retry-period"
+	flagWatchNamespace                        = "watch-namespace"
+	flagSyncPeriod                            = "sync-period"
+	flagReconcileMaxBackoff                   = "reconcile-max-backoff"
+	flagReconcileTerminalErrorRequeueInterval = "reconcile-terminal-error-requeue-interval"
+	flagReconcileTimeout                      = "reconcile-timeout"
+	flagKubeconfig                            = "kubeconfig"
 
-	defaultKubeconfig              = ""
-	defaultLeaderElectionID        = "aws-load-balancer-controller-leader"
-	defaultLeaderElectionNamespace = ""
-	defaultWatchNamespace          = corev1.NamespaceAll
-	defaultMetricsAddr             = ":8080"
-	defaultHealthProbeBindAddress  = ":61779"
-	defaultSyncPeriod              = 60 * time.Minute
-	defaultWebhookBindPort         = 9443
+	defaultKubeconfig                            = ""
+	defaultLeaderElectionID                      = "aws-load-balancer-controller-leader"
+	defaultLeaderElectionNamespace               = ""
+	defaultLeaderElectionLeaseDuration           = 15 * time.Second
+	defaultLeaderElectionRenewDeadline           = 10 * time.Second
+	defaultLeaderElectionRetryPeriod             = 2 * time.Second
+	defaultMetricsAddr                           = ":8080"
+	defaultHealthProbeBindAddress                = ":61779"
+	defaultSyncPeriod                            = 60 * time.Minute
+	defaultReconcileMaxBackoff                   = 1000 * time.Second
+	defaultReconcileTerminalErrorRequeueInterval = 10 * time.Minute
+	defaultReconcileTimeout                      = 0
+	defaultWebhookBindPort                       = 9443
 	// High enough QPS to fit all expected use cases. QPS=0 is not set here, because
 	// client code is overriding it.
 	defaultQPS = 1e6
@@ -39,16 +52,25 @@ const (
 
 // RuntimeConfig stores the configuration for the controller-runtime
 type RuntimeConfig struct {
-	APIServer               string
-	KubeConfig              string
-	WebhookBindPort         int
-	MetricsBindAddress      string
-	HealthProbeBindAddress  string
-	EnableLeaderElection    bool
-	LeaderElectionID        string
-	LeaderElectionNamespace string
-	WatchNamespace          string
-	SyncPeriod              time.Duration
+	APIServer                   string
+	KubeConfig                  string
+	WebhookBindPort             int
+	MetricsBindAddress          string
+	HealthProbeBindAddress      string
+	EnableLeaderElection        bool
+	LeaderElectionID            string
+	LeaderElectionNamespace     string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
+	WatchNamespaces             []string
+	SyncPeriod                  time.Duration
+	// the max backoff for retrying reconcile errors.
+	ReconcileMaxBackoff time.Duration
+	// the interval to requeue objects failed with terminal errors, zero means terminal errors are retried with backoff like other errors.
+	ReconcileTerminalErrorRequeueInterval time.Duration
+	// the timeout of each reconcile, zero means reconciles never time out.
+	ReconcileTimeout time.Duration
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -67,11 +89,52 @@ func (c *RuntimeConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.LeaderElectionID, flagLeaderElectionID, defaultLeaderElectionID,
 		"Name of the leader election ID to use for this controller")
 	fs.StringVar(&c.LeaderElectionNamespace, flagLeaderElectionNamespace, defaultLeaderElectionNamespace,
-		"Name of the leader election ID to use for this controller")
-	fs.StringVar(&c.WatchNamespace, flagWatchNamespace, defaultWatchNamespace,
-		"Namespace the controller watches for updates to Kubernetes objects, If empty, all namespaces are watched.")
+		"Namespace of the leader election resource, if empty, the namespace the controller is running in is used.")
+	fs.DurationVar(&c.LeaderElectionLeaseDuration, flagLeaderElectionLeaseDuration, defaultLeaderElectionLeaseDuration,
+		"Duration that non-leader candidates will wait to force acquire leadership.")
+	fs.DurationVar(&c.LeaderElectionRenewDeadline, flagLeaderElectionRenewDeadline, defaultLeaderElectionRenewDeadline,
+		"Duration that the acting leader will retry refreshing leadership before giving up.")
+	fs.DurationVar(&c.LeaderElectionRetryPeriod, flagLeaderElectionRetryPeriod, defaultLeaderElectionRetryPeriod,
+		"Duration the leader election clients should wait between tries of actions.")
+	fs.StringSliceVar(&c.WatchNamespaces, flagWatchNamespace, nil,
+		"Comma separated list of namespaces the controller watches for updates to Kubernetes objects, If empty, all namespaces are watched.")
 	fs.DurationVar(&c.SyncPeriod, flagSyncPeriod, defaultSyncPeriod,
 		"Period at which the controller forces the repopulation of its local object stores.")
+	fs.DurationVar(&c.ReconcileMaxBackoff, flagReconcileMaxBackoff, defaultReconcileMaxBackoff,
+		"Maximum backoff for retrying failed reconciles.")
+	fs.DurationVar(&c.ReconcileTerminalErrorRequeueInterval, flagReconcileTerminalErrorRequeueInterval, defaultReconcileTerminalErrorRequeueInterval,
+		"Interval to requeue objects failed with terminal AWS errors such as invalid configurations, 0 to retry them with backoff like other errors.")
+	fs.DurationVar(&c.ReconcileTimeout, flagReconcileTimeout, defaultReconcileTimeout,
+		"Timeout of each reconcile, after which pending AWS and Kubernetes calls are cancelled and the reconcile is retried with backoff, 0 to disable.")
+}
+
+// Validate the runtime configuration
+func (c *RuntimeConfig) Validate() error {
+	for _, namespace := range c.WatchNamespaces {
+		if namespace == "" {
+			return errors.Errorf("%v must not contain empty namespace", flagWatchNamespace)
+		}
+	}
+	if c.LeaderElectionRetryPeriod <= 0 {
+		return errors.Errorf("%v must be positive", flagLeaderElectionRetryPeriod)
+	}
+	// client-go requires the renewDeadline to be larger than retryPeriod with jitter.
+	if float64(c.LeaderElectionRenewDeadline) <= leaderelection.JitterFactor*float64(c.LeaderElectionRetryPeriod) {
+		return errors.Errorf("%v must be greater than %v * %v", flagLeaderElectionRenewDeadline, leaderelection.JitterFactor, flagLeaderElectionRetryPeriod)
+	}
+	if c.LeaderElectionLeaseDuration <= c.LeaderElectionRenewDeadline {
+		return errors.Errorf("%v must be greater than %v", flagLeaderElectionLeaseDuration, flagLeaderElectionRenewDeadline)
+	}
+	if c.ReconcileMaxBackoff <= 0 {
+		return errors.Errorf("%v must be positive", flagReconcileMaxBackoff)
+	}
+	if c.ReconcileTerminalErrorRequeueInterval < 0 {
+		return errors.Errorf("%v must not be negative", flagReconcileTerminalErrorRequeueInterval)
+	}
+	if c.ReconcileTimeout < 0 {
+		return errors.Errorf("%v must not be negative", flagReconcileTimeout)
+	}
+	return nil
 }
 
 // BuildRestConfig builds the REST config for the controller runtime
@@ -94,8 +157,8 @@ func BuildRestConfig(rtCfg RuntimeConfig) (*rest.Config, error) {
 }
 
 // BuildRuntimeOptions builds the options for the controller runtime based on config
-func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *runtime.Scheme) ctrl.Options {
-	return ctrl.Options{
+func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *k8sruntime.Scheme) ctrl.Options {
+	opts := ctrl.Options{
 		Scheme:                  scheme,
 		Port:                    rtCfg.WebhookBindPort,
 		MetricsBindAddress:      rtCfg.MetricsBindAddress,
@@ -103,7 +166,17 @@ func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *runtime.Scheme) ctrl.Optio
 		LeaderElection:          rtCfg.EnableLeaderElection,
 		LeaderElectionID:        rtCfg.LeaderElectionID,
 		LeaderElectionNamespace: rtCfg.LeaderElectionNamespace,
-		Namespace:               rtCfg.WatchNamespace,
+		LeaseDuration:           &rtCfg.LeaderElectionLeaseDuration,
+		RenewDeadline:           &rtCfg.LeaderElectionRenewDeadline,
+		RetryPeriod:             &rtCfg.LeaderElectionRetryPeriod,
 		SyncPeriod:              &rtCfg.SyncPeriod,
 	}
+	switch len(rtCfg.WatchNamespaces) {
+	case 0:
+	case 1:
+		opts.Namespace = rtCfg.WatchNamespaces[0]
+	default:
+		opts.NewCache = runtime.MultiNamespacedCacheBuilder(rtCfg.WatchNamespaces)
+	}
+	return opts
 }
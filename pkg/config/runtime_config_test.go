@@ -0,0 +1,115 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRuntimeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RuntimeConfig
+		wantErr error
+	}{
+		{
+			name: "default leader election settings",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   2 * time.Second,
+				ReconcileMaxBackoff:         1000 * time.Second,
+			},
+		},
+		{
+			name: "non-positive retryPeriod",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   0,
+			},
+			wantErr: errors.New("leader-election-retry-period must be positive"),
+		},
+		{
+			name: "renewDeadline not greater than retryPeriod with jitter",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 12 * time.Second,
+				LeaderElectionRetryPeriod:   10 * time.Second,
+			},
+			wantErr: errors.New("leader-election-renew-deadline must be greater than 1.2 * leader-election-retry-period"),
+		},
+		{
+			name: "leaseDuration not greater than renewDeadline",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration: 10 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   2 * time.Second,
+			},
+			wantErr: errors.New("leader-election-lease-duration must be greater than leader-election-renew-deadline"),
+		},
+		{
+			name: "multiple watch namespaces",
+			cfg: RuntimeConfig{
+				WatchNamespaces:             []string{"team-a", "team-b"},
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   2 * time.Second,
+				ReconcileMaxBackoff:         1000 * time.Second,
+			},
+		},
+		{
+			name: "non-positive reconcile max backoff",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   2 * time.Second,
+				ReconcileMaxBackoff:         0,
+			},
+			wantErr: errors.New("reconcile-max-backoff must be positive"),
+		},
+		{
+			name: "negative reconcile terminal error requeue interval",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration:           15 * time.Second,
+				LeaderElectionRenewDeadline:           10 * time.Second,
+				LeaderElectionRetryPeriod:             2 * time.Second,
+				ReconcileMaxBackoff:                   1000 * time.Second,
+				ReconcileTerminalErrorRequeueInterval: -time.Minute,
+			},
+			wantErr: errors.New("reconcile-terminal-error-requeue-interval must not be negative"),
+		},
+		{
+			name: "negative reconcile timeout",
+			cfg: RuntimeConfig{
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   2 * time.Second,
+				ReconcileMaxBackoff:         1000 * time.Second,
+				ReconcileTimeout:            -time.Minute,
+			},
+			wantErr: errors.New("reconcile-timeout must not be negative"),
+		},
+		{
+			name: "empty watch namespace",
+			cfg: RuntimeConfig{
+				WatchNamespaces:             []string{"team-a", ""},
+				LeaderElectionLeaseDuration: 15 * time.Second,
+				LeaderElectionRenewDeadline: 10 * time.Second,
+				LeaderElectionRetryPeriod:   2 * time.Second,
+			},
+			wantErr: errors.New("watch-namespace must not contain empty namespace"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ec2
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
@@ -16,8 +17,8 @@ import (
 )
 
 const (
-	defaultWaitSGDeletionPollInterval = 2 * time.Second
-	defaultWaitSGDeletionTimeout      = 2 * time.Minute
+	// securityGroups cannot be deleted until ENIs of deleted load balancers are released, which takes minutes.
+	defaultSGDeletionRequeueInterval = 10 * time.Second
 )
 
 // SecurityGroupManager is responsible for create/update/delete SecurityGroup resources.
@@ -40,8 +41,7 @@ func NewDefaultSecurityGroupManager(ec2Client services.EC2, trackingProvider tra
 		vpcID:                  vpcID,
 		logger:                 logger,
 
-		waitSGDeletionPollInterval: defaultWaitSGDeletionPollInterval,
-		waitSGDeletionTimeout:      defaultWaitSGDeletionTimeout,
+		sgDeletionRequeueInterval: defaultSGDeletionRequeueInterval,
 	}
 }
 
@@ -54,8 +54,8 @@ type defaultSecurityGroupManager struct {
 	vpcID                  string
 	logger                 logr.Logger
 
-	waitSGDeletionPollInterval time.Duration
-	waitSGDeletionTimeout      time.Duration
+	// interval to requeue securityGroup deletion while it still has dependencies.
+	sgDeletionRequeueInterval time.Duration
 }
 
 func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2model.SecurityGroup) (ec2model.SecurityGroupStatus, error) {
@@ -120,10 +120,10 @@ func (m *defaultSecurityGroupManager) Delete(ctx context.Context, sdkSG networki
 
 	m.logger.Info("deleting securityGroup",
 		"securityGroupID", sdkSG.SecurityGroupID)
-	if err := runtime.RetryImmediateOnError(m.waitSGDeletionPollInterval, m.waitSGDeletionTimeout, isSecurityGroupDependencyViolationError, func() error {
-		_, err := m.ec2Client.DeleteSecurityGroupWithContext(ctx, req)
-		return err
-	}); err != nil {
+	if _, err := m.ec2Client.DeleteSecurityGroupWithContext(ctx, req); err != nil {
+		if isSecurityGroupDependencyViolationError(err) {
+			return runtime.NewRequeueNeededAfter(fmt.Sprintf("securityGroup %v still has dependencies", sdkSG.SecurityGroupID), m.sgDeletionRequeueInterval)
+		}
 		return errors.Wrap(err, "failed to delete securityGroup")
 	}
 	m.logger.Info("deleted securityGroup",
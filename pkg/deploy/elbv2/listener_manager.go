@@ -21,6 +21,7 @@ import (
 type ListenerManager interface {
 	Create(ctx context.Context, resLS *elbv2model.Listener) (elbv2model.ListenerStatus, error)
 
+	// Update returns the listener status along with a switch pending error if default actions are kept until target groups are healthy.
 	Update(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) (elbv2model.ListenerStatus, error)
 
 	Delete(ctx context.Context, sdkLS ListenerWithTags) error
@@ -32,6 +33,7 @@ func NewDefaultListenerManager(elbv2Client services.ELBV2, trackingProvider trac
 		elbv2Client:                 elbv2Client,
 		trackingProvider:            trackingProvider,
 		taggingManager:              taggingManager,
+		tgHealthChecker:             NewDefaultTargetGroupHealthChecker(elbv2Client, logger),
 		logger:                      logger,
 		waitLSExistencePollInterval: defaultWaitLSExistencePollInterval,
 		waitLSExistenceTimeout:      defaultWaitLSExistenceTimeout,
@@ -45,6 +47,7 @@ type defaultListenerManager struct {
 	elbv2Client      services.ELBV2
 	trackingProvider tracking.Provider
 	taggingManager   TaggingManager
+	tgHealthChecker  TargetGroupHealthChecker
 	logger           logr.Logger
 
 	waitLSExistencePollInterval time.Duration
@@ -87,13 +90,23 @@ func (m *defaultListenerManager) Update(ctx context.Context, resLS *elbv2model.L
 	if err := m.updateSDKListenerWithTags(ctx, resLS, sdkLS); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
-	if err := m.updateSDKListenerWithSettings(ctx, resLS, sdkLS); err != nil {
+	certARNsToAdd, certARNsToRemove, err := m.computeSDKListenerExtraCertificatesChange(ctx, resLS, sdkLS, false)
+	if err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
-	if err := m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, false); err != nil {
+	// extra certificates are added before and removed after the default certificate is modified,
+	// so that certificates being rotated are served by listener throughout the update.
+	if err := m.addSDKListenerExtraCertificates(ctx, resLS, sdkLS, certARNsToAdd); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
-	return buildResListenerStatus(sdkLS), nil
+	switchPendingErr := m.updateSDKListenerWithSettings(ctx, resLS, sdkLS)
+	if switchPendingErr != nil && !isSwitchPendingError(switchPendingErr) {
+		return elbv2model.ListenerStatus{}, switchPendingErr
+	}
+	if err := m.removeSDKListenerExtraCertificates(ctx, resLS, sdkLS, certARNsToRemove); err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
+	return buildResListenerStatus(sdkLS), switchPendingErr
 }
 
 func (m *defaultListenerManager) Delete(ctx context.Context, sdkLS ListenerWithTags) error {
@@ -121,10 +134,21 @@ func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Conte
 	if err != nil {
 		return err
 	}
-	desiredDefaultCerts, _ := buildSDKCertificates(resLS.Spec.Certificates)
+	desiredDefaultCerts, _ := buildSDKCertificates(resolveListenerCertificates(resLS.Spec, sdkLS))
 	if !isSDKListenerSettingsDrifted(resLS.Spec, sdkLS, desiredDefaultActions, desiredDefaultCerts) {
 		return nil
 	}
+	pendingTGARNs, err := findSwitchPendingTargetGroups(ctx, m.tgHealthChecker, resLS.Spec.DefaultActions, sdkLS.Listener.DefaultActions)
+	if err != nil {
+		return err
+	}
+	if len(pendingTGARNs) != 0 {
+		// the current default actions are kept until target groups are healthy, while other settings are still updated.
+		desiredDefaultActions = sdkLS.Listener.DefaultActions
+		if !isSDKListenerSettingsDrifted(resLS.Spec, sdkLS, desiredDefaultActions, desiredDefaultCerts) {
+			return newSwitchPendingError(pendingTGARNs)
+		}
+	}
 	req := buildSDKModifyListenerInput(resLS.Spec, desiredDefaultActions, desiredDefaultCerts)
 	req.ListenerArn = sdkLS.Listener.ListenerArn
 	m.logger.Info("modifying listener",
@@ -138,15 +162,32 @@ func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Conte
 		"stackID", resLS.Stack().StackID(),
 		"resourceID", resLS.ID(),
 		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn))
+	if len(pendingTGARNs) != 0 {
+		return newSwitchPendingError(pendingTGARNs)
+	}
 	return nil
 }
 
 // updateSDKListenerWithExtraCertificates will update the extra certificates on listener.
-// currentExtraCertificates is the current extra certificates, if it's nil, the current extra certificates will be fetched from AWS.
+// if isNewSDKListener is false, the current extra certificates will be fetched from AWS.
 func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx context.Context, resLS *elbv2model.Listener,
 	sdkLS ListenerWithTags, isNewSDKListener bool) error {
+	certARNsToAdd, certARNsToRemove, err := m.computeSDKListenerExtraCertificatesChange(ctx, resLS, sdkLS, isNewSDKListener)
+	if err != nil {
+		return err
+	}
+	if err := m.addSDKListenerExtraCertificates(ctx, resLS, sdkLS, certARNsToAdd); err != nil {
+		return err
+	}
+	return m.removeSDKListenerExtraCertificates(ctx, resLS, sdkLS, certARNsToRemove)
+}
+
+// computeSDKListenerExtraCertificatesChange computes the extra certificates to add to and remove from listener.
+// the desired default certificate is never removed, since it might be promoted from an extra certificate.
+func (m *defaultListenerManager) computeSDKListenerExtraCertificatesChange(ctx context.Context, resLS *elbv2model.Listener,
+	sdkLS ListenerWithTags, isNewSDKListener bool) ([]string, []string, error) {
+	desiredDefaultCerts, desiredExtraCerts := buildSDKCertificates(resolveListenerCertificates(resLS.Spec, sdkLS))
 	desiredExtraCertARNs := sets.NewString()
-	_, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
 	for _, cert := range desiredExtraCerts {
 		desiredExtraCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
 	}
@@ -154,12 +195,22 @@ func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx cont
 	if !isNewSDKListener {
 		certARNs, err := m.fetchSDKListenerExtraCertificateARNs(ctx, sdkLS)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		currentExtraCertARNs.Insert(certARNs...)
 	}
 
-	for _, certARN := range desiredExtraCertARNs.Difference(currentExtraCertARNs).List() {
+	certARNsToAdd := desiredExtraCertARNs.Difference(currentExtraCertARNs)
+	certARNsToRemove := currentExtraCertARNs.Difference(desiredExtraCertARNs)
+	for _, cert := range desiredDefaultCerts {
+		certARNsToRemove.Delete(awssdk.StringValue(cert.CertificateArn))
+	}
+	return certARNsToAdd.List(), certARNsToRemove.List(), nil
+}
+
+func (m *defaultListenerManager) addSDKListenerExtraCertificates(ctx context.Context, resLS *elbv2model.Listener,
+	sdkLS ListenerWithTags, certARNs []string) error {
+	for _, certARN := range certARNs {
 		req := &elbv2sdk.AddListenerCertificatesInput{
 			ListenerArn: sdkLS.Listener.ListenerArn,
 			Certificates: []*elbv2sdk.Certificate{
@@ -182,8 +233,12 @@ func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx cont
 			"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
 			"certificateARN", certARN)
 	}
+	return nil
+}
 
-	for _, certARN := range currentExtraCertARNs.Difference(desiredExtraCertARNs).List() {
+func (m *defaultListenerManager) removeSDKListenerExtraCertificates(ctx context.Context, resLS *elbv2model.Listener,
+	sdkLS ListenerWithTags, certARNs []string) error {
+	for _, certARN := range certARNs {
 		req := &elbv2sdk.RemoveListenerCertificatesInput{
 			ListenerArn: sdkLS.Listener.ListenerArn,
 			Certificates: []*elbv2sdk.Certificate{
@@ -206,7 +261,6 @@ func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx cont
 			"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
 			"certificateARN", certARN)
 	}
-
 	return nil
 }
 
@@ -287,6 +341,26 @@ func buildSDKModifyListenerInput(lsSpec elbv2model.ListenerSpec, desiredDefaultA
 	return sdkObj
 }
 
+// resolveListenerCertificates resolves the certificates for listener, the first certificate is the default certificate.
+// if PreserveDefaultCertificate is set, the current default certificate of sdkLS is kept as default if it's still desired.
+func resolveListenerCertificates(lsSpec elbv2model.ListenerSpec, sdkLS ListenerWithTags) []elbv2model.Certificate {
+	if !lsSpec.PreserveDefaultCertificate || len(sdkLS.Listener.Certificates) == 0 {
+		return lsSpec.Certificates
+	}
+	currentDefaultCertARN := awssdk.StringValue(sdkLS.Listener.Certificates[0].CertificateArn)
+	for i, cert := range lsSpec.Certificates {
+		if awssdk.StringValue(cert.CertificateARN) != currentDefaultCertARN {
+			continue
+		}
+		certs := make([]elbv2model.Certificate, 0, len(lsSpec.Certificates))
+		certs = append(certs, cert)
+		certs = append(certs, lsSpec.Certificates[:i]...)
+		certs = append(certs, lsSpec.Certificates[i+1:]...)
+		return certs
+	}
+	return lsSpec.Certificates
+}
+
 // buildSDKCertificates builds the certificate list for listener.
 // returns the default certificates and extra certificates.
 func buildSDKCertificates(modelCerts []elbv2model.Certificate) ([]*elbv2sdk.Certificate, []*elbv2sdk.Certificate) {
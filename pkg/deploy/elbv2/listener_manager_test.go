@@ -1,10 +1,16 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -184,3 +190,416 @@ func Test_isSDKListenerSettingsDrifted(t *testing.T) {
 		})
 	}
 }
+
+func Test_resolveListenerCertificates(t *testing.T) {
+	type args struct {
+		lsSpec elbv2model.ListenerSpec
+		sdkLS  ListenerWithTags
+	}
+	tests := []struct {
+		name string
+		args args
+		want []elbv2model.Certificate
+	}{
+		{
+			name: "preserveDefaultCertificate unset - current default certificate not kept",
+			args: args{
+				lsSpec: elbv2model.ListenerSpec{
+					Certificates: []elbv2model.Certificate{
+						{CertificateARN: awssdk.String("cert-1")},
+						{CertificateARN: awssdk.String("cert-2")},
+					},
+				},
+				sdkLS: ListenerWithTags{
+					Listener: &elbv2sdk.Listener{
+						Certificates: []*elbv2sdk.Certificate{
+							{CertificateArn: awssdk.String("cert-2")},
+						},
+					},
+				},
+			},
+			want: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-1")},
+				{CertificateARN: awssdk.String("cert-2")},
+			},
+		},
+		{
+			name: "preserveDefaultCertificate set - current default certificate kept",
+			args: args{
+				lsSpec: elbv2model.ListenerSpec{
+					Certificates: []elbv2model.Certificate{
+						{CertificateARN: awssdk.String("cert-1")},
+						{CertificateARN: awssdk.String("cert-2")},
+						{CertificateARN: awssdk.String("cert-3")},
+					},
+					PreserveDefaultCertificate: true,
+				},
+				sdkLS: ListenerWithTags{
+					Listener: &elbv2sdk.Listener{
+						Certificates: []*elbv2sdk.Certificate{
+							{CertificateArn: awssdk.String("cert-2")},
+						},
+					},
+				},
+			},
+			want: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-2")},
+				{CertificateARN: awssdk.String("cert-1")},
+				{CertificateARN: awssdk.String("cert-3")},
+			},
+		},
+		{
+			name: "preserveDefaultCertificate set - current default certificate no longer desired",
+			args: args{
+				lsSpec: elbv2model.ListenerSpec{
+					Certificates: []elbv2model.Certificate{
+						{CertificateARN: awssdk.String("cert-1")},
+						{CertificateARN: awssdk.String("cert-3")},
+					},
+					PreserveDefaultCertificate: true,
+				},
+				sdkLS: ListenerWithTags{
+					Listener: &elbv2sdk.Listener{
+						Certificates: []*elbv2sdk.Certificate{
+							{CertificateArn: awssdk.String("cert-2")},
+						},
+					},
+				},
+			},
+			want: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-1")},
+				{CertificateARN: awssdk.String("cert-3")},
+			},
+		},
+		{
+			name: "preserveDefaultCertificate set - listener without certificates",
+			args: args{
+				lsSpec: elbv2model.ListenerSpec{
+					Certificates: []elbv2model.Certificate{
+						{CertificateARN: awssdk.String("cert-1")},
+					},
+					PreserveDefaultCertificate: true,
+				},
+				sdkLS: ListenerWithTags{
+					Listener: &elbv2sdk.Listener{},
+				},
+			},
+			want: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-1")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveListenerCertificates(tt.args.lsSpec, tt.args.sdkLS)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultListenerManager_updateSDKListenerWithExtraCertificates(t *testing.T) {
+	type describeListenerCertificatesAsListCall struct {
+		req  *elbv2sdk.DescribeListenerCertificatesInput
+		resp []*elbv2sdk.Certificate
+		err  error
+	}
+	type addListenerCertificatesWithContextCall struct {
+		req  *elbv2sdk.AddListenerCertificatesInput
+		resp *elbv2sdk.AddListenerCertificatesOutput
+		err  error
+	}
+	type removeListenerCertificatesWithContextCall struct {
+		req  *elbv2sdk.RemoveListenerCertificatesInput
+		resp *elbv2sdk.RemoveListenerCertificatesOutput
+		err  error
+	}
+	type fields struct {
+		describeListenerCertificatesAsListCalls    []describeListenerCertificatesAsListCall
+		addListenerCertificatesWithContextCalls    []addListenerCertificatesWithContextCall
+		removeListenerCertificatesWithContextCalls []removeListenerCertificatesWithContextCall
+	}
+	type args struct {
+		certificates     []elbv2model.Certificate
+		isNewSDKListener bool
+	}
+	sdkLS := ListenerWithTags{
+		Listener: &elbv2sdk.Listener{
+			ListenerArn: awssdk.String("my-listener"),
+			Certificates: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-default")},
+			},
+		},
+	}
+	describeReq := &elbv2sdk.DescribeListenerCertificatesInput{
+		ListenerArn: awssdk.String("my-listener"),
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "certificate of removed host should be removed, default and explicit certificates are kept",
+			fields: fields{
+				describeListenerCertificatesAsListCalls: []describeListenerCertificatesAsListCall{
+					{
+						req: describeReq,
+						resp: []*elbv2sdk.Certificate{
+							{CertificateArn: awssdk.String("cert-default"), IsDefault: awssdk.Bool(true)},
+							{CertificateArn: awssdk.String("cert-host-a"), IsDefault: awssdk.Bool(false)},
+							{CertificateArn: awssdk.String("cert-host-b"), IsDefault: awssdk.Bool(false)},
+							{CertificateArn: awssdk.String("cert-explicit"), IsDefault: awssdk.Bool(false)},
+						},
+					},
+				},
+				removeListenerCertificatesWithContextCalls: []removeListenerCertificatesWithContextCall{
+					{
+						req: &elbv2sdk.RemoveListenerCertificatesInput{
+							ListenerArn: awssdk.String("my-listener"),
+							Certificates: []*elbv2sdk.Certificate{
+								{CertificateArn: awssdk.String("cert-host-b")},
+							},
+						},
+						resp: &elbv2sdk.RemoveListenerCertificatesOutput{},
+					},
+				},
+			},
+			args: args{
+				certificates: []elbv2model.Certificate{
+					{CertificateARN: awssdk.String("cert-default")},
+					{CertificateARN: awssdk.String("cert-host-a")},
+					{CertificateARN: awssdk.String("cert-explicit")},
+				},
+			},
+		},
+		{
+			name: "certificate of new host should be added",
+			fields: fields{
+				describeListenerCertificatesAsListCalls: []describeListenerCertificatesAsListCall{
+					{
+						req: describeReq,
+						resp: []*elbv2sdk.Certificate{
+							{CertificateArn: awssdk.String("cert-default"), IsDefault: awssdk.Bool(true)},
+						},
+					},
+				},
+				addListenerCertificatesWithContextCalls: []addListenerCertificatesWithContextCall{
+					{
+						req: &elbv2sdk.AddListenerCertificatesInput{
+							ListenerArn: awssdk.String("my-listener"),
+							Certificates: []*elbv2sdk.Certificate{
+								{CertificateArn: awssdk.String("cert-host-a")},
+							},
+						},
+						resp: &elbv2sdk.AddListenerCertificatesOutput{},
+					},
+				},
+			},
+			args: args{
+				certificates: []elbv2model.Certificate{
+					{CertificateARN: awssdk.String("cert-default")},
+					{CertificateARN: awssdk.String("cert-host-a")},
+				},
+			},
+		},
+		{
+			name: "new listener should not describe certificates",
+			fields: fields{
+				addListenerCertificatesWithContextCalls: []addListenerCertificatesWithContextCall{
+					{
+						req: &elbv2sdk.AddListenerCertificatesInput{
+							ListenerArn: awssdk.String("my-listener"),
+							Certificates: []*elbv2sdk.Certificate{
+								{CertificateArn: awssdk.String("cert-host-a")},
+							},
+						},
+						resp: &elbv2sdk.AddListenerCertificatesOutput{},
+					},
+				},
+			},
+			args: args{
+				certificates: []elbv2model.Certificate{
+					{CertificateARN: awssdk.String("cert-default")},
+					{CertificateARN: awssdk.String("cert-host-a")},
+				},
+				isNewSDKListener: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeListenerCertificatesAsListCalls {
+				elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.addListenerCertificatesWithContextCalls {
+				elbv2Client.EXPECT().AddListenerCertificatesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.removeListenerCertificatesWithContextCalls {
+				elbv2Client.EXPECT().RemoveListenerCertificatesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+				LoadBalancerARN: coremodel.LiteralStringToken("my-lb"),
+				Port:            443,
+				Protocol:        elbv2model.ProtocolHTTPS,
+				Certificates:    tt.args.certificates,
+			})
+			m := &defaultListenerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKListenerWithExtraCertificates(context.Background(), resLS, sdkLS, tt.args.isNewSDKListener)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultListenerManager_Update_certificateRotation(t *testing.T) {
+	tests := []struct {
+		name             string
+		certificates     []elbv2model.Certificate
+		currentCerts     []*elbv2sdk.Certificate
+		wantAddedCerts   []string
+		wantDefaultCert  string
+		wantRemovedCerts []string
+	}{
+		{
+			name: "default certificate is rotated and previous default certificate becomes extra certificate",
+			certificates: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-new")},
+				{CertificateARN: awssdk.String("cert-old")},
+			},
+			currentCerts: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-old"), IsDefault: awssdk.Bool(true)},
+			},
+			wantAddedCerts:  []string{"cert-old"},
+			wantDefaultCert: "cert-new",
+		},
+		{
+			name: "default and extra certificates are rotated",
+			certificates: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-new")},
+				{CertificateARN: awssdk.String("cert-host-new")},
+			},
+			currentCerts: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-old"), IsDefault: awssdk.Bool(true)},
+				{CertificateArn: awssdk.String("cert-host-old"), IsDefault: awssdk.Bool(false)},
+			},
+			wantAddedCerts:   []string{"cert-host-new"},
+			wantDefaultCert:  "cert-new",
+			wantRemovedCerts: []string{"cert-host-old"},
+		},
+		{
+			name: "extra certificate promoted to default certificate is not removed",
+			certificates: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-new")},
+			},
+			currentCerts: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-old"), IsDefault: awssdk.Bool(true)},
+				{CertificateArn: awssdk.String("cert-new"), IsDefault: awssdk.Bool(false)},
+			},
+			wantDefaultCert: "cert-new",
+		},
+		{
+			name: "certificate renewed in place should not modify listener",
+			certificates: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-old")},
+				{CertificateARN: awssdk.String("cert-host-old")},
+			},
+			currentCerts: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-old"), IsDefault: awssdk.Bool(true)},
+				{CertificateArn: awssdk.String("cert-host-old"), IsDefault: awssdk.Bool(false)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+				LoadBalancerARN: coremodel.LiteralStringToken("my-lb"),
+				Port:            443,
+				Protocol:        elbv2model.ProtocolHTTPS,
+				DefaultActions: []elbv2model.Action{
+					{
+						Type: elbv2model.ActionTypeFixedResponse,
+						FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+							StatusCode: "404",
+						},
+					},
+				},
+				Certificates: tt.certificates,
+			})
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			var currentDefaultCerts []*elbv2sdk.Certificate
+			for _, cert := range tt.currentCerts {
+				if awssdk.BoolValue(cert.IsDefault) {
+					currentDefaultCerts = append(currentDefaultCerts, &elbv2sdk.Certificate{CertificateArn: cert.CertificateArn})
+				}
+			}
+			sdkLS := ListenerWithTags{
+				Listener: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("my-listener"),
+					Port:        awssdk.Int64(443),
+					Protocol:    awssdk.String("HTTPS"),
+					DefaultActions: []*elbv2sdk.Action{
+						{
+							Type: awssdk.String("fixed-response"),
+							FixedResponseConfig: &elbv2sdk.FixedResponseActionConfig{
+								StatusCode: awssdk.String("404"),
+							},
+						},
+					},
+					Certificates: currentDefaultCerts,
+				},
+				Tags: trackingProvider.ResourceTags(stack, resLS, nil),
+			}
+
+			elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), &elbv2sdk.DescribeListenerCertificatesInput{
+				ListenerArn: awssdk.String("my-listener"),
+			}).Return(tt.currentCerts, nil)
+			var orderedCalls []*gomock.Call
+			for _, certARN := range tt.wantAddedCerts {
+				orderedCalls = append(orderedCalls, elbv2Client.EXPECT().AddListenerCertificatesWithContext(gomock.Any(), &elbv2sdk.AddListenerCertificatesInput{
+					ListenerArn:  awssdk.String("my-listener"),
+					Certificates: []*elbv2sdk.Certificate{{CertificateArn: awssdk.String(certARN)}},
+				}).Return(&elbv2sdk.AddListenerCertificatesOutput{}, nil))
+			}
+			if tt.wantDefaultCert != "" {
+				orderedCalls = append(orderedCalls, elbv2Client.EXPECT().ModifyListenerWithContext(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *elbv2sdk.ModifyListenerInput) (*elbv2sdk.ModifyListenerOutput, error) {
+						assert.Equal(t, []*elbv2sdk.Certificate{{CertificateArn: awssdk.String(tt.wantDefaultCert)}}, req.Certificates)
+						return &elbv2sdk.ModifyListenerOutput{}, nil
+					}))
+			}
+			for _, certARN := range tt.wantRemovedCerts {
+				orderedCalls = append(orderedCalls, elbv2Client.EXPECT().RemoveListenerCertificatesWithContext(gomock.Any(), &elbv2sdk.RemoveListenerCertificatesInput{
+					ListenerArn:  awssdk.String("my-listener"),
+					Certificates: []*elbv2sdk.Certificate{{CertificateArn: awssdk.String(certARN)}},
+				}).Return(&elbv2sdk.RemoveListenerCertificatesOutput{}, nil))
+			}
+			gomock.InOrder(orderedCalls...)
+
+			m := &defaultListenerManager{
+				elbv2Client:      elbv2Client,
+				trackingProvider: trackingProvider,
+				taggingManager:   NewDefaultTaggingManager(elbv2Client, nil, &log.NullLogger{}),
+				logger:           &log.NullLogger{},
+			}
+			_, err := m.Update(context.Background(), resLS, sdkLS)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 type ListenerRuleManager interface {
 	Create(ctx context.Context, resLR *elbv2model.ListenerRule) (elbv2model.ListenerRuleStatus, error)
 
+	// Update returns the listener rule status along with a switch pending error if actions are kept until target groups are healthy.
 	Update(ctx context.Context, resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) (elbv2model.ListenerRuleStatus, error)
 
 	Delete(ctx context.Context, sdkLR ListenerRuleWithTags) error
@@ -31,6 +32,7 @@ func NewDefaultListenerRuleManager(elbv2Client services.ELBV2, trackingProvider
 		elbv2Client:                 elbv2Client,
 		trackingProvider:            trackingProvider,
 		taggingManager:              taggingManager,
+		tgHealthChecker:             NewDefaultTargetGroupHealthChecker(elbv2Client, logger),
 		logger:                      logger,
 		waitLSExistencePollInterval: defaultWaitLSExistencePollInterval,
 		waitLSExistenceTimeout:      defaultWaitLSExistenceTimeout,
@@ -42,6 +44,7 @@ type defaultListenerRuleManager struct {
 	elbv2Client      services.ELBV2
 	trackingProvider tracking.Provider
 	taggingManager   TaggingManager
+	tgHealthChecker  TargetGroupHealthChecker
 	logger           logr.Logger
 
 	waitLSExistencePollInterval time.Duration
@@ -85,10 +88,11 @@ func (m *defaultListenerRuleManager) Update(ctx context.Context, resLR *elbv2mod
 	if err := m.updateSDKListenerRuleWithTags(ctx, resLR, sdkLR); err != nil {
 		return elbv2model.ListenerRuleStatus{}, err
 	}
-	if err := m.updateSDKListenerRuleWithSettings(ctx, resLR, sdkLR); err != nil {
-		return elbv2model.ListenerRuleStatus{}, err
+	switchPendingErr := m.updateSDKListenerRuleWithSettings(ctx, resLR, sdkLR)
+	if switchPendingErr != nil && !isSwitchPendingError(switchPendingErr) {
+		return elbv2model.ListenerRuleStatus{}, switchPendingErr
 	}
-	return buildResListenerRuleStatus(sdkLR), nil
+	return buildResListenerRuleStatus(sdkLR), switchPendingErr
 }
 
 func (m *defaultListenerRuleManager) Delete(ctx context.Context, sdkLR ListenerRuleWithTags) error {
@@ -114,6 +118,17 @@ func (m *defaultListenerRuleManager) updateSDKListenerRuleWithSettings(ctx conte
 	if !isSDKListenerRuleSettingsDrifted(resLR.Spec, sdkLR, desiredActions, desiredConditions) {
 		return nil
 	}
+	pendingTGARNs, err := findSwitchPendingTargetGroups(ctx, m.tgHealthChecker, resLR.Spec.Actions, sdkLR.ListenerRule.Actions)
+	if err != nil {
+		return err
+	}
+	if len(pendingTGARNs) != 0 {
+		// the current actions are kept until target groups are healthy, while conditions are still updated.
+		desiredActions = sdkLR.ListenerRule.Actions
+		if !isSDKListenerRuleSettingsDrifted(resLR.Spec, sdkLR, desiredActions, desiredConditions) {
+			return newSwitchPendingError(pendingTGARNs)
+		}
+	}
 
 	req := buildSDKModifyListenerRuleInput(resLR.Spec, desiredActions, desiredConditions)
 	req.RuleArn = sdkLR.ListenerRule.RuleArn
@@ -128,6 +143,9 @@ func (m *defaultListenerRuleManager) updateSDKListenerRuleWithSettings(ctx conte
 		"stackID", resLR.Stack().StackID(),
 		"resourceID", resLR.ID(),
 		"arn", awssdk.StringValue(sdkLR.ListenerRule.RuleArn))
+	if len(pendingTGARNs) != 0 {
+		return newSwitchPendingError(pendingTGARNs)
+	}
 	return nil
 }
 
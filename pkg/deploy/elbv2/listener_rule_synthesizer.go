@@ -42,6 +42,8 @@ func (s *listenerRuleSynthesizer) Synthesize(ctx context.Context) error {
 
 	var resLSs []*elbv2model.Listener
 	s.stack.ListResources(&resLSs)
+	// listener rules pending traffic switching are reported after all listener rules are synthesized.
+	var switchPendingErr error
 	for _, resLS := range resLSs {
 		lsARN, err := resLS.ListenerARN().Resolve(ctx)
 		if err != nil {
@@ -49,10 +51,13 @@ func (s *listenerRuleSynthesizer) Synthesize(ctx context.Context) error {
 		}
 		resLRs := resLRsByLSARN[lsARN]
 		if err := s.synthesizeListenerRulesOnListener(ctx, lsARN, resLRs); err != nil {
-			return err
+			if !isSwitchPendingError(err) {
+				return err
+			}
+			switchPendingErr = err
 		}
 	}
-	return nil
+	return switchPendingErr
 }
 
 func (s *listenerRuleSynthesizer) PostSynthesize(ctx context.Context) error {
@@ -79,14 +84,18 @@ func (s *listenerRuleSynthesizer) synthesizeListenerRulesOnListener(ctx context.
 		}
 		resLR.SetStatus(lrStatus)
 	}
+	var switchPendingErr error
 	for _, resAndSDKLR := range matchedResAndSDKLRs {
 		lsStatus, err := s.lrManager.Update(ctx, resAndSDKLR.resLR, resAndSDKLR.sdkLR)
 		if err != nil {
-			return err
+			if !isSwitchPendingError(err) {
+				return err
+			}
+			switchPendingErr = err
 		}
 		resAndSDKLR.resLR.SetStatus(lsStatus)
 	}
-	return nil
+	return switchPendingErr
 }
 
 // findSDKListenersRulesOnLS returns the listenerRules configured on Listener.
@@ -11,10 +11,11 @@ import (
 )
 
 func NewListenerSynthesizer(elbv2Client services.ELBV2, taggingManager TaggingManager,
-	lsManager ListenerManager, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
+	lsManager ListenerManager, lbReplacements LoadBalancerReplacements, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
 	return &listenerSynthesizer{
 		elbv2Client:    elbv2Client,
 		lsManager:      lsManager,
+		lbReplacements: lbReplacements,
 		logger:         logger,
 		taggingManager: taggingManager,
 		stack:          stack,
@@ -24,6 +25,7 @@ func NewListenerSynthesizer(elbv2Client services.ELBV2, taggingManager TaggingMa
 type listenerSynthesizer struct {
 	elbv2Client    services.ELBV2
 	lsManager      ListenerManager
+	lbReplacements LoadBalancerReplacements
 	logger         logr.Logger
 	taggingManager TaggingManager
 
@@ -38,12 +40,17 @@ func (s *listenerSynthesizer) Synthesize(ctx context.Context) error {
 		return err
 	}
 
+	// listeners pending traffic switching are reported after all listeners are synthesized.
+	var switchPendingErr error
 	for lbARN, resLSs := range resLSsByLBARN {
 		if err := s.synthesizeListenersOnLB(ctx, lbARN, resLSs); err != nil {
-			return err
+			if !isSwitchPendingError(err) {
+				return err
+			}
+			switchPendingErr = err
 		}
 	}
-	return nil
+	return switchPendingErr
 }
 
 func (s *listenerSynthesizer) PostSynthesize(ctx context.Context) error {
@@ -62,6 +69,9 @@ func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN
 			return err
 		}
 	}
+	if err := s.migrateListenersFromReplacedLB(ctx, lbARN); err != nil {
+		return err
+	}
 	for _, resLS := range unmatchedResLSs {
 		lsStatus, err := s.lsManager.Create(ctx, resLS)
 		if err != nil {
@@ -69,13 +79,36 @@ func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN
 		}
 		resLS.SetStatus(lsStatus)
 	}
+	var switchPendingErr error
 	for _, resAndSDKLS := range matchedResAndSDKLSs {
 		lsStatus, err := s.lsManager.Update(ctx, resAndSDKLS.resLS, resAndSDKLS.sdkLS)
 		if err != nil {
-			return err
+			if !isSwitchPendingError(err) {
+				return err
+			}
+			switchPendingErr = err
 		}
 		resAndSDKLS.resLS.SetStatus(lsStatus)
 	}
+	return switchPendingErr
+}
+
+// migrateListenersFromReplacedLB deletes the listeners of the LoadBalancer replaced by the LoadBalancer with lbARN if any,
+// so that the replaced LoadBalancer keeps serving traffic until listeners are created on its replacement.
+func (s *listenerSynthesizer) migrateListenersFromReplacedLB(ctx context.Context, lbARN string) error {
+	replacedSDKLB, exists := s.lbReplacements.ReplacedLoadBalancer(lbARN)
+	if !exists {
+		return nil
+	}
+	sdkLSs, err := s.findSDKListenersOnLB(ctx, awssdk.StringValue(replacedSDKLB.LoadBalancer.LoadBalancerArn))
+	if err != nil {
+		return err
+	}
+	for _, sdkLS := range sdkLSs {
+		if err := s.lsManager.Delete(ctx, sdkLS); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -2,19 +2,86 @@ package elbv2
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"time"
 )
 
 const (
 	defaultWaitLSExistencePollInterval = 2 * time.Second
 	defaultWaitLSExistenceTimeout      = 20 * time.Second
+	// the interval to recheck target groups health while switching traffic to them.
+	defaultSwitchTGHealthRequeueInterval = 15 * time.Second
 )
 
+// findSwitchPendingTargetGroups returns the target groups that traffic is switching to per the switchConfig of desired forward actions,
+// but haven't reached the minimum healthy percentage yet. Target groups are switching when they receive traffic from desired actions
+// but not from current actions.
+func findSwitchPendingTargetGroups(ctx context.Context, tgHealthChecker TargetGroupHealthChecker,
+	desiredActions []elbv2model.Action, currentSDKActions []*elbv2sdk.Action) ([]string, error) {
+	currentTGARNs := sets.NewString()
+	for _, sdkAction := range currentSDKActions {
+		if sdkAction.TargetGroupArn != nil {
+			currentTGARNs.Insert(awssdk.StringValue(sdkAction.TargetGroupArn))
+		}
+		if sdkAction.ForwardConfig == nil {
+			continue
+		}
+		for _, tgt := range sdkAction.ForwardConfig.TargetGroups {
+			if tgt.Weight == nil || awssdk.Int64Value(tgt.Weight) > 0 {
+				currentTGARNs.Insert(awssdk.StringValue(tgt.TargetGroupArn))
+			}
+		}
+	}
+
+	var pendingTGARNs []string
+	for _, action := range desiredActions {
+		if action.ForwardConfig == nil || action.ForwardConfig.SwitchConfig == nil {
+			continue
+		}
+		switchCfg := action.ForwardConfig.SwitchConfig
+		for _, tgt := range action.ForwardConfig.TargetGroups {
+			if tgt.Weight != nil && *tgt.Weight == 0 {
+				continue
+			}
+			tgARN, err := tgt.TargetGroupARN.Resolve(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if currentTGARNs.Has(tgARN) {
+				continue
+			}
+			timeout := time.Duration(switchCfg.TimeoutSeconds) * time.Second
+			healthy, err := tgHealthChecker.CheckHealthy(ctx, tgARN, switchCfg.MinHealthyPercentage, timeout)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to switch traffic to targetGroup")
+			}
+			if !healthy {
+				pendingTGARNs = append(pendingTGARNs, tgARN)
+			}
+		}
+	}
+	return pendingTGARNs, nil
+}
+
+// newSwitchPendingError constructs an error to requeue until the pending target groups are healthy to switch traffic to.
+func newSwitchPendingError(pendingTGARNs []string) error {
+	return runtime.NewRequeueNeededAfter(fmt.Sprintf("waiting for targetGroups %v to be healthy before switching traffic", pendingTGARNs),
+		defaultSwitchTGHealthRequeueInterval)
+}
+
+// isSwitchPendingError checks whether err is returned when traffic switching is pending on target groups health.
+func isSwitchPendingError(err error) bool {
+	var requeueNeededAfter *runtime.RequeueNeededAfter
+	return errors.As(err, &requeueNeededAfter)
+}
+
 func buildSDKActions(modelActions []elbv2model.Action) ([]*elbv2sdk.Action, error) {
 	var sdkActions []*elbv2sdk.Action
 	if len(modelActions) != 0 {
@@ -1,10 +1,16 @@
 package elbv2
 
 import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
+	"time"
 )
 
 func Test_isListenerNotFoundError(t *testing.T) {
@@ -45,3 +51,154 @@ func Test_isListenerNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+// fakeTargetGroupHealthChecker records the targetGroups checked, reports unhealthy targetGroups and fails for timed out targetGroups.
+type fakeTargetGroupHealthChecker struct {
+	unhealthyTGARNs []string
+	timedOutTGARNs  []string
+	checkedTGARNs   []string
+}
+
+func (c *fakeTargetGroupHealthChecker) CheckHealthy(_ context.Context, tgARN string, _ int64, _ time.Duration) (bool, error) {
+	c.checkedTGARNs = append(c.checkedTGARNs, tgARN)
+	for _, timedOutTGARN := range c.timedOutTGARNs {
+		if timedOutTGARN == tgARN {
+			return false, errors.Errorf("targetGroup %v is unhealthy", tgARN)
+		}
+	}
+	for _, unhealthyTGARN := range c.unhealthyTGARNs {
+		if unhealthyTGARN == tgARN {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func Test_findSwitchPendingTargetGroups(t *testing.T) {
+	switchCfg := &elbv2model.TargetGroupSwitchConfig{
+		MinHealthyPercentage: 80,
+		TimeoutSeconds:       60,
+	}
+	buildBlueGreenAction := func(blueWeight int64, greenWeight int64, switchCfg *elbv2model.TargetGroupSwitchConfig) elbv2model.Action {
+		return elbv2model.Action{
+			Type: elbv2model.ActionTypeForward,
+			ForwardConfig: &elbv2model.ForwardActionConfig{
+				TargetGroups: []elbv2model.TargetGroupTuple{
+					{
+						TargetGroupARN: core.LiteralStringToken("tg-blue"),
+						Weight:         awssdk.Int64(blueWeight),
+					},
+					{
+						TargetGroupARN: core.LiteralStringToken("tg-green"),
+						Weight:         awssdk.Int64(greenWeight),
+					},
+				},
+				SwitchConfig: switchCfg,
+			},
+		}
+	}
+	blueActiveSDKActions := []*elbv2sdk.Action{
+		{
+			Type: awssdk.String("forward"),
+			ForwardConfig: &elbv2sdk.ForwardActionConfig{
+				TargetGroups: []*elbv2sdk.TargetGroupTuple{
+					{
+						TargetGroupArn: awssdk.String("tg-blue"),
+						Weight:         awssdk.Int64(1),
+					},
+					{
+						TargetGroupArn: awssdk.String("tg-green"),
+						Weight:         awssdk.Int64(0),
+					},
+				},
+			},
+		},
+	}
+	type args struct {
+		desiredActions    []elbv2model.Action
+		currentSDKActions []*elbv2sdk.Action
+	}
+	tests := []struct {
+		name              string
+		unhealthyTGARNs   []string
+		timedOutTGARNs    []string
+		args              args
+		want              []string
+		wantCheckedTGARNs []string
+		wantErr           error
+	}{
+		{
+			name: "switch to green target group",
+			args: args{
+				desiredActions:    []elbv2model.Action{buildBlueGreenAction(0, 1, switchCfg)},
+				currentSDKActions: blueActiveSDKActions,
+			},
+			wantCheckedTGARNs: []string{"tg-green"},
+		},
+		{
+			name:            "switch to unhealthy green target group",
+			unhealthyTGARNs: []string{"tg-green"},
+			args: args{
+				desiredActions:    []elbv2model.Action{buildBlueGreenAction(0, 1, switchCfg)},
+				currentSDKActions: blueActiveSDKActions,
+			},
+			want:              []string{"tg-green"},
+			wantCheckedTGARNs: []string{"tg-green"},
+		},
+		{
+			name:           "switch to green target group unhealthy beyond timeout",
+			timedOutTGARNs: []string{"tg-green"},
+			args: args{
+				desiredActions:    []elbv2model.Action{buildBlueGreenAction(0, 1, switchCfg)},
+				currentSDKActions: blueActiveSDKActions,
+			},
+			wantCheckedTGARNs: []string{"tg-green"},
+			wantErr:           errors.New("failed to switch traffic to targetGroup: targetGroup tg-green is unhealthy"),
+		},
+		{
+			name: "no switch when blue target group stays active",
+			args: args{
+				desiredActions:    []elbv2model.Action{buildBlueGreenAction(1, 0, switchCfg)},
+				currentSDKActions: blueActiveSDKActions,
+			},
+			wantCheckedTGARNs: nil,
+		},
+		{
+			name: "no switch without switchConfig",
+			args: args{
+				desiredActions:    []elbv2model.Action{buildBlueGreenAction(0, 1, nil)},
+				currentSDKActions: blueActiveSDKActions,
+			},
+			wantCheckedTGARNs: nil,
+		},
+		{
+			name: "no switch when green target group is active via targetGroupARN",
+			args: args{
+				desiredActions: []elbv2model.Action{buildBlueGreenAction(0, 1, switchCfg)},
+				currentSDKActions: []*elbv2sdk.Action{
+					{
+						Type:           awssdk.String("forward"),
+						TargetGroupArn: awssdk.String("tg-green"),
+					},
+				},
+			},
+			wantCheckedTGARNs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgHealthChecker := &fakeTargetGroupHealthChecker{
+				unhealthyTGARNs: tt.unhealthyTGARNs,
+				timedOutTGARNs:  tt.timedOutTGARNs,
+			}
+			got, err := findSwitchPendingTargetGroups(context.Background(), tgHealthChecker, tt.args.desiredActions, tt.args.currentSDKActions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.wantCheckedTGARNs, tgHealthChecker.checkedTGARNs)
+		})
+	}
+}
@@ -14,6 +14,13 @@ import (
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
+const (
+	// loadBalancer attribute that prevents the loadBalancer from being deleted.
+	lbAttrsDeletionProtectionEnabled = "deletion_protection.enabled"
+	// minimum number of subnets an application loadBalancer must keep.
+	minSubnetsForApplicationLoadBalancer = 2
+)
+
 // LoadBalancerManager is responsible for create/update/delete LoadBalancer resources.
 type LoadBalancerManager interface {
 	Create(ctx context.Context, resLB *elbv2model.LoadBalancer) (elbv2model.LoadBalancerStatus, error)
@@ -25,13 +32,14 @@ type LoadBalancerManager interface {
 
 // NewDefaultLoadBalancerManager constructs new defaultLoadBalancerManager.
 func NewDefaultLoadBalancerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
-	taggingManager TaggingManager, logger logr.Logger) *defaultLoadBalancerManager {
+	taggingManager TaggingManager, disableDeletionProtectionOnDelete bool, logger logr.Logger) *defaultLoadBalancerManager {
 	return &defaultLoadBalancerManager{
-		elbv2Client:          elbv2Client,
-		trackingProvider:     trackingProvider,
-		taggingManager:       taggingManager,
-		attributesReconciler: NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
-		logger:               logger,
+		elbv2Client:                       elbv2Client,
+		trackingProvider:                  trackingProvider,
+		taggingManager:                    taggingManager,
+		attributesReconciler:              NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
+		disableDeletionProtectionOnDelete: disableDeletionProtectionOnDelete,
+		logger:                            logger,
 	}
 }
 
@@ -43,6 +51,8 @@ type defaultLoadBalancerManager struct {
 	trackingProvider     tracking.Provider
 	taggingManager       TaggingManager
 	attributesReconciler LoadBalancerAttributeReconciler
+	// whether to disable deletion protection before deleting loadBalancers.
+	disableDeletionProtectionOnDelete bool
 
 	logger logr.Logger
 }
@@ -100,6 +110,9 @@ func (m *defaultLoadBalancerManager) Update(ctx context.Context, resLB *elbv2mod
 }
 
 func (m *defaultLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalancerWithTags) error {
+	if err := m.disableDeletionProtection(ctx, sdkLB); err != nil {
+		return err
+	}
 	req := &elbv2sdk.DeleteLoadBalancerInput{
 		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
 	}
@@ -113,6 +126,48 @@ func (m *defaultLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalan
 	return nil
 }
 
+// disableDeletionProtection disables the deletion protection of loadBalancer if enabled, so that it can be deleted.
+func (m *defaultLoadBalancerManager) disableDeletionProtection(ctx context.Context, sdkLB LoadBalancerWithTags) error {
+	lbARN := awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn)
+	describeReq := &elbv2sdk.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
+	}
+	describeResp, err := m.elbv2Client.DescribeLoadBalancerAttributesWithContext(ctx, describeReq)
+	if err != nil {
+		return err
+	}
+	deletionProtectionEnabled := false
+	for _, attr := range describeResp.Attributes {
+		if awssdk.StringValue(attr.Key) == lbAttrsDeletionProtectionEnabled {
+			deletionProtectionEnabled = awssdk.StringValue(attr.Value) == "true"
+		}
+	}
+	if !deletionProtectionEnabled {
+		return nil
+	}
+	if !m.disableDeletionProtectionOnDelete {
+		return errors.Errorf("loadBalancer %v has deletion protection enabled, manual intervention is needed to delete it", lbARN)
+	}
+
+	modifyReq := &elbv2sdk.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
+		Attributes: []*elbv2sdk.LoadBalancerAttribute{
+			{
+				Key:   awssdk.String(lbAttrsDeletionProtectionEnabled),
+				Value: awssdk.String("false"),
+			},
+		},
+	}
+	m.logger.Info("disabling loadBalancer deletion protection",
+		"arn", lbARN)
+	if _, err := m.elbv2Client.ModifyLoadBalancerAttributesWithContext(ctx, modifyReq); err != nil {
+		return err
+	}
+	m.logger.Info("disabled loadBalancer deletion protection",
+		"arn", lbARN)
+	return nil
+}
+
 func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithIPAddressType(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
 	if resLB.Spec.IPAddressType == nil {
 		return nil
@@ -156,6 +211,12 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSubnetMappings(ctx
 	if desiredSubnets.Equal(currentSubnets) {
 		return nil
 	}
+	// application loadBalancers in Local Zones or on Outposts can have a single subnet, which is validated by subnets resolver,
+	// so we only prevent loadBalancers from shrinking below the minimum here.
+	if resLB.Spec.Type == elbv2model.LoadBalancerTypeApplication && desiredSubnets.Len() < minSubnetsForApplicationLoadBalancer &&
+		currentSubnets.Len() >= minSubnetsForApplicationLoadBalancer {
+		return errors.Errorf("application loadBalancer must keep at least %v subnets, got %v", minSubnetsForApplicationLoadBalancer, desiredSubnets.List())
+	}
 
 	req := &elbv2sdk.SetSubnetsInput{
 		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
@@ -289,7 +350,8 @@ func buildSDKSubnetMapping(modelSubnetMapping elbv2model.SubnetMapping) *elbv2sd
 
 func buildResLoadBalancerStatus(sdkLB LoadBalancerWithTags) elbv2model.LoadBalancerStatus {
 	return elbv2model.LoadBalancerStatus{
-		LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
-		DNSName:         awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		LoadBalancerARN:       awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+		DNSName:               awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		CanonicalHostedZoneID: awssdk.StringValue(sdkLB.LoadBalancer.CanonicalHostedZoneId),
 	}
 }
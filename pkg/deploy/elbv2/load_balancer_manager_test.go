@@ -5,9 +5,12 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -288,14 +291,16 @@ func Test_buildResLoadBalancerStatus(t *testing.T) {
 			args: args{
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
-						LoadBalancerArn: awssdk.String("my-arn"),
-						DNSName:         awssdk.String("www.example.com"),
+						LoadBalancerArn:       awssdk.String("my-arn"),
+						DNSName:               awssdk.String("www.example.com"),
+						CanonicalHostedZoneId: awssdk.String("Z35SXDOTRQ7X7K"),
 					},
 				},
 			},
 			want: elbv2model.LoadBalancerStatus{
-				LoadBalancerARN: "my-arn",
-				DNSName:         "www.example.com",
+				LoadBalancerARN:       "my-arn",
+				DNSName:               "www.example.com",
+				CanonicalHostedZoneID: "Z35SXDOTRQ7X7K",
 			},
 		},
 	}
@@ -410,3 +415,341 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 		})
 	}
 }
+
+func Test_defaultLoadBalancerManager_Delete(t *testing.T) {
+	type describeLoadBalancerAttributesWithContextCall struct {
+		req  *elbv2sdk.DescribeLoadBalancerAttributesInput
+		resp *elbv2sdk.DescribeLoadBalancerAttributesOutput
+		err  error
+	}
+	type modifyLoadBalancerAttributesWithContextCall struct {
+		req  *elbv2sdk.ModifyLoadBalancerAttributesInput
+		resp *elbv2sdk.ModifyLoadBalancerAttributesOutput
+		err  error
+	}
+	type deleteLoadBalancerWithContextCall struct {
+		req  *elbv2sdk.DeleteLoadBalancerInput
+		resp *elbv2sdk.DeleteLoadBalancerOutput
+		err  error
+	}
+	type fields struct {
+		disableDeletionProtectionOnDelete              bool
+		describeLoadBalancerAttributesWithContextCalls []describeLoadBalancerAttributesWithContextCall
+		modifyLoadBalancerAttributesWithContextCalls   []modifyLoadBalancerAttributesWithContextCall
+		deleteLoadBalancerWithContextCalls             []deleteLoadBalancerWithContextCall
+	}
+	sdkLB := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn: awssdk.String("my-arn"),
+		},
+	}
+	describeReq := &elbv2sdk.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: awssdk.String("my-arn"),
+	}
+	deleteReq := &elbv2sdk.DeleteLoadBalancerInput{
+		LoadBalancerArn: awssdk.String("my-arn"),
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "deletion protection disabled",
+			fields: fields{
+				disableDeletionProtectionOnDelete: false,
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: describeReq,
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("deletion_protection.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				deleteLoadBalancerWithContextCalls: []deleteLoadBalancerWithContextCall{
+					{
+						req:  deleteReq,
+						resp: &elbv2sdk.DeleteLoadBalancerOutput{},
+					},
+				},
+			},
+		},
+		{
+			name: "deletion protection enabled and will be disabled before deletion",
+			fields: fields{
+				disableDeletionProtectionOnDelete: true,
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: describeReq,
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("deletion_protection.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("deletion_protection.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+						resp: &elbv2sdk.ModifyLoadBalancerAttributesOutput{},
+					},
+				},
+				deleteLoadBalancerWithContextCalls: []deleteLoadBalancerWithContextCall{
+					{
+						req:  deleteReq,
+						resp: &elbv2sdk.DeleteLoadBalancerOutput{},
+					},
+				},
+			},
+		},
+		{
+			name: "deletion protection enabled and needs manual intervention",
+			fields: fields{
+				disableDeletionProtectionOnDelete: false,
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: describeReq,
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("deletion_protection.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("loadBalancer my-arn has deletion protection enabled, manual intervention is needed to delete it"),
+		},
+		{
+			name: "failed to describe loadBalancer attributes",
+			fields: fields{
+				disableDeletionProtectionOnDelete: true,
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: describeReq,
+						err: errors.New("some error"),
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeLoadBalancerAttributesWithContextCalls {
+				elbv2Client.EXPECT().DescribeLoadBalancerAttributesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.modifyLoadBalancerAttributesWithContextCalls {
+				elbv2Client.EXPECT().ModifyLoadBalancerAttributesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.deleteLoadBalancerWithContextCalls {
+				elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			m := &defaultLoadBalancerManager{
+				elbv2Client:                       elbv2Client,
+				disableDeletionProtectionOnDelete: tt.fields.disableDeletionProtectionOnDelete,
+				logger:                            &log.NullLogger{},
+			}
+			err := m.Delete(context.Background(), sdkLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithSubnetMappings(t *testing.T) {
+	type setSubnetsWithContextCall struct {
+		req  *elbv2sdk.SetSubnetsInput
+		resp *elbv2sdk.SetSubnetsOutput
+		err  error
+	}
+	type fields struct {
+		setSubnetsWithContextCalls []setSubnetsWithContextCall
+	}
+	type args struct {
+		lbType         elbv2model.LoadBalancerType
+		subnetMappings []elbv2model.SubnetMapping
+		sdkLB          LoadBalancerWithTags
+	}
+	sdkLBWithTwoAZs := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn: awssdk.String("my-arn"),
+			AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+				{
+					SubnetId: awssdk.String("subnet-a"),
+					ZoneName: awssdk.String("us-west-2a"),
+				},
+				{
+					SubnetId: awssdk.String("subnet-b"),
+					ZoneName: awssdk.String("us-west-2b"),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "subnets unchanged",
+			args: args{
+				lbType: elbv2model.LoadBalancerTypeApplication,
+				subnetMappings: []elbv2model.SubnetMapping{
+					{SubnetID: "subnet-b"},
+					{SubnetID: "subnet-a"},
+				},
+				sdkLB: sdkLBWithTwoAZs,
+			},
+		},
+		{
+			name: "add a third AZ",
+			fields: fields{
+				setSubnetsWithContextCalls: []setSubnetsWithContextCall{
+					{
+						req: &elbv2sdk.SetSubnetsInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							SubnetMappings: []*elbv2sdk.SubnetMapping{
+								{SubnetId: awssdk.String("subnet-a")},
+								{SubnetId: awssdk.String("subnet-b")},
+								{SubnetId: awssdk.String("subnet-c")},
+							},
+						},
+						resp: &elbv2sdk.SetSubnetsOutput{},
+					},
+				},
+			},
+			args: args{
+				lbType: elbv2model.LoadBalancerTypeApplication,
+				subnetMappings: []elbv2model.SubnetMapping{
+					{SubnetID: "subnet-a"},
+					{SubnetID: "subnet-b"},
+					{SubnetID: "subnet-c"},
+				},
+				sdkLB: sdkLBWithTwoAZs,
+			},
+		},
+		{
+			name: "swap a subnet",
+			fields: fields{
+				setSubnetsWithContextCalls: []setSubnetsWithContextCall{
+					{
+						req: &elbv2sdk.SetSubnetsInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							SubnetMappings: []*elbv2sdk.SubnetMapping{
+								{SubnetId: awssdk.String("subnet-a")},
+								{SubnetId: awssdk.String("subnet-c")},
+							},
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				lbType: elbv2model.LoadBalancerTypeApplication,
+				subnetMappings: []elbv2model.SubnetMapping{
+					{SubnetID: "subnet-a"},
+					{SubnetID: "subnet-c"},
+				},
+				sdkLB: sdkLBWithTwoAZs,
+			},
+			wantErr: errors.New("some error"),
+		},
+		{
+			name: "application loadBalancer below minimum subnets",
+			args: args{
+				lbType: elbv2model.LoadBalancerTypeApplication,
+				subnetMappings: []elbv2model.SubnetMapping{
+					{SubnetID: "subnet-a"},
+				},
+				sdkLB: sdkLBWithTwoAZs,
+			},
+			wantErr: errors.New("application loadBalancer must keep at least 2 subnets, got [subnet-a]"),
+		},
+		{
+			name: "application loadBalancer in Local Zone swaps its single subnet",
+			fields: fields{
+				setSubnetsWithContextCalls: []setSubnetsWithContextCall{
+					{
+						req: &elbv2sdk.SetSubnetsInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							SubnetMappings: []*elbv2sdk.SubnetMapping{
+								{SubnetId: awssdk.String("subnet-lz-2")},
+							},
+						},
+						resp: &elbv2sdk.SetSubnetsOutput{},
+					},
+				},
+			},
+			args: args{
+				lbType: elbv2model.LoadBalancerTypeApplication,
+				subnetMappings: []elbv2model.SubnetMapping{
+					{SubnetID: "subnet-lz-2"},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{
+								SubnetId: awssdk.String("subnet-lz-1"),
+								ZoneName: awssdk.String("us-west-2-lax-1a"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.setSubnetsWithContextCalls {
+				elbv2Client.EXPECT().SetSubnetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				Type:           tt.args.lbType,
+				SubnetMappings: tt.args.subnetMappings,
+			})
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKLoadBalancerWithSubnetMappings(context.Background(), resLB, tt.args.sdkLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
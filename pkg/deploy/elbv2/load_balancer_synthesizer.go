@@ -2,6 +2,7 @@ package elbv2
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -10,30 +11,56 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"time"
 )
 
+const (
+	defaultLBReplacementRequeueInterval = 10 * time.Second
+)
+
+// LoadBalancerReplacements provides the LoadBalancers replaced within a deployment.
+type LoadBalancerReplacements interface {
+	// ReplacedLoadBalancer returns the LoadBalancer replaced by the LoadBalancer with lbARN, whose listeners are pending migration.
+	ReplacedLoadBalancer(lbARN string) (LoadBalancerWithTags, bool)
+}
+
 // NewLoadBalancerSynthesizer constructs loadBalancerSynthesizer
 func NewLoadBalancerSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	lbManager LoadBalancerManager, logger logr.Logger, stack core.Stack) *loadBalancerSynthesizer {
+	lbManager LoadBalancerManager, managedReplacement bool, logger logr.Logger, stack core.Stack) *loadBalancerSynthesizer {
 	return &loadBalancerSynthesizer{
-		elbv2Client:      elbv2Client,
-		trackingProvider: trackingProvider,
-		taggingManager:   taggingManager,
-		lbManager:        lbManager,
-		logger:           logger,
-		stack:            stack,
+		elbv2Client:        elbv2Client,
+		trackingProvider:   trackingProvider,
+		taggingManager:     taggingManager,
+		lbManager:          lbManager,
+		managedReplacement: managedReplacement,
+		logger:             logger,
+		stack:              stack,
+
+		lbReplacementRequeueInterval: defaultLBReplacementRequeueInterval,
+		replacedSDKLBsByARN:          make(map[string]LoadBalancerWithTags),
 	}
 }
 
+var _ LoadBalancerReplacements = &loadBalancerSynthesizer{}
+
 // loadBalancerSynthesizer is responsible for synthesize LoadBalancer resources types for certain stack.
 type loadBalancerSynthesizer struct {
 	elbv2Client      services.ELBV2
 	trackingProvider tracking.Provider
 	taggingManager   TaggingManager
 	lbManager        LoadBalancerManager
-	logger           logr.Logger
+	// whether LoadBalancers requiring replacement are deleted only after their replacement is created.
+	managedReplacement bool
+	logger             logr.Logger
+
+	lbReplacementRequeueInterval time.Duration
 
 	stack core.Stack
+	// replaced LoadBalancers whose listeners are pending migration, keyed by the ARN of their replacement.
+	replacedSDKLBsByARN map[string]LoadBalancerWithTags
+	// replaced LoadBalancers whose listeners are already migrated in previous reconciles, which are deleted during PostSynthesize.
+	replacedSDKLBs []LoadBalancerWithTags
 }
 
 func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
@@ -53,6 +80,10 @@ func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 	//  * LoadBalancer delete will automatically delete listeners attached to it.
 	//  * we can avoid the operation to detach a targetGroup from unmatched LBs. (a targetGroup can only attach to one LB).
 	// I don't like this, but it's the easiest solution to meet our requirement :D.
+	var sdkLBsToReplace []LoadBalancerWithTags
+	if s.managedReplacement {
+		sdkLBsToReplace, unmatchedSDKLBs = partitionSDKLoadBalancersToReplace(resLBs, unmatchedSDKLBs, s.trackingProvider.ResourceIDTagKey())
+	}
 	for _, sdkLB := range unmatchedSDKLBs {
 		if err := s.lbManager.Delete(ctx, sdkLB); err != nil {
 			return err
@@ -72,14 +103,50 @@ func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 		}
 		resAndSDKLB.resLB.SetStatus(lbStatus)
 	}
-	return nil
+	return s.trackReplacedSDKLoadBalancers(ctx, resLBs, sdkLBsToReplace)
 }
 
 func (s *loadBalancerSynthesizer) PostSynthesize(ctx context.Context) error {
-	// nothing to do here.
+	for _, sdkLB := range s.replacedSDKLBs {
+		if err := s.lbManager.Delete(ctx, sdkLB); err != nil {
+			return err
+		}
+	}
+	s.replacedSDKLBs = nil
 	return nil
 }
 
+func (s *loadBalancerSynthesizer) ReplacedLoadBalancer(lbARN string) (LoadBalancerWithTags, bool) {
+	sdkLB, exists := s.replacedSDKLBsByARN[lbARN]
+	return sdkLB, exists
+}
+
+// trackReplacedSDKLoadBalancers tracks the replaced LoadBalancers until they can be deleted.
+// The replaced LoadBalancers keep serving traffic until their listeners are migrated to the replacement by the listener synthesizer,
+// which must happen right before the listeners are created on the replacement, given a targetGroup can only be attached to one LoadBalancer.
+// They're only deleted in a later reconcile after their listeners are migrated, so that the Ingress or Service status is updated
+// with the replacement's DNS name before the deletion.
+func (s *loadBalancerSynthesizer) trackReplacedSDKLoadBalancers(ctx context.Context, resLBs []*elbv2model.LoadBalancer, sdkLBsToReplace []LoadBalancerWithTags) error {
+	resLBsByID := mapResLoadBalancerByResourceID(resLBs)
+	var requeueErr error
+	for _, sdkLB := range sdkLBsToReplace {
+		lbARN := awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn)
+		sdkLSs, err := s.taggingManager.ListListeners(ctx, lbARN)
+		if err != nil {
+			return err
+		}
+		if len(sdkLSs) == 0 {
+			s.replacedSDKLBs = append(s.replacedSDKLBs, sdkLB)
+			continue
+		}
+		resLB := resLBsByID[sdkLB.Tags[s.trackingProvider.ResourceIDTagKey()]]
+		s.replacedSDKLBsByARN[resLB.Status.LoadBalancerARN] = sdkLB
+		requeueErr = runtime.NewRequeueNeededAfter(fmt.Sprintf("loadBalancer %v is pending deletion after replaced by %v", lbARN, resLB.Status.LoadBalancerARN),
+			s.lbReplacementRequeueInterval)
+	}
+	return requeueErr
+}
+
 // findSDKLoadBalancers will find all AWS LoadBalancer created for stack.
 func (s *loadBalancerSynthesizer) findSDKLoadBalancers(ctx context.Context) ([]LoadBalancerWithTags, error) {
 	stackTags := s.trackingProvider.StackTags(s.stack)
@@ -157,6 +224,25 @@ func mapSDKLoadBalancerByResourceID(sdkLBs []LoadBalancerWithTags, resourceIDTag
 	return sdkLBsByID, nil
 }
 
+// partitionSDKLoadBalancersToReplace partitions unmatched sdk LoadBalancers into the ones that can be replaced by a LoadBalancer resource
+// before deletion, and the ones to delete right away.
+// a sdk LoadBalancer can only be replaced before deletion if its replacement has a different name, as LoadBalancer names are unique.
+func partitionSDKLoadBalancersToReplace(resLBs []*elbv2model.LoadBalancer, unmatchedSDKLBs []LoadBalancerWithTags,
+	resourceIDTagKey string) ([]LoadBalancerWithTags, []LoadBalancerWithTags) {
+	resLBsByID := mapResLoadBalancerByResourceID(resLBs)
+	var sdkLBsToReplace []LoadBalancerWithTags
+	var sdkLBsToDelete []LoadBalancerWithTags
+	for _, sdkLB := range unmatchedSDKLBs {
+		resLB, exists := resLBsByID[sdkLB.Tags[resourceIDTagKey]]
+		if exists && resLB.Spec.Name != awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerName) {
+			sdkLBsToReplace = append(sdkLBsToReplace, sdkLB)
+		} else {
+			sdkLBsToDelete = append(sdkLBsToDelete, sdkLB)
+		}
+	}
+	return sdkLBsToReplace, sdkLBsToDelete
+}
+
 // isSDKLoadBalancerRequiresReplacement checks whether a sdk LoadBalancer requires replacement to fulfill a LoadBalancer resource.
 func isSDKLoadBalancerRequiresReplacement(sdkLB LoadBalancerWithTags, resLB *elbv2model.LoadBalancer) bool {
 	if string(resLB.Spec.Type) != awssdk.StringValue(sdkLB.LoadBalancer.Type) {
@@ -594,3 +594,83 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 		})
 	}
 }
+
+func Test_partitionSDKLoadBalancersToReplace(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	resLB := &elbv2model.LoadBalancer{
+		ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "LoadBalancer"),
+		Spec: elbv2model.LoadBalancerSpec{
+			Name: "k8s-ns-ing-internetfacing",
+		},
+	}
+	sdkLBWithOtherName := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn:  awssdk.String("arn-1"),
+			LoadBalancerName: awssdk.String("k8s-ns-ing-internal"),
+		},
+		Tags: map[string]string{
+			"ingress.k8s.aws/resource": "LoadBalancer",
+		},
+	}
+	sdkLBWithSameName := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn:  awssdk.String("arn-2"),
+			LoadBalancerName: awssdk.String("k8s-ns-ing-internetfacing"),
+		},
+		Tags: map[string]string{
+			"ingress.k8s.aws/resource": "LoadBalancer",
+		},
+	}
+	sdkLBWithoutResLB := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn:  awssdk.String("arn-3"),
+			LoadBalancerName: awssdk.String("k8s-ns-ing-other"),
+		},
+		Tags: map[string]string{
+			"ingress.k8s.aws/resource": "OtherLoadBalancer",
+		},
+	}
+	type args struct {
+		resLBs          []*elbv2model.LoadBalancer
+		unmatchedSDKLBs []LoadBalancerWithTags
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantSDKLBsReplace []LoadBalancerWithTags
+		wantSDKLBsDelete  []LoadBalancerWithTags
+	}{
+		{
+			name: "loadBalancer replaced by loadBalancer with different name",
+			args: args{
+				resLBs:          []*elbv2model.LoadBalancer{resLB},
+				unmatchedSDKLBs: []LoadBalancerWithTags{sdkLBWithOtherName},
+			},
+			wantSDKLBsReplace: []LoadBalancerWithTags{sdkLBWithOtherName},
+		},
+		{
+			name: "loadBalancer replaced by loadBalancer with same name",
+			args: args{
+				resLBs:          []*elbv2model.LoadBalancer{resLB},
+				unmatchedSDKLBs: []LoadBalancerWithTags{sdkLBWithSameName},
+			},
+			wantSDKLBsDelete: []LoadBalancerWithTags{sdkLBWithSameName},
+		},
+		{
+			name: "loadBalancer not replaced",
+			args: args{
+				resLBs:          []*elbv2model.LoadBalancer{resLB},
+				unmatchedSDKLBs: []LoadBalancerWithTags{sdkLBWithOtherName, sdkLBWithoutResLB},
+			},
+			wantSDKLBsReplace: []LoadBalancerWithTags{sdkLBWithOtherName},
+			wantSDKLBsDelete:  []LoadBalancerWithTags{sdkLBWithoutResLB},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSDKLBsReplace, gotSDKLBsDelete := partitionSDKLoadBalancersToReplace(tt.args.resLBs, tt.args.unmatchedSDKLBs, "ingress.k8s.aws/resource")
+			assert.Equal(t, tt.wantSDKLBsReplace, gotSDKLBsReplace)
+			assert.Equal(t, tt.wantSDKLBsDelete, gotSDKLBsDelete)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"strings"
 )
 
 const (
@@ -92,10 +93,11 @@ type TaggingManager interface {
 }
 
 // NewDefaultTaggingManager constructs default TaggingManager.
-func NewDefaultTaggingManager(elbv2Client services.ELBV2, logger logr.Logger) *defaultTaggingManager {
+func NewDefaultTaggingManager(elbv2Client services.ELBV2, managedTagKeyPrefixes []string, logger logr.Logger) *defaultTaggingManager {
 	return &defaultTaggingManager{
-		elbv2Client: elbv2Client,
-		logger:      logger,
+		elbv2Client:           elbv2Client,
+		managedTagKeyPrefixes: managedTagKeyPrefixes,
+		logger:                logger,
 
 		describeTagsChunkSize: defaultDescribeTagsChunkSize,
 	}
@@ -107,7 +109,9 @@ var _ TaggingManager = &defaultTaggingManager{}
 // @TODO: use AWS Resource Groups Tagging API to optimize this implementation once it have PrivateLink support.
 type defaultTaggingManager struct {
 	elbv2Client services.ELBV2
-	logger      logr.Logger
+	// when non-empty, only tags with keys matching one of these prefixes will be removed.
+	managedTagKeyPrefixes []string
+	logger                logr.Logger
 
 	describeTagsChunkSize int
 }
@@ -132,6 +136,11 @@ func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, arn string, d
 		delete(tagsToUpdate, ignoredTagKey)
 		delete(tagsToRemove, ignoredTagKey)
 	}
+	for tagKey := range tagsToRemove {
+		if !m.isManagedTagKey(tagKey) {
+			delete(tagsToRemove, tagKey)
+		}
+	}
 
 	if len(tagsToUpdate) > 0 {
 		req := &elbv2sdk.AddTagsInput{
@@ -168,6 +177,19 @@ func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, arn string, d
 	return nil
 }
 
+// isManagedTagKey checks whether the tag key is managed by this controller, thus can be removed.
+func (m *defaultTaggingManager) isManagedTagKey(tagKey string) bool {
+	if len(m.managedTagKeyPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range m.managedTagKeyPrefixes {
+		if strings.HasPrefix(tagKey, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *defaultTaggingManager) ListListeners(ctx context.Context, lbARN string) ([]ListenerWithTags, error) {
 	req := &elbv2sdk.DescribeListenersInput{
 		LoadBalancerArn: awssdk.String(lbARN),
@@ -30,6 +30,7 @@ func Test_defaultTaggingManager_ReconcileTags(t *testing.T) {
 	}
 
 	type fields struct {
+		managedTagKeyPrefixes        []string
 		describeTagsWithContextCalls []describeTagsWithContextCall
 		addTagsWithContextCalls      []addTagsWithContextCall
 		removeTagsWithContextCalls   []removeTagsWithContextCall
@@ -208,6 +209,50 @@ func Test_defaultTaggingManager_ReconcileTags(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "only remove tags with managed tag key prefixes",
+			fields: fields{
+				managedTagKeyPrefixes:        []string{"elbv2.k8s.aws/", "team/"},
+				describeTagsWithContextCalls: nil,
+				addTagsWithContextCalls: []addTagsWithContextCall{
+					{
+						req: &elbv2sdk.AddTagsInput{
+							ResourceArns: []*string{awssdk.String("my-arn")},
+							Tags: []*elbv2sdk.Tag{
+								{
+									Key:   awssdk.String("keyB"),
+									Value: awssdk.String("valueB2"),
+								},
+							},
+						},
+					},
+				},
+				removeTagsWithContextCalls: []removeTagsWithContextCall{
+					{
+						req: &elbv2sdk.RemoveTagsInput{
+							ResourceArns: []*string{awssdk.String("my-arn")},
+							TagKeys:      []*string{awssdk.String("elbv2.k8s.aws/keyD"), awssdk.String("team/keyE")},
+						},
+					},
+				},
+			},
+			args: args{
+				arn: "my-arn",
+				desiredTags: map[string]string{
+					"elbv2.k8s.aws/keyA": "valueA",
+					"keyB":               "valueB2",
+				},
+				opts: []ReconcileTagsOption{
+					WithCurrentTags(map[string]string{
+						"elbv2.k8s.aws/keyA": "valueA",
+						"keyB":               "valueB",
+						"keyC":               "valueC",
+						"elbv2.k8s.aws/keyD": "valueD",
+						"team/keyE":          "valueE",
+					}),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -226,6 +271,7 @@ func Test_defaultTaggingManager_ReconcileTags(t *testing.T) {
 
 			m := &defaultTaggingManager{
 				elbv2Client:           elbv2Client,
+				managedTagKeyPrefixes: tt.fields.managedTagKeyPrefixes,
 				logger:                &log.NullLogger{},
 				describeTagsChunkSize: defaultDescribeTagsChunkSize,
 			}
@@ -40,6 +40,7 @@ func (r *defaultTargetGroupAttributeReconciler) Reconcile(ctx context.Context, r
 		return err
 	}
 
+	// only attributes within desiredAttrs are managed, other attributes are left at their current values.
 	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
 	if len(attributesToUpdate) > 0 {
 		req := &elbv2sdk.ModifyTargetGroupAttributesInput{
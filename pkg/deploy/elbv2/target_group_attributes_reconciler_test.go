@@ -149,6 +149,61 @@ func Test_defaultTargetGroupAttributeReconciler_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "only managed attributes should be updated, unmanaged attributes are left untouched",
+			fields: fields{
+				describeTargetGroupAttributesWithContextCalls: []describeTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("deregistration_delay.timeout_seconds"),
+									Value: awssdk.String("300"),
+								},
+								{
+									Key:   awssdk.String("proxy_protocol_v2.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+					},
+				},
+				modifyTargetGroupAttributesWithContextCalls: []modifyTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("deregistration_delay.timeout_seconds"),
+									Value: awssdk.String("120"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("my-arn"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+							{
+								Key:   "deregistration_delay.timeout_seconds",
+								Value: "120",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2,6 +2,7 @@ package elbv2
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -9,28 +10,29 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"time"
 )
 
 const (
-	defaultWaitTGBObservedPollInterval = 200 * time.Millisecond
-	defaultWaitTGBObservedTimeout      = 60 * time.Second
-	defaultWaitTGBDeletionPollInterval = 200 * time.Millisecond
-	defaultWaitTGBDeletionTimeout      = 60 * time.Second
+	defaultTGBObservedRequeueInterval = 2 * time.Second
+	defaultTGBDeletionRequeueInterval = 5 * time.Second
 )
 
 // TargetGroupBindingManager is responsible for create/update/delete TargetGroupBinding resources.
 type TargetGroupBindingManager interface {
 	Create(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource) (elbv2model.TargetGroupBindingResourceStatus, error)
 
+	// Update updates the TargetGroupBinding, the status is returned together with a RequeueNeededAfter error
+	// if the TargetGroupBinding isn't observed by its controller yet.
 	Update(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource, k8sTGB *elbv2api.TargetGroupBinding) (elbv2model.TargetGroupBindingResourceStatus, error)
 
+	// Delete deletes the TargetGroupBinding, a RequeueNeededAfter error is returned if it's still being deleted.
 	Delete(ctx context.Context, k8sTGB *elbv2api.TargetGroupBinding) error
 }
 
@@ -41,10 +43,8 @@ func NewDefaultTargetGroupBindingManager(k8sClient client.Client, trackingProvid
 		trackingProvider: trackingProvider,
 		logger:           logger,
 
-		waitTGBObservedPollInterval: defaultWaitTGBObservedPollInterval,
-		waitTGBObservedTimout:       defaultWaitTGBObservedTimeout,
-		waitTGBDeletionPollInterval: defaultWaitTGBDeletionPollInterval,
-		waitTGBDeletionTimeout:      defaultWaitTGBDeletionTimeout,
+		tgbObservedRequeueInterval: defaultTGBObservedRequeueInterval,
+		tgbDeletionRequeueInterval: defaultTGBDeletionRequeueInterval,
 	}
 }
 
@@ -56,10 +56,10 @@ type defaultTargetGroupBindingManager struct {
 	trackingProvider tracking.Provider
 	logger           logr.Logger
 
-	waitTGBObservedPollInterval time.Duration
-	waitTGBObservedTimout       time.Duration
-	waitTGBDeletionPollInterval time.Duration
-	waitTGBDeletionTimeout      time.Duration
+	// interval to requeue while TargetGroupBinding isn't observed by its controller yet.
+	tgbObservedRequeueInterval time.Duration
+	// interval to requeue while TargetGroupBinding is still being deleted.
+	tgbDeletionRequeueInterval time.Duration
 }
 
 func (m *defaultTargetGroupBindingManager) Create(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource) (elbv2model.TargetGroupBindingResourceStatus, error) {
@@ -97,7 +97,7 @@ func (m *defaultTargetGroupBindingManager) Update(ctx context.Context, resTGB *e
 		return elbv2model.TargetGroupBindingResourceStatus{}, err
 	}
 	if equality.Semantic.DeepEqual(k8sTGB.Spec, k8sTGBSpec) {
-		return buildResTargetGroupBindingStatus(k8sTGB), nil
+		return buildResTargetGroupBindingStatus(k8sTGB), m.checkTargetGroupBindingObserved(k8sTGB)
 	}
 
 	oldK8sTGB := k8sTGB.DeepCopy()
@@ -109,61 +109,49 @@ func (m *defaultTargetGroupBindingManager) Update(ctx context.Context, resTGB *e
 	if err := m.k8sClient.Patch(ctx, k8sTGB, client.MergeFrom(oldK8sTGB)); err != nil {
 		return elbv2model.TargetGroupBindingResourceStatus{}, err
 	}
-	if err := m.waitUntilTargetGroupBindingObserved(ctx, k8sTGB); err != nil {
-		return elbv2model.TargetGroupBindingResourceStatus{}, err
-	}
 	m.logger.Info("modified targetGroupBinding",
 		"stackID", resTGB.Stack().StackID(),
 		"resourceID", resTGB.ID(),
 		"targetGroupBinding", k8s.NamespacedName(k8sTGB))
-	return buildResTargetGroupBindingStatus(k8sTGB), nil
+	return buildResTargetGroupBindingStatus(k8sTGB), m.checkTargetGroupBindingObserved(k8sTGB)
 }
 
 func (m *defaultTargetGroupBindingManager) Delete(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	m.logger.Info("deleting targetGroupBinding",
 		"targetGroupBinding", k8s.NamespacedName(tgb))
-	if err := m.k8sClient.Delete(ctx, tgb); err != nil {
+	if err := m.k8sClient.Delete(ctx, tgb); err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
-	if err := m.waitUntilTargetGroupBindingDeleted(ctx, tgb); err != nil {
-		return errors.Wrap(err, "failed to wait targetGroupBinding deletion")
+	deleted, err := m.isTargetGroupBindingDeleted(ctx, tgb)
+	if err != nil {
+		return errors.Wrap(err, "failed to check targetGroupBinding deletion")
+	}
+	if !deleted {
+		return runtime.NewRequeueNeededAfter(fmt.Sprintf("targetGroupBinding %v is still being deleted", k8s.NamespacedName(tgb)), m.tgbDeletionRequeueInterval)
 	}
 	m.logger.Info("deleted targetGroupBinding",
 		"targetGroupBinding", k8s.NamespacedName(tgb))
 	return nil
 }
 
-func (m *defaultTargetGroupBindingManager) waitUntilTargetGroupBindingObserved(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	ctx, cancel := context.WithTimeout(ctx, m.waitTGBObservedTimout)
-	defer cancel()
-
-	observedTGB := &elbv2api.TargetGroupBinding{}
-	return wait.PollImmediateUntil(m.waitTGBObservedPollInterval, func() (bool, error) {
-		if err := m.k8sClient.Get(ctx, k8s.NamespacedName(tgb), observedTGB); err != nil {
-			return false, err
-		}
-		if awssdk.Int64Value(observedTGB.Status.ObservedGeneration) >= tgb.Generation {
-			return true, nil
-		}
-
-		return false, nil
-	}, ctx.Done())
+// checkTargetGroupBindingObserved checks whether the TargetGroupBinding is observed by its controller,
+// a RequeueNeededAfter error is returned if not, instead of waiting for it.
+func (m *defaultTargetGroupBindingManager) checkTargetGroupBindingObserved(tgb *elbv2api.TargetGroupBinding) error {
+	if awssdk.Int64Value(tgb.Status.ObservedGeneration) >= tgb.Generation {
+		return nil
+	}
+	return runtime.NewRequeueNeededAfter(fmt.Sprintf("targetGroupBinding %v is not observed yet", k8s.NamespacedName(tgb)), m.tgbObservedRequeueInterval)
 }
 
-func (m *defaultTargetGroupBindingManager) waitUntilTargetGroupBindingDeleted(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	ctx, cancel := context.WithTimeout(ctx, m.waitTGBDeletionTimeout)
-	defer cancel()
-
+func (m *defaultTargetGroupBindingManager) isTargetGroupBindingDeleted(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (bool, error) {
 	observedTGB := &elbv2api.TargetGroupBinding{}
-	return wait.PollImmediateUntil(m.waitTGBDeletionPollInterval, func() (bool, error) {
-		if err := m.k8sClient.Get(ctx, k8s.NamespacedName(tgb), observedTGB); err != nil {
-			if apierrors.IsNotFound(err) {
-				return true, nil
-			}
-			return false, err
+	if err := m.k8sClient.Get(ctx, k8s.NamespacedName(tgb), observedTGB); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
 		}
-		return false, nil
-	}, ctx.Done())
+		return false, err
+	}
+	return false, nil
 }
 
 func buildK8sTargetGroupBindingSpec(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource) (elbv2api.TargetGroupBindingSpec, error) {
@@ -186,6 +174,7 @@ func buildK8sTargetGroupBindingSpec(ctx context.Context, resTGB *elbv2model.Targ
 		k8sTGBSpec.Networking = &k8sTGBNetworking
 	}
 	k8sTGBSpec.NodeSelector = resTGB.Spec.Template.Spec.NodeSelector
+	k8sTGBSpec.PodSelector = resTGB.Spec.Template.Spec.PodSelector
 	return k8sTGBSpec, nil
 }
 
@@ -0,0 +1,98 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultTargetGroupBindingManager_checkTargetGroupBindingObserved(t *testing.T) {
+	tests := []struct {
+		name        string
+		tgb         *elbv2api.TargetGroupBinding
+		wantRequeue bool
+	}{
+		{
+			name: "targetGroupBinding is observed",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-tgb", Generation: 2},
+				Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: awssdk.Int64(2)},
+			},
+			wantRequeue: false,
+		},
+		{
+			name: "targetGroupBinding is not observed yet",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-tgb", Generation: 2},
+				Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: awssdk.Int64(1)},
+			},
+			wantRequeue: true,
+		},
+		{
+			name: "targetGroupBinding is never observed",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-tgb", Generation: 1},
+			},
+			wantRequeue: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultTargetGroupBindingManager{
+				logger:                     &log.NullLogger{},
+				tgbObservedRequeueInterval: 2 * time.Second,
+			}
+			err := m.checkTargetGroupBindingObserved(tt.tgb)
+			if tt.wantRequeue {
+				assert.EqualError(t, err, "requeue needed after 2s: targetGroupBinding awesome-ns/awesome-tgb is not observed yet")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultTargetGroupBindingManager_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+	}{
+		{
+			name:     "targetGroupBinding is deleted",
+			existing: true,
+		},
+		{
+			name:     "targetGroupBinding is already deleted",
+			existing: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-tgb"},
+			}
+			if tt.existing {
+				assert.NoError(t, k8sClient.Create(context.Background(), tgb.DeepCopy()))
+			}
+			m := &defaultTargetGroupBindingManager{
+				k8sClient:                  k8sClient,
+				logger:                     &log.NullLogger{},
+				tgbDeletionRequeueInterval: 5 * time.Second,
+			}
+			err := m.Delete(context.Background(), tgb)
+			assert.NoError(t, err)
+		})
+	}
+}
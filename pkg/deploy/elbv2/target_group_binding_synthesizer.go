@@ -3,19 +3,23 @@ package elbv2
 import (
 	"context"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewTargetGroupBindingSynthesizer constructs new targetGroupBindingSynthesizer
-func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider tracking.Provider, tgbManager TargetGroupBindingManager, logger logr.Logger, stack core.Stack) *targetGroupBindingSynthesizer {
+func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider tracking.Provider, taggingManager TaggingManager,
+	tgbManager TargetGroupBindingManager, logger logr.Logger, stack core.Stack) *targetGroupBindingSynthesizer {
 	return &targetGroupBindingSynthesizer{
 		k8sClient:        k8sClient,
 		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
 		tgbManager:       tgbManager,
 		logger:           logger,
 		stack:            stack,
@@ -28,6 +32,7 @@ func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider
 type targetGroupBindingSynthesizer struct {
 	k8sClient        client.Client
 	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
 	tgbManager       TargetGroupBindingManager
 	logger           logr.Logger
 	stack            core.Stack
@@ -49,30 +54,92 @@ func (s *targetGroupBindingSynthesizer) Synthesize(ctx context.Context) error {
 	}
 	s.unmatchedK8sTGBs = unmatchedK8sTGBs
 
+	// TargetGroups are tagged once their TargetGroupBinding is deployed,
+	// so a TargetGroupBinding for a tagged TargetGroup is missing, e.g. it's deleted manually.
+	tgbDeployedTGARNs := s.findTargetGroupBindingDeployedTGARNs()
 	for _, resTGB := range unmatchedResTGBs {
+		tgARN, err := resTGB.Spec.Template.Spec.TargetGroupARN.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		missing := tgbDeployedTGARNs.Has(tgARN)
+		if missing && resTGB.Spec.SkipRecreation {
+			s.logger.Info("skipped recreating missing targetGroupBinding",
+				"stackID", resTGB.Stack().StackID(),
+				"resourceID", resTGB.ID())
+			resTGB.SetStatus(elbv2model.TargetGroupBindingResourceStatus{
+				TargetGroupBindingRef: corev1.ObjectReference{
+					Namespace: resTGB.Spec.Template.Namespace,
+					Name:      resTGB.Spec.Template.Name,
+				},
+				RecreationSkipped: true,
+			})
+			continue
+		}
 		tgbStatus, err := s.tgbManager.Create(ctx, resTGB)
 		if err != nil {
 			return err
 		}
+		tgbStatus.Recreated = missing
 		resTGB.SetStatus(tgbStatus)
+		if err := s.tagTargetGroupBindingDeployed(ctx, tgARN, tgbDeployedTGARNs); err != nil {
+			return err
+		}
 	}
+	// TargetGroupBindings not observed yet are requeued after all TargetGroupBindings are updated.
+	var requeueErr error
 	for _, resAndK8sTGB := range matchedResAndK8sTGBs {
 		tgbStatus, err := s.tgbManager.Update(ctx, resAndK8sTGB.resTGB, resAndK8sTGB.k8sTGB)
 		if err != nil {
-			return err
+			if !runtime.IsRequeueNeeded(err) {
+				return err
+			}
+			requeueErr = err
 		}
 		resAndK8sTGB.resTGB.SetStatus(tgbStatus)
+		// TargetGroups whose TargetGroupBinding was deployed before they're tagged are tagged as well.
+		if err := s.tagTargetGroupBindingDeployed(ctx, resAndK8sTGB.k8sTGB.Spec.TargetGroupARN, tgbDeployedTGARNs); err != nil {
+			return err
+		}
 	}
-	return nil
+	return requeueErr
 }
 
 func (s *targetGroupBindingSynthesizer) PostSynthesize(ctx context.Context) error {
+	// TargetGroupBindings still being deleted are requeued after deletion of all TargetGroupBindings is issued.
+	var requeueErr error
 	for _, k8sTGB := range s.unmatchedK8sTGBs {
 		if err := s.tgbManager.Delete(ctx, k8sTGB); err != nil {
-			return err
+			if !runtime.IsRequeueNeeded(err) {
+				return err
+			}
+			requeueErr = err
 		}
 	}
-	return nil
+	return requeueErr
+}
+
+// findTargetGroupBindingDeployedTGARNs returns the ARNs of TargetGroups whose TargetGroupBinding has been deployed.
+func (s *targetGroupBindingSynthesizer) findTargetGroupBindingDeployedTGARNs() sets.String {
+	var resTGs []*elbv2model.TargetGroup
+	s.stack.ListResources(&resTGs)
+	tgbDeployedTGARNs := sets.NewString()
+	for _, resTG := range resTGs {
+		if resTG.Status != nil && resTG.Status.TargetGroupBindingDeployed {
+			tgbDeployedTGARNs.Insert(resTG.Status.TargetGroupARN)
+		}
+	}
+	return tgbDeployedTGARNs
+}
+
+// tagTargetGroupBindingDeployed tags the TargetGroup with tgARN once its TargetGroupBinding is deployed, unless it's already tagged.
+func (s *targetGroupBindingSynthesizer) tagTargetGroupBindingDeployed(ctx context.Context, tgARN string, tgbDeployedTGARNs sets.String) error {
+	if tgbDeployedTGARNs.Has(tgARN) {
+		return nil
+	}
+	// only the tag is added, other tags of the TargetGroup are kept as they're unknown to the reconcile.
+	return s.taggingManager.ReconcileTags(ctx, tgARN, map[string]string{tagKeyTargetGroupBindingDeployed: "true"},
+		WithCurrentTags(map[string]string{}))
 }
 
 func (s *targetGroupBindingSynthesizer) findK8sTargetGroupBindings(ctx context.Context) ([]*elbv2api.TargetGroupBinding, error) {
@@ -0,0 +1,140 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_targetGroupBindingSynthesizer_Synthesize(t *testing.T) {
+	tests := []struct {
+		name                  string
+		tgbDeployed           bool
+		skipRecreation        bool
+		wantTGBCreated        bool
+		wantRecreated         bool
+		wantRecreationSkipped bool
+		wantTGTagged          bool
+	}{
+		{
+			name:           "targetGroupBinding never deployed",
+			tgbDeployed:    false,
+			skipRecreation: false,
+			wantTGBCreated: true,
+			wantRecreated:  false,
+			wantTGTagged:   true,
+		},
+		{
+			name:           "targetGroupBinding never deployed with recreation skipped",
+			tgbDeployed:    false,
+			skipRecreation: true,
+			wantTGBCreated: true,
+			wantRecreated:  false,
+			wantTGTagged:   true,
+		},
+		{
+			name:           "missing targetGroupBinding once deployed",
+			tgbDeployed:    true,
+			skipRecreation: false,
+			wantTGBCreated: true,
+			wantRecreated:  true,
+			wantTGTagged:   false,
+		},
+		{
+			name:                  "missing targetGroupBinding once deployed with recreation skipped",
+			tgbDeployed:           true,
+			skipRecreation:        true,
+			wantTGBCreated:        false,
+			wantRecreationSkipped: true,
+			wantTGTagged:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			if tt.wantTGTagged {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), &elbv2sdk.AddTagsInput{
+					ResourceArns: awssdk.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-namespace-svc-1234567890/abcdef"}),
+					Tags: []*elbv2sdk.Tag{
+						{
+							Key:   awssdk.String("elbv2.k8s.aws/targetgroupbinding-deployed"),
+							Value: awssdk.String("true"),
+						},
+					},
+				}).Return(&elbv2sdk.AddTagsOutput{}, nil)
+			}
+			taggingManager := NewDefaultTaggingManager(elbv2Client, nil, &log.NullLogger{})
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			tgbManager := NewDefaultTargetGroupBindingManager(k8sClient, trackingProvider, &log.NullLogger{})
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resTG := elbv2model.NewTargetGroup(stack, "namespace/ingress-svc:http", elbv2model.TargetGroupSpec{
+				Name: "k8s-namespace-svc-1234567890",
+			})
+			resTG.SetStatus(elbv2model.TargetGroupStatus{
+				TargetGroupARN:             "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-namespace-svc-1234567890/abcdef",
+				TargetGroupBindingDeployed: tt.tgbDeployed,
+			})
+			targetType := elbv2api.TargetTypeIP
+			resTGB := elbv2model.NewTargetGroupBindingResource(stack, resTG.ID(), elbv2model.TargetGroupBindingResourceSpec{
+				Template: elbv2model.TargetGroupBindingTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "k8s-namespace-svc-1234567890",
+					},
+					Spec: elbv2model.TargetGroupBindingSpec{
+						TargetGroupARN: resTG.TargetGroupARN(),
+						TargetType:     &targetType,
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc",
+							Port: intstr.FromString("http"),
+						},
+					},
+				},
+				SkipRecreation: tt.skipRecreation,
+			})
+
+			s := NewTargetGroupBindingSynthesizer(k8sClient, trackingProvider, taggingManager, tgbManager, &log.NullLogger{}, stack)
+			err := s.Synthesize(context.Background())
+			assert.NoError(t, err)
+
+			tgbList := &elbv2api.TargetGroupBindingList{}
+			assert.NoError(t, k8sClient.List(context.Background(), tgbList))
+			if tt.wantTGBCreated {
+				assert.Len(t, tgbList.Items, 1)
+				assert.NotNil(t, resTGB.Status)
+				assert.Equal(t, tt.wantRecreated, resTGB.Status.Recreated)
+			} else {
+				assert.Len(t, tgbList.Items, 0)
+				assert.Equal(t, &elbv2model.TargetGroupBindingResourceStatus{
+					TargetGroupBindingRef: corev1.ObjectReference{
+						Namespace: "namespace",
+						Name:      "k8s-namespace-svc-1234567890",
+					},
+					RecreationSkipped: tt.wantRecreationSkipped,
+				}, resTGB.Status)
+			}
+		})
+	}
+}
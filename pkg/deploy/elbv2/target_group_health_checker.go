@@ -0,0 +1,105 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sync"
+	"time"
+)
+
+// TargetGroupHealthChecker is responsible for checking the health of targets within TargetGroups.
+type TargetGroupHealthChecker interface {
+	// CheckHealthy checks whether the percentage of healthy targets within TargetGroup reaches minHealthyPercentage.
+	// TargetGroups without registered targets or not used by any LoadBalancer are considered healthy,
+	// since their targets won't turn healthy until traffic is switched to them.
+	// returns an error if it isn't reached within timeout since first checked.
+	CheckHealthy(ctx context.Context, tgARN string, minHealthyPercentage int64, timeout time.Duration) (bool, error)
+}
+
+// NewDefaultTargetGroupHealthChecker constructs new defaultTargetGroupHealthChecker.
+func NewDefaultTargetGroupHealthChecker(elbv2Client services.ELBV2, logger logr.Logger) *defaultTargetGroupHealthChecker {
+	return &defaultTargetGroupHealthChecker{
+		elbv2Client:           elbv2Client,
+		logger:                logger,
+		unhealthySinceByTGARN: make(map[string]time.Time),
+		now:                   time.Now,
+	}
+}
+
+var _ TargetGroupHealthChecker = &defaultTargetGroupHealthChecker{}
+
+// default implementation for TargetGroupHealthChecker.
+type defaultTargetGroupHealthChecker struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+
+	mutex sync.Mutex
+	// the time TargetGroups were first checked unhealthy, which is kept across reconciles.
+	unhealthySinceByTGARN map[string]time.Time
+	now                   func() time.Time
+}
+
+func (c *defaultTargetGroupHealthChecker) CheckHealthy(ctx context.Context, tgARN string, minHealthyPercentage int64, timeout time.Duration) (bool, error) {
+	healthyPercentage, err := c.computeHealthyPercentage(ctx, tgARN)
+	if err != nil {
+		return false, err
+	}
+	c.logger.V(1).Info("checked targetGroup health",
+		"arn", tgARN,
+		"healthyPercentage", healthyPercentage,
+		"minHealthyPercentage", minHealthyPercentage)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if healthyPercentage >= minHealthyPercentage {
+		delete(c.unhealthySinceByTGARN, tgARN)
+		return true, nil
+	}
+	now := c.now()
+	unhealthySince, ok := c.unhealthySinceByTGARN[tgARN]
+	if !ok {
+		c.unhealthySinceByTGARN[tgARN] = now
+		return false, nil
+	}
+	if unhealthyDuration := now.Sub(unhealthySince); unhealthyDuration > timeout {
+		return false, errors.Errorf("targetGroup %v has %v%% healthy targets, below minHealthyPercentage %v%% after %v",
+			tgARN, healthyPercentage, minHealthyPercentage, timeout)
+	}
+	return false, nil
+}
+
+// computeHealthyPercentage computes the percentage of healthy targets within TargetGroup, draining targets are excluded.
+// it returns 100 if TargetGroup have no targets or all targets are unused.
+func (c *defaultTargetGroupHealthChecker) computeHealthyPercentage(ctx context.Context, tgARN string) (int64, error) {
+	req := &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	}
+	resp, err := c.elbv2Client.DescribeTargetHealthWithContext(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	totalCount, healthyCount, unusedCount := int64(0), int64(0), int64(0)
+	for _, targetHealthDescription := range resp.TargetHealthDescriptions {
+		if targetHealthDescription.TargetHealth == nil {
+			continue
+		}
+		switch awssdk.StringValue(targetHealthDescription.TargetHealth.State) {
+		case elbv2sdk.TargetHealthStateEnumDraining:
+			continue
+		case elbv2sdk.TargetHealthStateEnumHealthy:
+			healthyCount++
+		case elbv2sdk.TargetHealthStateEnumUnused:
+			unusedCount++
+		}
+		totalCount++
+	}
+	// targets are only registered once the TargetGroupBinding is deployed, and are only health checked once any rule uses the TargetGroup.
+	if totalCount == 0 || unusedCount == totalCount {
+		return 100, nil
+	}
+	return healthyCount * 100 / totalCount, nil
+}
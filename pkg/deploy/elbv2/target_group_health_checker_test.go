@@ -0,0 +1,172 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultTargetGroupHealthChecker_CheckHealthy(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	buildTargetHealthDescriptions := func(states ...string) []*elbv2sdk.TargetHealthDescription {
+		var descriptions []*elbv2sdk.TargetHealthDescription
+		for _, state := range states {
+			descriptions = append(descriptions, &elbv2sdk.TargetHealthDescription{
+				TargetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(state),
+				},
+			})
+		}
+		return descriptions
+	}
+	type args struct {
+		minHealthyPercentage int64
+		timeout              time.Duration
+	}
+	tests := []struct {
+		name                 string
+		describeTargetHealth *elbv2sdk.DescribeTargetHealthOutput
+		describeErr          error
+		unhealthySince       *time.Time
+		args                 args
+		want                 bool
+		wantErr              error
+	}{
+		{
+			name: "healthy percentage reached",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: buildTargetHealthDescriptions(
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumInitial,
+				),
+			},
+			args: args{
+				minHealthyPercentage: 75,
+				timeout:              time.Minute,
+			},
+			want: true,
+		},
+		{
+			name: "healthy percentage reached after being unhealthy",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: buildTargetHealthDescriptions(
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumHealthy,
+				),
+			},
+			unhealthySince: awssdk.Time(now.Add(-2 * time.Minute)),
+			args: args{
+				minHealthyPercentage: 100,
+				timeout:              time.Minute,
+			},
+			want: true,
+		},
+		{
+			name: "draining targets are excluded",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: buildTargetHealthDescriptions(
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumDraining,
+				),
+			},
+			args: args{
+				minHealthyPercentage: 100,
+				timeout:              time.Minute,
+			},
+			want: true,
+		},
+		{
+			name:                 "targetGroup without targets is considered healthy",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{},
+			args: args{
+				minHealthyPercentage: 100,
+				timeout:              time.Minute,
+			},
+			want: true,
+		},
+		{
+			name: "targetGroup with unused targets is considered healthy",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: buildTargetHealthDescriptions(
+					elbv2sdk.TargetHealthStateEnumUnused,
+					elbv2sdk.TargetHealthStateEnumUnused,
+				),
+			},
+			args: args{
+				minHealthyPercentage: 100,
+				timeout:              time.Minute,
+			},
+			want: true,
+		},
+		{
+			name: "healthy percentage not reached",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: buildTargetHealthDescriptions(
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumUnhealthy,
+				),
+			},
+			args: args{
+				minHealthyPercentage: 80,
+				timeout:              time.Minute,
+			},
+			want: false,
+		},
+		{
+			name: "healthy percentage not reached within timeout",
+			describeTargetHealth: &elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: buildTargetHealthDescriptions(
+					elbv2sdk.TargetHealthStateEnumHealthy,
+					elbv2sdk.TargetHealthStateEnumUnhealthy,
+				),
+			},
+			unhealthySince: awssdk.Time(now.Add(-2 * time.Minute)),
+			args: args{
+				minHealthyPercentage: 80,
+				timeout:              time.Minute,
+			},
+			wantErr: errors.New("targetGroup tg-green has 50% healthy targets, below minHealthyPercentage 80% after 1m0s"),
+		},
+		{
+			name:        "describe targetHealth failed",
+			describeErr: errors.New("some error"),
+			args: args{
+				minHealthyPercentage: 80,
+				timeout:              time.Minute,
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			req := &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-green")}
+			elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), req).Return(tt.describeTargetHealth, tt.describeErr)
+
+			c := NewDefaultTargetGroupHealthChecker(elbv2Client, &log.NullLogger{})
+			c.now = func() time.Time { return now }
+			if tt.unhealthySince != nil {
+				c.unhealthySinceByTGARN["tg-green"] = *tt.unhealthySince
+			}
+			got, err := c.CheckHealthy(context.Background(), "tg-green", tt.args.minHealthyPercentage, tt.args.timeout)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package elbv2
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -17,6 +19,10 @@ import (
 const (
 	defaultWaitTGDeletionPollInterval = 2 * time.Second
 	defaultWaitTGDeletionTimeout      = 20 * time.Second
+
+	// tagKeyTargetGroupBindingDeployed is the tag added to TargetGroups once their TargetGroupBinding is deployed,
+	// so that TargetGroupBindings missing afterwards can be told from ones never deployed.
+	tagKeyTargetGroupBindingDeployed = "elbv2.k8s.aws/targetgroupbinding-deployed"
 )
 
 // TargetGroupManager is responsible for create/update/delete TargetGroup resources.
@@ -113,6 +119,9 @@ func (m *defaultTargetGroupManager) Delete(ctx context.Context, sdkTG TargetGrou
 		_, err := m.elbv2Client.DeleteTargetGroupWithContext(ctx, req)
 		return err
 	}); err != nil {
+		if errors.Is(err, wait.ErrWaitTimeout) {
+			return runtime.NewRequeueNeeded(fmt.Sprintf("targetGroup %v is still in use", awssdk.StringValue(req.TargetGroupArn)))
+		}
 		return errors.Wrap(err, "failed to delete targetGroup")
 	}
 	m.logger.Info("deleted targetGroup",
@@ -145,9 +154,10 @@ func (m *defaultTargetGroupManager) updateSDKTargetGroupWithHealthCheck(ctx cont
 
 func (m *defaultTargetGroupManager) updateSDKTargetGroupWithTags(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) error {
 	desiredTGTags := m.trackingProvider.ResourceTags(resTG.Stack(), resTG, resTG.Spec.Tags)
+	ignoredTagKeys := append(m.trackingProvider.LegacyTagKeys(), tagKeyTargetGroupBindingDeployed)
 	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn), desiredTGTags,
 		WithCurrentTags(sdkTG.Tags),
-		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()))
+		WithIgnoredTagKeys(ignoredTagKeys))
 }
 
 func isSDKTargetGroupHealthCheckDrifted(tgSpec elbv2model.TargetGroupSpec, sdkTG TargetGroupWithTags) bool {
@@ -242,7 +252,8 @@ func buildSDKMatcher(modelMatcher elbv2model.HealthCheckMatcher) *elbv2sdk.Match
 
 func buildResTargetGroupStatus(sdkTG TargetGroupWithTags) elbv2model.TargetGroupStatus {
 	return elbv2model.TargetGroupStatus{
-		TargetGroupARN: awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		TargetGroupARN:             awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		TargetGroupBindingDeployed: sdkTG.Tags[tagKeyTargetGroupBindingDeployed] == "true",
 	}
 }
 
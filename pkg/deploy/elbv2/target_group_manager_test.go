@@ -1,14 +1,19 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_isSDKTargetGroupHealthCheckDrifted(t *testing.T) {
@@ -639,3 +644,90 @@ func Test_isTargetGroupResourceInUseError(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultTargetGroupManager_Delete(t *testing.T) {
+	type deleteTargetGroupWithContextCall struct {
+		req  *elbv2sdk.DeleteTargetGroupInput
+		resp *elbv2sdk.DeleteTargetGroupOutput
+		err  error
+	}
+	type fields struct {
+		deleteTargetGroupWithContextCalls []deleteTargetGroupWithContextCall
+	}
+	type args struct {
+		sdkTG TargetGroupWithTags
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "targetGroup deleted",
+			fields: fields{
+				deleteTargetGroupWithContextCalls: []deleteTargetGroupWithContextCall{
+					{
+						req:  &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DeleteTargetGroupOutput{},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{TargetGroup: &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String("my-arn")}},
+			},
+		},
+		{
+			name: "targetGroup still in use",
+			fields: fields{
+				deleteTargetGroupWithContextCalls: []deleteTargetGroupWithContextCall{
+					{
+						req: &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("my-arn")},
+						err: awserr.New("ResourceInUse", "some message", nil),
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{TargetGroup: &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String("my-arn")}},
+			},
+			wantErr: errors.New("requeue needed: targetGroup my-arn is still in use"),
+		},
+		{
+			name: "targetGroup deletion failed",
+			fields: fields{
+				deleteTargetGroupWithContextCalls: []deleteTargetGroupWithContextCall{
+					{
+						req: &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("my-arn")},
+						err: awserr.New("AccessDenied", "some message", nil),
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{TargetGroup: &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String("my-arn")}},
+			},
+			wantErr: errors.New("failed to delete targetGroup: AccessDenied: some message"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.deleteTargetGroupWithContextCalls {
+				elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), call.req).Return(call.resp, call.err).AnyTimes()
+			}
+			m := &defaultTargetGroupManager{
+				elbv2Client:                elbv2Client,
+				logger:                     &log.NullLogger{},
+				waitTGDeletionPollInterval: 10 * time.Millisecond,
+				waitTGDeletionTimeout:      50 * time.Millisecond,
+			}
+			err := m.Delete(context.Background(), tt.args.sdkTG)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
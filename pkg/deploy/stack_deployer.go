@@ -3,6 +3,7 @@ package deploy
 import (
 	"context"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
@@ -13,6 +14,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/wafv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -29,17 +31,18 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 
 	trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
 	ec2TaggingManager := ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger)
-	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), logger)
+	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), config.ManagedTagKeyPrefixes, logger)
 
 	return &defaultStackDeployer{
 		cloud:                               cloud,
 		k8sClient:                           k8sClient,
 		addonsConfig:                        config.AddonsConfig,
+		managedLBReplacement:                config.ManagedLoadBalancerReplacement,
 		trackingProvider:                    trackingProvider,
 		ec2TaggingManager:                   ec2TaggingManager,
 		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), logger),
 		elbv2TaggingManager:                 elbv2TaggingManager,
-		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, logger),
+		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.DisableDeletionProtectionOnCleanup, logger),
 		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, logger),
 		elbv2LRManager:                      elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, logger),
 		elbv2TGManager:                      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), logger),
@@ -59,6 +62,7 @@ type defaultStackDeployer struct {
 	cloud                               aws.Cloud
 	k8sClient                           client.Client
 	addonsConfig                        config.AddonsConfig
+	managedLBReplacement                bool
 	trackingProvider                    tracking.Provider
 	ec2TaggingManager                   ec2.TaggingManager
 	ec2SGManager                        ec2.SecurityGroupManager
@@ -83,13 +87,14 @@ type ResourceSynthesizer interface {
 
 // Deploy a resource stack.
 func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	lbSynthesizer := elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.managedLBReplacement, d.logger, stack)
 	synthesizers := []ResourceSynthesizer{
 		ec2.NewSecurityGroupSynthesizer(d.cloud.EC2(), d.trackingProvider, d.ec2TaggingManager, d.ec2SGManager, d.vpcID, d.logger, stack),
 		elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.logger, stack),
-		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, stack),
-		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LSManager, d.logger, stack),
+		lbSynthesizer,
+		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LSManager, lbSynthesizer, d.logger, stack),
 		elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LRManager, d.logger, stack),
-		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.logger, stack),
+		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGBManager, d.logger, stack),
 	}
 
 	if d.addonsConfig.WAFV2Enabled {
@@ -100,22 +105,38 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) err
 	}
 	shieldNeeded := false
 	if d.addonsConfig.ShieldEnabled {
-		shieldNeeded, _ = d.cloud.Shield().Available()
+		shieldNeeded, _ = d.cloud.Shield().Available(ctx)
 	}
 	if shieldNeeded {
 		synthesizers = append(synthesizers, shield.NewProtectionSynthesizer(d.shieldProtectionManager, d.logger, stack))
 	}
 
+	return deploySynthesizers(ctx, synthesizers)
+}
+
+// deploySynthesizers synthesizes resources in order of synthesizers, then cleans up resources in reverse order.
+// Listeners and ListenerRules are updated during synthesize, while TargetGroups and TargetGroupBindings are deleted during post synthesize,
+// so that TargetGroups are only deregistered and deleted after no ListenerRule forwards to them.
+// If traffic switching is pending on target groups health, the remaining resources are still synthesized so that new
+// TargetGroupBindings are deployed, but resources are only cleaned up after traffic is switched in later reconciles.
+func deploySynthesizers(ctx context.Context, synthesizers []ResourceSynthesizer) error {
+	var requeueNeededAfter *runtime.RequeueNeededAfter
+	var pendingErr error
 	for _, synthesizer := range synthesizers {
 		if err := synthesizer.Synthesize(ctx); err != nil {
-			return err
+			if !errors.As(err, &requeueNeededAfter) {
+				return err
+			}
+			pendingErr = err
 		}
 	}
+	if pendingErr != nil {
+		return pendingErr
+	}
 	for i := len(synthesizers) - 1; i >= 0; i-- {
 		if err := synthesizers[i].PostSynthesize(ctx); err != nil {
 			return err
 		}
 	}
-
 	return nil
 }
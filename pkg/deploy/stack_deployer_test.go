@@ -0,0 +1,97 @@
+package deploy
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"testing"
+	"time"
+)
+
+// fakeSynthesizer records the operations performed during synthesize and post synthesize.
+type fakeSynthesizer struct {
+	synthesizeOps     []string
+	postSynthesizeOps []string
+	synthesizeErr     error
+	recorder          *[]string
+}
+
+func (s *fakeSynthesizer) Synthesize(_ context.Context) error {
+	if s.synthesizeErr != nil {
+		return s.synthesizeErr
+	}
+	*s.recorder = append(*s.recorder, s.synthesizeOps...)
+	return nil
+}
+
+func (s *fakeSynthesizer) PostSynthesize(_ context.Context) error {
+	*s.recorder = append(*s.recorder, s.postSynthesizeOps...)
+	return nil
+}
+
+func Test_deploySynthesizers(t *testing.T) {
+	tests := []struct {
+		name            string
+		lrSynthesizeErr error
+		wantOps         []string
+		wantErr         error
+	}{
+		{
+			name: "backend removal - listener rule stops forwarding before targetGroup deletion",
+			wantOps: []string{
+				"create targetGroup",
+				"modify listenerRule",
+				"create targetGroupBinding",
+				"delete targetGroupBinding",
+				"delete targetGroup",
+			},
+		},
+		{
+			name:            "traffic switching - targetGroupBindings are created but nothing is deleted while switch is pending",
+			lrSynthesizeErr: runtime.NewRequeueNeededAfter("waiting for targetGroups", 15*time.Second),
+			wantOps: []string{
+				"create targetGroup",
+				"create targetGroupBinding",
+			},
+			wantErr: errors.New("requeue needed after 15s: waiting for targetGroups"),
+		},
+		{
+			name:            "backend removal - targetGroup is kept if listener rule failed to update",
+			lrSynthesizeErr: errors.New("some error"),
+			wantOps: []string{
+				"create targetGroup",
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOps []string
+			synthesizers := []ResourceSynthesizer{
+				&fakeSynthesizer{
+					synthesizeOps:     []string{"create targetGroup"},
+					postSynthesizeOps: []string{"delete targetGroup"},
+					recorder:          &gotOps,
+				},
+				&fakeSynthesizer{
+					synthesizeOps: []string{"modify listenerRule"},
+					synthesizeErr: tt.lrSynthesizeErr,
+					recorder:      &gotOps,
+				},
+				&fakeSynthesizer{
+					synthesizeOps:     []string{"create targetGroupBinding"},
+					postSynthesizeOps: []string{"delete targetGroupBinding"},
+					recorder:          &gotOps,
+				},
+			}
+			err := deploySynthesizers(context.Background(), synthesizers)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantOps, gotOps)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 // StackMarshaller will marshall a resource stack into JSON.
 type StackMarshaller interface {
+	// Marshal returns the JSON representation of stack.
+	// Resources are emitted in a stable order sorted by resource type then resource ID, so that outputs can be diffed across runs.
 	Marshal(stack core.Stack) (string, error)
 }
 
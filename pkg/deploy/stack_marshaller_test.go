@@ -41,6 +41,26 @@ func Test_defaultDeployer_Deploy(t *testing.T) {
 			},
 			want: `{"id":"namespace/name","resources":{"typeX":{"resA":{"spec":{"fieldA":["valueA"]}},"resB":{"spec":{"fieldA":[{"$ref":"#/resources/typeX/resA/status/fieldB"}]}}},"typeY":{"resC":{"spec":{"fieldA":["valueA",{"$ref":"#/resources/typeX/resB/status/fieldB"}]}}}}}`,
 		},
+		{
+			name: "multiple resources added out of order",
+			modelBuildFunc: func() core.Stack {
+				stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+				_ = core.NewFakeResource(stack, "typeY", "resD", core.FakeResourceSpec{
+					FieldA: []core.StringToken{core.LiteralStringToken("valueD")},
+				}, nil)
+				_ = core.NewFakeResource(stack, "typeY", "resC", core.FakeResourceSpec{
+					FieldA: []core.StringToken{core.LiteralStringToken("valueC")},
+				}, nil)
+				_ = core.NewFakeResource(stack, "typeX", "resB", core.FakeResourceSpec{
+					FieldA: []core.StringToken{core.LiteralStringToken("valueB")},
+				}, nil)
+				_ = core.NewFakeResource(stack, "typeX", "resA", core.FakeResourceSpec{
+					FieldA: []core.StringToken{core.LiteralStringToken("valueA")},
+				}, nil)
+				return stack
+			},
+			want: `{"id":"namespace/name","resources":{"typeX":{"resA":{"spec":{"fieldA":["valueA"]}},"resB":{"spec":{"fieldA":["valueB"]}}},"typeY":{"resC":{"spec":{"fieldA":["valueC"]}},"resD":{"spec":{"fieldA":["valueD"]}}}}}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,6 +70,12 @@ func Test_defaultDeployer_Deploy(t *testing.T) {
 			assert.Equal(t, tt.want, got)
 			if tt.wantErr == nil {
 				assert.NoError(t, err)
+				// output must be stable across runs.
+				for i := 0; i < 10; i++ {
+					gotAgain, err := d.Marshal(stack)
+					assert.NoError(t, err)
+					assert.Equal(t, got, gotAgain)
+				}
 			} else {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			}
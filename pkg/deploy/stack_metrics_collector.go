@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+const (
+	metricManagedResources = "aws_managed_resources"
+
+	labelController   = "controller"
+	labelNamespace    = "namespace"
+	labelName         = "name"
+	labelResourceType = "resource_type"
+
+	resourceTypeListener     = "listener"
+	resourceTypeListenerRule = "listener_rule"
+	resourceTypeTargetGroup  = "target_group"
+)
+
+// StackMetricsCollector exports the count of AWS resources managed for each stack.
+type StackMetricsCollector interface {
+	// Observe records the count of resources within stack deployed by controller.
+	Observe(controller string, stack core.Stack) error
+
+	// Forget removes the recorded counts for stack deployed by controller.
+	Forget(controller string, stackID core.StackID)
+}
+
+// NewDefaultStackMetricsCollector constructs new defaultStackMetricsCollector.
+// Metrics are registered to metricsRegisterer if it's not nil.
+func NewDefaultStackMetricsCollector(metricsRegisterer prometheus.Registerer) (*defaultStackMetricsCollector, error) {
+	managedResources := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricManagedResources,
+		Help: "Number of AWS resources managed by controller, per IngressGroup or Service",
+	}, []string{labelController, labelNamespace, labelName, labelResourceType})
+	if metricsRegisterer != nil {
+		if err := metricsRegisterer.Register(managedResources); err != nil {
+			return nil, err
+		}
+	}
+	return &defaultStackMetricsCollector{
+		managedResources: managedResources,
+	}, nil
+}
+
+var _ StackMetricsCollector = &defaultStackMetricsCollector{}
+
+// defaultStackMetricsCollector is the default implementation for StackMetricsCollector.
+type defaultStackMetricsCollector struct {
+	managedResources *prometheus.GaugeVec
+}
+
+func (c *defaultStackMetricsCollector) Observe(controller string, stack core.Stack) error {
+	var resLSs []*elbv2model.Listener
+	if err := stack.ListResources(&resLSs); err != nil {
+		return err
+	}
+	var resLRs []*elbv2model.ListenerRule
+	if err := stack.ListResources(&resLRs); err != nil {
+		return err
+	}
+	var resTGs []*elbv2model.TargetGroup
+	if err := stack.ListResources(&resTGs); err != nil {
+		return err
+	}
+	stackID := stack.StackID()
+	c.managedResources.With(c.buildLabels(controller, stackID, resourceTypeListener)).Set(float64(len(resLSs)))
+	c.managedResources.With(c.buildLabels(controller, stackID, resourceTypeListenerRule)).Set(float64(len(resLRs)))
+	c.managedResources.With(c.buildLabels(controller, stackID, resourceTypeTargetGroup)).Set(float64(len(resTGs)))
+	return nil
+}
+
+func (c *defaultStackMetricsCollector) Forget(controller string, stackID core.StackID) {
+	for _, resourceType := range []string{resourceTypeListener, resourceTypeListenerRule, resourceTypeTargetGroup} {
+		c.managedResources.Delete(c.buildLabels(controller, stackID, resourceType))
+	}
+}
+
+func (c *defaultStackMetricsCollector) buildLabels(controller string, stackID core.StackID, resourceType string) prometheus.Labels {
+	return prometheus.Labels{
+		labelController:   controller,
+		labelNamespace:    stackID.Namespace,
+		labelName:         stackID.Name,
+		labelResourceType: resourceType,
+	}
+}
@@ -0,0 +1,90 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func Test_defaultStackMetricsCollector_Observe(t *testing.T) {
+	tests := []struct {
+		name           string
+		modelBuildFunc func() core.Stack
+		want           map[string]float64
+	}{
+		{
+			name: "stack with listeners, rules and targetGroups",
+			modelBuildFunc: func() core.Stack {
+				stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+				lbARN := core.LiteralStringToken("lb-arn")
+				ls80 := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: lbARN})
+				ls443 := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{LoadBalancerARN: lbARN})
+				_ = elbv2model.NewListenerRule(stack, "80:1", elbv2model.ListenerRuleSpec{ListenerARN: ls80.ListenerARN()})
+				_ = elbv2model.NewListenerRule(stack, "443:1", elbv2model.ListenerRuleSpec{ListenerARN: ls443.ListenerARN()})
+				_ = elbv2model.NewListenerRule(stack, "443:2", elbv2model.ListenerRuleSpec{ListenerARN: ls443.ListenerARN()})
+				_ = elbv2model.NewTargetGroup(stack, "tg-1", elbv2model.TargetGroupSpec{})
+				return stack
+			},
+			want: map[string]float64{
+				resourceTypeListener:     2,
+				resourceTypeListenerRule: 3,
+				resourceTypeTargetGroup:  1,
+			},
+		},
+		{
+			name: "empty stack",
+			modelBuildFunc: func() core.Stack {
+				return core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			},
+			want: map[string]float64{
+				resourceTypeListener:     0,
+				resourceTypeListenerRule: 0,
+				resourceTypeTargetGroup:  0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewDefaultStackMetricsCollector(prometheus.NewRegistry())
+			assert.NoError(t, err)
+			stack := tt.modelBuildFunc()
+			err = c.Observe("ingress", stack)
+			assert.NoError(t, err)
+			for resourceType, count := range tt.want {
+				got := testutil.ToFloat64(c.managedResources.WithLabelValues("ingress", "namespace", "name", resourceType))
+				assert.Equal(t, count, got, resourceType)
+			}
+		})
+	}
+}
+
+func Test_defaultStackMetricsCollector_Forget(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := NewDefaultStackMetricsCollector(registry)
+	assert.NoError(t, err)
+	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+	_ = elbv2model.NewTargetGroup(stack, "tg-1", elbv2model.TargetGroupSpec{})
+	otherStack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "other"})
+	_ = elbv2model.NewTargetGroup(otherStack, "tg-1", elbv2model.TargetGroupSpec{})
+
+	assert.NoError(t, c.Observe("service", stack))
+	assert.NoError(t, c.Observe("service", otherStack))
+	assert.Equal(t, 6, countMetrics(t, registry))
+
+	c.Forget("service", stack.StackID())
+	assert.Equal(t, 3, countMetrics(t, registry))
+}
+
+func countMetrics(t *testing.T, gatherer prometheus.Gatherer) int {
+	metricFamilies, err := gatherer.Gather()
+	assert.NoError(t, err)
+	count := 0
+	for _, metricFamily := range metricFamilies {
+		count += len(metricFamily.GetMetric())
+	}
+	return count
+}
@@ -9,7 +9,8 @@ type StackSchema struct {
 	// Stack's ID
 	ID string `json:"id"`
 
-	// all resources within stack.
+	// all resources within stack, keyed by resource type then resource ID.
+	// encoding/json marshals map keys in sorted order, which keeps the JSON output stable regardless of traversal order.
 	Resources map[string]map[string]interface{} `json:"resources"`
 }
 
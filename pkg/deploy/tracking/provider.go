@@ -43,6 +43,12 @@ type Provider interface {
 	// ResourceIDTagKey provide the tagKey for resourceID.
 	ResourceIDTagKey() string
 
+	// StackIDTagKey provide the tagKey for stackID.
+	StackIDTagKey() string
+
+	// StacksTagFilter provide the tagFilter that matches resources of all stacks within cluster.
+	StacksTagFilter() TagFilter
+
 	// StackTags provide the tags for stack.
 	StackTags(stack core.Stack) map[string]string
 
@@ -82,11 +88,22 @@ func (p *defaultProvider) ResourceIDTagKey() string {
 	return p.prefixedTrackingKey("resource")
 }
 
+func (p *defaultProvider) StackIDTagKey() string {
+	return p.prefixedTrackingKey("stack")
+}
+
+func (p *defaultProvider) StacksTagFilter() TagFilter {
+	return TagFilter{
+		clusterNameTagKey: {p.clusterName},
+		p.StackIDTagKey(): nil,
+	}
+}
+
 func (p *defaultProvider) StackTags(stack core.Stack) map[string]string {
 	stackID := stack.StackID()
 	return map[string]string{
-		clusterNameTagKey:              p.clusterName,
-		p.prefixedTrackingKey("stack"): stackID.String(),
+		clusterNameTagKey: p.clusterName,
+		p.StackIDTagKey(): stackID.String(),
 	}
 }
 
@@ -114,8 +131,8 @@ func (p *defaultProvider) StackLabels(stack core.Stack) map[string]string {
 func (p *defaultProvider) StackTagsLegacy(stack core.Stack) map[string]string {
 	stackID := stack.StackID()
 	return map[string]string{
-		clusterNameTagKeyLegacy:        p.clusterName,
-		p.prefixedTrackingKey("stack"): stackID.String(),
+		clusterNameTagKeyLegacy: p.clusterName,
+		p.StackIDTagKey():       stackID.String(),
 	}
 }
 
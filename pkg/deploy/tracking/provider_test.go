@@ -77,6 +77,37 @@ func Test_defaultProvider_StackTags(t *testing.T) {
 	}
 }
 
+func Test_defaultProvider_StacksTagFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *defaultProvider
+		want     TagFilter
+	}{
+		{
+			name:     "stacksTagFilter for Ingress",
+			provider: NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+			want: TagFilter{
+				"elbv2.k8s.aws/cluster": {"cluster-name"},
+				"ingress.k8s.aws/stack": nil,
+			},
+		},
+		{
+			name:     "stacksTagFilter for Service",
+			provider: NewDefaultProvider("service.k8s.aws", "cluster-name"),
+			want: TagFilter{
+				"elbv2.k8s.aws/cluster": {"cluster-name"},
+				"service.k8s.aws/stack": nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.provider.StacksTagFilter()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_defaultProvider_ResourceTags(t *testing.T) {
 	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "ingressName"})
 	fakeRes := core.NewFakeResource(stack, "fake", "fake-id", core.FakeResourceSpec{}, nil)
@@ -0,0 +1,316 @@
+package gc
+
+import (
+	"context"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// tag prefixes used by the ingress and service controllers to track their stacks.
+	ingressTagPrefix = "ingress.k8s.aws"
+	serviceTagPrefix = "service.k8s.aws"
+)
+
+// OrphanedResourcesCollector deletes AWS resources provisioned by this controller whose owner no longer exists in cluster.
+// e.g. when an Ingress or Service is deleted while the controller is down.
+type OrphanedResourcesCollector interface {
+	// Collect deletes orphaned AWS resources, or only logs them in dry-run mode.
+	Collect(ctx context.Context) error
+}
+
+// NewDefaultOrphanedResourcesCollector constructs new defaultOrphanedResourcesCollector.
+// k8sReader should read directly from the API server, so that owners are never missed due to unsynced caches.
+func NewDefaultOrphanedResourcesCollector(elbv2Client services.ELBV2, ec2Client services.EC2, k8sReader client.Reader,
+	vpcID string, config config.ControllerConfig, dryRun bool, logger logr.Logger) *defaultOrphanedResourcesCollector {
+
+	networkingSGManager := networkingpkg.NewDefaultSecurityGroupManager(ec2Client, logger)
+	networkingSGReconciler := networkingpkg.NewDefaultSecurityGroupReconciler(networkingSGManager, logger)
+	ec2TaggingManager := ec2.NewDefaultTaggingManager(ec2Client, networkingSGManager, vpcID, logger)
+	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(elbv2Client, config.ManagedTagKeyPrefixes, logger)
+
+	var stackKinds []stackKind
+	for _, tagPrefix := range []string{ingressTagPrefix, serviceTagPrefix} {
+		trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
+		stackKinds = append(stackKinds, stackKind{
+			tagPrefix:        tagPrefix,
+			trackingProvider: trackingProvider,
+			elbv2LBManager:   elbv2.NewDefaultLoadBalancerManager(elbv2Client, trackingProvider, elbv2TaggingManager, config.DisableDeletionProtectionOnCleanup, logger),
+			elbv2TGManager:   elbv2.NewDefaultTargetGroupManager(elbv2Client, trackingProvider, elbv2TaggingManager, vpcID, logger),
+			ec2SGManager:     ec2.NewDefaultSecurityGroupManager(ec2Client, trackingProvider, ec2TaggingManager, networkingSGReconciler, vpcID, logger),
+		})
+	}
+
+	return &defaultOrphanedResourcesCollector{
+		k8sReader:           k8sReader,
+		annotationParser:    annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress),
+		ec2TaggingManager:   ec2TaggingManager,
+		elbv2TaggingManager: elbv2TaggingManager,
+		stackKinds:          stackKinds,
+		watchNamespaces:     sets.NewString(config.RuntimeConfig.WatchNamespaces...),
+		dryRun:              dryRun,
+		logger:              logger,
+	}
+}
+
+var _ OrphanedResourcesCollector = &defaultOrphanedResourcesCollector{}
+
+// stackKind contains the tracking and deletion abilities for stacks of a controller, i.e. ingress or service.
+type stackKind struct {
+	tagPrefix        string
+	trackingProvider tracking.Provider
+	elbv2LBManager   elbv2.LoadBalancerManager
+	elbv2TGManager   elbv2.TargetGroupManager
+	ec2SGManager     ec2.SecurityGroupManager
+}
+
+// stackResources contains the AWS resources provisioned for a stack.
+type stackResources struct {
+	lbs []elbv2.LoadBalancerWithTags
+	tgs []elbv2.TargetGroupWithTags
+	sgs []networkingpkg.SecurityGroupInfo
+}
+
+// default implementation for OrphanedResourcesCollector.
+type defaultOrphanedResourcesCollector struct {
+	k8sReader           client.Reader
+	annotationParser    annotations.Parser
+	ec2TaggingManager   ec2.TaggingManager
+	elbv2TaggingManager elbv2.TaggingManager
+	stackKinds          []stackKind
+	// when not empty, only stacks owned by objects within these namespaces are considered.
+	watchNamespaces sets.String
+	dryRun          bool
+
+	logger logr.Logger
+}
+
+// Start runs a single collection pass, it's meant to be added to controller manager to run on startup.
+// Failures are logged instead of returned, so that they won't stop the manager.
+func (c *defaultOrphanedResourcesCollector) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := c.Collect(ctx); err != nil {
+		c.logger.Error(err, "failed to collect orphaned resources")
+	}
+	return nil
+}
+
+func (c *defaultOrphanedResourcesCollector) Collect(ctx context.Context) error {
+	tgARNsInUse, err := c.listTargetGroupARNsInUse(ctx)
+	if err != nil {
+		return err
+	}
+	for _, kind := range c.stackKinds {
+		resourcesByStackID, err := c.listStackResources(ctx, kind)
+		if err != nil {
+			return err
+		}
+		for _, stackID := range sets.StringKeySet(resourcesByStackID).List() {
+			orphaned, err := c.isStackOrphaned(ctx, kind.tagPrefix, stackID)
+			if err != nil {
+				return err
+			}
+			if !orphaned {
+				continue
+			}
+			if err := c.collectStack(ctx, kind, stackID, resourcesByStackID[stackID], tgARNsInUse); err != nil {
+				c.logger.Error(err, "failed to delete orphaned resources", "tagPrefix", kind.tagPrefix, "stackID", stackID)
+			}
+		}
+	}
+	return nil
+}
+
+// collectStack deletes AWS resources of an orphaned stack.
+// LoadBalancers are deleted first, since targetGroups and securityGroups cannot be deleted while in use by them.
+func (c *defaultOrphanedResourcesCollector) collectStack(ctx context.Context, kind stackKind, stackID string, resources stackResources, tgARNsInUse sets.String) error {
+	if c.dryRun {
+		c.logger.Info("found orphaned resources, skipping deletion in dry-run mode",
+			"tagPrefix", kind.tagPrefix, "stackID", stackID,
+			"loadBalancers", len(resources.lbs), "targetGroups", len(resources.tgs), "securityGroups", len(resources.sgs))
+		return nil
+	}
+	c.logger.Info("deleting orphaned resources", "tagPrefix", kind.tagPrefix, "stackID", stackID)
+	for _, sdkLB := range resources.lbs {
+		if err := kind.elbv2LBManager.Delete(ctx, sdkLB); err != nil {
+			return err
+		}
+	}
+	for _, sdkTG := range resources.tgs {
+		tgARN := awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)
+		if tgARNsInUse.Has(tgARN) {
+			c.logger.Info("skipping orphaned targetGroup referenced by TargetGroupBinding", "arn", tgARN)
+			continue
+		}
+		if err := kind.elbv2TGManager.Delete(ctx, sdkTG); err != nil {
+			return err
+		}
+	}
+	for _, sdkSG := range resources.sgs {
+		if err := kind.ec2SGManager.Delete(ctx, sdkSG); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listStackResources lists AWS resources tagged with cluster and stack tags, indexed by stackID.
+func (c *defaultOrphanedResourcesCollector) listStackResources(ctx context.Context, kind stackKind) (map[string]stackResources, error) {
+	stackIDTagKey := kind.trackingProvider.StackIDTagKey()
+	tagFilter := kind.trackingProvider.StacksTagFilter()
+	resourcesByStackID := make(map[string]stackResources)
+
+	sdkLBs, err := c.elbv2TaggingManager.ListLoadBalancers(ctx, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+	for _, sdkLB := range sdkLBs {
+		stackID := sdkLB.Tags[stackIDTagKey]
+		resources := resourcesByStackID[stackID]
+		resources.lbs = append(resources.lbs, sdkLB)
+		resourcesByStackID[stackID] = resources
+	}
+
+	sdkTGs, err := c.elbv2TaggingManager.ListTargetGroups(ctx, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+	for _, sdkTG := range sdkTGs {
+		stackID := sdkTG.Tags[stackIDTagKey]
+		resources := resourcesByStackID[stackID]
+		resources.tgs = append(resources.tgs, sdkTG)
+		resourcesByStackID[stackID] = resources
+	}
+
+	sdkSGs, err := c.ec2TaggingManager.ListSecurityGroups(ctx, tagFilter)
+	if err != nil {
+		return nil, err
+	}
+	for _, sdkSG := range sdkSGs {
+		stackID := sdkSG.Tags[stackIDTagKey]
+		resources := resourcesByStackID[stackID]
+		resources.sgs = append(resources.sgs, sdkSG)
+		resourcesByStackID[stackID] = resources
+	}
+	return resourcesByStackID, nil
+}
+
+// isStackOrphaned checks whether the owner of stack no longer exists.
+// stacks whose owner cannot be determined are never considered orphaned.
+func (c *defaultOrphanedResourcesCollector) isStackOrphaned(ctx context.Context, tagPrefix string, stackID string) (bool, error) {
+	if stackID == "" {
+		return false, nil
+	}
+	if !strings.Contains(stackID, "/") {
+		// explicit IngressGroup, whose members might be from any namespace.
+		if tagPrefix != ingressTagPrefix || len(c.watchNamespaces) != 0 {
+			return false, nil
+		}
+		return c.isExplicitGroupOrphaned(ctx, stackID)
+	}
+
+	parts := strings.SplitN(stackID, "/", 2)
+	ownerKey := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	if len(c.watchNamespaces) != 0 && !c.watchNamespaces.Has(ownerKey.Namespace) {
+		return false, nil
+	}
+	var owner runtime.Object
+	switch tagPrefix {
+	case ingressTagPrefix:
+		owner = &networking.Ingress{}
+	case serviceTagPrefix:
+		owner = &corev1.Service{}
+	default:
+		return false, nil
+	}
+	if err := c.k8sReader.Get(ctx, ownerKey, owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+// isExplicitGroupOrphaned checks whether no Ingress or IngressClassParams references the explicit IngressGroup.
+func (c *defaultOrphanedResourcesCollector) isExplicitGroupOrphaned(ctx context.Context, groupName string) (bool, error) {
+	groupFinalizer := ingress.BuildGroupFinalizer(ingress.NewGroupIDForExplicitGroup(groupName))
+	ingList := &networking.IngressList{}
+	if err := c.k8sReader.List(ctx, ingList); err != nil {
+		return false, err
+	}
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		if k8s.HasFinalizer(ing, groupFinalizer) {
+			return false, nil
+		}
+		ingGroupName := ""
+		if c.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &ingGroupName, ing.Annotations) && ingGroupName == groupName {
+			return false, nil
+		}
+	}
+
+	ingClassParamsList := &elbv2api.IngressClassParamsList{}
+	if err := c.k8sReader.List(ctx, ingClassParamsList); err != nil {
+		return false, err
+	}
+	for _, ingClassParams := range ingClassParamsList.Items {
+		if ingClassParams.Spec.Group != nil && ingClassParams.Spec.Group.Name == groupName {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// listTargetGroupARNsInUse lists the ARNs of targetGroups referenced by any TargetGroupBinding, including its additionalTargetGroups.
+func (c *defaultOrphanedResourcesCollector) listTargetGroupARNsInUse(ctx context.Context) (sets.String, error) {
+	var listOptsPerNamespace [][]client.ListOption
+	if len(c.watchNamespaces) == 0 {
+		listOptsPerNamespace = append(listOptsPerNamespace, nil)
+	}
+	for _, namespace := range c.watchNamespaces.List() {
+		listOptsPerNamespace = append(listOptsPerNamespace, []client.ListOption{client.InNamespace(namespace)})
+	}
+
+	tgARNs := sets.NewString()
+	for _, listOpts := range listOptsPerNamespace {
+		tgbList := &elbv2api.TargetGroupBindingList{}
+		if err := c.k8sReader.List(ctx, tgbList, listOpts...); err != nil {
+			return nil, err
+		}
+		for _, tgb := range tgbList.Items {
+			tgARNs.Insert(tgb.Spec.TargetGroupARN)
+			for _, additionalTG := range tgb.Spec.AdditionalTargetGroups {
+				tgARNs.Insert(additionalTG.TargetGroupARN)
+			}
+		}
+	}
+	return tgARNs, nil
+}
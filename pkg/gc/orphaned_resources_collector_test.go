@@ -0,0 +1,319 @@
+package gc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultOrphanedResourcesCollector_Collect(t *testing.T) {
+	serviceStackTags := func(stackID string) map[string]string {
+		return map[string]string{
+			"elbv2.k8s.aws/cluster": "cluster-name",
+			"service.k8s.aws/stack": stackID,
+		}
+	}
+	ingressStackTags := func(stackID string) map[string]string {
+		return map[string]string{
+			"elbv2.k8s.aws/cluster": "cluster-name",
+			"ingress.k8s.aws/stack": stackID,
+		}
+	}
+
+	type env struct {
+		lbTagsByARN  map[string]map[string]string
+		tgTagsByARN  map[string]map[string]string
+		sgTagsByID   map[string]map[string]string
+		k8sObjects   []runtime.Object
+		watchNS      []string
+		dryRun       bool
+		wantLBARNs   []string
+		wantTGARNs   []string
+		wantSGIDs    []string
+		wantErrorMsg string
+	}
+	tests := []struct {
+		name string
+		env  env
+	}{
+		{
+			name: "resources of deleted service are deleted",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{"lb-arn": serviceStackTags("ns/svc")},
+				tgTagsByARN: map[string]map[string]string{"tg-arn": serviceStackTags("ns/svc")},
+				sgTagsByID:  map[string]map[string]string{"sg-id": serviceStackTags("ns/svc")},
+				wantLBARNs:  []string{"lb-arn"},
+				wantTGARNs:  []string{"tg-arn"},
+				wantSGIDs:   []string{"sg-id"},
+			},
+		},
+		{
+			name: "resources of deleted service are only logged in dry-run mode",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{"lb-arn": serviceStackTags("ns/svc")},
+				tgTagsByARN: map[string]map[string]string{"tg-arn": serviceStackTags("ns/svc")},
+				dryRun:      true,
+			},
+		},
+		{
+			name: "resources of existing service are kept",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{"lb-arn": serviceStackTags("ns/svc")},
+				tgTagsByARN: map[string]map[string]string{"tg-arn": serviceStackTags("ns/svc")},
+				k8sObjects: []runtime.Object{
+					&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"}},
+				},
+			},
+		},
+		{
+			name: "resources of other clusters or without stack tag are kept",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{
+					"lb-arn-other-cluster": {
+						"elbv2.k8s.aws/cluster": "other-cluster",
+						"service.k8s.aws/stack": "ns/svc",
+					},
+					"lb-arn-untracked": {
+						"elbv2.k8s.aws/cluster": "cluster-name",
+					},
+				},
+			},
+		},
+		{
+			name: "resources of deleted implicit IngressGroup are deleted, existing ones are kept",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{
+					"lb-arn-deleted":  ingressStackTags("ns/ing-deleted"),
+					"lb-arn-existing": ingressStackTags("ns/ing-existing"),
+				},
+				sgTagsByID: map[string]map[string]string{
+					"sg-id-deleted":  ingressStackTags("ns/ing-deleted"),
+					"sg-id-existing": ingressStackTags("ns/ing-existing"),
+				},
+				k8sObjects: []runtime.Object{
+					&networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing-existing"}},
+				},
+				wantLBARNs: []string{"lb-arn-deleted"},
+				wantSGIDs:  []string{"sg-id-deleted"},
+			},
+		},
+		{
+			name: "resources of explicit IngressGroup are kept while referenced by annotation, finalizer or IngressClassParams",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{
+					"lb-arn-annotation":   ingressStackTags("group-annotation"),
+					"lb-arn-finalizer":    ingressStackTags("group-finalizer"),
+					"lb-arn-class-params": ingressStackTags("group-class-params"),
+					"lb-arn-deleted":      ingressStackTags("group-deleted"),
+				},
+				k8sObjects: []runtime.Object{
+					&networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ns",
+						Name:        "ing-1",
+						Annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": "group-annotation"},
+					}},
+					&networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+						Namespace:  "ns",
+						Name:       "ing-2",
+						Finalizers: []string{"group.ingress.k8s.aws/group-finalizer"},
+					}},
+					&elbv2api.IngressClassParams{
+						ObjectMeta: metav1.ObjectMeta{Name: "class-params"},
+						Spec: elbv2api.IngressClassParamsSpec{
+							Group: &elbv2api.IngressGroup{Name: "group-class-params"},
+						},
+					},
+				},
+				wantLBARNs: []string{"lb-arn-deleted"},
+			},
+		},
+		{
+			name: "targetGroups referenced by TargetGroupBinding are kept",
+			env: env{
+				tgTagsByARN: map[string]map[string]string{
+					"tg-arn-bound":   serviceStackTags("ns/svc"),
+					"tg-arn-unbound": serviceStackTags("ns/svc"),
+				},
+				k8sObjects: []runtime.Object{
+					&elbv2api.TargetGroupBinding{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tgb"},
+						Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: "tg-arn-bound"},
+					},
+				},
+				wantTGARNs: []string{"tg-arn-unbound"},
+			},
+		},
+		{
+			name: "additional targetGroups referenced by TargetGroupBinding are kept",
+			env: env{
+				tgTagsByARN: map[string]map[string]string{
+					"tg-arn-bound":            serviceStackTags("ns/svc"),
+					"tg-arn-additional-bound": serviceStackTags("ns/svc"),
+					"tg-arn-unbound":          serviceStackTags("ns/svc"),
+				},
+				k8sObjects: []runtime.Object{
+					&elbv2api.TargetGroupBinding{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tgb"},
+						Spec: elbv2api.TargetGroupBindingSpec{
+							TargetGroupARN: "tg-arn-bound",
+							AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+								{
+									TargetGroupARN: "tg-arn-additional-bound",
+									Port:           intstr.FromInt(443),
+								},
+							},
+						},
+					},
+				},
+				wantTGARNs: []string{"tg-arn-unbound"},
+			},
+		},
+		{
+			name: "only stacks within watched namespaces are considered",
+			env: env{
+				lbTagsByARN: map[string]map[string]string{
+					"lb-arn-watched":   serviceStackTags("watched/svc"),
+					"lb-arn-unwatched": serviceStackTags("unwatched/svc"),
+					"lb-arn-group":     ingressStackTags("group"),
+				},
+				watchNS:    []string{"watched"},
+				wantLBARNs: []string{"lb-arn-watched"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			ec2Client := services.NewMockEC2(ctrl)
+			var deletedLBARNs, deletedTGARNs, deletedSGIDs []string
+			fakeELBV2(elbv2Client, tt.env.lbTagsByARN, tt.env.tgTagsByARN, &deletedLBARNs, &deletedTGARNs)
+			fakeEC2(ec2Client, tt.env.sgTagsByID, &deletedSGIDs)
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema, tt.env.k8sObjects...)
+
+			cfg := config.ControllerConfig{
+				ClusterName:   "cluster-name",
+				RuntimeConfig: config.RuntimeConfig{WatchNamespaces: tt.env.watchNS},
+			}
+			c := NewDefaultOrphanedResourcesCollector(elbv2Client, ec2Client, k8sClient, "vpc-id", cfg, tt.env.dryRun, &log.NullLogger{})
+			err := c.Collect(context.Background())
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.env.wantLBARNs, deletedLBARNs)
+			assert.ElementsMatch(t, tt.env.wantTGARNs, deletedTGARNs)
+			assert.ElementsMatch(t, tt.env.wantSGIDs, deletedSGIDs)
+		})
+	}
+}
+
+// fakeELBV2 serves loadBalancers and targetGroups with tags, and records the deleted ones.
+func fakeELBV2(elbv2Client *services.MockELBV2, lbTagsByARN map[string]map[string]string, tgTagsByARN map[string]map[string]string,
+	deletedLBARNs *[]string, deletedTGARNs *[]string) {
+	var sdkLBs []*elbv2sdk.LoadBalancer
+	for arn := range lbTagsByARN {
+		sdkLBs = append(sdkLBs, &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String(arn)})
+	}
+	var sdkTGs []*elbv2sdk.TargetGroup
+	for arn := range tgTagsByARN {
+		sdkTGs = append(sdkTGs, &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String(arn)})
+	}
+	elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(sdkLBs, nil).AnyTimes()
+	elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), gomock.Any()).Return(sdkTGs, nil).AnyTimes()
+	elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *elbv2sdk.DescribeTagsInput, opts ...request.Option) (*elbv2sdk.DescribeTagsOutput, error) {
+			resp := &elbv2sdk.DescribeTagsOutput{}
+			for _, arn := range awssdk.StringValueSlice(req.ResourceArns) {
+				tags, ok := lbTagsByARN[arn]
+				if !ok {
+					tags = tgTagsByARN[arn]
+				}
+				var sdkTags []*elbv2sdk.Tag
+				for key, value := range tags {
+					sdkTags = append(sdkTags, &elbv2sdk.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+				}
+				resp.TagDescriptions = append(resp.TagDescriptions, &elbv2sdk.TagDescription{
+					ResourceArn: awssdk.String(arn),
+					Tags:        sdkTags,
+				})
+			}
+			return resp, nil
+		}).AnyTimes()
+	elbv2Client.EXPECT().DescribeLoadBalancerAttributesWithContext(gomock.Any(), gomock.Any()).
+		Return(&elbv2sdk.DescribeLoadBalancerAttributesOutput{}, nil).AnyTimes()
+	elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *elbv2sdk.DeleteLoadBalancerInput, opts ...request.Option) (*elbv2sdk.DeleteLoadBalancerOutput, error) {
+			*deletedLBARNs = append(*deletedLBARNs, awssdk.StringValue(req.LoadBalancerArn))
+			return &elbv2sdk.DeleteLoadBalancerOutput{}, nil
+		}).AnyTimes()
+	elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *elbv2sdk.DeleteTargetGroupInput, opts ...request.Option) (*elbv2sdk.DeleteTargetGroupOutput, error) {
+			*deletedTGARNs = append(*deletedTGARNs, awssdk.StringValue(req.TargetGroupArn))
+			return &elbv2sdk.DeleteTargetGroupOutput{}, nil
+		}).AnyTimes()
+}
+
+// fakeEC2 serves securityGroups matching the tag filters of request, and records the deleted ones.
+func fakeEC2(ec2Client *services.MockEC2, sgTagsByID map[string]map[string]string, deletedSGIDs *[]string) {
+	ec2Client.EXPECT().DescribeSecurityGroupsAsList(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *ec2sdk.DescribeSecurityGroupsInput) ([]*ec2sdk.SecurityGroup, error) {
+			var sdkSGs []*ec2sdk.SecurityGroup
+			for sgID, tags := range sgTagsByID {
+				if !matchesTagFilters(tags, req.Filters) {
+					continue
+				}
+				var sdkTags []*ec2sdk.Tag
+				for key, value := range tags {
+					sdkTags = append(sdkTags, &ec2sdk.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+				}
+				sdkSGs = append(sdkSGs, &ec2sdk.SecurityGroup{GroupId: awssdk.String(sgID), Tags: sdkTags})
+			}
+			return sdkSGs, nil
+		}).AnyTimes()
+	ec2Client.EXPECT().DeleteSecurityGroupWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *ec2sdk.DeleteSecurityGroupInput, opts ...request.Option) (*ec2sdk.DeleteSecurityGroupOutput, error) {
+			*deletedSGIDs = append(*deletedSGIDs, awssdk.StringValue(req.GroupId))
+			return &ec2sdk.DeleteSecurityGroupOutput{}, nil
+		}).AnyTimes()
+}
+
+func matchesTagFilters(tags map[string]string, filters []*ec2sdk.Filter) bool {
+	for _, filter := range filters {
+		name := awssdk.StringValue(filter.Name)
+		values := awssdk.StringValueSlice(filter.Values)
+		switch {
+		case name == "tag-key":
+			if _, ok := tags[values[0]]; !ok {
+				return false
+			}
+		case strings.HasPrefix(name, "tag:"):
+			value, ok := tags[strings.TrimPrefix(name, "tag:")]
+			if !ok || value != values[0] {
+				return false
+			}
+		}
+	}
+	return true
+}
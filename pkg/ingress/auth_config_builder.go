@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"strings"
 )
 
 const (
@@ -12,6 +13,13 @@ const (
 	defaultAuthSessionCookieName        = "AWSELBAuthSessionCookie"
 	defaultAuthSessionTimeout           = 604800
 	defaultAuthOnUnauthenticatedRequest = "authenticate"
+
+	// the maximum number of query parameters to include in the redirect request to the authorization endpoint.
+	maxAuthRequestExtraParams = 10
+
+	// the valid range of authentication session timeout, in seconds.
+	minAuthSessionTimeout = 1
+	maxAuthSessionTimeout = 604800
 )
 
 // Auth config for Service / Ingresses
@@ -23,6 +31,9 @@ type AuthConfig struct {
 	Scope                    string
 	SessionCookieName        string
 	SessionTimeout           int64
+
+	// AuthenticationRequestExtraParams takes priority over the ones within IDPConfigOIDC.
+	AuthenticationRequestExtraParams map[string]string
 }
 
 // AuthConfig builder can build auth configuration for service or ingresses.
@@ -31,9 +42,11 @@ type AuthConfigBuilder interface {
 }
 
 // NewDefaultAuthConfigBuilder constructs new defaultAuthConfigBuilder.
-func NewDefaultAuthConfigBuilder(annotationParser annotations.Parser) *defaultAuthConfigBuilder {
+// userPoolDomainResolver is optional, the user pool domain won't be resolved if it's nil.
+func NewDefaultAuthConfigBuilder(annotationParser annotations.Parser, userPoolDomainResolver UserPoolDomainResolver) *defaultAuthConfigBuilder {
 	return &defaultAuthConfigBuilder{
-		annotationParser: annotationParser,
+		annotationParser:       annotationParser,
+		userPoolDomainResolver: userPoolDomainResolver,
 	}
 }
 
@@ -41,7 +54,8 @@ var _ AuthConfigBuilder = &defaultAuthConfigBuilder{}
 
 // default implementation for AuthConfigBuilder
 type defaultAuthConfigBuilder struct {
-	annotationParser annotations.Parser
+	annotationParser       annotations.Parser
+	userPoolDomainResolver UserPoolDomainResolver
 }
 
 func (b *defaultAuthConfigBuilder) Build(ctx context.Context, svcAndIngAnnotations map[string]string) (AuthConfig, error) {
@@ -49,8 +63,18 @@ func (b *defaultAuthConfigBuilder) Build(ctx context.Context, svcAndIngAnnotatio
 	if err != nil {
 		return AuthConfig{}, err
 	}
-	authOnUnauthenticatedRequest := b.buildAuthOnUnauthenticatedRequest(ctx, svcAndIngAnnotations)
-	authScope := b.buildAuthScope(ctx, svcAndIngAnnotations)
+	authOnUnauthenticatedRequest, err := b.buildAuthOnUnauthenticatedRequest(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+	authScope, err := b.buildAuthScope(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+	authRequestExtraParams, err := b.buildAuthRequestExtraParams(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return AuthConfig{}, err
+	}
 	authSessionCookieName := b.buildAuthSessionCookieName(ctx, svcAndIngAnnotations)
 	authSessionTimeout, err := b.buildAuthSessionTimeout(ctx, svcAndIngAnnotations)
 	if err != nil {
@@ -66,13 +90,14 @@ func (b *defaultAuthConfigBuilder) Build(ctx context.Context, svcAndIngAnnotatio
 	}
 
 	authConfig := AuthConfig{
-		Type:                     authType,
-		OnUnauthenticatedRequest: authOnUnauthenticatedRequest,
-		Scope:                    authScope,
-		SessionCookieName:        authSessionCookieName,
-		SessionTimeout:           authSessionTimeout,
-		IDPConfigOIDC:            authIDPOIDC,
-		IDPConfigCognito:         authIDPCognito,
+		Type:                             authType,
+		OnUnauthenticatedRequest:         authOnUnauthenticatedRequest,
+		Scope:                            authScope,
+		SessionCookieName:                authSessionCookieName,
+		SessionTimeout:                   authSessionTimeout,
+		IDPConfigOIDC:                    authIDPOIDC,
+		IDPConfigCognito:                 authIDPCognito,
+		AuthenticationRequestExtraParams: authRequestExtraParams,
 	}
 
 	return authConfig, nil
@@ -93,7 +118,7 @@ func (b *defaultAuthConfigBuilder) buildAuthType(_ context.Context, svcAndIngAnn
 	}
 }
 
-func (b *defaultAuthConfigBuilder) buildAuthIDPConfigCognito(_ context.Context, svcAndIngAnnotations map[string]string) (*AuthIDPConfigCognito, error) {
+func (b *defaultAuthConfigBuilder) buildAuthIDPConfigCognito(ctx context.Context, svcAndIngAnnotations map[string]string) (*AuthIDPConfigCognito, error) {
 	authIDP := AuthIDPConfigCognito{}
 	exists, err := b.annotationParser.ParseJSONAnnotation(annotations.IngressSuffixAuthIDPCognito, &authIDP, svcAndIngAnnotations)
 	if err != nil {
@@ -102,6 +127,13 @@ func (b *defaultAuthConfigBuilder) buildAuthIDPConfigCognito(_ context.Context,
 	if !exists {
 		return nil, nil
 	}
+	if len(authIDP.UserPoolDomain) == 0 && b.userPoolDomainResolver != nil {
+		userPoolDomain, err := b.userPoolDomainResolver.Resolve(ctx, authIDP.UserPoolARN)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve userPoolDomain")
+		}
+		authIDP.UserPoolDomain = userPoolDomain
+	}
 	return &authIDP, nil
 }
 
@@ -117,16 +149,34 @@ func (b *defaultAuthConfigBuilder) buildAuthIDPConfigOIDC(_ context.Context, svc
 	return &authIDP, nil
 }
 
-func (b *defaultAuthConfigBuilder) buildAuthOnUnauthenticatedRequest(_ context.Context, svcAndIngAnnotations map[string]string) string {
+func (b *defaultAuthConfigBuilder) buildAuthOnUnauthenticatedRequest(_ context.Context, svcAndIngAnnotations map[string]string) (string, error) {
 	rawOnUnauthenticatedRequest := defaultAuthOnUnauthenticatedRequest
 	_ = b.annotationParser.ParseStringAnnotation(annotations.IngressSuffixAuthOnUnauthenticatedRequest, &rawOnUnauthenticatedRequest, svcAndIngAnnotations)
-	return rawOnUnauthenticatedRequest
+	if err := validateAuthOnUnauthenticatedRequest(rawOnUnauthenticatedRequest); err != nil {
+		return "", err
+	}
+	return rawOnUnauthenticatedRequest, nil
 }
 
-func (b *defaultAuthConfigBuilder) buildAuthScope(_ context.Context, svcAndIngAnnotations map[string]string) string {
+func (b *defaultAuthConfigBuilder) buildAuthScope(_ context.Context, svcAndIngAnnotations map[string]string) (string, error) {
 	rawAuthScope := defaultAuthScope
 	_ = b.annotationParser.ParseStringAnnotation(annotations.IngressSuffixAuthScope, &rawAuthScope, svcAndIngAnnotations)
-	return rawAuthScope
+	if len(strings.TrimSpace(rawAuthScope)) == 0 {
+		return "", errors.New("authScope must not be empty")
+	}
+	return rawAuthScope, nil
+}
+
+func (b *defaultAuthConfigBuilder) buildAuthRequestExtraParams(_ context.Context, svcAndIngAnnotations map[string]string) (map[string]string, error) {
+	var rawAuthRequestExtraParams map[string]string
+	if _, err := b.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixAuthRequestExtraParams, &rawAuthRequestExtraParams, svcAndIngAnnotations); err != nil {
+		return nil, err
+	}
+	if len(rawAuthRequestExtraParams) > maxAuthRequestExtraParams {
+		return nil, errors.Errorf("authRequestExtraParams must not exceed %v params: %v",
+			maxAuthRequestExtraParams, len(rawAuthRequestExtraParams))
+	}
+	return rawAuthRequestExtraParams, nil
 }
 
 func (b *defaultAuthConfigBuilder) buildAuthSessionCookieName(_ context.Context, svcAndIngAnnotations map[string]string) string {
@@ -140,5 +190,19 @@ func (b *defaultAuthConfigBuilder) buildAuthSessionTimeout(_ context.Context, sv
 	if _, err := b.annotationParser.ParseInt64Annotation(annotations.IngressSuffixAuthSessionTimeout, &rawAuthSessionTimeout, svcAndIngAnnotations); err != nil {
 		return 0, err
 	}
+	if rawAuthSessionTimeout < minAuthSessionTimeout || rawAuthSessionTimeout > maxAuthSessionTimeout {
+		return 0, errors.Errorf("authSessionTimeout must be within [%v, %v] seconds: %v",
+			minAuthSessionTimeout, maxAuthSessionTimeout, rawAuthSessionTimeout)
+	}
 	return rawAuthSessionTimeout, nil
 }
+
+// validateAuthOnUnauthenticatedRequest validates the behavior if the user is not authenticated.
+func validateAuthOnUnauthenticatedRequest(onUnauthenticatedRequest string) error {
+	switch onUnauthenticatedRequest {
+	case "authenticate", "allow", "deny":
+		return nil
+	default:
+		return errors.Errorf("unknown authOnUnauthenticatedRequest: %v", onUnauthenticatedRequest)
+	}
+}
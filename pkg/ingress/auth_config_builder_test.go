@@ -2,6 +2,8 @@ package ingress
 
 import (
 	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"testing"
@@ -148,6 +150,129 @@ func Test_defaultAuthConfigBuilder_Build(t *testing.T) {
 				SessionTimeout:           defaultAuthSessionTimeout,
 			},
 		},
+		{
+			name: "oidc auth annotation with auth request extra params",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-type":                 "oidc",
+					"alb.ingress.kubernetes.io/auth-idp-oidc":             `{"issuer":"https://example.com","authorizationEndpoint":"https://authorization.example.com","tokenEndpoint":"https://token.example.com","userInfoEndpoint":"https://userinfo.example.com","secretName":"my-k8s-secret"}`,
+					"alb.ingress.kubernetes.io/auth-scope":                "openid email groups",
+					"alb.ingress.kubernetes.io/auth-request-extra-params": "prompt=login, audience=my-api",
+				},
+			},
+			want: AuthConfig{
+				Type: AuthTypeOIDC,
+				IDPConfigOIDC: &AuthIDPConfigOIDC{
+					Issuer:                "https://example.com",
+					AuthorizationEndpoint: "https://authorization.example.com",
+					TokenEndpoint:         "https://token.example.com",
+					UserInfoEndpoint:      "https://userinfo.example.com",
+					SecretName:            "my-k8s-secret",
+				},
+				OnUnauthenticatedRequest: defaultAuthOnUnauthenticatedRequest,
+				Scope:                    "openid email groups",
+				SessionCookieName:        defaultAuthSessionCookieName,
+				SessionTimeout:           defaultAuthSessionTimeout,
+				AuthenticationRequestExtraParams: map[string]string{
+					"prompt":   "login",
+					"audience": "my-api",
+				},
+			},
+		},
+		{
+			name: "malformed auth request extra params",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-request-extra-params": "prompt",
+				},
+			},
+			wantErr: errors.New("failed to parse stringMap annotation, alb.ingress.kubernetes.io/auth-request-extra-params: prompt"),
+		},
+		{
+			name: "too many auth request extra params",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-request-extra-params": "k1=v,k2=v,k3=v,k4=v,k5=v,k6=v,k7=v,k8=v,k9=v,k10=v,k11=v",
+				},
+			},
+			wantErr: errors.New("authRequestExtraParams must not exceed 10 params: 11"),
+		},
+		{
+			name: "empty auth scope",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-scope": " ",
+				},
+			},
+			wantErr: errors.New("authScope must not be empty"),
+		},
+		{
+			name: "unknown auth on unauthenticated request",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-on-unauthenticated-request": "reject",
+				},
+			},
+			wantErr: errors.New("unknown authOnUnauthenticatedRequest: reject"),
+		},
+		{
+			name: "auth session timeout at maximum",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-session-cookie":  "my-cookie",
+					"alb.ingress.kubernetes.io/auth-session-timeout": "604800",
+				},
+			},
+			want: AuthConfig{
+				Type:                     AuthTypeNone,
+				OnUnauthenticatedRequest: defaultAuthOnUnauthenticatedRequest,
+				Scope:                    defaultAuthScope,
+				SessionCookieName:        "my-cookie",
+				SessionTimeout:           604800,
+			},
+		},
+		{
+			name: "auth session timeout at minimum",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-session-timeout": "1",
+				},
+			},
+			want: AuthConfig{
+				Type:                     AuthTypeNone,
+				OnUnauthenticatedRequest: defaultAuthOnUnauthenticatedRequest,
+				Scope:                    defaultAuthScope,
+				SessionCookieName:        defaultAuthSessionCookieName,
+				SessionTimeout:           1,
+			},
+		},
+		{
+			name: "auth session timeout exceeds maximum",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-session-timeout": "604801",
+				},
+			},
+			wantErr: errors.New("authSessionTimeout must be within [1, 604800] seconds: 604801"),
+		},
+		{
+			name: "auth session timeout below minimum",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-session-timeout": "0",
+				},
+			},
+			wantErr: errors.New("authSessionTimeout must be within [1, 604800] seconds: 0"),
+		},
+		{
+			name: "auth session timeout is not an integer",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-session-timeout": "1d",
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, alb.ingress.kubernetes.io/auth-session-timeout: 1d: strconv.ParseInt: parsing \"1d\": invalid syntax"),
+		},
 		{
 			// The secret index functionality for Ingress/Service relies on this
 			// since we allow these auth annotations be configured on either Ingress/Service
@@ -196,3 +321,96 @@ func Test_defaultAuthConfigBuilder_Build(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultAuthConfigBuilder_buildAuthIDPConfigCognito(t *testing.T) {
+	type resolveCall struct {
+		userPoolARN string
+		domain      string
+		err         error
+	}
+	type args struct {
+		svcAndIngAnnotations map[string]string
+	}
+	tests := []struct {
+		name         string
+		resolveCalls []resolveCall
+		args         args
+		want         *AuthIDPConfigCognito
+		wantErr      error
+	}{
+		{
+			name: "userPoolDomain specified",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-idp-cognito": `{"userPoolARN":"arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx","userPoolClientID":"my-clientID","userPoolDomain":"my-domain"}`,
+				},
+			},
+			want: &AuthIDPConfigCognito{
+				UserPoolARN:      "arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx",
+				UserPoolClientID: "my-clientID",
+				UserPoolDomain:   "my-domain",
+			},
+		},
+		{
+			name: "userPoolDomain unspecified",
+			resolveCalls: []resolveCall{
+				{
+					userPoolARN: "arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx",
+					domain:      "my-domain",
+				},
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-idp-cognito": `{"userPoolARN":"arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx","userPoolClientID":"my-clientID"}`,
+				},
+			},
+			want: &AuthIDPConfigCognito{
+				UserPoolARN:      "arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx",
+				UserPoolClientID: "my-clientID",
+				UserPoolDomain:   "my-domain",
+			},
+		},
+		{
+			name: "userPoolDomain unspecified and resolve failed",
+			resolveCalls: []resolveCall{
+				{
+					userPoolARN: "arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx",
+					err:         errors.New("user pool xxx has no domain configured"),
+				},
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/auth-idp-cognito": `{"userPoolARN":"arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx","userPoolClientID":"my-clientID"}`,
+				},
+			},
+			wantErr: errors.New("failed to resolve userPoolDomain: user pool xxx has no domain configured"),
+		},
+		{
+			name: "no cognito annotation",
+			args: args{
+				svcAndIngAnnotations: map[string]string{},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userPoolDomainResolver := NewMockUserPoolDomainResolver(ctrl)
+			for _, call := range tt.resolveCalls {
+				userPoolDomainResolver.EXPECT().Resolve(gomock.Any(), call.userPoolARN).Return(call.domain, call.err)
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			b := NewDefaultAuthConfigBuilder(annotationParser, userPoolDomainResolver)
+			got, err := b.buildAuthIDPConfigCognito(context.Background(), tt.args.svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,19 +25,26 @@ const (
 	defaultImportedCertDomainsCacheTTL = 5 * time.Minute
 	// the domain names for private certificates won't change, cache for a longer time.
 	defaultPrivateCertDomainsCacheTTL = 10 * time.Hour
+
+	// strategy that chooses the certificate with the most specific domain matching tls hosts as default certificate.
+	defaultCertStrategyMostSpecificDomain = "most-specific-domain"
+	// strategy that chooses the certificate that expires last as default certificate.
+	defaultCertStrategyLongestValidity = "longest-validity"
 )
 
 // CertDiscovery is responsible for auto-discover TLS certificates for tls hosts.
 type CertDiscovery interface {
 	// Discover will try to find valid certificateARNs for each tlsHost.
+	// the first certificateARN is the one chosen as default certificate.
 	Discover(ctx context.Context, tlsHosts []string) ([]string, error)
 }
 
 // NewACMCertDiscovery constructs new acmCertDiscovery
-func NewACMCertDiscovery(acmClient services.ACM, logger logr.Logger) *acmCertDiscovery {
+func NewACMCertDiscovery(acmClient services.ACM, defaultCertStrategy string, logger logr.Logger) *acmCertDiscovery {
 	return &acmCertDiscovery{
-		acmClient: acmClient,
-		logger:    logger,
+		acmClient:           acmClient,
+		defaultCertStrategy: defaultCertStrategy,
+		logger:              logger,
 
 		loadDomainsByCertARNMutex:   sync.Mutex{},
 		certARNsCache:               cache.NewExpiring(),
@@ -51,8 +59,9 @@ var _ CertDiscovery = &acmCertDiscovery{}
 
 // CertDiscovery implementation for ACM certificates.
 type acmCertDiscovery struct {
-	acmClient services.ACM
-	logger    logr.Logger
+	acmClient           services.ACM
+	defaultCertStrategy string
+	logger              logr.Logger
 
 	// mutex to serialize the call to loadDomainsForAllCertificates
 	loadDomainsByCertARNMutex   sync.Mutex
@@ -63,16 +72,22 @@ type acmCertDiscovery struct {
 	privateCertDomainsCacheTTL  time.Duration
 }
 
+// certificateInfo contains the details of certificate used for discovery.
+type certificateInfo struct {
+	domains  sets.String
+	notAfter time.Time
+}
+
 func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]string, error) {
-	domainsByCertARN, err := d.loadDomainsForAllCertificates(ctx)
+	certInfoByARN, err := d.loadInfoForAllCertificates(ctx)
 	if err != nil {
 		return nil, err
 	}
 	certARNs := sets.NewString()
 	for _, host := range tlsHosts {
 		var certARNsForHost []string
-		for certARN, domains := range domainsByCertARN {
-			for domain := range domains {
+		for certARN, certInfo := range certInfoByARN {
+			for domain := range certInfo.domains {
 				if d.domainMatchesHost(domain, host) {
 					certARNsForHost = append(certARNsForHost, certARN)
 					break
@@ -88,10 +103,65 @@ func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]s
 		}
 		certARNs.Insert(certARNsForHost...)
 	}
-	return certARNs.List(), nil
+	return d.sortCertARNsByDefaultCertStrategy(certARNs.List(), tlsHosts, certInfoByARN), nil
 }
 
-func (d *acmCertDiscovery) loadDomainsForAllCertificates(ctx context.Context) (map[string]sets.String, error) {
+// sortCertARNsByDefaultCertStrategy sorts certARNs so that the certificate chosen by defaultCertStrategy comes first.
+// certARNs must be sorted already, which breaks ties between certificates ranked the same.
+func (d *acmCertDiscovery) sortCertARNsByDefaultCertStrategy(certARNs []string, tlsHosts []string, certInfoByARN map[string]certificateInfo) []string {
+	switch d.defaultCertStrategy {
+	case defaultCertStrategyLongestValidity:
+		sort.SliceStable(certARNs, func(i, j int) bool {
+			return certInfoByARN[certARNs[i]].notAfter.After(certInfoByARN[certARNs[j]].notAfter)
+		})
+	default:
+		specificityByCertARN := make(map[string]domainSpecificity, len(certARNs))
+		for _, certARN := range certARNs {
+			specificityByCertARN[certARN] = d.computeCertSpecificity(certInfoByARN[certARN].domains, tlsHosts)
+		}
+		sort.SliceStable(certARNs, func(i, j int) bool {
+			return specificityByCertARN[certARNs[j]].lessThan(specificityByCertARN[certARNs[i]])
+		})
+	}
+	return certARNs
+}
+
+// domainSpecificity ranks how specific a domain name is.
+// exact domain names are more specific than wildcard ones, and then domain names with more labels are more specific.
+type domainSpecificity struct {
+	exact  bool
+	labels int
+}
+
+func (s domainSpecificity) lessThan(other domainSpecificity) bool {
+	if s.exact != other.exact {
+		return other.exact
+	}
+	return s.labels < other.labels
+}
+
+// computeCertSpecificity computes the specificity of the most specific domain of certificate that matches any of the tlsHosts.
+func (d *acmCertDiscovery) computeCertSpecificity(certDomains sets.String, tlsHosts []string) domainSpecificity {
+	var certSpecificity domainSpecificity
+	for domain := range certDomains {
+		for _, host := range tlsHosts {
+			if !d.domainMatchesHost(domain, host) {
+				continue
+			}
+			specificity := domainSpecificity{
+				exact:  !strings.HasPrefix(domain, "*."),
+				labels: len(strings.Split(domain, ".")),
+			}
+			if certSpecificity.lessThan(specificity) {
+				certSpecificity = specificity
+			}
+			break
+		}
+	}
+	return certSpecificity
+}
+
+func (d *acmCertDiscovery) loadInfoForAllCertificates(ctx context.Context) (map[string]certificateInfo, error) {
 	d.loadDomainsByCertARNMutex.Lock()
 	defer d.loadDomainsByCertARNMutex.Unlock()
 
@@ -99,15 +169,15 @@ func (d *acmCertDiscovery) loadDomainsForAllCertificates(ctx context.Context) (m
 	if err != nil {
 		return nil, err
 	}
-	domainsByCertARN := make(map[string]sets.String, len(certARNs))
+	certInfoByARN := make(map[string]certificateInfo, len(certARNs))
 	for _, certARN := range certARNs {
-		certDomains, err := d.loadDomainsForCertificate(ctx, certARN)
+		certInfo, err := d.loadInfoForCertificate(ctx, certARN)
 		if err != nil {
 			return nil, err
 		}
-		domainsByCertARN[certARN] = certDomains
+		certInfoByARN[certARN] = certInfo
 	}
-	return domainsByCertARN, nil
+	return certInfoByARN, nil
 }
 
 func (d *acmCertDiscovery) loadAllCertificateARNs(ctx context.Context) ([]string, error) {
@@ -131,26 +201,29 @@ func (d *acmCertDiscovery) loadAllCertificateARNs(ctx context.Context) ([]string
 	return certARNs, nil
 }
 
-func (d *acmCertDiscovery) loadDomainsForCertificate(ctx context.Context, certARN string) (sets.String, error) {
+func (d *acmCertDiscovery) loadInfoForCertificate(ctx context.Context, certARN string) (certificateInfo, error) {
 	if rawCacheItem, ok := d.certDomainsCache.Get(certARN); ok {
-		return rawCacheItem.(sets.String), nil
+		return rawCacheItem.(certificateInfo), nil
 	}
 	req := &acm.DescribeCertificateInput{
 		CertificateArn: aws.String(certARN),
 	}
 	resp, err := d.acmClient.DescribeCertificateWithContext(ctx, req)
 	if err != nil {
-		return nil, err
+		return certificateInfo{}, err
 	}
 	certDetail := resp.Certificate
-	domains := sets.NewString(aws.StringValueSlice(certDetail.SubjectAlternativeNames)...)
+	certInfo := certificateInfo{
+		domains:  sets.NewString(aws.StringValueSlice(certDetail.SubjectAlternativeNames)...),
+		notAfter: aws.TimeValue(certDetail.NotAfter),
+	}
 	switch aws.StringValue(certDetail.Type) {
 	case acm.CertificateTypeImported:
-		d.certDomainsCache.Set(certARN, domains, d.importedCertDomainsCacheTTL)
+		d.certDomainsCache.Set(certARN, certInfo, d.importedCertDomainsCacheTTL)
 	case acm.CertificateTypeAmazonIssued, acm.CertificateTypePrivate:
-		d.certDomainsCache.Set(certARN, domains, d.privateCertDomainsCacheTTL)
+		d.certDomainsCache.Set(certARN, certInfo, d.privateCertDomainsCacheTTL)
 	}
-	return domains, nil
+	return certInfo, nil
 }
 
 func (d *acmCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
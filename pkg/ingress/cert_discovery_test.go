@@ -1,8 +1,13 @@
 package ingress
 
 import (
+	"context"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
@@ -64,3 +69,92 @@ func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_acmCertDiscovery_Discover(t *testing.T) {
+	now := time.Now()
+	certInfoByARN := map[string]certificateInfo{
+		"cert-wildcard": {
+			domains:  sets.NewString("*.example.com"),
+			notAfter: now.Add(300 * 24 * time.Hour),
+		},
+		"cert-exact": {
+			domains:  sets.NewString("api.example.com"),
+			notAfter: now.Add(30 * 24 * time.Hour),
+		},
+		"cert-exact-deeper": {
+			domains:  sets.NewString("api.eu.example.org"),
+			notAfter: now.Add(90 * 24 * time.Hour),
+		},
+		"cert-other": {
+			domains:  sets.NewString("other.example.net"),
+			notAfter: now.Add(900 * 24 * time.Hour),
+		},
+		"cert-another": {
+			domains:  sets.NewString("another.example.net"),
+			notAfter: now.Add(900 * 24 * time.Hour),
+		},
+	}
+	tests := []struct {
+		name                string
+		defaultCertStrategy string
+		tlsHosts            []string
+		want                []string
+		wantErr             error
+	}{
+		{
+			name:                "no certificate found for host",
+			defaultCertStrategy: defaultCertStrategyMostSpecificDomain,
+			tlsHosts:            []string{"www.example.com", "api.example.org"},
+			wantErr:             errors.New("none certificate found for host: api.example.org"),
+		},
+		{
+			name:                "most-specific-domain prefers exact domains over wildcard domains",
+			defaultCertStrategy: defaultCertStrategyMostSpecificDomain,
+			tlsHosts:            []string{"www.example.com", "api.eu.example.org"},
+			want:                []string{"cert-exact-deeper", "cert-wildcard"},
+		},
+		{
+			name:                "most-specific-domain prefers domains with more labels",
+			defaultCertStrategy: defaultCertStrategyMostSpecificDomain,
+			tlsHosts:            []string{"other.example.net", "api.eu.example.org"},
+			want:                []string{"cert-exact-deeper", "cert-other"},
+		},
+		{
+			name:                "most-specific-domain breaks ties by certificate ARN",
+			defaultCertStrategy: defaultCertStrategyMostSpecificDomain,
+			tlsHosts:            []string{"other.example.net", "another.example.net"},
+			want:                []string{"cert-another", "cert-other"},
+		},
+		{
+			name:                "longest-validity prefers certificates expiring last",
+			defaultCertStrategy: defaultCertStrategyLongestValidity,
+			tlsHosts:            []string{"www.example.com", "api.eu.example.org", "other.example.net"},
+			want:                []string{"cert-other", "cert-wildcard", "cert-exact-deeper"},
+		},
+		{
+			name:                "longest-validity breaks ties by certificate ARN",
+			defaultCertStrategy: defaultCertStrategyLongestValidity,
+			tlsHosts:            []string{"other.example.net", "another.example.net", "api.eu.example.org"},
+			want:                []string{"cert-another", "cert-other", "cert-exact-deeper"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewACMCertDiscovery(nil, tt.defaultCertStrategy, &log.NullLogger{})
+			var certARNs []string
+			for certARN, certInfo := range certInfoByARN {
+				certARNs = append(certARNs, certARN)
+				d.certDomainsCache.Set(certARN, certInfo, time.Hour)
+			}
+			d.certARNsCache.Set(certARNsCacheKey, certARNs, time.Hour)
+
+			got, err := d.Discover(context.Background(), tt.tlsHosts)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"regexp"
+	"strings"
 )
 
 // NOTE: these types are user-facing data structures.
@@ -24,10 +27,29 @@ type FixedResponseActionConfig struct {
 	StatusCode string `json:"statusCode"`
 }
 
+const (
+	// ALB limits the message body of fixed responses to 1KB.
+	fixedResponseMessageBodyMaxLength = 1024
+)
+
+var (
+	fixedResponseStatusCodePattern = regexp.MustCompile(`^[245]\d\d$`)
+	fixedResponseContentTypes      = sets.NewString("text/plain", "text/css", "text/html", "application/javascript", "application/json")
+)
+
 func (c *FixedResponseActionConfig) validate() error {
 	if len(c.StatusCode) == 0 {
 		return errors.New("statusCode is required")
 	}
+	if !fixedResponseStatusCodePattern.MatchString(c.StatusCode) {
+		return errors.Errorf("statusCode must be 2XX, 4XX or 5XX: %v", c.StatusCode)
+	}
+	if c.ContentType != nil && !fixedResponseContentTypes.Has(*c.ContentType) {
+		return errors.Errorf("contentType must be within %v: %v", fixedResponseContentTypes.List(), *c.ContentType)
+	}
+	if c.MessageBody != nil && len(*c.MessageBody) > fixedResponseMessageBodyMaxLength {
+		return errors.Errorf("messageBody must be at most %v bytes: %v", fixedResponseMessageBodyMaxLength, len(*c.MessageBody))
+	}
 	return nil
 }
 
@@ -78,8 +100,17 @@ type TargetGroupTuple struct {
 	// The weight.
 	// +optional
 	Weight *int64 `json:"weight,omitempty"`
+
+	// The health check path of the target group for the K8s service, which overrides the healthcheck-path annotation.
+	// +optional
+	HealthCheckPath *string `json:"healthCheckPath,omitempty"`
 }
 
+const (
+	// ALB limits the health check path of target groups to 1024 characters.
+	healthCheckPathMaxLength = 1024
+)
+
 func (t *TargetGroupTuple) validate() error {
 	if (t.TargetGroupARN != nil) == (t.ServiceName != nil) {
 		return errors.New("precisely one of targetGroupARN and serviceName can be specified")
@@ -88,6 +119,18 @@ func (t *TargetGroupTuple) validate() error {
 	if t.ServiceName != nil && t.ServicePort == nil {
 		return errors.New("missing servicePort")
 	}
+	if t.HealthCheckPath != nil {
+		if t.ServiceName == nil {
+			return errors.New("healthCheckPath can only be specified with serviceName")
+		}
+		healthCheckPath := *t.HealthCheckPath
+		if !strings.HasPrefix(healthCheckPath, "/") {
+			return errors.Errorf("healthCheckPath %v must start with /", healthCheckPath)
+		}
+		if len(healthCheckPath) > healthCheckPathMaxLength {
+			return errors.Errorf("healthCheckPath cannot exceed %v characters", healthCheckPathMaxLength)
+		}
+	}
 	return nil
 }
 
@@ -102,6 +145,35 @@ type TargetGroupStickinessConfig struct {
 	DurationSeconds *int64 `json:"durationSeconds,omitempty"`
 }
 
+const (
+	// the default timeout to wait for target groups to become healthy before switching traffic to them.
+	defaultTargetGroupSwitchTimeoutSeconds = 60
+	// the switch is retried across reconciles until the timeout, which is limited to surface unhealthy target groups in time.
+	maxTargetGroupSwitchTimeoutSeconds = 600
+)
+
+// Information about the health requirement of target groups before switching traffic to them.
+// Traffic is only switched to target groups that had no traffic once the percentage of their healthy targets
+// reaches minHealthyPercentage, otherwise the switch is requeued, and reported as failure after timeoutSeconds.
+type TargetGroupSwitchConfig struct {
+	// The minimum percentage of healthy targets within target groups before switching traffic to them.
+	MinHealthyPercentage int64 `json:"minHealthyPercentage"`
+
+	// The timeout, in seconds, to wait for target groups to become healthy. It defaults to 60 seconds.
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+func (c *TargetGroupSwitchConfig) validate() error {
+	if c.MinHealthyPercentage < 1 || c.MinHealthyPercentage > 100 {
+		return errors.Errorf("minHealthyPercentage must be within [1, 100]: %v", c.MinHealthyPercentage)
+	}
+	if c.TimeoutSeconds != nil && (*c.TimeoutSeconds < 1 || *c.TimeoutSeconds > maxTargetGroupSwitchTimeoutSeconds) {
+		return errors.Errorf("timeoutSeconds must be within [1, %v]: %v", maxTargetGroupSwitchTimeoutSeconds, *c.TimeoutSeconds)
+	}
+	return nil
+}
+
 // Information about a forward action.
 type ForwardActionConfig struct {
 	// One or more target groups.
@@ -111,6 +183,10 @@ type ForwardActionConfig struct {
 	// The target group stickiness for the rule.
 	// +optional
 	TargetGroupStickinessConfig *TargetGroupStickinessConfig `json:"targetGroupStickinessConfig,omitempty"`
+
+	// The health requirement of target groups before switching traffic to them.
+	// +optional
+	SwitchConfig *TargetGroupSwitchConfig `json:"switchConfig,omitempty"`
 }
 
 func (c *ForwardActionConfig) validate() error {
@@ -119,6 +195,11 @@ func (c *ForwardActionConfig) validate() error {
 			return errors.Wrap(err, "invalid TargetGroupTuple")
 		}
 	}
+	if c.SwitchConfig != nil {
+		if err := c.SwitchConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid TargetGroupSwitchConfig")
+		}
+	}
 	if len(c.TargetGroups) > 1 {
 		for _, t := range c.TargetGroups {
 			if t.Weight == nil {
@@ -129,6 +210,80 @@ func (c *ForwardActionConfig) validate() error {
 	return nil
 }
 
+// The slot of target group for blue/green switching.
+type BlueGreenSlot string
+
+const (
+	BlueGreenSlotBlue  BlueGreenSlot = "blue"
+	BlueGreenSlotGreen BlueGreenSlot = "green"
+)
+
+// Information about a blue/green switching between two target groups.
+// All traffic is routed to the active target group, while the inactive one is kept with zero weight for instant rollback.
+type BlueGreenConfig struct {
+	// The active slot, either blue or green.
+	Active BlueGreenSlot `json:"active"`
+
+	// The blue target group.
+	Blue TargetGroupTuple `json:"blue"`
+
+	// The green target group.
+	Green TargetGroupTuple `json:"green"`
+
+	// The health requirement of the active target group before switching traffic to it.
+	// +optional
+	SwitchConfig *TargetGroupSwitchConfig `json:"switchConfig,omitempty"`
+}
+
+func (c *BlueGreenConfig) validate() error {
+	switch c.Active {
+	case BlueGreenSlotBlue, BlueGreenSlotGreen:
+	default:
+		return errors.Errorf("active must be %v or %v: %v", BlueGreenSlotBlue, BlueGreenSlotGreen, c.Active)
+	}
+	if err := c.validateTargetGroupTuple(BlueGreenSlotBlue, c.Blue); err != nil {
+		return err
+	}
+	if err := c.validateTargetGroupTuple(BlueGreenSlotGreen, c.Green); err != nil {
+		return err
+	}
+	if c.SwitchConfig != nil {
+		if err := c.SwitchConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid TargetGroupSwitchConfig")
+		}
+	}
+	return nil
+}
+
+func (c *BlueGreenConfig) validateTargetGroupTuple(slot BlueGreenSlot, t TargetGroupTuple) error {
+	if err := t.validate(); err != nil {
+		return errors.Wrapf(err, "invalid %v TargetGroupTuple", slot)
+	}
+	if t.Weight != nil {
+		return errors.Errorf("weight cannot be specified for %v TargetGroupTuple", slot)
+	}
+	return nil
+}
+
+// buildForwardAction builds the forward action that routes all traffic to the active target group.
+// the target groups are always ordered as blue then green, so that switching only changes their weights.
+func (c *BlueGreenConfig) buildForwardAction() Action {
+	blue, green := c.Blue, c.Green
+	blueWeight, greenWeight := int64(1), int64(0)
+	if c.Active == BlueGreenSlotGreen {
+		blueWeight, greenWeight = 0, 1
+	}
+	blue.Weight = &blueWeight
+	green.Weight = &greenWeight
+	return Action{
+		Type: ActionTypeForward,
+		ForwardConfig: &ForwardActionConfig{
+			TargetGroups: []TargetGroupTuple{blue, green},
+			SwitchConfig: c.SwitchConfig,
+		},
+	}
+}
+
 // The type of action.
 type ActionType string
 
@@ -223,10 +378,39 @@ type HTTPHeaderConditionConfig struct {
 	Values []string `json:"values"`
 }
 
+const (
+	// ALB limits the header name of HTTP header conditions to 40 characters.
+	httpHeaderNameMaxLength = 40
+	// ALB limits each value of HTTP header conditions to 128 characters.
+	httpHeaderValueMaxLength = 128
+)
+
+var (
+	// httpHeaderNamePattern matches the token characters of RFC 7230, except the `*` wildcard.
+	httpHeaderNamePattern = regexp.MustCompile("^[!#$%&'+.^_`|~0-9A-Za-z-]+$")
+)
+
 func (c *HTTPHeaderConditionConfig) validate() error {
+	if len(c.HTTPHeaderName) == 0 {
+		return errors.New("httpHeaderName cannot be empty")
+	}
+	if len(c.HTTPHeaderName) > httpHeaderNameMaxLength {
+		return errors.Errorf("httpHeaderName cannot exceed %v characters", httpHeaderNameMaxLength)
+	}
+	if !httpHeaderNamePattern.MatchString(c.HTTPHeaderName) {
+		return errors.Errorf("httpHeaderName %v is invalid, wildcards are only supported in values", c.HTTPHeaderName)
+	}
 	if len(c.Values) == 0 {
 		return errors.New("values cannot be empty")
 	}
+	for _, value := range c.Values {
+		if len(value) == 0 {
+			return errors.New("value cannot be empty")
+		}
+		if len(value) > httpHeaderValueMaxLength {
+			return errors.Errorf("value %v cannot exceed %v characters", value, httpHeaderValueMaxLength)
+		}
+	}
 	return nil
 }
 
@@ -19,6 +19,12 @@ const (
 type EnhancedBackend struct {
 	Conditions []RuleCondition
 	Action     Action
+
+	// AuthOnUnauthenticatedRequest overrides the auth-on-unauthenticated-request behavior for this backend if non-nil.
+	AuthOnUnauthenticatedRequest *string
+
+	// Tags are additional tags applied to listener rules routing to this backend.
+	Tags map[string]string
 }
 
 // EnhancedBackendBuilder is capable of build  EnhancedBackend for Ingress backend.
@@ -56,12 +62,45 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 		action = b.buildActionViaServiceAndServicePort(ctx, backend.ServiceName, backend.ServicePort)
 	}
 
+	authOnUnauthenticatedRequest, err := b.buildAuthOnUnauthenticatedRequest(ctx, ing.Annotations, backend.ServiceName)
+	if err != nil {
+		return EnhancedBackend{}, err
+	}
+
+	tags, err := b.buildTags(ctx, ing.Annotations, backend.ServiceName)
+	if err != nil {
+		return EnhancedBackend{}, err
+	}
+
 	return EnhancedBackend{
-		Conditions: conditions,
-		Action:     action,
+		Conditions:                   conditions,
+		Action:                       action,
+		AuthOnUnauthenticatedRequest: authOnUnauthenticatedRequest,
+		Tags:                         tags,
 	}, nil
 }
 
+func (b *defaultEnhancedBackendBuilder) buildTags(_ context.Context, ingAnnotation map[string]string, svcName string) (map[string]string, error) {
+	var tags map[string]string
+	annotationKey := fmt.Sprintf("%v.%v", annotations.IngressSuffixRuleTags, svcName)
+	if _, err := b.annotationParser.ParseStringMapAnnotation(annotationKey, &tags, ingAnnotation); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (b *defaultEnhancedBackendBuilder) buildAuthOnUnauthenticatedRequest(_ context.Context, ingAnnotation map[string]string, svcName string) (*string, error) {
+	var rawOnUnauthenticatedRequest string
+	annotationKey := fmt.Sprintf("%v.%v", annotations.IngressSuffixAuthOnUnauthenticatedRequest, svcName)
+	if exists := b.annotationParser.ParseStringAnnotation(annotationKey, &rawOnUnauthenticatedRequest, ingAnnotation); !exists {
+		return nil, nil
+	}
+	if err := validateAuthOnUnauthenticatedRequest(rawOnUnauthenticatedRequest); err != nil {
+		return nil, err
+	}
+	return &rawOnUnauthenticatedRequest, nil
+}
+
 func (b *defaultEnhancedBackendBuilder) buildConditions(_ context.Context, ingAnnotation map[string]string, svcName string) ([]RuleCondition, error) {
 	var conditions []RuleCondition
 	annotationKey := fmt.Sprintf("conditions.%v", svcName)
@@ -84,7 +123,44 @@ func (b *defaultEnhancedBackendBuilder) buildActionViaAnnotation(_ context.Conte
 	if err != nil {
 		return Action{}, err
 	}
-	if !exists {
+
+	// fixed-response.<name> is a shorthand for fixed-response action.
+	fixedResponseConfig := FixedResponseActionConfig{}
+	fixedResponseAnnotationKey := fmt.Sprintf("fixed-response.%v", svcName)
+	fixedResponseExists, err := b.annotationParser.ParseJSONAnnotation(fixedResponseAnnotationKey, &fixedResponseConfig, ingAnnotation)
+	if err != nil {
+		return Action{}, err
+	}
+	if exists && fixedResponseExists {
+		return Action{}, errors.Errorf("conflicting %v and %v configuration", annotationKey, fixedResponseAnnotationKey)
+	}
+
+	// blue-green.<name> routes all traffic to either the blue or green target group.
+	blueGreenConfig := BlueGreenConfig{}
+	blueGreenAnnotationKey := fmt.Sprintf("blue-green.%v", svcName)
+	blueGreenExists, err := b.annotationParser.ParseJSONAnnotation(blueGreenAnnotationKey, &blueGreenConfig, ingAnnotation)
+	if err != nil {
+		return Action{}, err
+	}
+	if blueGreenExists && exists {
+		return Action{}, errors.Errorf("conflicting %v and %v configuration", annotationKey, blueGreenAnnotationKey)
+	}
+	if blueGreenExists && fixedResponseExists {
+		return Action{}, errors.Errorf("conflicting %v and %v configuration", fixedResponseAnnotationKey, blueGreenAnnotationKey)
+	}
+
+	switch {
+	case fixedResponseExists:
+		action = Action{
+			Type:                ActionTypeFixedResponse,
+			FixedResponseConfig: &fixedResponseConfig,
+		}
+	case blueGreenExists:
+		if err := blueGreenConfig.validate(); err != nil {
+			return Action{}, errors.Wrap(err, "invalid BlueGreenConfig")
+		}
+		action = blueGreenConfig.buildForwardAction()
+	case !exists:
 		return Action{}, errors.Errorf("missing %v configuration", annotationKey)
 	}
 	if err := action.validate(); err != nil {
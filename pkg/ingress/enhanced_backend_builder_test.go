@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"strings"
 	"testing"
 )
 
@@ -158,6 +159,105 @@ func Test_defaultEnhancedBackendBuilder_Build(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "vanilla serviceBackend with auth on unauthenticated request override",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/auth-on-unauthenticated-request":        "authenticate",
+							"alb.ingress.kubernetes.io/auth-on-unauthenticated-request.my-svc": "deny",
+						},
+					},
+				},
+				backend: networking.IngressBackend{
+					ServiceName: "my-svc",
+					ServicePort: portHTTP,
+				},
+			},
+			want: EnhancedBackend{
+				Action: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("my-svc"),
+								ServicePort: &portHTTP,
+							},
+						},
+					},
+				},
+				AuthOnUnauthenticatedRequest: awssdk.String("deny"),
+			},
+		},
+		{
+			name: "vanilla serviceBackend with rule tags",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/rule-tags.my-svc":    "CostCenter=checkout,Route=cart",
+							"alb.ingress.kubernetes.io/rule-tags.other-svc": "CostCenter=search",
+						},
+					},
+				},
+				backend: networking.IngressBackend{
+					ServiceName: "my-svc",
+					ServicePort: portHTTP,
+				},
+			},
+			want: EnhancedBackend{
+				Action: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("my-svc"),
+								ServicePort: &portHTTP,
+							},
+						},
+					},
+				},
+				Tags: map[string]string{
+					"CostCenter": "checkout",
+					"Route":      "cart",
+				},
+			},
+		},
+		{
+			name: "vanilla serviceBackend with invalid rule tags",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/rule-tags.my-svc": "CostCenter",
+						},
+					},
+				},
+				backend: networking.IngressBackend{
+					ServiceName: "my-svc",
+					ServicePort: portHTTP,
+				},
+			},
+			wantErr: errors.New("failed to parse stringMap annotation, alb.ingress.kubernetes.io/rule-tags.my-svc: CostCenter"),
+		},
+		{
+			name: "vanilla serviceBackend with unknown auth on unauthenticated request override",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/auth-on-unauthenticated-request.my-svc": "reject",
+						},
+					},
+				},
+				backend: networking.IngressBackend{
+					ServiceName: "my-svc",
+					ServicePort: portHTTP,
+				},
+			},
+			wantErr: errors.New("unknown authOnUnauthenticatedRequest: reject"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -491,6 +591,64 @@ func Test_defaultEnhancedBackendBuilder_buildConditions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "http header condition - content type with wildcard values",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.grpc-web": `[{"field":"http-header","httpHeaderConfig":{"httpHeaderName": "Content-Type", "values":["application/grpc-web*"]}}]`,
+				},
+				svcName: "grpc-web",
+			},
+			want: []RuleCondition{
+				{
+					Field: RuleConditionFieldHTTPHeader,
+					HTTPHeaderConfig: &HTTPHeaderConditionConfig{
+						HTTPHeaderName: "Content-Type",
+						Values:         []string{"application/grpc-web*"},
+					},
+				},
+			},
+		},
+		{
+			name: "http header condition - wildcard in header name",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.grpc-web": `[{"field":"http-header","httpHeaderConfig":{"httpHeaderName": "Content-*", "values":["application/grpc-web*"]}}]`,
+				},
+				svcName: "grpc-web",
+			},
+			wantErr: errors.New("invalid httpHeaderConfig: httpHeaderName Content-* is invalid, wildcards are only supported in values"),
+		},
+		{
+			name: "http header condition - empty header name",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.grpc-web": `[{"field":"http-header","httpHeaderConfig":{"values":["application/grpc-web*"]}}]`,
+				},
+				svcName: "grpc-web",
+			},
+			wantErr: errors.New("invalid httpHeaderConfig: httpHeaderName cannot be empty"),
+		},
+		{
+			name: "http header condition - empty value",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.grpc-web": `[{"field":"http-header","httpHeaderConfig":{"httpHeaderName": "Content-Type", "values":[""]}}]`,
+				},
+				svcName: "grpc-web",
+			},
+			wantErr: errors.New("invalid httpHeaderConfig: value cannot be empty"),
+		},
+		{
+			name: "http header condition - value too long",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.grpc-web": `[{"field":"http-header","httpHeaderConfig":{"httpHeaderName": "Content-Type", "values":["application/grpc-web*` + strings.Repeat("a", 108) + `"]}}]`,
+				},
+				svcName: "grpc-web",
+			},
+			wantErr: errors.New("invalid httpHeaderConfig: value application/grpc-web*" + strings.Repeat("a", 108) + " cannot exceed 128 characters"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -748,6 +906,251 @@ func Test_defaultEnhancedBackendBuilder_buildActionViaAnnotation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fixed response action - shorthand",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/fixed-response.maintenance": `{"contentType":"text/html","statusCode":"503","messageBody":"<h1>Under maintenance</h1>"}`,
+				},
+				svcName: "maintenance",
+			},
+			want: Action{
+				Type: ActionTypeFixedResponse,
+				FixedResponseConfig: &FixedResponseActionConfig{
+					ContentType: awssdk.String("text/html"),
+					MessageBody: awssdk.String("<h1>Under maintenance</h1>"),
+					StatusCode:  "503",
+				},
+			},
+		},
+		{
+			name: "fixed response action - shorthand conflicts with action",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.maintenance":        `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"503"}}`,
+					"alb.ingress.kubernetes.io/fixed-response.maintenance": `{"contentType":"text/html","statusCode":"503"}`,
+				},
+				svcName: "maintenance",
+			},
+			wantErr: errors.New("conflicting actions.maintenance and fixed-response.maintenance configuration"),
+		},
+		{
+			name: "fixed response action - invalid status code",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/fixed-response.maintenance": `{"contentType":"text/html","statusCode":"302"}`,
+				},
+				svcName: "maintenance",
+			},
+			wantErr: errors.New("invalid FixedResponseConfig: statusCode must be 2XX, 4XX or 5XX: 302"),
+		},
+		{
+			name: "fixed response action - invalid content type",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/fixed-response.maintenance": `{"contentType":"text/xml","statusCode":"503"}`,
+				},
+				svcName: "maintenance",
+			},
+			wantErr: errors.New("invalid FixedResponseConfig: contentType must be within [application/javascript application/json text/css text/html text/plain]: text/xml"),
+		},
+		{
+			name: "fixed response action - message body too long",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/fixed-response.maintenance": `{"contentType":"text/plain","statusCode":"503","messageBody":"` + strings.Repeat("x", 1025) + `"}`,
+				},
+				svcName: "maintenance",
+			},
+			wantErr: errors.New("invalid FixedResponseConfig: messageBody must be at most 1024 bytes: 1025"),
+		},
+		{
+			name: "forward action - health check path override",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.forward-svc": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"svc-1","servicePort":80,"weight":50,"healthCheckPath":"/svc-1/healthz"},{"serviceName":"svc-2","servicePort":80,"weight":50}]}}`,
+				},
+				svcName: "forward-svc",
+			},
+			want: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							ServiceName:     awssdk.String("svc-1"),
+							ServicePort:     &port80,
+							Weight:          awssdk.Int64(50),
+							HealthCheckPath: awssdk.String("/svc-1/healthz"),
+						},
+						{
+							ServiceName: awssdk.String("svc-2"),
+							ServicePort: &port80,
+							Weight:      awssdk.Int64(50),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "forward action - health check path override with targetGroupARN",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.forward-svc": `{"type":"forward","forwardConfig":{"targetGroups":[{"targetGroupARN":"tg-arn","healthCheckPath":"/healthz"}]}}`,
+				},
+				svcName: "forward-svc",
+			},
+			wantErr: errors.New("invalid ForwardConfig: invalid TargetGroupTuple: healthCheckPath can only be specified with serviceName"),
+		},
+		{
+			name: "forward action - relative health check path override",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.forward-svc": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"svc-1","servicePort":80,"healthCheckPath":"healthz"}]}}`,
+				},
+				svcName: "forward-svc",
+			},
+			wantErr: errors.New("invalid ForwardConfig: invalid TargetGroupTuple: healthCheckPath healthz must start with /"),
+		},
+		{
+			name: "blue-green action - blue active",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"blue","blue":{"serviceName":"svc-blue","servicePort":80},"green":{"serviceName":"svc-green","servicePort":"80"}}`,
+				},
+				svcName: "cutover",
+			},
+			want: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							ServiceName: awssdk.String("svc-blue"),
+							ServicePort: &port80,
+							Weight:      awssdk.Int64(1),
+						},
+						{
+							ServiceName: awssdk.String("svc-green"),
+							ServicePort: &port80,
+							Weight:      awssdk.Int64(0),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "blue-green action - green active",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"green","blue":{"serviceName":"svc-blue","servicePort":"http"},"green":{"targetGroupARN":"tg-green"}}`,
+				},
+				svcName: "cutover",
+			},
+			want: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							ServiceName: awssdk.String("svc-blue"),
+							ServicePort: &portHTTP,
+							Weight:      awssdk.Int64(0),
+						},
+						{
+							TargetGroupARN: awssdk.String("tg-green"),
+							Weight:         awssdk.Int64(1),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "blue-green action - with switchConfig",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"green","blue":{"targetGroupARN":"tg-blue"},"green":{"targetGroupARN":"tg-green"},"switchConfig":{"minHealthyPercentage":80,"timeoutSeconds":120}}`,
+				},
+				svcName: "cutover",
+			},
+			want: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							TargetGroupARN: awssdk.String("tg-blue"),
+							Weight:         awssdk.Int64(0),
+						},
+						{
+							TargetGroupARN: awssdk.String("tg-green"),
+							Weight:         awssdk.Int64(1),
+						},
+					},
+					SwitchConfig: &TargetGroupSwitchConfig{
+						MinHealthyPercentage: 80,
+						TimeoutSeconds:       awssdk.Int64(120),
+					},
+				},
+			},
+		},
+		{
+			name: "blue-green action - invalid minHealthyPercentage",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"green","blue":{"targetGroupARN":"tg-blue"},"green":{"targetGroupARN":"tg-green"},"switchConfig":{"minHealthyPercentage":120}}`,
+				},
+				svcName: "cutover",
+			},
+			wantErr: errors.New("invalid BlueGreenConfig: invalid TargetGroupSwitchConfig: minHealthyPercentage must be within [1, 100]: 120"),
+		},
+		{
+			name: "blue-green action - invalid timeoutSeconds",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"green","blue":{"targetGroupARN":"tg-blue"},"green":{"targetGroupARN":"tg-green"},"switchConfig":{"minHealthyPercentage":80,"timeoutSeconds":3600}}`,
+				},
+				svcName: "cutover",
+			},
+			wantErr: errors.New("invalid BlueGreenConfig: invalid TargetGroupSwitchConfig: timeoutSeconds must be within [1, 600]: 3600"),
+		},
+		{
+			name: "blue-green action - conflicts with action",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.cutover":    `{"type":"forward","targetGroupARN":"tg-blue"}`,
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"blue","blue":{"targetGroupARN":"tg-blue"},"green":{"targetGroupARN":"tg-green"}}`,
+				},
+				svcName: "cutover",
+			},
+			wantErr: errors.New("conflicting actions.cutover and blue-green.cutover configuration"),
+		},
+		{
+			name: "blue-green action - invalid active slot",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"red","blue":{"targetGroupARN":"tg-blue"},"green":{"targetGroupARN":"tg-green"}}`,
+				},
+				svcName: "cutover",
+			},
+			wantErr: errors.New("invalid BlueGreenConfig: active must be blue or green: red"),
+		},
+		{
+			name: "blue-green action - missing green target group",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"blue","blue":{"targetGroupARN":"tg-blue"}}`,
+				},
+				svcName: "cutover",
+			},
+			wantErr: errors.New("invalid BlueGreenConfig: invalid green TargetGroupTuple: precisely one of targetGroupARN and serviceName can be specified"),
+		},
+		{
+			name: "blue-green action - weight specified",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/blue-green.cutover": `{"active":"blue","blue":{"targetGroupARN":"tg-blue","weight":100},"green":{"targetGroupARN":"tg-green"}}`,
+				},
+				svcName: "cutover",
+			},
+			wantErr: errors.New("invalid BlueGreenConfig: weight cannot be specified for blue TargetGroupTuple"),
+		},
 		{
 			name: "non-exists action",
 			args: args{
@@ -38,7 +38,7 @@ type defaultFinalizerManager struct {
 }
 
 func (m *defaultFinalizerManager) AddGroupFinalizer(ctx context.Context, groupID GroupID, members []ClassifiedIngress) error {
-	finalizer := buildGroupFinalizer(groupID)
+	finalizer := BuildGroupFinalizer(groupID)
 	for _, member := range members {
 		if err := m.k8sFinalizerManager.AddFinalizers(ctx, member.Ing, finalizer); err != nil {
 			return err
@@ -48,7 +48,7 @@ func (m *defaultFinalizerManager) AddGroupFinalizer(ctx context.Context, groupID
 }
 
 func (m *defaultFinalizerManager) RemoveGroupFinalizer(ctx context.Context, groupID GroupID, inactiveMembers []*networking.Ingress) error {
-	finalizer := buildGroupFinalizer(groupID)
+	finalizer := BuildGroupFinalizer(groupID)
 	for _, ing := range inactiveMembers {
 		if err := m.k8sFinalizerManager.RemoveFinalizers(ctx, ing, finalizer); err != nil {
 			return err
@@ -57,10 +57,10 @@ func (m *defaultFinalizerManager) RemoveGroupFinalizer(ctx context.Context, grou
 	return nil
 }
 
-// buildGroupFinalizer returns a finalizer for specified Ingress group
+// BuildGroupFinalizer returns the finalizer for specified Ingress group
 // for explicit group, the format is "group.ingress.k8s.aws/awesome-group"
 // for implicit group, the format is "ingress.k8s.aws/resources"
-func buildGroupFinalizer(groupID GroupID) string {
+func BuildGroupFinalizer(groupID GroupID) string {
 	if groupID.IsExplicit() {
 		return fmt.Sprintf("%s%s", explicitGroupFinalizerPrefix, groupID.Name)
 	}
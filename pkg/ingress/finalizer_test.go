@@ -393,7 +393,7 @@ func Test_defaultFinalizerManager_RemoveGroupFinalizer(t *testing.T) {
 	}
 }
 
-func Test_buildGroupFinalizer(t *testing.T) {
+func Test_BuildGroupFinalizer(t *testing.T) {
 	tests := []struct {
 		name    string
 		groupID GroupID
@@ -418,7 +418,7 @@ func Test_buildGroupFinalizer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildGroupFinalizer(tt.groupID)
+			got := BuildGroupFinalizer(tt.groupID)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
@@ -29,6 +30,9 @@ var (
 
 	// err represents that ingress group is invalid.
 	errInvalidIngressGroup = errors.New("invalid ingress group")
+
+	// ErrMissingExplicitOptIn represents that ingress isn't explicitly opted in while explicit opt-in is required.
+	ErrMissingExplicitOptIn = errors.New("missing explicit opt-in")
 )
 
 // GroupLoader loads Ingress groups.
@@ -38,6 +42,7 @@ type GroupLoader interface {
 
 	// LoadGroupIDIfAny loads the groupID for Ingress if Ingress belong to any IngressGroup.
 	// Ingresses that is not managed by this controller or in deletion state won't have a groupID.
+	// ErrMissingExplicitOptIn is returned for Ingresses ignored due to missing explicit opt-in.
 	LoadGroupIDIfAny(ctx context.Context, ing *networking.Ingress) (*GroupID, error)
 
 	// LoadGroupIDsPendingFinalization returns groupIDs that have associated finalizer on Ingress.
@@ -45,7 +50,7 @@ type GroupLoader interface {
 }
 
 // NewDefaultGroupLoader constructs new GroupLoader instance.
-func NewDefaultGroupLoader(client client.Client, eventRecorder record.EventRecorder, annotationParser annotations.Parser, classLoader ClassLoader, classAnnotationMatcher ClassAnnotationMatcher, manageIngressesWithoutIngressClass bool) *defaultGroupLoader {
+func NewDefaultGroupLoader(client client.Client, eventRecorder record.EventRecorder, annotationParser annotations.Parser, classLoader ClassLoader, classAnnotationMatcher ClassAnnotationMatcher, ignoreIngressClassAnnotation bool, manageIngressesWithoutIngressClass bool, rejectCrossNamespaceGroups bool, allowCrossNamespaceGroups bool, groupAllowedNamespaces map[string]sets.String, requireExplicitOptIn bool) *defaultGroupLoader {
 	return &defaultGroupLoader{
 		client:           client,
 		eventRecorder:    eventRecorder,
@@ -53,7 +58,12 @@ func NewDefaultGroupLoader(client client.Client, eventRecorder record.EventRecor
 
 		classLoader:                        classLoader,
 		classAnnotationMatcher:             classAnnotationMatcher,
+		ignoreIngressClassAnnotation:       ignoreIngressClassAnnotation,
 		manageIngressesWithoutIngressClass: manageIngressesWithoutIngressClass,
+		rejectCrossNamespaceGroups:         rejectCrossNamespaceGroups,
+		allowCrossNamespaceGroups:          allowCrossNamespaceGroups,
+		groupAllowedNamespaces:             groupAllowedNamespaces,
+		requireExplicitOptIn:               requireExplicitOptIn,
 	}
 }
 
@@ -71,9 +81,30 @@ type defaultGroupLoader struct {
 	// classAnnotationMatcher checks whether ingresses with "kubernetes.io/ingress.class" annotation should be managed.
 	classAnnotationMatcher ClassAnnotationMatcher
 
+	// ignoreIngressClassAnnotation specifies whether the "kubernetes.io/ingress.class" annotation should be ignored,
+	// so that ingresses are only selected by "spec.ingressClassName".
+	ignoreIngressClassAnnotation bool
+
 	// manageIngressesWithoutIngressClass specifies whether ingresses without "kubernetes.io/ingress.class" annotation
 	// and "spec.ingressClassName" should be managed or not.
 	manageIngressesWithoutIngressClass bool
+
+	// rejectCrossNamespaceGroups specifies whether IngressGroups with members from multiple namespaces should be rejected.
+	// it's enabled when the controller is scoped to specific namespaces.
+	rejectCrossNamespaceGroups bool
+
+	// allowCrossNamespaceGroups specifies whether Ingresses are allowed to join explicit IngressGroups owned by other namespaces.
+	// when disabled, Ingresses from namespaces other than the owner namespace are excluded from the IngressGroup,
+	// unless the namespaces are allowed explicitly by groupAllowedNamespaces.
+	allowCrossNamespaceGroups bool
+
+	// groupAllowedNamespaces restricts the namespaces of Ingresses that are allowed to join explicit IngressGroups.
+	// IngressGroups without entry accept Ingresses from any namespace.
+	groupAllowedNamespaces map[string]sets.String
+
+	// requireExplicitOptIn specifies whether ingresses must carry the explicit opt-in annotation to be managed,
+	// even if they match the ingress class.
+	requireExplicitOptIn bool
 }
 
 func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID) (Group, error) {
@@ -84,7 +115,7 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID) (Group,
 
 	var members []ClassifiedIngress
 	var inactiveMembers []*networking.Ingress
-	finalizer := buildGroupFinalizer(groupID)
+	finalizer := BuildGroupFinalizer(groupID)
 	for index := range ingList.Items {
 		ing := &ingList.Items[index]
 		classifiedIngress, isGroupMember, err := m.isGroupMember(ctx, groupID, ing)
@@ -92,16 +123,33 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID) (Group,
 			return Group{}, errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 		}
 		if isGroupMember {
+			if !k8s.HasFinalizer(ing, finalizer) {
+				m.checkGroupNameConflict(classifiedIngress)
+			}
 			members = append(members, classifiedIngress)
 		} else if m.containsGroupFinalizer(groupID, finalizer, ing) {
 			inactiveMembers = append(inactiveMembers, ing)
 		}
 	}
+	if groupID.IsExplicit() && !m.allowCrossNamespaceGroups {
+		var rejectedMembers []*networking.Ingress
+		members, rejectedMembers = m.rejectCrossNamespaceMembers(groupID, finalizer, members)
+		for _, ing := range rejectedMembers {
+			if k8s.HasFinalizer(ing, finalizer) {
+				inactiveMembers = append(inactiveMembers, ing)
+			}
+		}
+	}
 
 	sortedMembers, err := m.sortGroupMembers(members)
 	if err != nil {
 		return Group{}, err
 	}
+	if m.rejectCrossNamespaceGroups {
+		if err := m.validateGroupMembersNamespace(groupID, sortedMembers); err != nil {
+			return Group{}, err
+		}
+	}
 
 	return Group{
 		ID:              groupID,
@@ -128,13 +176,75 @@ func (m *defaultGroupLoader) LoadGroupIDsPendingFinalization(_ context.Context,
 	return groupIDs
 }
 
+// validateGroupMembersNamespace validates all members of IngressGroup are from the same namespace.
+func (m *defaultGroupLoader) validateGroupMembersNamespace(groupID GroupID, members []ClassifiedIngress) error {
+	namespaces := sets.NewString()
+	for _, member := range members {
+		namespaces.Insert(member.Ing.Namespace)
+	}
+	if len(namespaces) > 1 {
+		return errors.Errorf("cross namespace IngressGroup is not allowed when watching specific namespaces, group: %v, namespaces: %v", groupID, namespaces.List())
+	}
+	return nil
+}
+
+// rejectCrossNamespaceMembers excludes members from namespaces other than the owner namespace of explicit IngressGroup.
+// IngressGroups with allowed namespaces configured explicitly accept members from all the allowed namespaces.
+// It returns the remaining members along with the rejected Ingresses.
+func (m *defaultGroupLoader) rejectCrossNamespaceMembers(groupID GroupID, finalizer string, members []ClassifiedIngress) ([]ClassifiedIngress, []*networking.Ingress) {
+	if _, restricted := m.groupAllowedNamespaces[groupID.Name]; restricted || len(members) == 0 {
+		return members, nil
+	}
+	ownerNamespace := resolveGroupOwnerNamespace(finalizer, members)
+	var acceptedMembers []ClassifiedIngress
+	var rejectedMembers []*networking.Ingress
+	for _, member := range members {
+		if member.Ing.Namespace == ownerNamespace {
+			acceptedMembers = append(acceptedMembers, member)
+			continue
+		}
+		m.eventRecorder.Eventf(member.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonCrossNamespaceGroup,
+			"ingress is excluded from group %v owned by namespace %v, cross namespace IngressGroup is not allowed", groupID, ownerNamespace)
+		rejectedMembers = append(rejectedMembers, member.Ing)
+	}
+	return acceptedMembers, rejectedMembers
+}
+
+// resolveGroupOwnerNamespace returns the namespace that owns the explicit IngressGroup.
+// Members already holding the group finalizer take precedence, so that established IngressGroups cannot be taken over by other namespaces.
+// Among the candidates, the earliest created Ingress owns the IngressGroup, ties are broken by lexical order of full-qualified name.
+func resolveGroupOwnerNamespace(finalizer string, members []ClassifiedIngress) string {
+	var candidates []*networking.Ingress
+	for _, member := range members {
+		if k8s.HasFinalizer(member.Ing, finalizer) {
+			candidates = append(candidates, member.Ing)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, member := range members {
+			candidates = append(candidates, member.Ing)
+		}
+	}
+
+	owner := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.CreationTimestamp.Before(&owner.CreationTimestamp) {
+			owner = candidate
+		} else if candidate.CreationTimestamp.Equal(&owner.CreationTimestamp) &&
+			k8s.NamespacedName(candidate).String() < k8s.NamespacedName(owner).String() {
+			owner = candidate
+		}
+	}
+	return owner.Namespace
+}
+
 // isGroupMember checks whether specified Ingress is member of specific IngressGroup.
 // If it's group member, a valid ClassifiedIngress will be returned as well.
 // NOTE: this function should only error out when it's not certain whether the specified ingress is group member. (e.g. due to APIServer failures).
 func (m *defaultGroupLoader) isGroupMember(ctx context.Context, groupID GroupID, ing *networking.Ingress) (ClassifiedIngress, bool, error) {
 	classifiedIngress, ingGroupID, err := m.loadGroupIDIfAnyHelper(ctx, ing)
 	if err != nil {
-		if errors.Is(err, ErrInvalidIngressClass) || errors.Is(err, errInvalidIngressGroup) {
+		if errors.Is(err, ErrInvalidIngressClass) || errors.Is(err, errInvalidIngressGroup) || errors.Is(err, ErrMissingExplicitOptIn) {
 			return ClassifiedIngress{}, false, nil
 		}
 		return ClassifiedIngress{}, false, err
@@ -159,6 +269,11 @@ func (m *defaultGroupLoader) loadGroupIDIfAnyHelper(ctx context.Context, ing *ne
 	if !matchesIngressClass {
 		return ClassifiedIngress{}, nil, nil
 	}
+	// Ingresses already managed before explicit opt-in is required keep their groupID, so that their resources are kept.
+	// the IngressGroup reconcile is skipped until they're opted in.
+	if m.requireExplicitOptIn && !k8s.HasExplicitOptIn(ing) && len(m.LoadGroupIDsPendingFinalization(ctx, ing)) == 0 {
+		return ClassifiedIngress{}, nil, ErrMissingExplicitOptIn
+	}
 
 	groupID, err := m.loadGroupID(classifiedIngress)
 	if err != nil {
@@ -170,7 +285,7 @@ func (m *defaultGroupLoader) loadGroupIDIfAnyHelper(ctx context.Context, ing *ne
 // classifyIngress will classify the Ingress resource and returns whether it should be managed by this controller, along with the ClassifiedIngress object.
 func (m *defaultGroupLoader) classifyIngress(ctx context.Context, ing *networking.Ingress) (ClassifiedIngress, bool, error) {
 	// the "kubernetes.io/ingress.class" annotation takes higher priority than "ingressClassName" field
-	if ingClassAnnotation, exists := ing.Annotations[annotations.IngressClass]; exists {
+	if ingClassAnnotation, exists := ing.Annotations[annotations.IngressClass]; exists && !m.ignoreIngressClassAnnotation {
 		if matchesIngressClass := m.classAnnotationMatcher.Matches(ingClassAnnotation); matchesIngressClass {
 			return ClassifiedIngress{
 				Ing:            ing,
@@ -212,19 +327,18 @@ func (m *defaultGroupLoader) classifyIngress(ctx context.Context, ing *networkin
 
 // loadGroupID loads the groupID for classified Ingress.
 func (m *defaultGroupLoader) loadGroupID(classifiedIng ClassifiedIngress) (GroupID, error) {
-	// the "group" settings in associated IngClassParams takes higher priority than "group.name" annotation on Ingresses.
-	if classifiedIng.IngClassConfig.IngClassParams != nil && classifiedIng.IngClassConfig.IngClassParams.Spec.Group != nil {
-		groupName := classifiedIng.IngClassConfig.IngClassParams.Spec.Group.Name
+	// the "group.name" annotation on Ingresses takes higher priority than "group" settings in associated IngClassParams.
+	groupName := ""
+	exists := m.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &groupName, classifiedIng.Ing.Annotations)
+	if !exists && classifiedIng.IngClassConfig.IngClassParams != nil && classifiedIng.IngClassConfig.IngClassParams.Spec.Group != nil {
+		groupName = classifiedIng.IngClassConfig.IngClassParams.Spec.Group.Name
+		exists = true
+	}
+	if exists {
 		if err := validateGroupName(groupName); err != nil {
 			return GroupID{}, fmt.Errorf("%w: %v", errInvalidIngressGroup, err.Error())
 		}
-		groupID := NewGroupIDForExplicitGroup(groupName)
-		return groupID, nil
-	}
-
-	groupName := ""
-	if exists := m.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &groupName, classifiedIng.Ing.Annotations); exists {
-		if err := validateGroupName(groupName); err != nil {
+		if err := m.validateGroupMemberNamespace(groupName, classifiedIng.Ing); err != nil {
 			return GroupID{}, fmt.Errorf("%w: %v", errInvalidIngressGroup, err.Error())
 		}
 		groupID := NewGroupIDForExplicitGroup(groupName)
@@ -235,6 +349,34 @@ func (m *defaultGroupLoader) loadGroupID(classifiedIng ClassifiedIngress) (Group
 	return groupID, nil
 }
 
+// validateGroupMemberNamespace validates whether Ingress's namespace is allowed to join the explicit IngressGroup.
+func (m *defaultGroupLoader) validateGroupMemberNamespace(groupName string, ing *networking.Ingress) error {
+	allowedNamespaces, restricted := m.groupAllowedNamespaces[groupName]
+	if !restricted || allowedNamespaces.Has(ing.Namespace) {
+		return nil
+	}
+	return errors.Errorf("namespace %v is not allowed to join group %v", ing.Namespace, groupName)
+}
+
+// checkGroupNameConflict records an event on Ingress if the "group.name" annotation conflicts with group in associated IngClassParams.
+// the "group.name" annotation takes effect in such case.
+// it's only checked when Ingress joins the group, so that the event is recorded once instead of on every reconcile.
+func (m *defaultGroupLoader) checkGroupNameConflict(classifiedIng ClassifiedIngress) {
+	if classifiedIng.IngClassConfig.IngClassParams == nil || classifiedIng.IngClassConfig.IngClassParams.Spec.Group == nil {
+		return
+	}
+	classGroupName := classifiedIng.IngClassConfig.IngClassParams.Spec.Group.Name
+	annotationGroupName := ""
+	if exists := m.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &annotationGroupName, classifiedIng.Ing.Annotations); !exists {
+		return
+	}
+	if annotationGroupName != classGroupName {
+		m.eventRecorder.Eventf(classifiedIng.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonConflictingGroupName,
+			"group.name annotation %v conflicts with group %v from IngressClassParams %v, the annotation takes effect",
+			annotationGroupName, classGroupName, classifiedIng.IngClassConfig.IngClassParams.Name)
+	}
+}
+
 func (m *defaultGroupLoader) containsGroupFinalizer(groupID GroupID, finalizer string, ing *networking.Ingress) bool {
 	if groupID.IsExplicit() {
 		return k8s.HasFinalizer(ing, finalizer)
@@ -11,7 +11,9 @@ import (
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	mock_client "sigs.k8s.io/aws-load-balancer-controller/mocks/controller-runtime/client"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
@@ -262,6 +264,16 @@ func Test_defaultGroupLoader_Load(t *testing.T) {
 			},
 		},
 	}
+	ing8FromOtherNamespace := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "other-ns",
+			Name:      "ing-8",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":          "alb",
+				"alb.ingress.kubernetes.io/group.name": "awesome-group",
+			},
+		},
+	}
 
 	type env struct {
 		ingClassList       []*networking.IngressClass
@@ -278,6 +290,53 @@ func Test_defaultGroupLoader_Load(t *testing.T) {
 		want    Group
 		wantErr error
 	}{
+		{
+			name: "load explicit group(awesome-group) - ing-8 from other namespace is rejected",
+			env: env{
+				ingClassList: []*networking.IngressClass{
+					ingClassA, ingClassB, ingClassC, ingClassD,
+				},
+				ingClassParamsList: []*elbv2api.IngressClassParams{
+					ingClassAParams, ingClassBParams, ingClassCParams,
+				},
+				ingList: []*networking.Ingress{
+					ing1, ing2, ing3, ing4, ing5, ing6, ing7, ing8FromOtherNamespace,
+				},
+			},
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+			},
+			want: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{
+						Ing: ing1,
+						IngClassConfig: ClassConfiguration{
+							IngClass:       ingClassA,
+							IngClassParams: ingClassAParams,
+						},
+					},
+					{
+						Ing: ing2,
+						IngClassConfig: ClassConfiguration{
+							IngClass:       ingClassB,
+							IngClassParams: ingClassBParams,
+						},
+					},
+					{
+						Ing: ing5,
+						IngClassConfig: ClassConfiguration{
+							IngClass: ingClassD,
+						},
+					},
+					{
+						Ing:            ing7,
+						IngClassConfig: ClassConfiguration{},
+					},
+				},
+				InactiveMembers: nil,
+			},
+		},
 		{
 			name: "load explicit group(awesome-group)",
 			env: env{
@@ -625,6 +684,7 @@ func Test_defaultGroupLoader_Load(t *testing.T) {
 			classAnnotationMatcher := NewDefaultClassAnnotationMatcher("alb")
 			m := &defaultGroupLoader{
 				client:                             k8sClient,
+				eventRecorder:                      record.NewFakeRecorder(10),
 				annotationParser:                   annotationParser,
 				classLoader:                        classLoader,
 				classAnnotationMatcher:             classAnnotationMatcher,
@@ -1337,12 +1397,13 @@ func Test_defaultGroupLoader_loadGroupIDIfAnyHelper(t *testing.T) {
 		ing *networking.Ingress
 	}
 	tests := []struct {
-		name              string
-		env               env
-		args              args
-		wantClassifiedIng ClassifiedIngress
-		wantGroupID       *GroupID
-		wantErr           error
+		name                 string
+		env                  env
+		requireExplicitOptIn bool
+		args                 args
+		wantClassifiedIng    ClassifiedIngress
+		wantGroupID          *GroupID
+		wantErr              error
 	}{
 		{
 			name: "ingress no longer belong to any IngressGroup when it's been deleted",
@@ -1553,6 +1614,104 @@ func Test_defaultGroupLoader_loadGroupIDIfAnyHelper(t *testing.T) {
 			wantGroupID:       nil,
 			wantErr:           errors.New("invalid ingress group: groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character"),
 		},
+		{
+			name:                 "ingress explicitly opted in while explicit opt-in is required",
+			requireExplicitOptIn: true,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+							"elbv2.k8s.aws/managed":       "true",
+						},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+							"elbv2.k8s.aws/managed":       "true",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantGroupID: &ingImplicitGroupID,
+			wantErr:     nil,
+		},
+		{
+			name:                 "ingress not opted in while explicit opt-in is required",
+			requireExplicitOptIn: true,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{},
+			wantGroupID:       nil,
+			wantErr:           errors.New("missing explicit opt-in"),
+		},
+		{
+			name:                 "ingress managed before explicit opt-in is required",
+			requireExplicitOptIn: true,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+						Finalizers: []string{"ingress.k8s.aws/resources"},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+						Finalizers: []string{"ingress.k8s.aws/resources"},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantGroupID: &ingImplicitGroupID,
+			wantErr:     nil,
+		},
+		{
+			name:                 "ingress isn't matched by controller's class while explicit opt-in is required",
+			requireExplicitOptIn: true,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "nginx",
+						},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{},
+			wantGroupID:       nil,
+			wantErr:           nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1579,6 +1738,7 @@ func Test_defaultGroupLoader_loadGroupIDIfAnyHelper(t *testing.T) {
 				classLoader:                        classLoader,
 				classAnnotationMatcher:             classAnnotationMatcher,
 				manageIngressesWithoutIngressClass: false,
+				requireExplicitOptIn:               tt.requireExplicitOptIn,
 			}
 			gotClassifiedIng, gotGroupID, err := m.loadGroupIDIfAnyHelper(context.Background(), tt.args.ing)
 			if tt.wantErr != nil {
@@ -1602,6 +1762,7 @@ func Test_defaultGroupLoader_classifyIngress(t *testing.T) {
 	}
 	type fields struct {
 		ingressClass                       string
+		ignoreIngressClassAnnotation       bool
 		manageIngressesWithoutIngressClass bool
 	}
 	type args struct {
@@ -1919,6 +2080,95 @@ func Test_defaultGroupLoader_classifyIngress(t *testing.T) {
 			},
 			wantIngressClassMatches: false,
 		},
+		{
+			name: "class specified via annotation - ignoreIngressClassAnnotation is set",
+			fields: fields{
+				ingressClass:                 "alb",
+				ignoreIngressClassAnnotation: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantIngressClassMatches: false,
+		},
+		{
+			name: "class specified via both annotation & ingressClassName - ignoreIngressClassAnnotation is set",
+			env: env{
+				ingClassList: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "ing-class",
+						},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+						},
+					},
+				},
+			},
+			fields: fields{
+				ingressClass:                 "alb",
+				ignoreIngressClassAnnotation: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "nginx",
+						},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("ing-class"),
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "nginx",
+						},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("ing-class"),
+					},
+				},
+				IngClassConfig: ClassConfiguration{
+					IngClass: &networking.IngressClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "ing-class",
+						},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+						},
+					},
+				},
+			},
+			wantIngressClassMatches: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1941,6 +2191,7 @@ func Test_defaultGroupLoader_classifyIngress(t *testing.T) {
 				annotationParser:                   annotationParser,
 				classLoader:                        classLoader,
 				classAnnotationMatcher:             classAnnotationMatcher,
+				ignoreIngressClassAnnotation:       tt.fields.ignoreIngressClassAnnotation,
 				manageIngressesWithoutIngressClass: tt.fields.manageIngressesWithoutIngressClass,
 			}
 
@@ -1965,10 +2216,11 @@ func Test_defaultGroupLoader_loadGroupID(t *testing.T) {
 		classifiedIng ClassifiedIngress
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    GroupID
-		wantErr error
+		name                   string
+		groupAllowedNamespaces map[string]sets.String
+		args                   args
+		want                   GroupID
+		wantErr                error
 	}{
 		{
 			name: "groupName specified via Ingress annotation",
@@ -2076,7 +2328,7 @@ func Test_defaultGroupLoader_loadGroupID(t *testing.T) {
 					},
 				},
 			},
-			want: GroupID{Name: "awesome-group-via-params"},
+			want: GroupID{Name: "awesome-group-via-anno"},
 		},
 		{
 			name: "groupName specified via both Ingress annotation & IngressClassParams",
@@ -2102,7 +2354,7 @@ func Test_defaultGroupLoader_loadGroupID(t *testing.T) {
 					},
 				},
 			},
-			want: GroupID{Name: "awesome-group-via-params"},
+			want: GroupID{Name: "awesome-group-via-anno"},
 		},
 		{
 			name: "groupName not specified",
@@ -2126,13 +2378,101 @@ func Test_defaultGroupLoader_loadGroupID(t *testing.T) {
 			},
 			want: GroupID{Namespace: "ing-ns", Name: "ing-name"},
 		},
+		{
+			name: "groupName specified via Ingress annotation and namespace allowed",
+			groupAllowedNamespaces: map[string]sets.String{
+				"awesome-group": sets.NewString("ing-ns", "other-ns"),
+			},
+			args: args{
+				classifiedIng: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "ing-ns",
+							Name:      "ing-name",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/group.name": "awesome-group",
+							},
+						},
+					},
+					IngClassConfig: ClassConfiguration{},
+				},
+			},
+			want: GroupID{Name: "awesome-group"},
+		},
+		{
+			name: "groupName specified via Ingress annotation and namespace disallowed",
+			groupAllowedNamespaces: map[string]sets.String{
+				"awesome-group": sets.NewString("other-ns"),
+			},
+			args: args{
+				classifiedIng: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "ing-ns",
+							Name:      "ing-name",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/group.name": "awesome-group",
+							},
+						},
+					},
+					IngClassConfig: ClassConfiguration{},
+				},
+			},
+			wantErr: errors.New("invalid ingress group: namespace ing-ns is not allowed to join group awesome-group"),
+		},
+		{
+			name: "groupName not specified and namespace restriction unaffected",
+			groupAllowedNamespaces: map[string]sets.String{
+				"awesome-group": sets.NewString("other-ns"),
+			},
+			args: args{
+				classifiedIng: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   "ing-ns",
+							Name:        "ing-name",
+							Annotations: map[string]string{},
+						},
+					},
+					IngClassConfig: ClassConfiguration{},
+				},
+			},
+			want: GroupID{Namespace: "ing-ns", Name: "ing-name"},
+		},
+		{
+			name: "groupName specified via IngressClassParams and namespace disallowed",
+			groupAllowedNamespaces: map[string]sets.String{
+				"awesome-group": sets.NewString("other-ns"),
+			},
+			args: args{
+				classifiedIng: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "ing-ns",
+							Name:      "ing-name",
+						},
+					},
+					IngClassConfig: ClassConfiguration{
+						IngClassParams: &elbv2api.IngressClassParams{
+							Spec: elbv2api.IngressClassParamsSpec{
+								Group: &elbv2api.IngressGroup{
+									Name: "awesome-group",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid ingress group: namespace ing-ns is not allowed to join group awesome-group"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
 			m := &defaultGroupLoader{
-				annotationParser: annotationParser,
+				annotationParser:       annotationParser,
+				groupAllowedNamespaces: tt.groupAllowedNamespaces,
 			}
 			got, err := m.loadGroupID(tt.args.classifiedIng)
 			if tt.wantErr != nil {
@@ -2757,3 +3097,250 @@ func Test_validateGroupName(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultGroupLoader_validateGroupMembersNamespace(t *testing.T) {
+	type args struct {
+		groupID GroupID
+		members []ClassifiedIngress
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "no members",
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: nil,
+			},
+		},
+		{
+			name: "members from single namespace",
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: []ClassifiedIngress{
+					{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-1"}}},
+					{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-2"}}},
+				},
+			},
+		},
+		{
+			name: "members from multiple namespaces",
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: []ClassifiedIngress{
+					{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "ing-1"}}},
+					{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-2"}}},
+				},
+			},
+			wantErr: errors.New("cross namespace IngressGroup is not allowed when watching specific namespaces, group: awesome-group, namespaces: [ns-a ns-b]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultGroupLoader{
+				rejectCrossNamespaceGroups: true,
+			}
+			err := m.validateGroupMembersNamespace(tt.args.groupID, tt.args.members)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultGroupLoader_rejectCrossNamespaceMembers(t *testing.T) {
+	ingA1 := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-1"}}
+	ingA2 := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-2"}}
+	ingB1 := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "ing-1"}}
+	type args struct {
+		groupID GroupID
+		members []ClassifiedIngress
+	}
+	tests := []struct {
+		name                   string
+		groupAllowedNamespaces map[string]sets.String
+		args                   args
+		wantMembers            []ClassifiedIngress
+		wantRejectedMembers    []*networking.Ingress
+		wantEvents             int
+	}{
+		{
+			name: "no members",
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: nil,
+			},
+			wantMembers:         nil,
+			wantRejectedMembers: nil,
+		},
+		{
+			name: "members from single namespace",
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: []ClassifiedIngress{{Ing: ingA1}, {Ing: ingA2}},
+			},
+			wantMembers:         []ClassifiedIngress{{Ing: ingA1}, {Ing: ingA2}},
+			wantRejectedMembers: nil,
+		},
+		{
+			name: "members from multiple namespaces",
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: []ClassifiedIngress{{Ing: ingB1}, {Ing: ingA1}, {Ing: ingA2}},
+			},
+			wantMembers:         []ClassifiedIngress{{Ing: ingA1}, {Ing: ingA2}},
+			wantRejectedMembers: []*networking.Ingress{ingB1},
+			wantEvents:          1,
+		},
+		{
+			name: "members from multiple namespaces allowed explicitly",
+			groupAllowedNamespaces: map[string]sets.String{
+				"awesome-group": sets.NewString("ns-a", "ns-b"),
+			},
+			args: args{
+				groupID: GroupID{Name: "awesome-group"},
+				members: []ClassifiedIngress{{Ing: ingB1}, {Ing: ingA1}, {Ing: ingA2}},
+			},
+			wantMembers:         []ClassifiedIngress{{Ing: ingB1}, {Ing: ingA1}, {Ing: ingA2}},
+			wantRejectedMembers: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			m := &defaultGroupLoader{
+				eventRecorder:          eventRecorder,
+				groupAllowedNamespaces: tt.groupAllowedNamespaces,
+			}
+			gotMembers, gotRejectedMembers := m.rejectCrossNamespaceMembers(tt.args.groupID, "group.ingress.k8s.aws/awesome-group", tt.args.members)
+			assert.Equal(t, tt.wantMembers, gotMembers)
+			assert.Equal(t, tt.wantRejectedMembers, gotRejectedMembers)
+			assert.Len(t, eventRecorder.Events, tt.wantEvents)
+		})
+	}
+}
+
+func Test_resolveGroupOwnerNamespace(t *testing.T) {
+	earlier := metav1.Date(2021, 03, 28, 11, 11, 11, 0, time.UTC)
+	later := metav1.Date(2021, 03, 29, 11, 11, 11, 0, time.UTC)
+	tests := []struct {
+		name    string
+		members []ClassifiedIngress
+		want    string
+	}{
+		{
+			name: "single member",
+			members: []ClassifiedIngress{
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-1"}}},
+			},
+			want: "ns-a",
+		},
+		{
+			name: "earliest created member owns the group",
+			members: []ClassifiedIngress{
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-1", CreationTimestamp: later}}},
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "ing-1", CreationTimestamp: earlier}}},
+			},
+			want: "ns-b",
+		},
+		{
+			name: "members created at same time are ordered by name",
+			members: []ClassifiedIngress{
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "ing-1", CreationTimestamp: earlier}}},
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-2", CreationTimestamp: earlier}}},
+			},
+			want: "ns-a",
+		},
+		{
+			name: "members holding group finalizer take precedence",
+			members: []ClassifiedIngress{
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "ing-1", CreationTimestamp: earlier}}},
+				{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "ing-1", CreationTimestamp: later,
+					Finalizers: []string{"group.ingress.k8s.aws/awesome-group"}}}},
+			},
+			want: "ns-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveGroupOwnerNamespace("group.ingress.k8s.aws/awesome-group", tt.members)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultGroupLoader_checkGroupNameConflict(t *testing.T) {
+	ingClassParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "awesome-class-params",
+		},
+		Spec: elbv2api.IngressClassParamsSpec{
+			Group: &elbv2api.IngressGroup{
+				Name: "awesome-group",
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		classifiedIng ClassifiedIngress
+		wantEvents    int
+	}{
+		{
+			name: "IngressClassParams without group",
+			classifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "another-group",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantEvents: 0,
+		},
+		{
+			name: "group.name annotation matches IngressClassParams group",
+			classifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "awesome-group",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{IngClassParams: ingClassParams},
+			},
+			wantEvents: 0,
+		},
+		{
+			name: "group.name annotation conflicts with IngressClassParams group",
+			classifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "another-group",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{IngClassParams: ingClassParams},
+			},
+			wantEvents: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			m := &defaultGroupLoader{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				eventRecorder:    eventRecorder,
+			}
+			m.checkGroupNameConflict(tt.classifiedIng)
+			assert.Len(t, eventRecorder.Events, tt.wantEvents)
+		})
+	}
+}
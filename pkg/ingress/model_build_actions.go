@@ -70,6 +70,9 @@ func (t *defaultModelBuildTask) buildAuthAction(ctx context.Context, ing *networ
 	if err != nil {
 		return nil, err
 	}
+	if backend.AuthOnUnauthenticatedRequest != nil {
+		authCfg.OnUnauthenticatedRequest = *backend.AuthOnUnauthenticatedRequest
+	}
 	switch authCfg.Type {
 	case AuthTypeCognito:
 		action, err := t.buildAuthenticateCognitoAction(ctx, authCfg)
@@ -138,7 +141,7 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 			if err := t.k8sClient.Get(ctx, svcKey, svc); err != nil {
 				return elbv2model.Action{}, err
 			}
-			tg, err := t.buildTargetGroup(ctx, ing, svc, *tgt.ServicePort)
+			tg, err := t.buildTargetGroup(ctx, ing, svc, *tgt.ServicePort, tgt.HealthCheckPath)
 			if err != nil {
 				return elbv2model.Action{}, err
 			}
@@ -157,11 +160,24 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 		}
 	}
 
+	var switchCfg *elbv2model.TargetGroupSwitchConfig
+	if actionCfg.ForwardConfig.SwitchConfig != nil {
+		timeoutSeconds := int64(defaultTargetGroupSwitchTimeoutSeconds)
+		if actionCfg.ForwardConfig.SwitchConfig.TimeoutSeconds != nil {
+			timeoutSeconds = *actionCfg.ForwardConfig.SwitchConfig.TimeoutSeconds
+		}
+		switchCfg = &elbv2model.TargetGroupSwitchConfig{
+			MinHealthyPercentage: actionCfg.ForwardConfig.SwitchConfig.MinHealthyPercentage,
+			TimeoutSeconds:       timeoutSeconds,
+		}
+	}
+
 	return elbv2model.Action{
 		Type: elbv2model.ActionTypeForward,
 		ForwardConfig: &elbv2model.ForwardActionConfig{
 			TargetGroups:                targetGroupTuples,
 			TargetGroupStickinessConfig: stickinessCfg,
+			SwitchConfig:                switchCfg,
 		},
 	}, nil
 }
@@ -215,6 +231,10 @@ func (t *defaultModelBuildTask) buildAuthenticateOIDCAction(ctx context.Context,
 
 	clientID := strings.TrimRightFunc(string(rawClientID), unicode.IsSpace)
 	clientSecret := string(rawClientSecret)
+	authRequestExtraParams := authCfg.IDPConfigOIDC.AuthenticationRequestExtraParams
+	if len(authCfg.AuthenticationRequestExtraParams) != 0 {
+		authRequestExtraParams = algorithm.MergeStringMap(authCfg.AuthenticationRequestExtraParams, authRequestExtraParams)
+	}
 	return elbv2model.Action{
 		Type: elbv2model.ActionTypeAuthenticateOIDC,
 		AuthenticateOIDCConfig: &elbv2model.AuthenticateOIDCActionConfig{
@@ -224,7 +244,7 @@ func (t *defaultModelBuildTask) buildAuthenticateOIDCAction(ctx context.Context,
 			UserInfoEndpoint:                 authCfg.IDPConfigOIDC.UserInfoEndpoint,
 			ClientID:                         clientID,
 			ClientSecret:                     clientSecret,
-			AuthenticationRequestExtraParams: authCfg.IDPConfigOIDC.AuthenticationRequestExtraParams,
+			AuthenticationRequestExtraParams: authRequestExtraParams,
 			OnUnauthenticatedRequest:         &onUnauthenticatedRequest,
 			Scope:                            &authCfg.Scope,
 			SessionCookieName:                &authCfg.SessionCookieName,
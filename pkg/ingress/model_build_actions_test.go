@@ -6,9 +6,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
@@ -140,6 +144,68 @@ func Test_defaultModelBuildTask_buildAuthenticateOIDCAction(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "auth request extra params take priority over IDPConfigOIDC",
+			env: env{
+				secrets: []*corev1.Secret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "my-k8s-secret",
+						},
+						Data: map[string][]byte{
+							"clientID":     []byte("my-client-id"),
+							"clientSecret": []byte("my-client-secret"),
+						},
+					},
+				},
+			},
+			args: args{
+				authCfg: AuthConfig{
+					Type: AuthTypeOIDC,
+					IDPConfigOIDC: &AuthIDPConfigOIDC{
+						Issuer:                "https://example.com",
+						AuthorizationEndpoint: "https://authorization.example.com",
+						TokenEndpoint:         "https://token.example.com",
+						UserInfoEndpoint:      "https://userinfo.example.co",
+						SecretName:            "my-k8s-secret",
+						AuthenticationRequestExtraParams: map[string]string{
+							"key1": "value1",
+							"key2": "value2",
+						},
+					},
+					OnUnauthenticatedRequest: "authenticate",
+					Scope:                    "openid groups",
+					SessionCookieName:        "my-session-cookie",
+					SessionTimeout:           65536,
+					AuthenticationRequestExtraParams: map[string]string{
+						"key2": "value2-override",
+						"key3": "value3",
+					},
+				},
+				namespace: "my-ns",
+			},
+			want: elbv2model.Action{
+				Type: elbv2model.ActionTypeAuthenticateOIDC,
+				AuthenticateOIDCConfig: &elbv2model.AuthenticateOIDCActionConfig{
+					Issuer:                "https://example.com",
+					AuthorizationEndpoint: "https://authorization.example.com",
+					TokenEndpoint:         "https://token.example.com",
+					UserInfoEndpoint:      "https://userinfo.example.co",
+					ClientID:              "my-client-id",
+					ClientSecret:          "my-client-secret",
+					AuthenticationRequestExtraParams: map[string]string{
+						"key1": "value1",
+						"key2": "value2-override",
+						"key3": "value3",
+					},
+					OnUnauthenticatedRequest: &authBehaviorAuthenticate,
+					Scope:                    awssdk.String("openid groups"),
+					SessionCookieName:        awssdk.String("my-session-cookie"),
+					SessionTimeout:           awssdk.Int64(65536),
+				},
+			},
+		},
 		{
 			name: "missing IDPConfigOIDC",
 			args: args{
@@ -249,6 +315,115 @@ func Test_defaultModelBuildTask_buildAuthenticateOIDCAction(t *testing.T) {
 	}
 }
 
+func Test_defaultModelBuildTask_buildAuthAction(t *testing.T) {
+	type args struct {
+		ing     *networking.Ingress
+		backend EnhancedBackend
+	}
+	authBehaviorAuthenticate := elbv2model.AuthenticateCognitoActionConditionalBehaviorAuthenticate
+	authBehaviorDeny := elbv2model.AuthenticateCognitoActionConditionalBehaviorDeny
+	fixedResponseAction := Action{
+		Type: ActionTypeFixedResponse,
+		FixedResponseConfig: &FixedResponseActionConfig{
+			StatusCode: "200",
+		},
+	}
+	ingAnnotations := map[string]string{
+		"alb.ingress.kubernetes.io/auth-type":        "cognito",
+		"alb.ingress.kubernetes.io/auth-idp-cognito": `{"userPoolARN":"arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx","userPoolClientID":"my-clientID","userPoolDomain":"my-domain"}`,
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *elbv2model.Action
+		wantErr error
+	}{
+		{
+			name: "use auth on unauthenticated request from Ingress",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "my-ns",
+						Annotations: ingAnnotations,
+					},
+				},
+				backend: EnhancedBackend{
+					Action: fixedResponseAction,
+				},
+			},
+			want: &elbv2model.Action{
+				Type: elbv2model.ActionTypeAuthenticateCognito,
+				AuthenticateCognitoConfig: &elbv2model.AuthenticateCognitoActionConfig{
+					UserPoolARN:              "arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx",
+					UserPoolClientID:         "my-clientID",
+					UserPoolDomain:           "my-domain",
+					OnUnauthenticatedRequest: &authBehaviorAuthenticate,
+					Scope:                    awssdk.String(defaultAuthScope),
+					SessionCookieName:        awssdk.String(defaultAuthSessionCookieName),
+					SessionTimeout:           awssdk.Int64(defaultAuthSessionTimeout),
+				},
+			},
+		},
+		{
+			name: "use auth on unauthenticated request from backend override",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "my-ns",
+						Annotations: ingAnnotations,
+					},
+				},
+				backend: EnhancedBackend{
+					Action:                       fixedResponseAction,
+					AuthOnUnauthenticatedRequest: awssdk.String("deny"),
+				},
+			},
+			want: &elbv2model.Action{
+				Type: elbv2model.ActionTypeAuthenticateCognito,
+				AuthenticateCognitoConfig: &elbv2model.AuthenticateCognitoActionConfig{
+					UserPoolARN:              "arn:aws:cognito-idp:us-west-2:xxx:userpool/xxx",
+					UserPoolClientID:         "my-clientID",
+					UserPoolDomain:           "my-domain",
+					OnUnauthenticatedRequest: &authBehaviorDeny,
+					Scope:                    awssdk.String(defaultAuthScope),
+					SessionCookieName:        awssdk.String(defaultAuthSessionCookieName),
+					SessionTimeout:           awssdk.Int64(defaultAuthSessionTimeout),
+				},
+			},
+		},
+		{
+			name: "no auth",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+					},
+				},
+				backend: EnhancedBackend{
+					Action:                       fixedResponseAction,
+					AuthOnUnauthenticatedRequest: awssdk.String("deny"),
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			task := &defaultModelBuildTask{
+				authConfigBuilder: NewDefaultAuthConfigBuilder(annotationParser, nil),
+			}
+			got, err := task.buildAuthAction(context.Background(), tt.args.ing, tt.args.backend)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildSSLRedirectAction(t *testing.T) {
 	type args struct {
 		sslRedirectConfig SSLRedirectConfig
@@ -284,3 +459,272 @@ func Test_defaultModelBuildTask_buildSSLRedirectAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildForwardAction_healthCheckPathOverride(t *testing.T) {
+	svcAnnotations := map[string]string{
+		"alb.ingress.kubernetes.io/healthcheck-path": "/common/healthz",
+	}
+	svc1 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "awesome-ns",
+			Name:        "svc-1",
+			Annotations: svcAnnotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+	svc2 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "awesome-ns",
+			Name:        "svc-2",
+			Annotations: svcAnnotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+	port80 := intstr.FromInt(80)
+	tests := []struct {
+		name                string
+		actions             []Action
+		wantHealthCheckPath map[string]string
+		wantErr             error
+	}{
+		{
+			name: "override health check path of one target group only",
+			actions: []Action{
+				{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName:     awssdk.String("svc-1"),
+								ServicePort:     &port80,
+								HealthCheckPath: awssdk.String("/svc-1/healthz"),
+							},
+							{
+								ServiceName: awssdk.String("svc-2"),
+								ServicePort: &port80,
+							},
+						},
+					},
+				},
+			},
+			wantHealthCheckPath: map[string]string{
+				"awesome-ns/ing-1-svc-1:80": "/svc-1/healthz",
+				"awesome-ns/ing-1-svc-2:80": "/common/healthz",
+			},
+		},
+		{
+			name: "overridden health check path is kept when target group is referenced again without override",
+			actions: []Action{
+				{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("svc-1"),
+								ServicePort: &port80,
+							},
+						},
+					},
+				},
+				{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName:     awssdk.String("svc-1"),
+								ServicePort:     &port80,
+								HealthCheckPath: awssdk.String("/svc-1/healthz"),
+							},
+						},
+					},
+				},
+				{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("svc-1"),
+								ServicePort: &port80,
+							},
+						},
+					},
+				},
+			},
+			wantHealthCheckPath: map[string]string{
+				"awesome-ns/ing-1-svc-1:80": "/svc-1/healthz",
+			},
+		},
+		{
+			name: "conflicting health check path overrides",
+			actions: []Action{
+				{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName:     awssdk.String("svc-1"),
+								ServicePort:     &port80,
+								HealthCheckPath: awssdk.String("/svc-1/healthz"),
+							},
+						},
+					},
+				},
+				{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName:     awssdk.String("svc-1"),
+								ServicePort:     &port80,
+								HealthCheckPath: awssdk.String("/svc-1/ready"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting healthCheckPath for targetGroup awesome-ns/ing-1-svc-1:80: /svc-1/healthz | /svc-1/ready"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, svc := range []*corev1.Service{svc1, svc2} {
+				assert.NoError(t, k8sClient.Create(context.Background(), svc.DeepCopy()))
+			}
+			task := &defaultModelBuildTask{
+				k8sClient:                                 k8sClient,
+				annotationParser:                          annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				stack:                                     core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"}),
+				defaultTargetType:                         elbv2model.TargetTypeIP,
+				defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
+				defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
+				defaultHealthCheckPathHTTP:                "/",
+				defaultHealthCheckPathGRPC:                "/AWS.ALB/healthcheck",
+				defaultHealthCheckIntervalSeconds:         15,
+				defaultHealthCheckTimeoutSeconds:          5,
+				defaultHealthCheckHealthyThresholdCount:   2,
+				defaultHealthCheckUnhealthyThresholdCount: 2,
+				defaultHealthCheckMatcherHTTPCode:         "200",
+				defaultHealthCheckMatcherGRPCCode:         "12",
+				tgByResID:                                 make(map[string]*elbv2model.TargetGroup),
+				tgHealthCheckPathByResID:                  make(map[string]string),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+			}
+			var err error
+			for _, action := range tt.actions {
+				if _, err = task.buildForwardAction(context.Background(), ing, action); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotHealthCheckPath := make(map[string]string)
+				for tgResID, tg := range task.tgByResID {
+					gotHealthCheckPath[tgResID] = *tg.Spec.HealthCheckConfig.Path
+				}
+				assert.Equal(t, tt.wantHealthCheckPath, gotHealthCheckPath)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildForwardAction_switchConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		actionCfg Action
+		want      *elbv2model.TargetGroupSwitchConfig
+	}{
+		{
+			name: "without switchConfig",
+			actionCfg: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							TargetGroupARN: awssdk.String("tg-blue"),
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "with switchConfig and default timeout",
+			actionCfg: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							TargetGroupARN: awssdk.String("tg-blue"),
+						},
+					},
+					SwitchConfig: &TargetGroupSwitchConfig{
+						MinHealthyPercentage: 80,
+					},
+				},
+			},
+			want: &elbv2model.TargetGroupSwitchConfig{
+				MinHealthyPercentage: 80,
+				TimeoutSeconds:       60,
+			},
+		},
+		{
+			name: "with switchConfig and timeout",
+			actionCfg: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							TargetGroupARN: awssdk.String("tg-blue"),
+						},
+					},
+					SwitchConfig: &TargetGroupSwitchConfig{
+						MinHealthyPercentage: 100,
+						TimeoutSeconds:       awssdk.Int64(300),
+					},
+				},
+			},
+			want: &elbv2model.TargetGroupSwitchConfig{
+				MinHealthyPercentage: 100,
+				TimeoutSeconds:       300,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+			}
+			got, err := task.buildForwardAction(context.Background(), ing, tt.actionCfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.ForwardConfig.SwitchConfig)
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -14,6 +16,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"strings"
 )
 
@@ -50,6 +53,8 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, lbARN cor
 		Certificates:    certs,
 		SSLPolicy:       config.sslPolicy,
 		Tags:            tags,
+
+		PreserveDefaultCertificate: config.tlsCertsDiscovered,
 	}, nil
 }
 
@@ -80,9 +85,53 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	// default backend with missing backend Services falls back to 404 like paths with missing backend Services are skipped.
+	if t.skipPathsWithMissingService {
+		missingSvcKeys, err := t.findMissingBackendServices(ctx, ing, enhancedBackend)
+		if err != nil {
+			return nil, err
+		}
+		if len(missingSvcKeys) != 0 {
+			t.eventRecorder.Eventf(ing, corev1.EventTypeWarning, k8s.IngressEventReasonMissingBackendService,
+				"skipped default backend due to missing backend services: %v", missingSvcKeys)
+			action404 := t.build404Action(ctx)
+			return []elbv2model.Action{action404}, nil
+		}
+	}
 	return t.buildActions(ctx, protocol, ing, enhancedBackend)
 }
 
+// isEmptyListener checks whether the listener for port would have neither rules nor a configured default action.
+// listeners involved in SSLRedirect are never considered empty.
+func (t *defaultModelBuildTask) isEmptyListener(port int64, protocol elbv2model.Protocol, ingList []*networking.Ingress) bool {
+	if t.sslRedirectConfig != nil && (protocol == elbv2model.ProtocolHTTP || port == t.sslRedirectConfig.SSLPort) {
+		return false
+	}
+	return !hasDefaultBackend(ingList) && !hasRulePaths(ingList)
+}
+
+// hasRulePaths checks whether any Ingress within ingList defined rules with paths.
+func hasRulePaths(ingList []*networking.Ingress) bool {
+	for _, ing := range ingList {
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP != nil && len(rule.HTTP.Paths) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasDefaultBackend checks whether any Ingress within ingList defined default backend.
+func hasDefaultBackend(ingList []*networking.Ingress) bool {
+	for _, ing := range ingList {
+		if ing.Spec.Backend != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // the listen port config for specific listener port.
 type listenPortConfig struct {
 	protocol       elbv2model.Protocol
@@ -90,11 +139,21 @@ type listenPortConfig struct {
 	inboundCIDRv6s []string
 	sslPolicy      *string
 	tlsCerts       []string
+	// whether tlsCerts are all discovered via ACM instead of explicitly specified, and the default certificate isn't explicitly specified either.
+	tlsCertsDiscovered bool
 }
 
 func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context.Context, ing *networking.Ingress) (map[int64]listenPortConfig, error) {
-	explicitTLSCertARNs := t.computeIngressExplicitTLSCertARNs(ctx, ing)
-	explicitSSLPolicy := t.computeIngressExplicitSSLPolicy(ctx, ing)
+	explicitTLSCertARNs, err := t.computeIngressExplicitTLSCertARNs(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
+	explicitDefaultTLSCertARN := ""
+	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixDefaultCertificateARN, &explicitDefaultTLSCertARN, ing.Annotations)
+	explicitSSLPolicy, err := t.computeIngressExplicitSSLPolicy(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
 	inboundCIDRv4s, inboundCIDRV6s, err := t.computeIngressExplicitInboundCIDRs(ctx, ing)
 	if err != nil {
 		return nil, err
@@ -130,9 +189,18 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 		if protocol == elbv2model.ProtocolHTTPS {
 			if len(explicitTLSCertARNs) == 0 {
 				cfg.tlsCerts = inferredTLSCertARNs
+				cfg.tlsCertsDiscovered = true
 			} else {
 				cfg.tlsCerts = explicitTLSCertARNs
 			}
+			if explicitDefaultTLSCertARN != "" {
+				tlsCerts, err := moveDefaultTLSCertARNToFront(explicitDefaultTLSCertARN, cfg.tlsCerts)
+				if err != nil {
+					return nil, err
+				}
+				cfg.tlsCerts = tlsCerts
+				cfg.tlsCertsDiscovered = false
+			}
 			cfg.sslPolicy = explicitSSLPolicy
 		}
 		listenPortConfigByPort[port] = cfg
@@ -141,10 +209,52 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 	return listenPortConfigByPort, nil
 }
 
-func (t *defaultModelBuildTask) computeIngressExplicitTLSCertARNs(_ context.Context, ing *networking.Ingress) []string {
+func (t *defaultModelBuildTask) computeIngressExplicitTLSCertARNs(_ context.Context, ing *networking.Ingress) ([]string, error) {
 	var rawTLSCertARNs []string
 	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixCertificateARN, &rawTLSCertARNs, ing.Annotations)
-	return rawTLSCertARNs
+	for _, certARN := range rawTLSCertARNs {
+		if err := validateTLSCertARN(certARN); err != nil {
+			return nil, err
+		}
+	}
+	return rawTLSCertARNs, nil
+}
+
+// moveDefaultTLSCertARNToFront moves defaultTLSCertARN to the front of tlsCertARNs, so that it's used as the default certificate.
+func moveDefaultTLSCertARNToFront(defaultTLSCertARN string, tlsCertARNs []string) ([]string, error) {
+	for i, certARN := range tlsCertARNs {
+		if certARN != defaultTLSCertARN {
+			continue
+		}
+		sortedTLSCertARNs := make([]string, 0, len(tlsCertARNs))
+		sortedTLSCertARNs = append(sortedTLSCertARNs, certARN)
+		sortedTLSCertARNs = append(sortedTLSCertARNs, tlsCertARNs[:i]...)
+		sortedTLSCertARNs = append(sortedTLSCertARNs, tlsCertARNs[i+1:]...)
+		return sortedTLSCertARNs, nil
+	}
+	return nil, errors.Errorf("default certificate must be one of the listener certificates %v: %v", tlsCertARNs, defaultTLSCertARN)
+}
+
+// validateTLSCertARN checks the certificate ARN is either an ACM certificate or an IAM server certificate,
+// both of them are supported as listener certificates.
+func validateTLSCertARN(certARN string) error {
+	parsedARN, err := arn.Parse(certARN)
+	if err != nil {
+		return errors.Errorf("invalid certificate ARN: %v", certARN)
+	}
+	switch parsedARN.Service {
+	case "acm":
+		if parsedARN.Region == "" || !strings.HasPrefix(parsedARN.Resource, "certificate/") {
+			return errors.Errorf("invalid ACM certificate ARN: %v", certARN)
+		}
+	case "iam":
+		if parsedARN.Region != "" || !strings.HasPrefix(parsedARN.Resource, "server-certificate/") {
+			return errors.Errorf("invalid IAM server certificate ARN: %v", certARN)
+		}
+	default:
+		return errors.Errorf("certificate ARN must be either ACM certificate or IAM server certificate: %v", certARN)
+	}
+	return nil
 }
 
 func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Context, ing *networking.Ingress) ([]string, error) {
@@ -184,14 +294,20 @@ func (t *defaultModelBuildTask) computeIngressListenPorts(_ context.Context, ing
 			if port < 1 || port > 65535 {
 				return nil, errors.Errorf("listen port must be within [1, 65535]: %v", port)
 			}
+			var listenProtocol elbv2model.Protocol
 			switch protocol {
 			case string(elbv2model.ProtocolHTTP):
-				portAndProtocols[port] = elbv2model.ProtocolHTTP
+				listenProtocol = elbv2model.ProtocolHTTP
 			case string(elbv2model.ProtocolHTTPS):
-				portAndProtocols[port] = elbv2model.ProtocolHTTPS
+				listenProtocol = elbv2model.ProtocolHTTPS
 			default:
 				return nil, errors.Errorf("listen protocol must be within [%v, %v]: %v", elbv2model.ProtocolHTTP, elbv2model.ProtocolHTTPS, protocol)
 			}
+			// a listener can only have a single protocol, the same port cannot be listened with both HTTP and HTTPS.
+			if existingProtocol, exists := portAndProtocols[port]; exists && existingProtocol != listenProtocol {
+				return nil, errors.Errorf("conflicting listen protocols for port %v: %v | %v", port, existingProtocol, listenProtocol)
+			}
+			portAndProtocols[port] = listenProtocol
 		}
 	}
 	return portAndProtocols, nil
@@ -216,12 +332,20 @@ func (t *defaultModelBuildTask) computeIngressExplicitInboundCIDRs(_ context.Con
 	return inboundCIDRv4s, inboundCIDRv6s, nil
 }
 
-func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(_ context.Context, ing *networking.Ingress) *string {
+func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(ctx context.Context, ing *networking.Ingress) (*string, error) {
 	var rawSSLPolicy string
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSSLPolicy, &rawSSLPolicy, ing.Annotations); !exists {
-		return nil
+		return nil, nil
+	}
+	if err := t.sslPolicyValidator.Validate(ctx, rawSSLPolicy); err != nil {
+		var unknownSSLPolicyErr *networkingpkg.UnknownSSLPolicyError
+		if errors.As(err, &unknownSSLPolicyErr) {
+			t.eventRecorder.Eventf(ing, corev1.EventTypeWarning, k8s.IngressEventReasonUnknownSSLPolicy,
+				"SSL policy %v is not supported by ELBV2", rawSSLPolicy)
+		}
+		return nil, err
 	}
-	return &rawSSLPolicy
+	return &rawSSLPolicy, nil
 }
 
 func (t *defaultModelBuildTask) modelBuildListenerTags(_ context.Context, ingList []*networking.Ingress) (map[string]string, error) {
@@ -245,5 +369,8 @@ func (t *defaultModelBuildTask) modelBuildListenerTags(_ context.Context, ingLis
 	for k, v := range annotationTags {
 		mergedTags[k] = v
 	}
+	for k, v := range k8s.BuildResourceMetadataTags(t.resourceNamespaceTagKey, t.resourceNameTagKey, t.ingGroup.ID.Namespace, t.ingGroup.ID.Name) {
+		mergedTags[k] = v
+	}
 	return mergedTags, nil
 }
@@ -3,8 +3,13 @@ package ingress
 import (
 	"context"
 	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
@@ -12,6 +17,19 @@ import (
 	"strings"
 )
 
+const (
+	// pathExpansionTrailingSlash expands path "/foo" into path patterns "/foo" and "/foo/*".
+	pathExpansionTrailingSlash = "trailing-slash"
+	// pathExpansionCase expands path patterns with their lowercase and uppercase variants.
+	pathExpansionCase = "case"
+
+	// maximum number of conditions per rule, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-listeners.html#rule-condition-types
+	maxConditionsPerRule = 5
+
+	// maximum priority of listener rules, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/listener-update-rules.html
+	maxListenerRulePriority = 50000
+)
+
 func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN core.StringToken, port int64, protocol elbv2model.Protocol, ingList []*networking.Ingress) error {
 	if t.sslRedirectConfig != nil && protocol == elbv2model.ProtocolHTTP {
 		return nil
@@ -19,6 +37,10 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 
 	var rules []Rule
 	for _, ing := range ingList {
+		pathExpansions, err := t.buildPathExpansions(ctx, ing)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+		}
 		for _, rule := range ing.Spec.Rules {
 			if rule.HTTP == nil {
 				continue
@@ -28,7 +50,18 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
-				conditions, err := t.buildRuleConditions(ctx, rule, path, enhancedBackend)
+				if t.skipPathsWithMissingService {
+					missingSvcKeys, err := t.findMissingBackendServices(ctx, ing, enhancedBackend)
+					if err != nil {
+						return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+					}
+					if len(missingSvcKeys) != 0 {
+						t.eventRecorder.Eventf(ing, corev1.EventTypeWarning, k8s.IngressEventReasonMissingBackendService,
+							"skipped path %v due to missing backend services: %v", path.Path, missingSvcKeys)
+						continue
+					}
+				}
+				conditions, err := t.buildRuleConditions(ctx, rule, path, enhancedBackend, pathExpansions)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
@@ -36,7 +69,7 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
-				tags, err := t.modelBuildListenerRuleTags(ctx, ing)
+				tags, err := t.modelBuildListenerRuleTags(ctx, ing, enhancedBackend.Tags)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
@@ -52,8 +85,20 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 	if err != nil {
 		return err
 	}
+	if t.rejectEmptyListeners && len(optimizedRules) == 0 && !hasDefaultBackend(ingList) {
+		return errors.Errorf("listener for port %v has no rules and would only respond 404", port)
+	}
 
-	priority := int64(1)
+	priorityOffset, explicitPriorityOffset, err := t.buildListenerRulePriorityOffset(ctx)
+	if err != nil {
+		return err
+	}
+	if explicitPriorityOffset {
+		if err := t.validateListenerRulePriorityBand(ctx, priorityOffset, int64(len(optimizedRules))); err != nil {
+			return errors.Wrapf(err, "listener for port %v", port)
+		}
+	}
+	priority := priorityOffset + 1
 	for _, rule := range optimizedRules {
 		ruleResID := fmt.Sprintf("%v:%v", port, priority)
 		_ = elbv2model.NewListenerRule(t.stack, ruleResID, elbv2model.ListenerRuleSpec{
@@ -69,8 +114,116 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 	return nil
 }
 
+// findMissingBackendServices finds the Services referenced by backend that don't exist.
+func (t *defaultModelBuildTask) findMissingBackendServices(ctx context.Context, ing *networking.Ingress, backend EnhancedBackend) ([]types.NamespacedName, error) {
+	if backend.Action.Type != ActionTypeForward || backend.Action.ForwardConfig == nil {
+		return nil, nil
+	}
+	var missingSvcKeys []types.NamespacedName
+	for _, tgt := range backend.Action.ForwardConfig.TargetGroups {
+		if tgt.ServiceName == nil {
+			continue
+		}
+		svcKey := types.NamespacedName{
+			Namespace: ing.Namespace,
+			Name:      awssdk.StringValue(tgt.ServiceName),
+		}
+		svc := &corev1.Service{}
+		if err := t.k8sClient.Get(ctx, svcKey, svc); err != nil {
+			if apierrors.IsNotFound(err) {
+				missingSvcKeys = append(missingSvcKeys, svcKey)
+				continue
+			}
+			return nil, err
+		}
+	}
+	return missingSvcKeys, nil
+}
+
+// buildListenerRulePriorityOffset builds the offset added to priorities of listener rules within IngressGroup.
+// The offset must be consistent across Ingresses within IngressGroup that specify it, and whether it's explicitly specified is returned.
+func (t *defaultModelBuildTask) buildListenerRulePriorityOffset(_ context.Context) (int64, bool, error) {
+	var priorityOffset int64
+	var explicitPriorityOffsetIng *networking.Ingress
+	for _, member := range t.ingGroup.Members {
+		var memberPriorityOffset int64
+		exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixGroupPriorityOffset, &memberPriorityOffset, member.Ing.Annotations)
+		if err != nil {
+			return 0, false, errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(member.Ing))
+		}
+		if !exists {
+			continue
+		}
+		if memberPriorityOffset < 0 || memberPriorityOffset >= maxListenerRulePriority {
+			return 0, false, errors.Errorf("listener rule priority offset must be within [0:%v), ingress: %v, offset: %v",
+				maxListenerRulePriority, k8s.NamespacedName(member.Ing), memberPriorityOffset)
+		}
+		if explicitPriorityOffsetIng != nil && memberPriorityOffset != priorityOffset {
+			return 0, false, errors.Errorf("conflicting listener rule priority offset %v: %v | %v: %v",
+				k8s.NamespacedName(explicitPriorityOffsetIng), priorityOffset, k8s.NamespacedName(member.Ing), memberPriorityOffset)
+		}
+		priorityOffset = memberPriorityOffset
+		explicitPriorityOffsetIng = member.Ing
+	}
+	return priorityOffset, explicitPriorityOffsetIng != nil, nil
+}
+
+// validateListenerRulePriorityBand validates the priority band of ruleCount listener rules starting after priorityOffset.
+// The band must not exceed the maximum priority, and must not contain the start of priority bands of other IngressGroups.
+// Since every IngressGroup validates its own band, overlapped bands are always rejected by the IngressGroup with the lower offset.
+func (t *defaultModelBuildTask) validateListenerRulePriorityBand(ctx context.Context, priorityOffset int64, ruleCount int64) error {
+	if ruleCount == 0 {
+		return nil
+	}
+	if priorityOffset+ruleCount > maxListenerRulePriority {
+		return errors.Errorf("listener rule priority band [%v:%v] exceeds maximum priority %v",
+			priorityOffset+1, priorityOffset+ruleCount, maxListenerRulePriority)
+	}
+	otherPriorityOffsets, err := t.findOtherGroupsListenerRulePriorityOffsets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, otherPriorityOffset := range otherPriorityOffsets.List() {
+		if otherPriorityOffset >= priorityOffset && otherPriorityOffset < priorityOffset+ruleCount {
+			return errors.Errorf("listener rule priority band [%v:%v] overlaps with priority band of other IngressGroup starting at %v",
+				priorityOffset+1, priorityOffset+ruleCount, otherPriorityOffset+1)
+		}
+	}
+	return nil
+}
+
+// findOtherGroupsListenerRulePriorityOffsets finds the listener rule priority offsets specified by Ingresses outside of IngressGroup.
+func (t *defaultModelBuildTask) findOtherGroupsListenerRulePriorityOffsets(ctx context.Context) (sets.Int64, error) {
+	memberIngKeys := sets.NewString()
+	for _, member := range t.ingGroup.Members {
+		memberIngKeys.Insert(k8s.NamespacedName(member.Ing).String())
+	}
+	for _, inactiveMember := range t.ingGroup.InactiveMembers {
+		memberIngKeys.Insert(k8s.NamespacedName(inactiveMember).String())
+	}
+
+	ingList := &networking.IngressList{}
+	if err := t.k8sClient.List(ctx, ingList); err != nil {
+		return nil, err
+	}
+	priorityOffsets := sets.NewInt64()
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if memberIngKeys.Has(k8s.NamespacedName(ing).String()) {
+			continue
+		}
+		var priorityOffset int64
+		exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixGroupPriorityOffset, &priorityOffset, ing.Annotations)
+		if err != nil || !exists {
+			continue
+		}
+		priorityOffsets.Insert(priorityOffset)
+	}
+	return priorityOffsets, nil
+}
+
 func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule networking.IngressRule,
-	path networking.HTTPIngressPath, backend EnhancedBackend) ([]elbv2model.RuleCondition, error) {
+	path networking.HTTPIngressPath, backend EnhancedBackend, pathExpansions []string) ([]elbv2model.RuleCondition, error) {
 	var hosts []string
 	if rule.Host != "" {
 		hosts = append(hosts, rule.Host)
@@ -81,6 +234,9 @@ func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule ne
 		if err != nil {
 			return nil, err
 		}
+		if path.PathType == nil || *path.PathType == networking.PathTypeImplementationSpecific {
+			pathPatterns = expandPathPatterns(pathPatterns, pathExpansions)
+		}
 		paths = append(paths, pathPatterns...)
 	}
 	var conditions []elbv2model.RuleCondition
@@ -131,9 +287,89 @@ func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule ne
 	if len(conditions) == 0 {
 		conditions = append(conditions, t.buildPathPatternCondition(ctx, []string{"/*"}))
 	}
+	if err := validateRuleConditions(conditions); err != nil {
+		return nil, err
+	}
 	return conditions, nil
 }
 
+// validateRuleConditions checks the combined conditions of a rule against ALB limits.
+// each rule can include at most one of host-header, http-request-method, path-pattern and source-ip conditions,
+// and at most maxConditionsPerRule conditions in total since each condition has at least one match evaluation.
+func validateRuleConditions(conditions []elbv2model.RuleCondition) error {
+	if len(conditions) > maxConditionsPerRule {
+		return errors.Errorf("rule has %v conditions, exceeds limit %v", len(conditions), maxConditionsPerRule)
+	}
+	singletonFields := sets.NewString()
+	for _, condition := range conditions {
+		switch condition.Field {
+		case elbv2model.RuleConditionFieldHostHeader, elbv2model.RuleConditionFieldHTTPRequestMethod,
+			elbv2model.RuleConditionFieldPathPattern, elbv2model.RuleConditionFieldSourceIP:
+			if singletonFields.Has(string(condition.Field)) {
+				return errors.Errorf("rule can include at most one %v condition", condition.Field)
+			}
+			singletonFields.Insert(string(condition.Field))
+		}
+	}
+	return nil
+}
+
+// buildPathExpansions will build the opt-in expansions of implementationSpecific paths for Ingress.
+func (t *defaultModelBuildTask) buildPathExpansions(_ context.Context, ing *networking.Ingress) ([]string, error) {
+	var pathExpansions []string
+	if !t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixPathExpansion, &pathExpansions, ing.Annotations) {
+		return nil, nil
+	}
+	for _, pathExpansion := range pathExpansions {
+		switch pathExpansion {
+		case pathExpansionTrailingSlash, pathExpansionCase:
+		default:
+			return nil, errors.Errorf("unknown path expansion %v, must be %v or %v", pathExpansion, pathExpansionTrailingSlash, pathExpansionCase)
+		}
+	}
+	return pathExpansions, nil
+}
+
+// expandPathPatterns will expand path patterns with pathExpansions, duplicated path patterns are removed.
+// with trailing-slash expansion, path patterns without wildcards like "/foo" or "/foo/" are expanded into "/foo" and "/foo/*",
+// an special case is "/", which is left as is to avoid matching all paths.
+// with case expansion, the lowercase and uppercase variants of each path pattern are added.
+func expandPathPatterns(pathPatterns []string, pathExpansions []string) []string {
+	expandedPathPatterns := pathPatterns
+	for _, pathExpansion := range pathExpansions {
+		var pathPatternsForExpansion []string
+		for _, pathPattern := range expandedPathPatterns {
+			switch pathExpansion {
+			case pathExpansionTrailingSlash:
+				if pathPattern == "/" || strings.ContainsAny(pathPattern, "*?") {
+					pathPatternsForExpansion = append(pathPatternsForExpansion, pathPattern)
+					continue
+				}
+				normalizedPathPattern := strings.TrimSuffix(pathPattern, "/")
+				pathPatternsForExpansion = append(pathPatternsForExpansion, normalizedPathPattern, normalizedPathPattern+"/*")
+			case pathExpansionCase:
+				pathPatternsForExpansion = append(pathPatternsForExpansion, pathPattern, strings.ToLower(pathPattern), strings.ToUpper(pathPattern))
+			}
+		}
+		expandedPathPatterns = pathPatternsForExpansion
+	}
+	return removeDuplicatedPathPatterns(expandedPathPatterns)
+}
+
+// removeDuplicatedPathPatterns will remove duplicated path patterns while preserving the order.
+func removeDuplicatedPathPatterns(pathPatterns []string) []string {
+	var dedupedPathPatterns []string
+	seen := make(map[string]bool, len(pathPatterns))
+	for _, pathPattern := range pathPatterns {
+		if seen[pathPattern] {
+			continue
+		}
+		seen[pathPattern] = true
+		dedupedPathPatterns = append(dedupedPathPatterns, pathPattern)
+	}
+	return dedupedPathPatterns
+}
+
 // buildPathPatterns will build ELBv2's path patterns for given path and pathType.
 func (t *defaultModelBuildTask) buildPathPatterns(path string, pathType *networking.PathType) ([]string, error) {
 	normalizedPathType := networking.PathTypeImplementationSpecific
@@ -257,7 +493,8 @@ func (t *defaultModelBuildTask) buildPathPatternCondition(_ context.Context, pat
 	}
 }
 
-func (t *defaultModelBuildTask) modelBuildListenerRuleTags(_ context.Context, ing *networking.Ingress) (map[string]string, error) {
+// modelBuildListenerRuleTags computes the tags of listener rules defined by ing, ruleTags are the additional tags of the rule's backend.
+func (t *defaultModelBuildTask) modelBuildListenerRuleTags(_ context.Context, ing *networking.Ingress, ruleTags map[string]string) (map[string]string, error) {
 	var rawTags map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, ing.Annotations); err != nil {
 		return nil, err
@@ -269,5 +506,11 @@ func (t *defaultModelBuildTask) modelBuildListenerRuleTags(_ context.Context, in
 	for k, v := range rawTags {
 		mergedTags[k] = v
 	}
+	for k, v := range ruleTags {
+		mergedTags[k] = v
+	}
+	for k, v := range k8s.BuildResourceMetadataTags(t.resourceNamespaceTagKey, t.resourceNameTagKey, ing.Namespace, ing.Name) {
+		mergedTags[k] = v
+	}
 	return mergedTags, nil
 }
@@ -1,9 +1,21 @@
 package ingress
 
 import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -330,3 +342,814 @@ func Test_defaultModelBuildTask_buildPathPatternsForPrefixPathType(t *testing.T)
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildRuleConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    networking.IngressRule
+		path    networking.HTTPIngressPath
+		backend EnhancedBackend
+		want    []elbv2model.RuleCondition
+		wantErr error
+	}{
+		{
+			name: "host, query-string and http-header conditions combined",
+			rule: networking.IngressRule{
+				Host: "www.example.com",
+			},
+			path: networking.HTTPIngressPath{
+				Path: "/api",
+			},
+			backend: EnhancedBackend{
+				Conditions: []RuleCondition{
+					{
+						Field: RuleConditionFieldQueryString,
+						QueryStringConfig: &QueryStringConditionConfig{
+							Values: []QueryStringKeyValuePair{{Key: awssdk.String("version"), Value: "v2"}},
+						},
+					},
+					{
+						Field: RuleConditionFieldHTTPHeader,
+						HTTPHeaderConfig: &HTTPHeaderConditionConfig{
+							HTTPHeaderName: "X-Canary",
+							Values:         []string{"true"},
+						},
+					},
+				},
+			},
+			want: []elbv2model.RuleCondition{
+				{
+					Field: elbv2model.RuleConditionFieldQueryString,
+					QueryStringConfig: &elbv2model.QueryStringConditionConfig{
+						Values: []elbv2model.QueryStringKeyValuePair{{Key: awssdk.String("version"), Value: "v2"}},
+					},
+				},
+				{
+					Field: elbv2model.RuleConditionFieldHTTPHeader,
+					HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+						HTTPHeaderName: "X-Canary",
+						Values:         []string{"true"},
+					},
+				},
+				{
+					Field: elbv2model.RuleConditionFieldHostHeader,
+					HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+						Values: []string{"www.example.com"},
+					},
+				},
+				{
+					Field: elbv2model.RuleConditionFieldPathPattern,
+					PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+						Values: []string{"/api"},
+					},
+				},
+			},
+		},
+		{
+			name: "conditions exceeding limit",
+			rule: networking.IngressRule{
+				Host: "www.example.com",
+			},
+			path: networking.HTTPIngressPath{
+				Path: "/api",
+			},
+			backend: EnhancedBackend{
+				Conditions: []RuleCondition{
+					{
+						Field: RuleConditionFieldHTTPHeader,
+						HTTPHeaderConfig: &HTTPHeaderConditionConfig{
+							HTTPHeaderName: "X-Header-1",
+							Values:         []string{"true"},
+						},
+					},
+					{
+						Field: RuleConditionFieldHTTPHeader,
+						HTTPHeaderConfig: &HTTPHeaderConditionConfig{
+							HTTPHeaderName: "X-Header-2",
+							Values:         []string{"true"},
+						},
+					},
+					{
+						Field: RuleConditionFieldHTTPRequestMethod,
+						HTTPRequestMethodConfig: &HTTPRequestMethodConditionConfig{
+							Values: []string{"GET"},
+						},
+					},
+					{
+						Field: RuleConditionFieldSourceIP,
+						SourceIPConfig: &SourceIPConditionConfig{
+							Values: []string{"10.0.0.0/8"},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("rule has 6 conditions, exceeds limit 5"),
+		},
+		{
+			name: "duplicated http-request-method conditions",
+			path: networking.HTTPIngressPath{
+				Path: "/api",
+			},
+			backend: EnhancedBackend{
+				Conditions: []RuleCondition{
+					{
+						Field: RuleConditionFieldHTTPRequestMethod,
+						HTTPRequestMethodConfig: &HTTPRequestMethodConditionConfig{
+							Values: []string{"GET"},
+						},
+					},
+					{
+						Field: RuleConditionFieldHTTPRequestMethod,
+						HTTPRequestMethodConfig: &HTTPRequestMethodConditionConfig{
+							Values: []string{"HEAD"},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("rule can include at most one http-request-method condition"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{}
+			got, err := task.buildRuleConditions(context.Background(), tt.rule, tt.path, tt.backend, nil)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildPathExpansions(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "no path-expansion annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "with path-expansion annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/path-expansion": "trailing-slash, case",
+					},
+				},
+			},
+			want: []string{"trailing-slash", "case"},
+		},
+		{
+			name: "with unknown path-expansion annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/path-expansion": "trailing-slash,regex",
+					},
+				},
+			},
+			wantErr: errors.New("unknown path expansion regex, must be trailing-slash or case"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildPathExpansions(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_expandPathPatterns(t *testing.T) {
+	type args struct {
+		pathPatterns   []string
+		pathExpansions []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "no expansions",
+			args: args{
+				pathPatterns: []string{"/api"},
+			},
+			want: []string{"/api"},
+		},
+		{
+			name: "trailing-slash expansion",
+			args: args{
+				pathPatterns:   []string{"/api"},
+				pathExpansions: []string{"trailing-slash"},
+			},
+			want: []string{"/api", "/api/*"},
+		},
+		{
+			name: "trailing-slash expansion with trailing slash",
+			args: args{
+				pathPatterns:   []string{"/api/"},
+				pathExpansions: []string{"trailing-slash"},
+			},
+			want: []string{"/api", "/api/*"},
+		},
+		{
+			name: "trailing-slash expansion skips root path and wildcards",
+			args: args{
+				pathPatterns:   []string{"/", "/api/*", "/v?"},
+				pathExpansions: []string{"trailing-slash"},
+			},
+			want: []string{"/", "/api/*", "/v?"},
+		},
+		{
+			name: "case expansion",
+			args: args{
+				pathPatterns:   []string{"/Api"},
+				pathExpansions: []string{"case"},
+			},
+			want: []string{"/Api", "/api", "/API"},
+		},
+		{
+			name: "case expansion removes duplicates",
+			args: args{
+				pathPatterns:   []string{"/api", "/"},
+				pathExpansions: []string{"case"},
+			},
+			want: []string{"/api", "/API", "/"},
+		},
+		{
+			name: "trailing-slash and case expansion",
+			args: args{
+				pathPatterns:   []string{"/api"},
+				pathExpansions: []string{"trailing-slash", "case"},
+			},
+			want: []string{"/api", "/API", "/api/*", "/API/*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandPathPatterns(tt.args.pathPatterns, tt.args.pathExpansions)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_modelBuildListenerRuleTags(t *testing.T) {
+	type fields struct {
+		defaultTags             map[string]string
+		resourceNamespaceTagKey string
+		resourceNameTagKey      string
+	}
+	type args struct {
+		ing      *networking.Ingress
+		ruleTags map[string]string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name: "default tags and annotation tags",
+			fields: fields{
+				defaultTags: map[string]string{
+					"k1": "v1",
+					"k2": "v2",
+				},
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/tags": "k2=v2a,k3=v3",
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				"k1": "v1",
+				"k2": "v2a",
+				"k3": "v3",
+			},
+		},
+		{
+			name: "default tags, annotation tags and resource metadata tags",
+			fields: fields{
+				defaultTags: map[string]string{
+					"k1": "v1",
+				},
+				resourceNamespaceTagKey: "elbv2.k8s.aws/namespace",
+				resourceNameTagKey:      "elbv2.k8s.aws/resource",
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/tags": "k3=v3",
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				"k1":                      "v1",
+				"k3":                      "v3",
+				"elbv2.k8s.aws/namespace": "awesome-ns",
+				"elbv2.k8s.aws/resource":  "awesome-ing",
+			},
+		},
+		{
+			name: "rule tags take precedence over annotation tags",
+			fields: fields{
+				defaultTags: map[string]string{
+					"k1": "v1",
+				},
+				resourceNamespaceTagKey: "elbv2.k8s.aws/namespace",
+				resourceNameTagKey:      "elbv2.k8s.aws/resource",
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/tags": "k2=v2,k3=v3",
+						},
+					},
+				},
+				ruleTags: map[string]string{
+					"k1":                     "v1a",
+					"k3":                     "v3a",
+					"elbv2.k8s.aws/resource": "other-ing",
+				},
+			},
+			want: map[string]string{
+				"k1":                      "v1a",
+				"k2":                      "v2",
+				"k3":                      "v3a",
+				"elbv2.k8s.aws/namespace": "awesome-ns",
+				"elbv2.k8s.aws/resource":  "awesome-ing",
+			},
+		},
+		{
+			name: "invalid annotation tags",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/tags": "k3",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse stringMap annotation, alb.ingress.kubernetes.io/tags: k3"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				defaultTags:             tt.fields.defaultTags,
+				resourceNamespaceTagKey: tt.fields.resourceNamespaceTagKey,
+				resourceNameTagKey:      tt.fields.resourceNameTagKey,
+				annotationParser:        annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.modelBuildListenerRuleTags(context.Background(), tt.args.ing, tt.args.ruleTags)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_rejectEmptyListeners(t *testing.T) {
+	ingWithoutRules := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-ing",
+		},
+	}
+	ingWithDefaultBackend := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-ing",
+		},
+		Spec: networking.IngressSpec{
+			Backend: &networking.IngressBackend{
+				ServiceName: "awesome-svc",
+				ServicePort: intstr.FromInt(80),
+			},
+		},
+	}
+	type fields struct {
+		rejectEmptyListeners bool
+	}
+	type args struct {
+		ingList []*networking.Ingress
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "listener without rules is allowed by default",
+			fields: fields{
+				rejectEmptyListeners: false,
+			},
+			args: args{
+				ingList: []*networking.Ingress{ingWithoutRules},
+			},
+		},
+		{
+			name: "listener without rules is rejected",
+			fields: fields{
+				rejectEmptyListeners: true,
+			},
+			args: args{
+				ingList: []*networking.Ingress{ingWithoutRules},
+			},
+			wantErr: errors.New("listener for port 80 has no rules and would only respond 404"),
+		},
+		{
+			name: "listener without rules but with default backend is allowed",
+			fields: fields{
+				rejectEmptyListeners: true,
+			},
+			args: args{
+				ingList: []*networking.Ingress{ingWithDefaultBackend},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser:     annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ruleOptimizer:        NewDefaultRuleOptimizer(&log.NullLogger{}),
+				stack:                core.NewDefaultStack(core.StackID{Name: "awesome-group"}),
+				rejectEmptyListeners: tt.fields.rejectEmptyListeners,
+			}
+			err := task.buildListenerRules(context.Background(), core.LiteralStringToken("awesome-ls-arn"), 80, elbv2model.ProtocolHTTP, tt.args.ingList)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_ruleTags(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-ing",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/tags":                    "Team=shop",
+				"alb.ingress.kubernetes.io/fixed-response.cart":     `{"statusCode":"200"}`,
+				"alb.ingress.kubernetes.io/fixed-response.checkout": `{"statusCode":"200"}`,
+				"alb.ingress.kubernetes.io/rule-tags.checkout":      "CostCenter=checkout",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/checkout",
+									Backend: networking.IngressBackend{
+										ServiceName: "checkout",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+								{
+									Path: "/cart",
+									Backend: networking.IngressBackend{
+										ServiceName: "cart",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+	stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+	task := &defaultModelBuildTask{
+		annotationParser:       annotationParser,
+		enhancedBackendBuilder: NewDefaultEnhancedBackendBuilder(annotationParser),
+		ruleOptimizer:          NewDefaultRuleOptimizer(&log.NullLogger{}),
+		stack:                  stack,
+	}
+	err := task.buildListenerRules(context.Background(), core.LiteralStringToken("awesome-ls-arn"), 80, elbv2model.ProtocolHTTP, []*networking.Ingress{ing})
+	assert.NoError(t, err)
+
+	var resLRs []*elbv2model.ListenerRule
+	assert.NoError(t, stack.ListResources(&resLRs))
+	tagsByPriority := make(map[int64]map[string]string, len(resLRs))
+	for _, resLR := range resLRs {
+		tagsByPriority[resLR.Spec.Priority] = resLR.Spec.Tags
+	}
+	assert.Equal(t, map[int64]map[string]string{
+		1: {
+			"Team":       "shop",
+			"CostCenter": "checkout",
+		},
+		2: {
+			"Team": "shop",
+		},
+	}, tagsByPriority)
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_priorityOffset(t *testing.T) {
+	buildIngress := func(name string, priorityOffset string, paths ...string) *networking.Ingress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        name,
+				Annotations: map[string]string{},
+			},
+			Spec: networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{},
+						},
+					},
+				},
+			},
+		}
+		if priorityOffset != "" {
+			ing.Annotations["alb.ingress.kubernetes.io/group.priority-offset"] = priorityOffset
+		}
+		for _, path := range paths {
+			ing.Annotations["alb.ingress.kubernetes.io/actions."+path] = `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"200"}}`
+			ing.Spec.Rules[0].HTTP.Paths = append(ing.Spec.Rules[0].HTTP.Paths, networking.HTTPIngressPath{
+				Path: "/" + path,
+				Backend: networking.IngressBackend{
+					ServiceName: path,
+					ServicePort: intstr.FromString("use-annotation"),
+				},
+			})
+		}
+		return ing
+	}
+	tests := []struct {
+		name           string
+		members        []*networking.Ingress
+		otherIngresses []*networking.Ingress
+		wantPriorities []int64
+		wantErr        error
+	}{
+		{
+			name: "priorities start from 1 without offset",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "", "cart", "checkout"),
+			},
+			otherIngresses: []*networking.Ingress{
+				buildIngress("other-ing", "0", "search"),
+			},
+			wantPriorities: []int64{1, 2},
+		},
+		{
+			name: "priorities start after offset",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "100", "cart"),
+				buildIngress("ing-2", "", "checkout"),
+			},
+			wantPriorities: []int64{101, 102},
+		},
+		{
+			name: "priority band doesn't overlap with other IngressGroups",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "100", "cart", "checkout"),
+			},
+			otherIngresses: []*networking.Ingress{
+				buildIngress("other-ing-1", "0", "search"),
+				buildIngress("other-ing-2", "102", "search"),
+			},
+			wantPriorities: []int64{101, 102},
+		},
+		{
+			name: "priority band overlaps with other IngressGroup",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "100", "cart", "checkout"),
+			},
+			otherIngresses: []*networking.Ingress{
+				buildIngress("other-ing", "101", "search"),
+			},
+			wantErr: errors.New("listener for port 80: listener rule priority band [101:102] overlaps with priority band of other IngressGroup starting at 102"),
+		},
+		{
+			name: "priority band exceeds maximum priority",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "49999", "cart", "checkout"),
+			},
+			wantErr: errors.New("listener for port 80: listener rule priority band [50000:50001] exceeds maximum priority 50000"),
+		},
+		{
+			name: "conflicting offsets within IngressGroup",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "100", "cart"),
+				buildIngress("ing-2", "200", "checkout"),
+			},
+			wantErr: errors.New("conflicting listener rule priority offset awesome-ns/ing-1: 100 | awesome-ns/ing-2: 200"),
+		},
+		{
+			name: "negative offset",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", "-1", "cart"),
+			},
+			wantErr: errors.New("listener rule priority offset must be within [0:50000), ingress: awesome-ns/ing-1, offset: -1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			var members []ClassifiedIngress
+			for _, ing := range tt.members {
+				assert.NoError(t, k8sClient.Create(ctx, ing.DeepCopy()))
+				members = append(members, ClassifiedIngress{Ing: ing})
+			}
+			for _, ing := range tt.otherIngresses {
+				assert.NoError(t, k8sClient.Create(ctx, ing.DeepCopy()))
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			task := &defaultModelBuildTask{
+				k8sClient:              k8sClient,
+				annotationParser:       annotationParser,
+				enhancedBackendBuilder: NewDefaultEnhancedBackendBuilder(annotationParser),
+				ruleOptimizer:          NewDefaultRuleOptimizer(&log.NullLogger{}),
+				ingGroup:               Group{Members: members},
+				stack:                  stack,
+			}
+			err := task.buildListenerRules(ctx, core.LiteralStringToken("awesome-ls-arn"), 80, elbv2model.ProtocolHTTP, tt.members)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resLRs []*elbv2model.ListenerRule
+			assert.NoError(t, stack.ListResources(&resLRs))
+			var gotPriorities []int64
+			for _, resLR := range resLRs {
+				gotPriorities = append(gotPriorities, resLR.Spec.Priority)
+			}
+			assert.ElementsMatch(t, tt.wantPriorities, gotPriorities)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_missingBackendService(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-ing",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.missing-svc-action": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"missing-svc","servicePort":"80"}]}}`,
+				"alb.ingress.kubernetes.io/actions.tg-action":          `{"type":"forward","targetGroupARN":"awesome-tg-arn"}`,
+				"alb.ingress.kubernetes.io/actions.fixed-action":       `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"200"}}`,
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/missing",
+									Backend: networking.IngressBackend{
+										ServiceName: "missing-svc-action",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+								{
+									Path: "/tg",
+									Backend: networking.IngressBackend{
+										ServiceName: "tg-action",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+								{
+									Path: "/fixed",
+									Backend: networking.IngressBackend{
+										ServiceName: "fixed-action",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                        string
+		skipPathsWithMissingService bool
+		wantPaths                   []string
+		wantEvents                  []string
+		wantErr                     error
+	}{
+		{
+			name:                        "missing backend service fails the IngressGroup by default",
+			skipPathsWithMissingService: false,
+			wantErr:                     errors.New("ingress: awesome-ns/awesome-ing: services \"missing-svc\" not found"),
+		},
+		{
+			name:                        "path with missing backend service is skipped",
+			skipPathsWithMissingService: true,
+			wantPaths:                   []string{"/tg", "/fixed"},
+			wantEvents: []string{
+				"Warning MissingBackendService skipped path /missing due to missing backend services: [awesome-ns/missing-svc]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			eventRecorder := record.NewFakeRecorder(10)
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			task := &defaultModelBuildTask{
+				k8sClient:                   k8sClient,
+				eventRecorder:               eventRecorder,
+				annotationParser:            annotationParser,
+				enhancedBackendBuilder:      NewDefaultEnhancedBackendBuilder(annotationParser),
+				ruleOptimizer:               NewDefaultRuleOptimizer(&log.NullLogger{}),
+				ingGroup:                    Group{Members: []ClassifiedIngress{{Ing: ing}}},
+				stack:                       stack,
+				skipPathsWithMissingService: tt.skipPathsWithMissingService,
+			}
+			err := task.buildListenerRules(ctx, core.LiteralStringToken("awesome-ls-arn"), 80, elbv2model.ProtocolHTTP, []*networking.Ingress{ing})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resLRs []*elbv2model.ListenerRule
+			assert.NoError(t, stack.ListResources(&resLRs))
+			var gotPaths []string
+			for _, resLR := range resLRs {
+				for _, condition := range resLR.Spec.Conditions {
+					if condition.PathPatternConfig != nil {
+						gotPaths = append(gotPaths, condition.PathPatternConfig.Values...)
+					}
+				}
+			}
+			assert.ElementsMatch(t, tt.wantPaths, gotPaths)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -0,0 +1,608 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_computeIngressExplicitTLSCertARNs(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "no annotation",
+			ing:  &networking.Ingress{},
+			want: nil,
+		},
+		{
+			name: "ACM certificates and IAM server certificates",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/12345678-1234-1234-1234-123456789012,arn:aws:iam::123456789012:server-certificate/legacy-cert",
+					},
+				},
+			},
+			want: []string{
+				"arn:aws:acm:us-west-2:123456789012:certificate/12345678-1234-1234-1234-123456789012",
+				"arn:aws:iam::123456789012:server-certificate/legacy-cert",
+			},
+		},
+		{
+			name: "invalid certificate",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/12345678-1234-1234-1234-123456789012,legacy-cert",
+					},
+				},
+			},
+			wantErr: errors.New("invalid certificate ARN: legacy-cert"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.computeIngressExplicitTLSCertARNs(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressListenPortConfigByPort_defaultCertificate(t *testing.T) {
+	type discoverCall struct {
+		tlsHosts []string
+		certARNs []string
+	}
+	tests := []struct {
+		name                   string
+		ing                    *networking.Ingress
+		discoverCalls          []discoverCall
+		wantTLSCerts           []string
+		wantTLSCertsDiscovered bool
+		wantErr                error
+	}{
+		{
+			name: "discovered certificates ordered by default certificate strategy",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTPS": 443}]`,
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{{Host: "a.example.com"}, {Host: "b.example.com"}},
+				},
+			},
+			discoverCalls: []discoverCall{
+				{
+					tlsHosts: []string{"a.example.com", "b.example.com"},
+					certARNs: []string{"cert-b", "cert-a"},
+				},
+			},
+			wantTLSCerts:           []string{"cert-b", "cert-a"},
+			wantTLSCertsDiscovered: true,
+		},
+		{
+			name: "explicit default certificate among discovered certificates",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports":            `[{"HTTPS": 443}]`,
+						"alb.ingress.kubernetes.io/default-certificate-arn": "cert-a",
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{{Host: "a.example.com"}, {Host: "b.example.com"}},
+				},
+			},
+			discoverCalls: []discoverCall{
+				{
+					tlsHosts: []string{"a.example.com", "b.example.com"},
+					certARNs: []string{"cert-b", "cert-a"},
+				},
+			},
+			wantTLSCerts:           []string{"cert-a", "cert-b"},
+			wantTLSCertsDiscovered: false,
+		},
+		{
+			name: "explicit default certificate among explicit certificates",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/certificate-arn":         "arn:aws:acm:us-west-2:123456789012:certificate/cert-1,arn:aws:acm:us-west-2:123456789012:certificate/cert-2",
+						"alb.ingress.kubernetes.io/default-certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/cert-2",
+					},
+				},
+			},
+			wantTLSCerts: []string{
+				"arn:aws:acm:us-west-2:123456789012:certificate/cert-2",
+				"arn:aws:acm:us-west-2:123456789012:certificate/cert-1",
+			},
+			wantTLSCertsDiscovered: false,
+		},
+		{
+			name: "explicit default certificate not among listener certificates",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports":            `[{"HTTPS": 443}]`,
+						"alb.ingress.kubernetes.io/default-certificate-arn": "cert-c",
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{{Host: "a.example.com"}},
+				},
+			},
+			discoverCalls: []discoverCall{
+				{
+					tlsHosts: []string{"a.example.com"},
+					certARNs: []string{"cert-a"},
+				},
+			},
+			wantErr: errors.New("default certificate must be one of the listener certificates [cert-a]: cert-c"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certDiscovery := NewMockCertDiscovery(ctrl)
+			for _, call := range tt.discoverCalls {
+				certDiscovery.EXPECT().Discover(gomock.Any(), call.tlsHosts).Return(call.certARNs, nil)
+			}
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				certDiscovery:    certDiscovery,
+			}
+			got, err := task.computeIngressListenPortConfigByPort(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTLSCerts, got[443].tlsCerts)
+				assert.Equal(t, tt.wantTLSCertsDiscovered, got[443].tlsCertsDiscovered)
+			}
+		})
+	}
+}
+
+func Test_validateTLSCertARN(t *testing.T) {
+	tests := []struct {
+		name    string
+		certARN string
+		wantErr error
+	}{
+		{
+			name:    "ACM certificate",
+			certARN: "arn:aws:acm:us-west-2:123456789012:certificate/12345678-1234-1234-1234-123456789012",
+		},
+		{
+			name:    "ACM certificate in China partition",
+			certARN: "arn:aws-cn:acm:cn-north-1:123456789012:certificate/12345678-1234-1234-1234-123456789012",
+		},
+		{
+			name:    "IAM server certificate",
+			certARN: "arn:aws:iam::123456789012:server-certificate/legacy-cert",
+		},
+		{
+			name:    "IAM server certificate with path",
+			certARN: "arn:aws:iam::123456789012:server-certificate/cloudfront/legacy-cert",
+		},
+		{
+			name:    "ACM certificate without region",
+			certARN: "arn:aws:acm::123456789012:certificate/12345678-1234-1234-1234-123456789012",
+			wantErr: errors.New("invalid ACM certificate ARN: arn:aws:acm::123456789012:certificate/12345678-1234-1234-1234-123456789012"),
+		},
+		{
+			name:    "ACM private certificate authority",
+			certARN: "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012",
+			wantErr: errors.New("certificate ARN must be either ACM certificate or IAM server certificate: arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"),
+		},
+		{
+			name:    "IAM resource other than server certificate",
+			certARN: "arn:aws:iam::123456789012:role/my-role",
+			wantErr: errors.New("invalid IAM server certificate ARN: arn:aws:iam::123456789012:role/my-role"),
+		},
+		{
+			name:    "not an ARN",
+			certARN: "legacy-cert",
+			wantErr: errors.New("invalid certificate ARN: legacy-cert"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTLSCertARN(tt.certARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressListenPorts(t *testing.T) {
+	tests := []struct {
+		name      string
+		ing       *networking.Ingress
+		preferTLS bool
+		want      map[int64]elbv2model.Protocol
+		wantErr   error
+	}{
+		{
+			name: "no annotation",
+			ing:  &networking.Ingress{},
+			want: map[int64]elbv2model.Protocol{80: elbv2model.ProtocolHTTP},
+		},
+		{
+			name:      "no annotation with TLS preferred",
+			ing:       &networking.Ingress{},
+			preferTLS: true,
+			want:      map[int64]elbv2model.Protocol{443: elbv2model.ProtocolHTTPS},
+		},
+		{
+			name: "HTTP and HTTPS on different ports",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}, {"HTTP": 80}]`,
+					},
+				},
+			},
+			want: map[int64]elbv2model.Protocol{80: elbv2model.ProtocolHTTP, 443: elbv2model.ProtocolHTTPS},
+		},
+		{
+			name: "HTTP and HTTPS on same port",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 8080}, {"HTTPS": 8080}]`,
+					},
+				},
+			},
+			wantErr: errors.New("conflicting listen protocols for port 8080: HTTP | HTTPS"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.computeIngressListenPorts(context.Background(), tt.ing, tt.preferTLS)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressExplicitSSLPolicy(t *testing.T) {
+	type validateCall struct {
+		sslPolicy string
+		err       error
+	}
+	tests := []struct {
+		name          string
+		ing           *networking.Ingress
+		validateCalls []validateCall
+		want          *string
+		wantErr       error
+		wantEvents    int
+	}{
+		{
+			name: "no annotation",
+			ing:  &networking.Ingress{},
+			want: nil,
+		},
+		{
+			name: "known SSL policy",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01",
+					},
+				},
+			},
+			validateCalls: []validateCall{
+				{
+					sslPolicy: "ELBSecurityPolicy-TLS-1-2-2017-01",
+				},
+			},
+			want: awssdk.String("ELBSecurityPolicy-TLS-1-2-2017-01"),
+		},
+		{
+			name: "unknown SSL policy",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-Unknown",
+					},
+				},
+			},
+			validateCalls: []validateCall{
+				{
+					sslPolicy: "ELBSecurityPolicy-Unknown",
+					err:       &networkingpkg.UnknownSSLPolicyError{SSLPolicy: "ELBSecurityPolicy-Unknown"},
+				},
+			},
+			wantErr:    errors.New("unknown SSL policy: ELBSecurityPolicy-Unknown"),
+			wantEvents: 1,
+		},
+		{
+			name: "failed to validate SSL policy",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01",
+					},
+				},
+			},
+			validateCalls: []validateCall{
+				{
+					sslPolicy: "ELBSecurityPolicy-TLS-1-2-2017-01",
+					err:       errors.New("failed to describe SSL policies: some error"),
+				},
+			},
+			wantErr:    errors.New("failed to describe SSL policies: some error"),
+			wantEvents: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sslPolicyValidator := networkingpkg.NewMockSSLPolicyValidator(ctrl)
+			for _, call := range tt.validateCalls {
+				sslPolicyValidator.EXPECT().Validate(gomock.Any(), call.sslPolicy).Return(call.err)
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				annotationParser:   annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				sslPolicyValidator: sslPolicyValidator,
+				eventRecorder:      eventRecorder,
+			}
+			got, err := task.computeIngressExplicitSSLPolicy(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Len(t, eventRecorder.Events, tt.wantEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_isEmptyListener(t *testing.T) {
+	ingWithoutRules := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-without-rules",
+		},
+	}
+	ingWithRules := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-with-rules",
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networking.IngressBackend{
+										ServiceName: "awesome-svc",
+										ServicePort: intstr.FromInt(80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingWithDefaultBackend := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-with-default-backend",
+		},
+		Spec: networking.IngressSpec{
+			Backend: &networking.IngressBackend{
+				ServiceName: "awesome-svc",
+				ServicePort: intstr.FromInt(80),
+			},
+		},
+	}
+	type args struct {
+		port     int64
+		protocol elbv2model.Protocol
+		ingList  []*networking.Ingress
+	}
+	tests := []struct {
+		name              string
+		sslRedirectConfig *SSLRedirectConfig
+		args              args
+		want              bool
+	}{
+		{
+			name: "listener without rules",
+			args: args{
+				port:     80,
+				protocol: elbv2model.ProtocolHTTP,
+				ingList:  []*networking.Ingress{ingWithoutRules},
+			},
+			want: true,
+		},
+		{
+			name: "listener with rules",
+			args: args{
+				port:     80,
+				protocol: elbv2model.ProtocolHTTP,
+				ingList:  []*networking.Ingress{ingWithoutRules, ingWithRules},
+			},
+			want: false,
+		},
+		{
+			name: "listener with default backend",
+			args: args{
+				port:     80,
+				protocol: elbv2model.ProtocolHTTP,
+				ingList:  []*networking.Ingress{ingWithDefaultBackend},
+			},
+			want: false,
+		},
+		{
+			name:              "HTTP listener without rules but with SSLRedirect",
+			sslRedirectConfig: &SSLRedirectConfig{SSLPort: 443},
+			args: args{
+				port:     80,
+				protocol: elbv2model.ProtocolHTTP,
+				ingList:  []*networking.Ingress{ingWithoutRules},
+			},
+			want: false,
+		},
+		{
+			name:              "SSLRedirect target listener without rules",
+			sslRedirectConfig: &SSLRedirectConfig{SSLPort: 443},
+			args: args{
+				port:     443,
+				protocol: elbv2model.ProtocolHTTPS,
+				ingList:  []*networking.Ingress{ingWithoutRules},
+			},
+			want: false,
+		},
+		{
+			name:              "other HTTPS listener without rules with SSLRedirect",
+			sslRedirectConfig: &SSLRedirectConfig{SSLPort: 443},
+			args: args{
+				port:     8443,
+				protocol: elbv2model.ProtocolHTTPS,
+				ingList:  []*networking.Ingress{ingWithoutRules},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				sslRedirectConfig: tt.sslRedirectConfig,
+			}
+			got := task.isEmptyListener(tt.args.port, tt.args.protocol, tt.args.ingList)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerDefaultActions_missingBackendService(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-ing",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.missing-svc-action": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"missing-svc","servicePort":"80"}]}}`,
+			},
+		},
+		Spec: networking.IngressSpec{
+			Backend: &networking.IngressBackend{
+				ServiceName: "missing-svc-action",
+				ServicePort: intstr.FromString("use-annotation"),
+			},
+		},
+	}
+	tests := []struct {
+		name                        string
+		skipPathsWithMissingService bool
+		want                        []elbv2model.Action
+		wantEvents                  []string
+		wantErr                     error
+	}{
+		{
+			name:                        "missing backend service fails the IngressGroup by default",
+			skipPathsWithMissingService: false,
+			wantErr:                     errors.New("services \"missing-svc\" not found"),
+		},
+		{
+			name:                        "default backend with missing backend service falls back to 404",
+			skipPathsWithMissingService: true,
+			want: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeFixedResponse,
+					FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+						ContentType: awssdk.String("text/plain"),
+						StatusCode:  "404",
+					},
+				},
+			},
+			wantEvents: []string{
+				"Warning MissingBackendService skipped default backend due to missing backend services: [awesome-ns/missing-svc]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			eventRecorder := record.NewFakeRecorder(10)
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			task := &defaultModelBuildTask{
+				k8sClient:                   k8sClient,
+				eventRecorder:               eventRecorder,
+				annotationParser:            annotationParser,
+				enhancedBackendBuilder:      NewDefaultEnhancedBackendBuilder(annotationParser),
+				ingGroup:                    Group{Members: []ClassifiedIngress{{Ing: ing}}},
+				stack:                       core.NewDefaultStack(core.StackID{Name: "awesome-group"}),
+				skipPathsWithMissingService: tt.skipPathsWithMissingService,
+			}
+			got, err := task.buildListenerDefaultActions(ctx, elbv2model.ProtocolHTTP, []*networking.Ingress{ing})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -17,10 +17,13 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"strconv"
 	"strings"
 )
 
 const (
+	lbAttrsRoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled = "routing.http.x_amzn_tls_version_and_cipher_suite.enabled"
+
 	resourceIDLoadBalancer = "LoadBalancer"
 )
 
@@ -43,10 +46,11 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
-	subnetMappings, err := t.buildLoadBalancerSubnetMappings(ctx, scheme)
+	ec2Subnets, err := t.buildLoadBalancerSubnets(ctx, scheme)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
+	subnetMappings := buildLoadBalancerSubnetMappingsWithSubnets(ec2Subnets)
 	securityGroups, err := t.buildLoadBalancerSecurityGroups(ctx, listenPortConfigByPort, ipAddressType)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
@@ -55,6 +59,9 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
+	if err := validateLoadBalancerCOIPv4Pool(coIPv4Pool, scheme, ec2Subnets); err != nil {
+		return elbv2model.LoadBalancerSpec{}, err
+	}
 	loadBalancerAttributes, err := t.buildLoadBalancerAttributes(ctx)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
@@ -82,6 +89,28 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 
 var invalidLoadBalancerNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
+// loadBalancerNamePattern matches names with alphanumeric characters and hyphens, which don't begin or end with a hyphen.
+var loadBalancerNamePattern = regexp.MustCompile("^[[:alnum:]]([[:alnum:]-]*[[:alnum:]])?$")
+
+const (
+	// maximum length of loadBalancer name.
+	maxLoadBalancerNameLength = 32
+)
+
+// validateLoadBalancerName validates custom loadBalancer name against the AWS naming rules.
+func validateLoadBalancerName(name string) error {
+	if len(name) > maxLoadBalancerNameLength {
+		return errors.Errorf("load balancer name %v must be no more than %v characters", name, maxLoadBalancerNameLength)
+	}
+	if !loadBalancerNamePattern.MatchString(name) {
+		return errors.Errorf("load balancer name %v must contain only alphanumeric characters or hyphens, and must not begin or end with a hyphen", name)
+	}
+	if strings.HasPrefix(name, "internal-") {
+		return errors.Errorf("load balancer name %v must not begin with \"internal-\"", name)
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme elbv2model.LoadBalancerScheme) (string, error) {
 	explicitNames := sets.String{}
 	for _, member := range t.ingGroup.Members {
@@ -93,6 +122,9 @@ func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme
 	}
 	if len(explicitNames) == 1 {
 		name, _ := explicitNames.PopAny()
+		if err := validateLoadBalancerName(name); err != nil {
+			return "", err
+		}
 		return name, nil
 	}
 	if len(explicitNames) > 1 {
@@ -167,7 +199,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context
 	}
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]elbv2model.SubnetMapping, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerSubnets(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]*ec2sdk.Subnet, error) {
 	var explicitSubnetNameOrIDsList [][]string
 	for _, member := range t.ingGroup.Members {
 		var rawSubnetNameOrIDs []string
@@ -185,7 +217,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 		if err != nil {
 			return nil, errors.Wrap(err, "couldn't auto-discover subnets")
 		}
-		return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
+		return chosenSubnets, nil
 	}
 
 	chosenSubnetNameOrIDs := explicitSubnetNameOrIDsList[0]
@@ -202,7 +234,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 	if err != nil {
 		return nil, err
 	}
-	return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
+	return chosenSubnets, nil
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) ([]core.StringToken, error) {
@@ -265,6 +297,22 @@ func (t *defaultModelBuildTask) buildLoadBalancerCOIPv4Pool(_ context.Context) (
 	return &rawCOIPv4Pool, nil
 }
 
+// validateLoadBalancerCOIPv4Pool validates CustomerOwnedIPv4Pool is only used with internet-facing ALB on Outpost subnets.
+func validateLoadBalancerCOIPv4Pool(coIPv4Pool *string, scheme elbv2model.LoadBalancerScheme, subnets []*ec2sdk.Subnet) error {
+	if coIPv4Pool == nil {
+		return nil
+	}
+	if scheme != elbv2model.LoadBalancerSchemeInternetFacing {
+		return errors.Errorf("CustomerOwnedIPv4Pool is only supported for %v loadBalancer", elbv2model.LoadBalancerSchemeInternetFacing)
+	}
+	for _, subnet := range subnets {
+		if len(awssdk.StringValue(subnet.OutpostArn)) == 0 {
+			return errors.Errorf("CustomerOwnedIPv4Pool is only supported for subnets on Outpost, got subnet %v", awssdk.StringValue(subnet.SubnetId))
+		}
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
 	mergedAttributes := make(map[string]string)
 	for _, member := range t.ingGroup.Members {
@@ -273,12 +321,18 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 			return nil, err
 		}
 		for attrKey, attrValue := range rawAttributes {
-			if existingAttrValue, exists := mergedAttributes[attrKey]; exists && existingAttrValue != attrValue {
+			// members are sorted by group order, so later members override earlier ones when merged by order.
+			if existingAttrValue, exists := mergedAttributes[attrKey]; exists && existingAttrValue != attrValue && !t.orderedLBAttributesMerge {
 				return nil, errors.Errorf("conflicting loadBalancerAttribute %v: %v | %v", attrKey, existingAttrValue, attrValue)
 			}
 			mergedAttributes[attrKey] = attrValue
 		}
 	}
+	if rawTLSHeadersEnabled, ok := mergedAttributes[lbAttrsRoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled]; ok {
+		if _, err := strconv.ParseBool(rawTLSHeadersEnabled); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse attribute %v=%v", lbAttrsRoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled, rawTLSHeadersEnabled)
+		}
+	}
 	attributes := make([]elbv2model.LoadBalancerAttribute, 0, len(mergedAttributes))
 	for attrKey, attrValue := range mergedAttributes {
 		attributes = append(attributes, elbv2model.LoadBalancerAttribute{
@@ -310,6 +364,10 @@ func (t *defaultModelBuildTask) buildLoadBalancerTags(_ context.Context) (map[st
 	for k, v := range annotationTags {
 		mergedTags[k] = v
 	}
+	// for explicit IngressGroup, the namespace is empty and the name is the groupName.
+	for k, v := range k8s.BuildResourceMetadataTags(t.resourceNamespaceTagKey, t.resourceNameTagKey, t.ingGroup.ID.Namespace, t.ingGroup.ID.Name) {
+		mergedTags[k] = v
+	}
 	return mergedTags, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -224,6 +225,76 @@ func Test_defaultModelBuildTask_buildLoadBalancerCOIPv4Pool(t *testing.T) {
 	}
 }
 
+func Test_validateLoadBalancerCOIPv4Pool(t *testing.T) {
+	outpostSubnets := []*ec2sdk.Subnet{
+		{
+			SubnetId:   awssdk.String("subnet-a"),
+			OutpostArn: awssdk.String("arn:aws:outposts:us-west-2:123456789012:outpost/op-abc"),
+		},
+	}
+	type args struct {
+		coIPv4Pool *string
+		scheme     elbv2.LoadBalancerScheme
+		subnets    []*ec2sdk.Subnet
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "COIPv4 not configured",
+			args: args{
+				coIPv4Pool: nil,
+				scheme:     elbv2.LoadBalancerSchemeInternal,
+				subnets: []*ec2sdk.Subnet{
+					{
+						SubnetId: awssdk.String("subnet-a"),
+					},
+				},
+			},
+		},
+		{
+			name: "COIPv4 configured on internet-facing ALB on Outpost subnets",
+			args: args{
+				coIPv4Pool: awssdk.String("ipv4pool-coip-abc"),
+				scheme:     elbv2.LoadBalancerSchemeInternetFacing,
+				subnets:    outpostSubnets,
+			},
+		},
+		{
+			name: "COIPv4 configured on internal ALB",
+			args: args{
+				coIPv4Pool: awssdk.String("ipv4pool-coip-abc"),
+				scheme:     elbv2.LoadBalancerSchemeInternal,
+				subnets:    outpostSubnets,
+			},
+			wantErr: errors.New("CustomerOwnedIPv4Pool is only supported for internet-facing loadBalancer"),
+		},
+		{
+			name: "COIPv4 configured on non-Outpost subnets",
+			args: args{
+				coIPv4Pool: awssdk.String("ipv4pool-coip-abc"),
+				scheme:     elbv2.LoadBalancerSchemeInternetFacing,
+				subnets: append(outpostSubnets, &ec2sdk.Subnet{
+					SubnetId: awssdk.String("subnet-b"),
+				}),
+			},
+			wantErr: errors.New("CustomerOwnedIPv4Pool is only supported for subnets on Outpost, got subnet subnet-b"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLoadBalancerCOIPv4Pool(tt.args.coIPv4Pool, tt.args.scheme, tt.args.subnets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildLoadBalancerTags(t *testing.T) {
 	type fields struct {
 		ingGroup    Group
@@ -555,6 +626,98 @@ func Test_defaultModelBuildTask_buildLoadBalancerName(t *testing.T) {
 			},
 			wantErr: errors.New("conflicting load balancer name: map[baz:{} foo:{}]"),
 		},
+		{
+			name: "name annotation too long",
+			fields: fields{
+				ingGroup: Group{
+					ID: GroupID{Namespace: "awesome-ns", Name: "ing-1"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "ing-1",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-name": "my-very-long-load-balancer-name-xy",
+									},
+								},
+							},
+						},
+					},
+				},
+				scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			},
+			wantErr: errors.New("load balancer name my-very-long-load-balancer-name-xy must be no more than 32 characters"),
+		},
+		{
+			name: "name annotation with invalid characters",
+			fields: fields{
+				ingGroup: Group{
+					ID: GroupID{Namespace: "awesome-ns", Name: "ing-1"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "ing-1",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-name": "my_lb",
+									},
+								},
+							},
+						},
+					},
+				},
+				scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			},
+			wantErr: errors.New("load balancer name my_lb must contain only alphanumeric characters or hyphens, and must not begin or end with a hyphen"),
+		},
+		{
+			name: "name annotation ending with hyphen",
+			fields: fields{
+				ingGroup: Group{
+					ID: GroupID{Namespace: "awesome-ns", Name: "ing-1"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "ing-1",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-name": "my-lb-",
+									},
+								},
+							},
+						},
+					},
+				},
+				scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			},
+			wantErr: errors.New("load balancer name my-lb- must contain only alphanumeric characters or hyphens, and must not begin or end with a hyphen"),
+		},
+		{
+			name: "name annotation beginning with internal-",
+			fields: fields{
+				ingGroup: Group{
+					ID: GroupID{Namespace: "awesome-ns", Name: "ing-1"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "ing-1",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-name": "internal-lb",
+									},
+								},
+							},
+						},
+					},
+				},
+				scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			},
+			wantErr: errors.New("load balancer name internal-lb must not begin with \"internal-\""),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -571,3 +734,192 @@ func Test_defaultModelBuildTask_buildLoadBalancerName(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildLoadBalancerAttributes(t *testing.T) {
+	ingGroup := Group{
+		Members: []ClassifiedIngress{
+			{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60,routing.http2.enabled=true",
+						},
+					},
+				},
+			},
+			{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=120",
+						},
+					},
+				},
+			},
+		},
+	}
+	type fields struct {
+		ingGroup                 Group
+		orderedLBAttributesMerge bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []elbv2.LoadBalancerAttribute
+		wantErr error
+	}{
+		{
+			name: "non-conflicting attributes",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60",
+									},
+								},
+							},
+						},
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60,routing.http2.enabled=true",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "60",
+				},
+				{
+					Key:   "routing.http2.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "conflicting attributes with strict merge",
+			fields: fields{
+				ingGroup:                 ingGroup,
+				orderedLBAttributesMerge: false,
+			},
+			wantErr: errors.New("conflicting loadBalancerAttribute idle_timeout.timeout_seconds: 60 | 120"),
+		},
+		{
+			name: "conflicting attributes with ordered merge",
+			fields: fields{
+				ingGroup:                 ingGroup,
+				orderedLBAttributesMerge: true,
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "120",
+				},
+				{
+					Key:   "routing.http2.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "tls version and cipher suite headers enabled along with other routing attributes",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=true,routing.http.drop_invalid_header_fields.enabled=true,routing.http2.enabled=true",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "routing.http.x_amzn_tls_version_and_cipher_suite.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "routing.http.drop_invalid_header_fields.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "routing.http2.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "tls version and cipher suite headers disabled",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=false",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "routing.http.x_amzn_tls_version_and_cipher_suite.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "tls version and cipher suite headers with invalid value",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=yes-please",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse attribute routing.http.x_amzn_tls_version_and_cipher_suite.enabled=yes-please: strconv.ParseBool: parsing \"yes-please\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				ingGroup:                 tt.fields.ingGroup,
+				orderedLBAttributesMerge: tt.fields.orderedLBAttributesMerge,
+				annotationParser:         annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildLoadBalancerAttributes(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
@@ -15,6 +16,8 @@ import (
 
 const (
 	resourceIDManagedSecurityGroup = "ManagedLBSecurityGroup"
+	// the max length of securityGroup rule description.
+	maxSecurityGroupRuleDescriptionLength = 255
 )
 
 func (t *defaultModelBuildTask) buildManagedSecurityGroup(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) (*ec2model.SecurityGroup, error) {
@@ -34,7 +37,11 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupSpec(ctx context.Contex
 	if err != nil {
 		return ec2model.SecurityGroupSpec{}, err
 	}
-	ingressPermissions := t.buildManagedSecurityGroupIngressPermissions(ctx, listenPortConfigByPort, ipAddressType)
+	ingressDescription, err := t.buildManagedSecurityGroupIngressDescription(ctx)
+	if err != nil {
+		return ec2model.SecurityGroupSpec{}, err
+	}
+	ingressPermissions := t.buildManagedSecurityGroupIngressPermissions(ctx, listenPortConfigByPort, ipAddressType, ingressDescription)
 	return ec2model.SecurityGroupSpec{
 		GroupName:   name,
 		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
@@ -45,6 +52,9 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupSpec(ctx context.Contex
 
 var invalidSecurityGroupNamePtn, _ = regexp.Compile("[[:^alnum:]]")
 
+// securityGroupRuleDescriptionPtn matches the characters allowed by EC2 in securityGroup rule descriptions.
+var securityGroupRuleDescriptionPtn = regexp.MustCompile(`^[a-zA-Z0-9. _\-:/()#,@\[\]+=&;{}!$*]*$`)
+
 func (t *defaultModelBuildTask) buildManagedSecurityGroupName(_ context.Context) string {
 	uuidHash := sha256.New()
 	_, _ = uuidHash.Write([]byte(t.clusterName))
@@ -85,7 +95,34 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupTags(_ context.Context)
 	return mergedTags, nil
 }
 
-func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) []ec2model.IPPermission {
+// buildManagedSecurityGroupIngressDescription builds the description for ingress rules of managed securityGroup.
+// it defaults to "k8s ELB <ingressGroupID>", and can be overridden by annotation on any member Ingress.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressDescription(_ context.Context) (string, error) {
+	explicitDescriptions := sets.NewString()
+	for _, member := range t.ingGroup.Members {
+		rawDescription := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSecurityGroupRuleDescription, &rawDescription, member.Ing.Annotations); !exists {
+			continue
+		}
+		explicitDescriptions.Insert(rawDescription)
+	}
+	if len(explicitDescriptions) == 0 {
+		return fmt.Sprintf("k8s ELB %v", t.ingGroup.ID), nil
+	}
+	if len(explicitDescriptions) > 1 {
+		return "", errors.Errorf("conflicting security group rules description: %v", explicitDescriptions.List())
+	}
+	description, _ := explicitDescriptions.PopAny()
+	if len(description) > maxSecurityGroupRuleDescriptionLength {
+		return "", errors.Errorf("security group rules description must be no more than %v characters: %v", maxSecurityGroupRuleDescriptionLength, description)
+	}
+	if !securityGroupRuleDescriptionPtn.MatchString(description) {
+		return "", errors.Errorf("security group rules description contains invalid characters: %v", description)
+	}
+	return description, nil
+}
+
+func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType, description string) []ec2model.IPPermission {
 	var permissions []ec2model.IPPermission
 	for port, cfg := range listenPortConfigByPort {
 		for _, cidr := range cfg.inboundCIDRv4s {
@@ -95,7 +132,8 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 				ToPort:     awssdk.Int64(port),
 				IPRanges: []ec2model.IPRange{
 					{
-						CIDRIP: cidr,
+						CIDRIP:      cidr,
+						Description: description,
 					},
 				},
 			})
@@ -108,7 +146,8 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 					ToPort:     awssdk.Int64(port),
 					IPv6Range: []ec2model.IPv6Range{
 						{
-							CIDRIPv6: cidr,
+							CIDRIPv6:    cidr,
+							Description: description,
 						},
 					},
 				})
@@ -193,3 +193,130 @@ func Test_defaultModelBuildTask_buildManagedSecurityGroupTags(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildManagedSecurityGroupIngressDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		ingGroup Group
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "no description annotation",
+			ingGroup: Group{
+				ID: GroupID{Namespace: "awesome-ns", Name: "ing-1"},
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: "k8s ELB awesome-ns/ing-1",
+		},
+		{
+			name: "no description annotation for explicit group",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: "k8s ELB awesome-group",
+		},
+		{
+			name: "description annotation on some members",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/security-group-rules-description": "team-a [public] web",
+								},
+							},
+						},
+					},
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: "team-a [public] web",
+		},
+		{
+			name: "conflicting description annotation",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/security-group-rules-description": "team-a",
+								},
+							},
+						},
+					},
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/security-group-rules-description": "team-b",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting security group rules description: [team-a team-b]"),
+		},
+		{
+			name: "description annotation with invalid characters",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/security-group-rules-description": "team-a's web",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("security group rules description contains invalid characters: team-a's web"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				ingGroup:         tt.ingGroup,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildManagedSecurityGroupIngressDescription(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
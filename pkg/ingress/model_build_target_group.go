@@ -18,16 +18,26 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strconv"
+	"strings"
 )
 
 const (
 	healthCheckPortTrafficPort = "traffic-port"
+
+	// valid range of health check success codes for HTTP and gRPC.
+	minHealthCheckMatcherHTTPCode int64 = 200
+	maxHealthCheckMatcherHTTPCode int64 = 499
+	minHealthCheckMatcherGRPCCode int64 = 0
+	maxHealthCheckMatcherGRPCCode int64 = 99
 )
 
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
-	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) (*elbv2model.TargetGroup, error) {
+	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString, healthCheckPath *string) (*elbv2model.TargetGroup, error) {
 	tgResID := t.buildTargetGroupResourceID(k8s.NamespacedName(ing), k8s.NamespacedName(svc), port)
 	if tg, exists := t.tgByResID[tgResID]; exists {
+		if err := t.overrideTargetGroupHealthCheckPath(ctx, tgResID, &tg.Spec, healthCheckPath); err != nil {
+			return nil, err
+		}
 		return tg, nil
 	}
 
@@ -35,25 +45,39 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if err := t.overrideTargetGroupHealthCheckPath(ctx, tgResID, &tgSpec, healthCheckPath); err != nil {
+		return nil, err
+	}
 	nodeSelector, err := t.buildTargetGroupBindingNodeSelector(ctx, ing, svc, tgSpec.TargetType)
 	if err != nil {
 		return nil, err
 	}
+	podSelector, err := t.buildTargetGroupBindingPodSelector(ctx, svc, tgSpec.TargetType)
+	if err != nil {
+		return nil, err
+	}
+	tgbNetworking, err := t.buildTargetGroupBindingNetworking(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
+	skipRecreation, err := t.buildTargetGroupBindingSkipRecreation(ctx, ing, svc)
+	if err != nil {
+		return nil, err
+	}
 	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
 	t.tgByResID[tgResID] = tg
-	_ = t.buildTargetGroupBinding(ctx, tg, svc, port, nodeSelector)
+	_ = t.buildTargetGroupBinding(ctx, tg, svc, port, nodeSelector, podSelector, tgbNetworking, skipRecreation)
 	return tg, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, nodeSelector *metav1.LabelSelector) *elbv2model.TargetGroupBindingResource {
-	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port, nodeSelector)
+func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, nodeSelector *metav1.LabelSelector, podSelector *metav1.LabelSelector, tgbNetworking *elbv2model.TargetGroupBindingNetworking, skipRecreation bool) *elbv2model.TargetGroupBindingResource {
+	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port, nodeSelector, podSelector, tgbNetworking, skipRecreation)
 	tgb := elbv2model.NewTargetGroupBindingResource(t.stack, tg.ID(), tgbSpec)
 	return tgb
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, nodeSelector *metav1.LabelSelector) elbv2model.TargetGroupBindingResourceSpec {
+func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(_ context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, nodeSelector *metav1.LabelSelector, podSelector *metav1.LabelSelector, tgbNetworking *elbv2model.TargetGroupBindingNetworking, skipRecreation bool) elbv2model.TargetGroupBindingResourceSpec {
 	targetType := elbv2api.TargetType(tg.Spec.TargetType)
-	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx)
 	return elbv2model.TargetGroupBindingResourceSpec{
 		Template: elbv2model.TargetGroupBindingTemplate{
 			ObjectMeta: metav1.ObjectMeta{
@@ -69,15 +93,54 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 				},
 				Networking:   tgbNetworking,
 				NodeSelector: nodeSelector,
+				PodSelector:  podSelector,
 			},
 		},
+		SkipRecreation: skipRecreation,
 	}
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(_ context.Context) *elbv2model.TargetGroupBindingNetworking {
-	if t.managedSG == nil {
+// overrideTargetGroupHealthCheckPath overrides the health check path of targetGroup with the one specified via actions.
+// The same targetGroup can be referenced by multiple actions, but the overridden health check paths cannot conflict.
+func (t *defaultModelBuildTask) overrideTargetGroupHealthCheckPath(_ context.Context, tgResID string, tgSpec *elbv2model.TargetGroupSpec, healthCheckPath *string) error {
+	if healthCheckPath == nil {
 		return nil
 	}
+	if existingHealthCheckPath, exists := t.tgHealthCheckPathByResID[tgResID]; exists && existingHealthCheckPath != *healthCheckPath {
+		return errors.Errorf("conflicting healthCheckPath for targetGroup %v: %v | %v", tgResID, existingHealthCheckPath, *healthCheckPath)
+	}
+	overriddenHealthCheckPath := *healthCheckPath
+	t.tgHealthCheckPathByResID[tgResID] = overriddenHealthCheckPath
+	tgSpec.HealthCheckConfig.Path = &overriddenHealthCheckPath
+	return nil
+}
+
+// buildTargetGroupBindingSkipRecreation checks whether missing TargetGroupBinding shouldn't be recreated for existing TargetGroup,
+// so that TargetGroupBindings removed intentionally are respected.
+func (t *defaultModelBuildTask) buildTargetGroupBindingSkipRecreation(_ context.Context, ing *networking.Ingress, svc *corev1.Service) (bool, error) {
+	recreate := true
+	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Annotations)
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixRecreateTargetGroupBinding, &recreate, svcAndIngAnnotations); err != nil {
+		return false, err
+	}
+	return !recreate, nil
+}
+
+// buildTargetGroupBindingNetworking builds the networking rules for backend securityGroups to allow traffic from managed securityGroup.
+// it returns nil if there is no managed securityGroup or the backend securityGroup rules are managed externally.
+func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(_ context.Context, ing *networking.Ingress) (*elbv2model.TargetGroupBindingNetworking, error) {
+	if t.managedSG == nil {
+		return nil, nil
+	}
+	manageBackendSGRules := t.defaultManageBackendSGRules
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixManageBackendSGRules, &manageBackendSGRules, ing.Annotations); err != nil {
+		return nil, err
+	}
+	if !manageBackendSGRules {
+		t.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonBackendSGRulesUnmanaged,
+			"Backend security group rules must be managed externally to allow traffic from the LoadBalancer's securityGroup")
+		return nil, nil
+	}
 	protocolTCP := elbv2api.NetworkingProtocolTCP
 	return &elbv2model.TargetGroupBindingNetworking{
 		Ingress: []elbv2model.NetworkingIngressRule{
@@ -97,7 +160,7 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(_ context.Cont
 				},
 			},
 		},
-	}
+	}, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
@@ -123,7 +186,7 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
-	tags, err := t.buildTargetGroupTags(ctx, svcAndIngAnnotations)
+	tags, err := t.buildTargetGroupTags(ctx, ing, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
@@ -198,7 +261,20 @@ func (t *defaultModelBuildTask) buildTargetGroupPort(_ context.Context, targetTy
 	return 1
 }
 
+// buildTargetGroupProtocol constructs the TargetGroup's protocol from the target-group-protocol annotation,
+// which defaults to the backend-protocol annotation if absent.
 func (t *defaultModelBuildTask) buildTargetGroupProtocol(_ context.Context, svcAndIngAnnotations map[string]string) (elbv2model.Protocol, error) {
+	rawTGProtocol := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetGroupProtocol, &rawTGProtocol, svcAndIngAnnotations); exists {
+		switch rawTGProtocol {
+		case string(elbv2model.ProtocolHTTP):
+			return elbv2model.ProtocolHTTP, nil
+		case string(elbv2model.ProtocolHTTPS):
+			return elbv2model.ProtocolHTTPS, nil
+		default:
+			return "", errors.Errorf("target group protocol must be within [%v, %v] for application load balancers: %v", elbv2model.ProtocolHTTP, elbv2model.ProtocolHTTPS, rawTGProtocol)
+		}
+	}
 	rawBackendProtocol := string(t.defaultBackendProtocol)
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixBackendProtocol, &rawBackendProtocol, svcAndIngAnnotations)
 	switch rawBackendProtocol {
@@ -236,7 +312,10 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
 	}
 	healthCheckPath := t.buildTargetGroupHealthCheckPath(ctx, svcAndIngAnnotations, tgProtocolVersion)
-	healthCheckMatcher := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	healthCheckMatcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
 	healthCheckIntervalSeconds, err := t.buildTargetGroupHealthCheckIntervalSeconds(ctx, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
@@ -316,7 +395,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Contex
 	return rawHealthCheckPath
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) elbv2model.HealthCheckMatcher {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) (elbv2model.HealthCheckMatcher, error) {
 	var rawHealthCheckMatcherHTTPCode string
 	switch tgProtocolVersion {
 	case elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2:
@@ -327,13 +406,47 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Con
 
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawHealthCheckMatcherHTTPCode, svcAndIngAnnotations)
 	if tgProtocolVersion == elbv2model.ProtocolVersionGRPC {
+		if err := validateHealthCheckMatcherCodes(rawHealthCheckMatcherHTTPCode, minHealthCheckMatcherGRPCCode, maxHealthCheckMatcherGRPCCode); err != nil {
+			return elbv2model.HealthCheckMatcher{}, errors.Wrapf(err, "invalid success-codes for backend protocol version %v, gRPC codes must be within [%v-%v]",
+				tgProtocolVersion, minHealthCheckMatcherGRPCCode, maxHealthCheckMatcherGRPCCode)
+		}
 		return elbv2model.HealthCheckMatcher{
 			GRPCCode: &rawHealthCheckMatcherHTTPCode,
-		}
+		}, nil
+	}
+	if err := validateHealthCheckMatcherCodes(rawHealthCheckMatcherHTTPCode, minHealthCheckMatcherHTTPCode, maxHealthCheckMatcherHTTPCode); err != nil {
+		return elbv2model.HealthCheckMatcher{}, errors.Wrapf(err, "invalid success-codes for backend protocol version %v, HTTP codes must be within [%v-%v]",
+			tgProtocolVersion, minHealthCheckMatcherHTTPCode, maxHealthCheckMatcherHTTPCode)
 	}
 	return elbv2model.HealthCheckMatcher{
 		HTTPCode: &rawHealthCheckMatcherHTTPCode,
+	}, nil
+}
+
+// validateHealthCheckMatcherCodes validates the health check success codes are within [minCode, maxCode].
+// codes can be a single value(e.g. "200"), multiple values(e.g. "200,202") or a range of values(e.g. "200-299").
+func validateHealthCheckMatcherCodes(codes string, minCode int64, maxCode int64) error {
+	for _, rawCodeRange := range strings.Split(codes, ",") {
+		rawCodeBounds := strings.Split(rawCodeRange, "-")
+		if len(rawCodeBounds) > 2 {
+			return errors.Errorf("invalid code range %q", rawCodeRange)
+		}
+		var codeBounds []int64
+		for _, rawCode := range rawCodeBounds {
+			code, err := strconv.ParseInt(strings.TrimSpace(rawCode), 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid code %q", rawCode)
+			}
+			if code < minCode || code > maxCode {
+				return errors.Errorf("code %v is out of range", code)
+			}
+			codeBounds = append(codeBounds, code)
+		}
+		if len(codeBounds) == 2 && codeBounds[0] > codeBounds[1] {
+			return errors.Errorf("invalid code range %q, lower bound must not be greater than upper bound", rawCodeRange)
+		}
 	}
+	return nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckIntervalSeconds(_ context.Context, svcAndIngAnnotations map[string]string) (int64, error) {
@@ -387,7 +500,7 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, sv
 	return attributes, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, svcAndIngAnnotations map[string]string) (map[string]string, error) {
+func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, ing *networking.Ingress, svcAndIngAnnotations map[string]string) (map[string]string, error) {
 	var annotationTags map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &annotationTags, svcAndIngAnnotations); err != nil {
 		return nil, err
@@ -399,6 +512,9 @@ func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, svcAndIn
 	for k, v := range annotationTags {
 		mergedTags[k] = v
 	}
+	for k, v := range k8s.BuildResourceMetadataTags(t.resourceNamespaceTagKey, t.resourceNameTagKey, ing.Namespace, ing.Name) {
+		mergedTags[k] = v
+	}
 	return mergedTags, nil
 }
 
@@ -406,6 +522,8 @@ func (t *defaultModelBuildTask) buildTargetGroupResourceID(ingKey types.Namespac
 	return fmt.Sprintf("%s/%s-%s:%s", ingKey.Namespace, ingKey.Name, svcKey.Name, port.String())
 }
 
+// buildTargetGroupBindingNodeSelector builds the nodeSelector for instance type targetGroupBinding via the target-node-labels annotation,
+// the annotation on Service takes priority over the one on Ingress.
 func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Context, ing *networking.Ingress, svc *corev1.Service, targetType elbv2model.TargetType) (*metav1.LabelSelector, error) {
 	if targetType != elbv2model.TargetTypeInstance {
 		return nil, nil
@@ -419,7 +537,47 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Co
 	if len(targetNodeLabels) == 0 {
 		return nil, nil
 	}
-	return &metav1.LabelSelector{
+	nodeSelector := &metav1.LabelSelector{
 		MatchLabels: targetNodeLabels,
-	}, nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(nodeSelector); err != nil {
+		return nil, errors.Wrapf(err, "invalid target-node-labels of service: %v", k8s.NamespacedName(svc))
+	}
+	return nodeSelector, nil
+}
+
+// buildTargetGroupBindingPodSelector builds the podSelector for ip type targetGroupBinding via the target-pod-labels annotation of Service.
+// The pod labels can only narrow down the pods selected by the Service, so they cannot conflict with the Service selector.
+func (t *defaultModelBuildTask) buildTargetGroupBindingPodSelector(_ context.Context, svc *corev1.Service, targetType elbv2model.TargetType) (*metav1.LabelSelector, error) {
+	var targetPodLabels map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTargetPodLabels, &targetPodLabels, svc.Annotations); err != nil {
+		return nil, err
+	}
+	if len(targetPodLabels) == 0 {
+		return nil, nil
+	}
+	svcKey := k8s.NamespacedName(svc)
+	if targetType != elbv2model.TargetTypeIP {
+		return nil, errors.Errorf("target-pod-labels is only supported for ip targetType, service: %v", svcKey)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, errors.Errorf("target-pod-labels is only supported for service with selector, service: %v", svcKey)
+	}
+	podLabels := make(map[string]string, len(svc.Spec.Selector)+len(targetPodLabels))
+	for key, value := range svc.Spec.Selector {
+		podLabels[key] = value
+	}
+	for key, value := range targetPodLabels {
+		if svcSelectorValue, exists := svc.Spec.Selector[key]; exists && svcSelectorValue != value {
+			return nil, errors.Errorf("target-pod-labels %v=%v conflicts with selector %v=%v of service: %v", key, value, key, svcSelectorValue, svcKey)
+		}
+		podLabels[key] = value
+	}
+	podSelector := &metav1.LabelSelector{
+		MatchLabels: podLabels,
+	}
+	if _, err := metav1.LabelSelectorAsSelector(podSelector); err != nil {
+		return nil, errors.Wrapf(err, "invalid target-pod-labels of service: %v", svcKey)
+	}
+	return podSelector, nil
 }
@@ -10,7 +10,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
@@ -194,11 +197,81 @@ func Test_defaultModelBuildTask_buildTargetGroupPort(t *testing.T) {
 	}
 }
 
+func Test_defaultModelBuildTask_buildTargetGroupProtocol(t *testing.T) {
+	tests := []struct {
+		name                 string
+		svcAndIngAnnotations map[string]string
+		want                 elbv2model.Protocol
+		wantErr              error
+	}{
+		{
+			name:                 "defaults to HTTP",
+			svcAndIngAnnotations: map[string]string{},
+			want:                 elbv2model.ProtocolHTTP,
+		},
+		{
+			name: "backend-protocol is used when target-group-protocol is absent",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-protocol": "HTTPS",
+			},
+			want: elbv2model.ProtocolHTTPS,
+		},
+		{
+			name: "target-group-protocol overrides backend-protocol",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-protocol":      "HTTPS",
+				"alb.ingress.kubernetes.io/target-group-protocol": "HTTP",
+			},
+			want: elbv2model.ProtocolHTTP,
+		},
+		{
+			name: "target-group-protocol is used regardless of invalid backend-protocol",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-protocol":      "TCP",
+				"alb.ingress.kubernetes.io/target-group-protocol": "HTTPS",
+			},
+			want: elbv2model.ProtocolHTTPS,
+		},
+		{
+			name: "target-group-protocol invalid for application load balancers",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-protocol": "TCP",
+			},
+			wantErr: errors.New("target group protocol must be within [HTTP, HTTPS] for application load balancers: TCP"),
+		},
+		{
+			name: "backend-protocol invalid",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-protocol": "TLS",
+			},
+			wantErr: errors.New("backend protocol must be within [HTTP, HTTPS]: TLS"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser:       annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				defaultBackendProtocol: elbv2model.ProtocolHTTP,
+			}
+			got, err := task.buildTargetGroupProtocol(context.Background(), tt.svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 	type fields struct {
-		defaultTags map[string]string
+		defaultTags             map[string]string
+		resourceNamespaceTagKey string
+		resourceNameTagKey      string
 	}
 	type args struct {
+		ing                  *networking.Ingress
 		svcAndIngAnnotations map[string]string
 	}
 	tests := []struct {
@@ -214,6 +287,7 @@ func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 				defaultTags: nil,
 			},
 			args: args{
+				ing:                  &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-ing"}},
 				svcAndIngAnnotations: map[string]string{},
 			},
 			want: map[string]string{},
@@ -224,6 +298,7 @@ func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 				defaultTags: nil,
 			},
 			args: args{
+				ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-ing"}},
 				svcAndIngAnnotations: map[string]string{
 					"alb.ingress.kubernetes.io/tags": "k1=v1,k2=v2",
 				},
@@ -242,6 +317,7 @@ func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 				},
 			},
 			args: args{
+				ing:                  &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-ing"}},
 				svcAndIngAnnotations: map[string]string{},
 			},
 			want: map[string]string{
@@ -258,6 +334,7 @@ func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 				},
 			},
 			args: args{
+				ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-ing"}},
 				svcAndIngAnnotations: map[string]string{
 					"alb.ingress.kubernetes.io/tags": "k1=v1,k2=v2,k3=v3a",
 				},
@@ -269,14 +346,38 @@ func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 				"k4": "v4",
 			},
 		},
+		{
+			name: "non-empty resource metadata tag keys",
+			fields: fields{
+				defaultTags: map[string]string{
+					"k3": "v3",
+				},
+				resourceNamespaceTagKey: "elbv2.k8s.aws/namespace",
+				resourceNameTagKey:      "elbv2.k8s.aws/resource",
+			},
+			args: args{
+				ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-ing"}},
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/tags": "k1=v1,elbv2.k8s.aws/resource=other-ing",
+				},
+			},
+			want: map[string]string{
+				"k1":                      "v1",
+				"k3":                      "v3",
+				"elbv2.k8s.aws/namespace": "awesome-ns",
+				"elbv2.k8s.aws/resource":  "awesome-ing",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &defaultModelBuildTask{
-				defaultTags:      tt.fields.defaultTags,
-				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				defaultTags:             tt.fields.defaultTags,
+				resourceNamespaceTagKey: tt.fields.resourceNamespaceTagKey,
+				resourceNameTagKey:      tt.fields.resourceNameTagKey,
+				annotationParser:        annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
 			}
-			got, err := task.buildTargetGroupTags(context.Background(), tt.args.svcAndIngAnnotations)
+			got, err := task.buildTargetGroupTags(context.Background(), tt.args.ing, tt.args.svcAndIngAnnotations)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -404,10 +505,11 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 		tgProtocolVersion    elbv2model.ProtocolVersion
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   elbv2model.HealthCheckMatcher
+		name    string
+		fields  fields
+		args    args
+		want    elbv2model.HealthCheckMatcher
+		wantErr error
 	}{
 		{
 			name: "HTTP1, without annotation configured",
@@ -499,6 +601,94 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 				GRPCCode: awssdk.String("0"),
 			},
 		},
+		{
+			name: "HTTP1, with multiple codes configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200,301-302,404",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200,301-302,404"),
+			},
+		},
+		{
+			name: "GRPC, with range configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "0-99",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("0-99"),
+			},
+		},
+		{
+			name: "GRPC, with HTTP codes configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200-300",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("invalid success-codes for backend protocol version GRPC, gRPC codes must be within [0-99]: code 200 is out of range"),
+		},
+		{
+			name: "HTTP1, with gRPC codes configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "0",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("invalid success-codes for backend protocol version HTTP1, HTTP codes must be within [200-499]: code 0 is out of range"),
+		},
+		{
+			name: "HTTP2, with malformed codes configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200-2xx",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP2,
+			},
+			wantErr: errors.New("invalid success-codes for backend protocol version HTTP2, HTTP codes must be within [200-499]: invalid code \"2xx\""),
+		},
+		{
+			name: "HTTP1, with reversed range configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "299-200",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("invalid success-codes for backend protocol version HTTP1, HTTP codes must be within [200-499]: invalid code range \"299-200\", lower bound must not be greater than upper bound"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -507,8 +697,134 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 				defaultHealthCheckMatcherHTTPCode: tt.fields.defaultHealthCheckMatcherHTTPCode,
 				defaultHealthCheckMatcherGRPCCode: tt.fields.defaultHealthCheckMatcherGRPCCode,
 			}
-			got := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
-			assert.Equal(t, tt.want, got)
+			got, err := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupSpec_mixedProtocolVersions(t *testing.T) {
+	httpSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "http-svc",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+	grpcSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "grpc-svc",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-protocol-version": "GRPC",
+				"alb.ingress.kubernetes.io/success-codes":            "0-99",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Port:       50051,
+					TargetPort: intstr.FromInt(50051),
+				},
+			},
+		},
+	}
+	type wantMatcher struct {
+		protocolVersion elbv2model.ProtocolVersion
+		healthCheckPath string
+		matcher         elbv2model.HealthCheckMatcher
+	}
+	tests := []struct {
+		name           string
+		ingAnnotations map[string]string
+		svc            *corev1.Service
+		port           intstr.IntOrString
+		want           wantMatcher
+	}{
+		{
+			name: "HTTP backend uses Ingress success codes as httpCode",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/success-codes": "200-399",
+			},
+			svc:  httpSvc,
+			port: intstr.FromInt(80),
+			want: wantMatcher{
+				protocolVersion: elbv2model.ProtocolVersionHTTP1,
+				healthCheckPath: "/",
+				matcher: elbv2model.HealthCheckMatcher{
+					HTTPCode: awssdk.String("200-399"),
+				},
+			},
+		},
+		{
+			name: "GRPC backend in same Ingress uses Service success codes as grpcCode",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/success-codes": "200-399",
+			},
+			svc:  grpcSvc,
+			port: intstr.FromInt(50051),
+			want: wantMatcher{
+				protocolVersion: elbv2model.ProtocolVersionGRPC,
+				healthCheckPath: "/AWS.ALB/healthcheck",
+				matcher: elbv2model.HealthCheckMatcher{
+					GRPCCode: awssdk.String("0-99"),
+				},
+			},
+		},
+		{
+			name:           "HTTP backend uses default httpCode",
+			ingAnnotations: nil,
+			svc:            httpSvc,
+			port:           intstr.FromInt(80),
+			want: wantMatcher{
+				protocolVersion: elbv2model.ProtocolVersionHTTP1,
+				healthCheckPath: "/",
+				matcher: elbv2model.HealthCheckMatcher{
+					HTTPCode: awssdk.String("200"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser:                          annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				defaultTargetType:                         elbv2model.TargetTypeIP,
+				defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
+				defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
+				defaultHealthCheckPathHTTP:                "/",
+				defaultHealthCheckPathGRPC:                "/AWS.ALB/healthcheck",
+				defaultHealthCheckIntervalSeconds:         15,
+				defaultHealthCheckTimeoutSeconds:          5,
+				defaultHealthCheckHealthyThresholdCount:   2,
+				defaultHealthCheckUnhealthyThresholdCount: 2,
+				defaultHealthCheckMatcherHTTPCode:         "200",
+				defaultHealthCheckMatcherGRPCCode:         "12",
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "ing-1",
+					Annotations: tt.ingAnnotations,
+				},
+			}
+			got, err := task.buildTargetGroupSpec(context.Background(), ing, tt.svc, tt.port)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.protocolVersion, *got.ProtocolVersion)
+			assert.Equal(t, tt.want.healthCheckPath, *got.HealthCheckConfig.Path)
+			assert.Equal(t, tt.want.matcher, *got.HealthCheckConfig.Matcher)
 		})
 	}
 }
@@ -619,6 +935,26 @@ func Test_defaultModelBuildTask_buildTargetGroupBindingNodeSelector(t *testing.T
 			},
 			wantErr: errors.New("failed to parse stringMap annotation, alb.ingress.kubernetes.io/target-node-labels: key1"),
 		},
+		{
+			name: "invalid label",
+			fields: fields{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-node-labels": "node.label/key1=invalid value",
+						},
+					},
+				},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-svc",
+					},
+				},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			wantErr: errors.New("invalid target-node-labels of service: awesome-ns/awesome-svc: invalid label value: \"invalid value\": at key: \"node.label/key1\": a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -635,3 +971,356 @@ func Test_defaultModelBuildTask_buildTargetGroupBindingNodeSelector(t *testing.T
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingPodSelector(t *testing.T) {
+	type fields struct {
+		svc        *corev1.Service
+		targetType elbv2model.TargetType
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *metav1.LabelSelector
+		wantErr error
+	}{
+		{
+			name: "no annotation",
+			fields: fields{
+				svc: &corev1.Service{
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "awesome"},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: nil,
+		},
+		{
+			name: "service has annotation",
+			fields: fields{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "awesome-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-pod-labels": "role=web, pod.label/tier=frontend",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "awesome"},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app":            "awesome",
+					"role":           "web",
+					"pod.label/tier": "frontend",
+				},
+			},
+		},
+		{
+			name: "service has annotation matching service selector",
+			fields: fields{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "awesome-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-pod-labels": "app=awesome, role=web",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "awesome"},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app":  "awesome",
+					"role": "web",
+				},
+			},
+		},
+		{
+			name: "service annotation conflicts with service selector",
+			fields: fields{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "awesome-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-pod-labels": "app=other",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "awesome"},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("target-pod-labels app=other conflicts with selector app=awesome of service: namespace/awesome-svc"),
+		},
+		{
+			name: "service without selector",
+			fields: fields{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "awesome-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-pod-labels": "role=web",
+						},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("target-pod-labels is only supported for service with selector, service: namespace/awesome-svc"),
+		},
+		{
+			name: "target type instance",
+			fields: fields{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "awesome-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-pod-labels": "role=web",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "awesome"},
+					},
+				},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			wantErr: errors.New("target-pod-labels is only supported for ip targetType, service: namespace/awesome-svc"),
+		},
+		{
+			name: "invalid label value",
+			fields: fields{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "awesome-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-pod-labels": "role=web/app",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "awesome"},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("invalid target-pod-labels of service: namespace/awesome-svc: invalid label value: \"web/app\": at key: \"role\": a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupBindingPodSelector(context.Background(), tt.fields.svc, tt.fields.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingSkipRecreation(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		svc     *corev1.Service
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "no annotation",
+			ing:  &networking.Ingress{},
+			svc:  &corev1.Service{},
+			want: false,
+		},
+		{
+			name: "ingress opts out recreation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/recreate-target-group-binding": "false",
+					},
+				},
+			},
+			svc:  &corev1.Service{},
+			want: true,
+		},
+		{
+			name: "service annotation overrides ingress",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/recreate-target-group-binding": "false",
+					},
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/recreate-target-group-binding": "true",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "annotation parse error",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/recreate-target-group-binding": "no",
+					},
+				},
+			},
+			svc:     &corev1.Service{},
+			wantErr: errors.New("failed to parse bool annotation, alb.ingress.kubernetes.io/recreate-target-group-binding: no: strconv.ParseBool: parsing \"no\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupBindingSkipRecreation(context.Background(), tt.ing, tt.svc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingNetworking(t *testing.T) {
+	tests := []struct {
+		name                        string
+		ing                         *networking.Ingress
+		withManagedSG               bool
+		defaultManageBackendSGRules bool
+		wantNetworking              bool
+		wantEvent                   bool
+		wantErr                     error
+	}{
+		{
+			name: "without managed securityGroup",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			withManagedSG:               false,
+			defaultManageBackendSGRules: true,
+			wantNetworking:              false,
+		},
+		{
+			name: "backend securityGroup rules managed by default",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			withManagedSG:               true,
+			defaultManageBackendSGRules: true,
+			wantNetworking:              true,
+		},
+		{
+			name: "backend securityGroup rules disabled by annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/manage-backend-security-group-rules": "false",
+					},
+				},
+			},
+			withManagedSG:               true,
+			defaultManageBackendSGRules: true,
+			wantNetworking:              false,
+			wantEvent:                   true,
+		},
+		{
+			name: "backend securityGroup rules unmanaged by default",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			withManagedSG:               true,
+			defaultManageBackendSGRules: false,
+			wantNetworking:              false,
+			wantEvent:                   true,
+		},
+		{
+			name: "backend securityGroup rules enabled by annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/manage-backend-security-group-rules": "true",
+					},
+				},
+			},
+			withManagedSG:               true,
+			defaultManageBackendSGRules: false,
+			wantNetworking:              true,
+		},
+		{
+			name: "invalid annotation value",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/manage-backend-security-group-rules": "maybe",
+					},
+				},
+			},
+			withManagedSG:               true,
+			defaultManageBackendSGRules: true,
+			wantErr:                     errors.New("failed to parse bool annotation, alb.ingress.kubernetes.io/manage-backend-security-group-rules: maybe: strconv.ParseBool: parsing \"maybe\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				annotationParser:            annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				eventRecorder:               eventRecorder,
+				defaultManageBackendSGRules: tt.defaultManageBackendSGRules,
+			}
+			if tt.withManagedSG {
+				task.managedSG = ec2model.NewSecurityGroup(stack, resourceIDManagedSecurityGroup, ec2model.SecurityGroupSpec{})
+			}
+			got, err := task.buildTargetGroupBindingNetworking(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantNetworking {
+				assert.NotNil(t, got)
+				assert.Len(t, got.Ingress, 1)
+			} else {
+				assert.Nil(t, got)
+			}
+			if tt.wantEvent {
+				assert.Len(t, eventRecorder.Events, 1)
+			} else {
+				assert.Len(t, eventRecorder.Events, 0)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
@@ -33,27 +34,37 @@ type ModelBuilder interface {
 // NewDefaultModelBuilder constructs new defaultModelBuilder.
 func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventRecorder,
 	ec2Client services.EC2, acmClient services.ACM,
-	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver,
+	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver, sslPolicyValidator networkingpkg.SSLPolicyValidator,
 	authConfigBuilder AuthConfigBuilder, enhancedBackendBuilder EnhancedBackendBuilder,
-	vpcID string, clusterName string, defaultTags map[string]string, defaultSSLPolicy string,
-	logger logr.Logger) *defaultModelBuilder {
-	certDiscovery := NewACMCertDiscovery(acmClient, logger)
+	vpcID string, clusterName string, defaultTags map[string]string, defaultSSLPolicy string, defaultTargetType elbv2model.TargetType,
+	resourceNamespaceTagKey string, resourceNameTagKey string, lbAttributesMergeStrategy string, manageBackendSGRules bool,
+	rejectEmptyListeners bool, skipEmptyListeners bool, defaultCertStrategy string, missingBackendServicePolicy string, logger logr.Logger) *defaultModelBuilder {
+	certDiscovery := NewACMCertDiscovery(acmClient, defaultCertStrategy, logger)
 	ruleOptimizer := NewDefaultRuleOptimizer(logger)
 	return &defaultModelBuilder{
-		k8sClient:              k8sClient,
-		eventRecorder:          eventRecorder,
-		ec2Client:              ec2Client,
-		vpcID:                  vpcID,
-		clusterName:            clusterName,
-		annotationParser:       annotationParser,
-		subnetsResolver:        subnetsResolver,
-		certDiscovery:          certDiscovery,
-		authConfigBuilder:      authConfigBuilder,
-		enhancedBackendBuilder: enhancedBackendBuilder,
-		ruleOptimizer:          ruleOptimizer,
-		defaultTags:            defaultTags,
-		defaultSSLPolicy:       defaultSSLPolicy,
-		logger:                 logger,
+		k8sClient:                   k8sClient,
+		eventRecorder:               eventRecorder,
+		ec2Client:                   ec2Client,
+		vpcID:                       vpcID,
+		clusterName:                 clusterName,
+		annotationParser:            annotationParser,
+		subnetsResolver:             subnetsResolver,
+		sslPolicyValidator:          sslPolicyValidator,
+		certDiscovery:               certDiscovery,
+		authConfigBuilder:           authConfigBuilder,
+		enhancedBackendBuilder:      enhancedBackendBuilder,
+		ruleOptimizer:               ruleOptimizer,
+		defaultTags:                 defaultTags,
+		defaultSSLPolicy:            defaultSSLPolicy,
+		defaultTargetType:           defaultTargetType,
+		resourceNamespaceTagKey:     resourceNamespaceTagKey,
+		resourceNameTagKey:          resourceNameTagKey,
+		orderedLBAttributesMerge:    lbAttributesMergeStrategy == config.LBAttributesMergeStrategyOrdered,
+		manageBackendSGRules:        manageBackendSGRules,
+		rejectEmptyListeners:        rejectEmptyListeners,
+		skipEmptyListeners:          skipEmptyListeners,
+		skipPathsWithMissingService: missingBackendServicePolicy == config.MissingBackendServicePolicyLenient,
+		logger:                      logger,
 	}
 }
 
@@ -70,12 +81,28 @@ type defaultModelBuilder struct {
 
 	annotationParser       annotations.Parser
 	subnetsResolver        networkingpkg.SubnetsResolver
+	sslPolicyValidator     networkingpkg.SSLPolicyValidator
 	certDiscovery          CertDiscovery
 	authConfigBuilder      AuthConfigBuilder
 	enhancedBackendBuilder EnhancedBackendBuilder
 	ruleOptimizer          RuleOptimizer
 	defaultTags            map[string]string
 	defaultSSLPolicy       string
+	defaultTargetType      elbv2model.TargetType
+
+	resourceNamespaceTagKey string
+	resourceNameTagKey      string
+
+	// whether conflicting load balancer attributes within IngressGroup are resolved by group order instead of rejected.
+	orderedLBAttributesMerge bool
+	// whether inbound rules on backend securityGroups are managed by default.
+	manageBackendSGRules bool
+	// whether listeners without rules and default backend are rejected instead of responding 404.
+	rejectEmptyListeners bool
+	// whether listeners without rules and default backend are omitted instead of responding 404.
+	skipEmptyListeners bool
+	// whether paths with missing backend Services are skipped instead of failing the IngressGroup.
+	skipPathsWithMissingService bool
 
 	logger logr.Logger
 }
@@ -91,6 +118,7 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		clusterName:            b.clusterName,
 		annotationParser:       b.annotationParser,
 		subnetsResolver:        b.subnetsResolver,
+		sslPolicyValidator:     b.sslPolicyValidator,
 		certDiscovery:          b.certDiscovery,
 		authConfigBuilder:      b.authConfigBuilder,
 		enhancedBackendBuilder: b.enhancedBackendBuilder,
@@ -104,7 +132,7 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		defaultIPAddressType:                      elbv2model.IPAddressTypeIPV4,
 		defaultScheme:                             elbv2model.LoadBalancerSchemeInternal,
 		defaultSSLPolicy:                          b.defaultSSLPolicy,
-		defaultTargetType:                         elbv2model.TargetTypeInstance,
+		defaultTargetType:                         b.defaultTargetType,
 		defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
 		defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
 		defaultHealthCheckPathHTTP:                "/",
@@ -115,9 +143,18 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		defaultHealthCheckUnhealthyThresholdCount: 2,
 		defaultHealthCheckMatcherHTTPCode:         "200",
 		defaultHealthCheckMatcherGRPCCode:         "12",
-
-		loadBalancer: nil,
-		tgByResID:    make(map[string]*elbv2model.TargetGroup),
+		defaultManageBackendSGRules:               b.manageBackendSGRules,
+
+		resourceNamespaceTagKey:     b.resourceNamespaceTagKey,
+		resourceNameTagKey:          b.resourceNameTagKey,
+		orderedLBAttributesMerge:    b.orderedLBAttributesMerge,
+		rejectEmptyListeners:        b.rejectEmptyListeners,
+		skipEmptyListeners:          b.skipEmptyListeners,
+		skipPathsWithMissingService: b.skipPathsWithMissingService,
+
+		loadBalancer:             nil,
+		tgByResID:                make(map[string]*elbv2model.TargetGroup),
+		tgHealthCheckPathByResID: make(map[string]string),
 	}
 	if err := task.run(ctx); err != nil {
 		return nil, nil, err
@@ -134,6 +171,7 @@ type defaultModelBuildTask struct {
 	clusterName            string
 	annotationParser       annotations.Parser
 	subnetsResolver        networkingpkg.SubnetsResolver
+	sslPolicyValidator     networkingpkg.SSLPolicyValidator
 	certDiscovery          CertDiscovery
 	authConfigBuilder      AuthConfigBuilder
 	enhancedBackendBuilder EnhancedBackendBuilder
@@ -159,10 +197,26 @@ type defaultModelBuildTask struct {
 	defaultHealthCheckUnhealthyThresholdCount int64
 	defaultHealthCheckMatcherHTTPCode         string
 	defaultHealthCheckMatcherGRPCCode         string
+	defaultManageBackendSGRules               bool
+
+	// tag keys used to record the owning Kubernetes resource on AWS resources, empty means disabled.
+	resourceNamespaceTagKey string
+	resourceNameTagKey      string
+
+	// whether conflicting load balancer attributes within IngressGroup are resolved by group order instead of rejected.
+	orderedLBAttributesMerge bool
+	// whether listeners without rules and default backend are rejected instead of responding 404.
+	rejectEmptyListeners bool
+	// whether listeners without rules and default backend are omitted instead of responding 404.
+	skipEmptyListeners bool
+	// whether paths with missing backend Services are skipped instead of failing the IngressGroup.
+	skipPathsWithMissingService bool
 
 	loadBalancer *elbv2model.LoadBalancer
 	managedSG    *ec2model.SecurityGroup
 	tgByResID    map[string]*elbv2model.TargetGroup
+	// health check paths overridden via actions, keyed by targetGroup's resourceID.
+	tgHealthCheckPathByResID map[string]string
 }
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
@@ -196,12 +250,21 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 		listenPortConfigByPort[port] = mergedCfg
 	}
 
-	lb, err := t.buildLoadBalancer(ctx, listenPortConfigByPort)
+	var err error
+	t.sslRedirectConfig, err = t.buildSSLRedirectConfig(ctx, listenPortConfigByPort)
 	if err != nil {
 		return err
 	}
+	if t.skipEmptyListeners {
+		for port, cfg := range listenPortConfigByPort {
+			if t.isEmptyListener(port, cfg.protocol, ingListByPort[port]) {
+				t.logger.V(1).Info("skipping listener without rules", "port", port)
+				delete(listenPortConfigByPort, port)
+			}
+		}
+	}
 
-	t.sslRedirectConfig, err = t.buildSSLRedirectConfig(ctx, listenPortConfigByPort)
+	lb, err := t.buildLoadBalancer(ctx, listenPortConfigByPort)
 	if err != nil {
 		return err
 	}
@@ -235,10 +298,12 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 
 	var mergedTLSCerts []string
 	mergedTLSCertsSet := sets.NewString()
+	mergedTLSCertsDiscovered := true
 
-	for _, cfg := range listenPortConfigs {
+	for i := range listenPortConfigs {
+		cfg := listenPortConfigs[i]
 		if mergedProtocolProvider == nil {
-			mergedProtocolProvider = &cfg.ingKey
+			mergedProtocolProvider = &listenPortConfigs[i].ingKey
 			mergedProtocol = cfg.listenPortConfig.protocol
 		} else if mergedProtocol != cfg.listenPortConfig.protocol {
 			return listenPortConfig{}, errors.Errorf("conflicting protocol, %v: %v | %v: %v",
@@ -249,7 +314,7 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 			cfgInboundCIDRv4s := sets.NewString(cfg.listenPortConfig.inboundCIDRv4s...)
 			cfgInboundCIDRv6s := sets.NewString(cfg.listenPortConfig.inboundCIDRv6s...)
 			if mergedInboundCIDRsProvider == nil {
-				mergedInboundCIDRsProvider = &cfg.ingKey
+				mergedInboundCIDRsProvider = &listenPortConfigs[i].ingKey
 				mergedInboundCIDRv4s = cfgInboundCIDRv4s
 				mergedInboundCIDRv6s = cfgInboundCIDRv6s
 			} else if !mergedInboundCIDRv4s.Equal(cfgInboundCIDRv4s) || !mergedInboundCIDRv6s.Equal(cfgInboundCIDRv6s) {
@@ -260,7 +325,7 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 
 		if cfg.listenPortConfig.sslPolicy != nil {
 			if mergedSSLPolicyProvider == nil {
-				mergedSSLPolicyProvider = &cfg.ingKey
+				mergedSSLPolicyProvider = &listenPortConfigs[i].ingKey
 				mergedSSLPolicy = cfg.listenPortConfig.sslPolicy
 			} else if awssdk.StringValue(mergedSSLPolicy) != awssdk.StringValue(cfg.listenPortConfig.sslPolicy) {
 				return listenPortConfig{}, errors.Errorf("conflicting sslPolicy, %v: %v | %v: %v",
@@ -268,6 +333,9 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 			}
 		}
 
+		if !cfg.listenPortConfig.tlsCertsDiscovered {
+			mergedTLSCertsDiscovered = false
+		}
 		for _, cert := range cfg.listenPortConfig.tlsCerts {
 			if mergedTLSCertsSet.Has(cert) {
 				continue
@@ -291,6 +359,8 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 		inboundCIDRv6s: mergedInboundCIDRv6s.List(),
 		sslPolicy:      mergedSSLPolicy,
 		tlsCerts:       mergedTLSCerts,
+
+		tlsCertsDiscovered: mergedTLSCertsDiscovered && len(mergedTLSCerts) != 0,
 	}, nil
 }
 
@@ -11,6 +11,7 @@ import (
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -227,7 +228,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"k8s ELB ns-1/ing-1"
                                 }
                             ]
                         }
@@ -677,7 +679,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"k8s ELB ns-1/ing-1"
                                 }
                             ]
                         }
@@ -1128,7 +1131,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":443,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"k8s ELB ns-1/ing-1"
                                 }
                             ]
                         }
@@ -1571,7 +1575,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"k8s ELB ns-1/ing-1"
                                 }
                             ]
                         }
@@ -1859,7 +1864,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 
 			certDiscovery := NewMockCertDiscovery(ctrl)
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
-			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser, nil)
 			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(annotationParser)
 			ruleOptimizer := NewDefaultRuleOptimizer(&log.NullLogger{})
 
@@ -1879,7 +1884,9 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 				ruleOptimizer:          ruleOptimizer,
 				logger:                 &log.NullLogger{},
 
-				defaultSSLPolicy: "ELBSecurityPolicy-2016-08",
+				defaultSSLPolicy:     "ELBSecurityPolicy-2016-08",
+				defaultTargetType:    elbv2model.TargetTypeInstance,
+				manageBackendSGRules: true,
 			}
 
 			gotStack, _, err := b.Build(context.Background(), tt.args.ingGroup)
@@ -2460,3 +2467,58 @@ func Test_defaultModelBuildTask_buildSSLRedirectConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_mergeListenPortConfigs(t *testing.T) {
+	tests := []struct {
+		name              string
+		listenPortConfigs []listenPortConfigWithIngress
+		want              listenPortConfig
+		wantErr           error
+	}{
+		{
+			name: "same protocol from multiple Ingresses",
+			listenPortConfigs: []listenPortConfigWithIngress{
+				{
+					ingKey:           types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"},
+					listenPortConfig: listenPortConfig{protocol: elbv2model.ProtocolHTTP},
+				},
+				{
+					ingKey:           types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"},
+					listenPortConfig: listenPortConfig{protocol: elbv2model.ProtocolHTTP},
+				},
+			},
+			want: listenPortConfig{
+				protocol:           elbv2model.ProtocolHTTP,
+				inboundCIDRv4s:     []string{"0.0.0.0/0"},
+				inboundCIDRv6s:     []string{"::/0"},
+				tlsCertsDiscovered: false,
+			},
+		},
+		{
+			name: "conflicting protocols from multiple Ingresses",
+			listenPortConfigs: []listenPortConfigWithIngress{
+				{
+					ingKey:           types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"},
+					listenPortConfig: listenPortConfig{protocol: elbv2model.ProtocolHTTP},
+				},
+				{
+					ingKey:           types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"},
+					listenPortConfig: listenPortConfig{protocol: elbv2model.ProtocolHTTPS},
+				},
+			},
+			wantErr: errors.New("conflicting protocol, awesome-ns/ing-1: HTTP | awesome-ns/ing-2: HTTPS"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{}
+			got, err := task.mergeListenPortConfigs(context.Background(), tt.listenPortConfigs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
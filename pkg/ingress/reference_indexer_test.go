@@ -250,7 +250,7 @@ func Test_defaultReferenceIndexer_BuildServiceRefIndexes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
-			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser, nil)
 			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(annotationParser)
 			i := &defaultReferenceIndexer{
 				enhancedBackendBuilder: enhancedBackendBuilder,
@@ -301,7 +301,7 @@ func Test_defaultReferenceIndexer_BuildSecretRefIndexes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
-			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser, nil)
 			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(annotationParser)
 			i := &defaultReferenceIndexer{
 				enhancedBackendBuilder: enhancedBackendBuilder,
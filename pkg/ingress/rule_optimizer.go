@@ -5,10 +5,17 @@ import (
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
+const (
+	// maximum number of condition values per condition and per rule, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-listeners.html#rule-condition-types
+	maxConditionValuesPerCondition = 3
+	maxConditionValuesPerRule      = 5
+)
+
 type Rule struct {
 	Conditions []elbv2model.RuleCondition
 	Actions    []elbv2model.Action
@@ -39,6 +46,7 @@ var _ RuleOptimizer = &defaultRuleOptimizer{}
 //   * It will omit any redirect rules that would result in a infinite redirect loop.
 //   * it will omit any rules that take priority by a redirect rule with a super set of conditions
 //  	(ideally this could applies to other action type as well, but we only consider redirect action for now)
+//   * it will split any rules that exceed the condition values limit into multiple rules with same actions.
 type defaultRuleOptimizer struct {
 	logger logr.Logger
 }
@@ -46,7 +54,7 @@ type defaultRuleOptimizer struct {
 func (o *defaultRuleOptimizer) Optimize(_ context.Context, port int64, protocol elbv2model.Protocol, rules []Rule) ([]Rule, error) {
 	optimizedRules := o.omitInfiniteRedirectRules(port, protocol, rules)
 	optimizedRules = o.omitOvershadowedRulesAfterRedirectRules(optimizedRules)
-	return optimizedRules, nil
+	return o.splitRulesExceedingConditionValuesLimit(optimizedRules)
 }
 
 func (o *defaultRuleOptimizer) omitInfiniteRedirectRules(port int64, protocol elbv2model.Protocol, rules []Rule) []Rule {
@@ -83,6 +91,127 @@ func (o *defaultRuleOptimizer) omitOvershadowedRulesAfterRedirectRules(rules []R
 	return optimizedRules
 }
 
+func (o *defaultRuleOptimizer) splitRulesExceedingConditionValuesLimit(rules []Rule) ([]Rule, error) {
+	var optimizedRules []Rule
+	for _, rule := range rules {
+		splitRules, err := splitRuleByConditionValues(rule, maxConditionValuesPerCondition, maxConditionValuesPerRule)
+		if err != nil {
+			return nil, err
+		}
+		if len(splitRules) > 1 {
+			o.logger.V(1).Info("split rule exceeding condition values limit", "conditions", rule.Conditions, "rules", len(splitRules))
+		}
+		optimizedRules = append(optimizedRules, splitRules...)
+	}
+	return optimizedRules, nil
+}
+
+// splitRuleByConditionValues splits rule into multiple rules with same actions and tags so that each condition has at most maxValuesPerCondition values,
+// and each rule has at most maxValuesPerRule values.
+// Values within a condition are ORed, so splitting the values of one condition across rules keeps the semantic of the original rule.
+func splitRuleByConditionValues(rule Rule, maxValuesPerCondition int, maxValuesPerRule int) ([]Rule, error) {
+	totalValues := 0
+	largestConditionIdx := -1
+	for idx, condition := range rule.Conditions {
+		valuesCount := conditionValuesCount(condition)
+		totalValues += valuesCount
+		if valuesCount > 1 && (largestConditionIdx == -1 || valuesCount > conditionValuesCount(rule.Conditions[largestConditionIdx])) {
+			largestConditionIdx = idx
+		}
+	}
+	if largestConditionIdx == -1 {
+		if totalValues <= maxValuesPerRule {
+			return []Rule{rule}, nil
+		}
+		return nil, errors.Errorf("rule conditions have %v values, exceeds limit %v", totalValues, maxValuesPerRule)
+	}
+	largestCondition := rule.Conditions[largestConditionIdx]
+	largestConditionValues := conditionValuesCount(largestCondition)
+	if totalValues <= maxValuesPerRule && largestConditionValues <= maxValuesPerCondition {
+		return []Rule{rule}, nil
+	}
+
+	chunkSize := maxValuesPerRule - (totalValues - largestConditionValues)
+	if chunkSize > maxValuesPerCondition {
+		chunkSize = maxValuesPerCondition
+	}
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	var splitRules []Rule
+	for start := 0; start < largestConditionValues; start += chunkSize {
+		end := start + chunkSize
+		if end > largestConditionValues {
+			end = largestConditionValues
+		}
+		conditions := make([]elbv2model.RuleCondition, len(rule.Conditions))
+		copy(conditions, rule.Conditions)
+		conditions[largestConditionIdx] = sliceConditionValues(largestCondition, start, end)
+		chunkRules, err := splitRuleByConditionValues(Rule{
+			Conditions: conditions,
+			Actions:    rule.Actions,
+			Tags:       rule.Tags,
+		}, maxValuesPerCondition, maxValuesPerRule)
+		if err != nil {
+			return nil, err
+		}
+		splitRules = append(splitRules, chunkRules...)
+	}
+	return splitRules, nil
+}
+
+// conditionValuesCount returns the number of values within rule condition.
+func conditionValuesCount(condition elbv2model.RuleCondition) int {
+	switch {
+	case condition.HostHeaderConfig != nil:
+		return len(condition.HostHeaderConfig.Values)
+	case condition.HTTPHeaderConfig != nil:
+		return len(condition.HTTPHeaderConfig.Values)
+	case condition.HTTPRequestMethodConfig != nil:
+		return len(condition.HTTPRequestMethodConfig.Values)
+	case condition.PathPatternConfig != nil:
+		return len(condition.PathPatternConfig.Values)
+	case condition.QueryStringConfig != nil:
+		return len(condition.QueryStringConfig.Values)
+	case condition.SourceIPConfig != nil:
+		return len(condition.SourceIPConfig.Values)
+	}
+	return 0
+}
+
+// sliceConditionValues returns a copy of rule condition with values within [start, end).
+func sliceConditionValues(condition elbv2model.RuleCondition, start int, end int) elbv2model.RuleCondition {
+	slicedCondition := condition
+	switch {
+	case condition.HostHeaderConfig != nil:
+		slicedCondition.HostHeaderConfig = &elbv2model.HostHeaderConditionConfig{
+			Values: condition.HostHeaderConfig.Values[start:end],
+		}
+	case condition.HTTPHeaderConfig != nil:
+		slicedCondition.HTTPHeaderConfig = &elbv2model.HTTPHeaderConditionConfig{
+			HTTPHeaderName: condition.HTTPHeaderConfig.HTTPHeaderName,
+			Values:         condition.HTTPHeaderConfig.Values[start:end],
+		}
+	case condition.HTTPRequestMethodConfig != nil:
+		slicedCondition.HTTPRequestMethodConfig = &elbv2model.HTTPRequestMethodConditionConfig{
+			Values: condition.HTTPRequestMethodConfig.Values[start:end],
+		}
+	case condition.PathPatternConfig != nil:
+		slicedCondition.PathPatternConfig = &elbv2model.PathPatternConditionConfig{
+			Values: condition.PathPatternConfig.Values[start:end],
+		}
+	case condition.QueryStringConfig != nil:
+		slicedCondition.QueryStringConfig = &elbv2model.QueryStringConditionConfig{
+			Values: condition.QueryStringConfig.Values[start:end],
+		}
+	case condition.SourceIPConfig != nil:
+		slicedCondition.SourceIPConfig = &elbv2model.SourceIPConditionConfig{
+			Values: condition.SourceIPConfig.Values[start:end],
+		}
+	}
+	return slicedCondition
+}
+
 // isInfiniteRedirectRule checks whether specified rule will cause a infinite redirect loop.
 func isInfiniteRedirectRule(port int64, protocol elbv2model.Protocol, rule Rule) bool {
 	redirectActionCFG := findRedirectActionConfig(rule.Actions)
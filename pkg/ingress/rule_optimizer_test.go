@@ -3,6 +3,7 @@ package ingress
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -229,6 +230,89 @@ func Test_defaultRuleOptimizer_Optimize(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "rule exceeding condition values limit should be split",
+			args: args{
+				port:     443,
+				protocol: elbv2model.ProtocolHTTPS,
+				rules: []Rule{
+					{
+						Conditions: []elbv2model.RuleCondition{
+							{
+								Field: elbv2model.RuleConditionFieldHostHeader,
+								HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+									Values: []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com", "f.example.com"},
+								},
+							},
+							{
+								Field: elbv2model.RuleConditionFieldPathPattern,
+								PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+									Values: []string{"/app"},
+								},
+							},
+						},
+						Actions: []elbv2model.Action{
+							{
+								Type: elbv2model.ActionTypeFixedResponse,
+								FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+									StatusCode: "200",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []Rule{
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com", "c.example.com"},
+							},
+						},
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/app"},
+							},
+						},
+					},
+					Actions: []elbv2model.Action{
+						{
+							Type: elbv2model.ActionTypeFixedResponse,
+							FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+								StatusCode: "200",
+							},
+						},
+					},
+				},
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"d.example.com", "e.example.com", "f.example.com"},
+							},
+						},
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/app"},
+							},
+						},
+					},
+					Actions: []elbv2model.Action{
+						{
+							Type: elbv2model.ActionTypeFixedResponse,
+							FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+								StatusCode: "200",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -246,6 +330,201 @@ func Test_defaultRuleOptimizer_Optimize(t *testing.T) {
 	}
 }
 
+func Test_splitRuleByConditionValues(t *testing.T) {
+	actions := []elbv2model.Action{
+		{
+			Type: elbv2model.ActionTypeFixedResponse,
+			FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+				StatusCode: "200",
+			},
+		},
+	}
+	type args struct {
+		rule                  Rule
+		maxValuesPerCondition int
+		maxValuesPerRule      int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Rule
+		wantErr error
+	}{
+		{
+			name: "rule within limit",
+			args: args{
+				rule: Rule{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com"},
+							},
+						},
+					},
+					Actions: actions,
+				},
+				maxValuesPerCondition: 3,
+				maxValuesPerRule:      5,
+			},
+			want: []Rule{
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com"},
+							},
+						},
+					},
+					Actions: actions,
+				},
+			},
+		},
+		{
+			name: "rule with multiple conditions exceeding limit",
+			args: args{
+				rule: Rule{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com", "c.example.com"},
+							},
+						},
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/a", "/b", "/c"},
+							},
+						},
+					},
+					Actions: actions,
+					Tags:    map[string]string{"k": "v"},
+				},
+				maxValuesPerCondition: 3,
+				maxValuesPerRule:      5,
+			},
+			want: []Rule{
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com"},
+							},
+						},
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/a", "/b", "/c"},
+							},
+						},
+					},
+					Actions: actions,
+					Tags:    map[string]string{"k": "v"},
+				},
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"c.example.com"},
+							},
+						},
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/a", "/b", "/c"},
+							},
+						},
+					},
+					Actions: actions,
+					Tags:    map[string]string{"k": "v"},
+				},
+			},
+		},
+		{
+			name: "condition exceeding limit",
+			args: args{
+				rule: Rule{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"},
+							},
+						},
+					},
+					Actions: actions,
+				},
+				maxValuesPerCondition: 3,
+				maxValuesPerRule:      5,
+			},
+			want: []Rule{
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com", "c.example.com"},
+							},
+						},
+					},
+					Actions: actions,
+				},
+				{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"d.example.com"},
+							},
+						},
+					},
+					Actions: actions,
+				},
+			},
+		},
+		{
+			name: "rule with single value conditions exceeding limit",
+			args: args{
+				rule: Rule{
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com"},
+							},
+						},
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/a"},
+							},
+						},
+					},
+					Actions: actions,
+				},
+				maxValuesPerCondition: 3,
+				maxValuesPerRule:      1,
+			},
+			wantErr: errors.New("rule conditions have 2 values, exceeds limit 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitRuleByConditionValues(tt.args.rule, tt.args.maxValuesPerCondition, tt.args.maxValuesPerRule)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_isInfiniteRedirectRule(t *testing.T) {
 	type args struct {
 		port     int64
@@ -0,0 +1,94 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"strings"
+	"time"
+)
+
+const (
+	userPoolResourcePrefix = "userpool/"
+	// the domain of user pools rarely changes, cache for 10 minutes.
+	defaultUserPoolDomainCacheTTL = 10 * time.Minute
+)
+
+// UserPoolDomainResolver is responsible for resolving the domain of Cognito user pools.
+type UserPoolDomainResolver interface {
+	// Resolve returns the domain prefix or fully-qualified domain name of user pool.
+	Resolve(ctx context.Context, userPoolARN string) (string, error)
+}
+
+// NewCognitoUserPoolDomainResolver constructs new cognitoUserPoolDomainResolver
+func NewCognitoUserPoolDomainResolver(cognitoIDPClient services.CognitoIdentityProvider, logger logr.Logger) *cognitoUserPoolDomainResolver {
+	return &cognitoUserPoolDomainResolver{
+		cognitoIDPClient:    cognitoIDPClient,
+		logger:              logger,
+		userPoolDomainCache: cache.NewExpiring(),
+		userPoolDomainTTL:   defaultUserPoolDomainCacheTTL,
+	}
+}
+
+var _ UserPoolDomainResolver = &cognitoUserPoolDomainResolver{}
+
+// UserPoolDomainResolver implementation for Cognito user pools.
+type cognitoUserPoolDomainResolver struct {
+	cognitoIDPClient services.CognitoIdentityProvider
+	logger           logr.Logger
+
+	userPoolDomainCache *cache.Expiring
+	userPoolDomainTTL   time.Duration
+}
+
+func (r *cognitoUserPoolDomainResolver) Resolve(ctx context.Context, userPoolARN string) (string, error) {
+	if rawCacheItem, exists := r.userPoolDomainCache.Get(userPoolARN); exists {
+		return rawCacheItem.(string), nil
+	}
+	userPoolID, err := extractUserPoolID(userPoolARN)
+	if err != nil {
+		return "", err
+	}
+	req := &cognitoidentityprovider.DescribeUserPoolInput{
+		UserPoolId: awssdk.String(userPoolID),
+	}
+	resp, err := r.cognitoIDPClient.DescribeUserPoolWithContext(ctx, req)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe user pool %v", userPoolID)
+	}
+	// prefer the custom domain, which is what users see on the hosted UI if configured.
+	var domain string
+	if resp.UserPool != nil {
+		domain = awssdk.StringValue(resp.UserPool.CustomDomain)
+		if len(domain) == 0 {
+			domain = awssdk.StringValue(resp.UserPool.Domain)
+		}
+	}
+	if len(domain) == 0 {
+		return "", errors.Errorf("user pool %v has no domain configured", userPoolID)
+	}
+	r.logger.V(1).Info("resolved user pool domain", "userPoolARN", userPoolARN, "domain", domain)
+	r.userPoolDomainCache.Set(userPoolARN, domain, r.userPoolDomainTTL)
+	return domain, nil
+}
+
+// extractUserPoolID extracts the user pool ID from user pool ARN.
+func extractUserPoolID(userPoolARN string) (string, error) {
+	parsedARN, err := arn.Parse(userPoolARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid userPoolARN: %v", userPoolARN)
+	}
+	if parsedARN.Service != "cognito-idp" || !strings.HasPrefix(parsedARN.Resource, userPoolResourcePrefix) {
+		return "", errors.Errorf("invalid userPoolARN: %v", userPoolARN)
+	}
+	userPoolID := strings.TrimPrefix(parsedARN.Resource, userPoolResourcePrefix)
+	if len(userPoolID) == 0 {
+		return "", errors.Errorf("invalid userPoolARN: %v", userPoolARN)
+	}
+	return userPoolID, nil
+}
@@ -0,0 +1,65 @@
+package ingress
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_cognitoUserPoolDomainResolver_Resolve_cached(t *testing.T) {
+	userPoolARN := "arn:aws:cognito-idp:us-west-2:123456789012:userpool/us-west-2_abcdefghi"
+	r := &cognitoUserPoolDomainResolver{
+		logger:              &log.NullLogger{},
+		userPoolDomainCache: cache.NewExpiring(),
+		userPoolDomainTTL:   defaultUserPoolDomainCacheTTL,
+	}
+	r.userPoolDomainCache.Set(userPoolARN, "my-domain", r.userPoolDomainTTL)
+
+	got, err := r.Resolve(context.Background(), userPoolARN)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-domain", got)
+}
+
+func Test_extractUserPoolID(t *testing.T) {
+	tests := []struct {
+		name        string
+		userPoolARN string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "valid userPoolARN",
+			userPoolARN: "arn:aws:cognito-idp:us-west-2:123456789012:userpool/us-west-2_abcdefghi",
+			want:        "us-west-2_abcdefghi",
+		},
+		{
+			name:        "malformed userPoolARN",
+			userPoolARN: "us-west-2_abcdefghi",
+			wantErr:     errors.New("invalid userPoolARN: us-west-2_abcdefghi: arn: invalid prefix"),
+		},
+		{
+			name:        "non cognito-idp ARN",
+			userPoolARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+			wantErr:     errors.New("invalid userPoolARN: arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"),
+		},
+		{
+			name:        "userPoolARN without userPoolID",
+			userPoolARN: "arn:aws:cognito-idp:us-west-2:123456789012:userpool/",
+			wantErr:     errors.New("invalid userPoolARN: arn:aws:cognito-idp:us-west-2:123456789012:userpool/"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractUserPoolID(tt.userPoolARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -90,10 +91,21 @@ func (m *PodReadinessGate) computeTargetHealthReadinessGateConditionTypes(ctx co
 		} else {
 			svcSelector = labels.SelectorFromSet(svc.Spec.Selector)
 		}
-		if svcSelector.Matches(labels.Set(pod.Labels)) {
-			targetHealthCondType := targetgroupbinding.BuildTargetHealthPodConditionType(&tgb)
-			targetHealthCondTypes = append(targetHealthCondTypes, targetHealthCondType)
+		if !svcSelector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// pods excluded from registration by podSelector of TargetGroupBinding never become healthy targets.
+		if tgb.Spec.PodSelector != nil {
+			podSelector, err := metav1.LabelSelectorAsSelector(tgb.Spec.PodSelector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid podSelector of targetGroupBinding: %v", k8s.NamespacedName(&tgb))
+			}
+			if !podSelector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
 		}
+		targetHealthCondType := targetgroupbinding.BuildTargetHealthPodConditionType(&tgb)
+		targetHealthCondTypes = append(targetHealthCondTypes, targetHealthCondType)
 	}
 	return targetHealthCondTypes, nil
 }
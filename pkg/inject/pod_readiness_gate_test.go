@@ -112,6 +112,23 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 			},
 		},
 	}
+	tgb6 := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tgb-6-l6qw6",
+			Namespace: testNS1,
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType: &targetTypeIP,
+			ServiceRef: elbv2api.ServiceReference{
+				Name: svc1.Name,
+			},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"stable": "true",
+				},
+			},
+		},
+	}
 
 	tests := []struct {
 		name      string
@@ -165,6 +182,52 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 				EnablePodReadinessGateInject: true,
 			},
 		},
+		{
+			name:      "matching tgb with podSelector matching pod",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb6},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "true",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-6-l6qw6",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject: true,
+			},
+		},
+		{
+			name:      "matching tgb with podSelector excluding pod",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1, tgb6},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject: true,
+			},
+		},
 		{
 			name:      "multiple tgb with ip targetType",
 			namespace: testNS1,
@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	k8sdiscovery "k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsEndpointSlicesSupported checks whether EndpointSlices are served by the API server via API discovery.
+func IsEndpointSlicesSupported(discoveryClient k8sdiscovery.ServerGroupsInterface) (bool, error) {
+	groupList, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groupList.Groups {
+		if group.Name != discovery.GroupName {
+			continue
+		}
+		for _, version := range group.Versions {
+			if version.GroupVersion == discovery.SchemeGroupVersion.String() {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// NewEndpointSlicesEndpointsRepo constructs new endpointSlicesEndpointsRepo.
+func NewEndpointSlicesEndpointsRepo(k8sClient client.Client) *endpointSlicesEndpointsRepo {
+	return &endpointSlicesEndpointsRepo{
+		k8sClient: k8sClient,
+	}
+}
+
+var _ EndpointsRepo = &endpointSlicesEndpointsRepo{}
+
+// endpointSlicesEndpointsRepo is an implementation for EndpointsRepo, which builds Endpoints of service from its EndpointSlices.
+type endpointSlicesEndpointsRepo struct {
+	k8sClient client.Client
+}
+
+// Get returns Endpoints of service specified with svcKey built from its EndpointSlices, and whether it exists.
+// If no EndpointSlice exists for service, its Endpoints are returned instead, since Endpoints of services without selector
+// might not be mirrored into EndpointSlices.
+func (r *endpointSlicesEndpointsRepo) Get(ctx context.Context, svcKey types.NamespacedName) (*corev1.Endpoints, bool, error) {
+	epsliceList := &discovery.EndpointSliceList{}
+	if err := r.k8sClient.List(ctx, epsliceList,
+		client.InNamespace(svcKey.Namespace),
+		client.MatchingLabels{discovery.LabelServiceName: svcKey.Name}); err != nil {
+		return nil, false, err
+	}
+	if len(epsliceList.Items) == 0 {
+		eps := &corev1.Endpoints{}
+		if err := r.k8sClient.Get(ctx, svcKey, eps); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		return eps, true, nil
+	}
+	return buildEndpointsFromEndpointSlices(svcKey, epsliceList.Items), true, nil
+}
+
+// buildEndpointsFromEndpointSlices builds Endpoints of service from its EndpointSlices, with one subset per EndpointSlice.
+// Endpoints without ready condition are considered as ready, and EndpointSlices of FQDN addresses are ignored.
+func buildEndpointsFromEndpointSlices(svcKey types.NamespacedName, epslices []discovery.EndpointSlice) *corev1.Endpoints {
+	eps := &corev1.Endpoints{}
+	eps.Namespace = svcKey.Namespace
+	eps.Name = svcKey.Name
+	for _, epslice := range epslices {
+		if epslice.AddressType != discovery.AddressTypeIPv4 && epslice.AddressType != discovery.AddressTypeIPv6 {
+			continue
+		}
+		epSubset := corev1.EndpointSubset{}
+		for _, epslicePort := range epslice.Ports {
+			epPort := corev1.EndpointPort{}
+			if epslicePort.Name != nil {
+				epPort.Name = *epslicePort.Name
+			}
+			if epslicePort.Port != nil {
+				epPort.Port = *epslicePort.Port
+			}
+			if epslicePort.Protocol != nil {
+				epPort.Protocol = *epslicePort.Protocol
+			}
+			epPort.AppProtocol = epslicePort.AppProtocol
+			epSubset.Ports = append(epSubset.Ports, epPort)
+		}
+		for _, endpoint := range epslice.Endpoints {
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			for _, address := range endpoint.Addresses {
+				epAddr := corev1.EndpointAddress{
+					IP:        address,
+					TargetRef: endpoint.TargetRef,
+				}
+				if nodeName, ok := endpoint.Topology[corev1.LabelHostname]; ok {
+					epAddr.NodeName = &nodeName
+				}
+				if ready {
+					epSubset.Addresses = append(epSubset.Addresses, epAddr)
+				} else {
+					epSubset.NotReadyAddresses = append(epSubset.NotReadyAddresses, epAddr)
+				}
+			}
+		}
+		eps.Subsets = append(eps.Subsets, epSubset)
+	}
+	return eps
+}
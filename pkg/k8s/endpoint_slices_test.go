@@ -0,0 +1,231 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_IsEndpointSlicesSupported(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		want      bool
+	}{
+		{
+			name: "EndpointSlices are served",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "endpoints", Kind: "Endpoints", Namespaced: true}},
+				},
+				{
+					GroupVersion: "discovery.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "EndpointSlices are not served",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "endpoints", Kind: "Endpoints", Namespaced: true}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "only unsupported version of EndpointSlices are served",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "discovery.k8s.io/v1alpha1",
+					APIResources: []metav1.APIResource{{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true}},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: tt.resources}}
+			got, err := IsEndpointSlicesSupported(discoveryClient)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_endpointSlicesEndpointsRepo_Get(t *testing.T) {
+	portName := "http"
+	portNumber := int32(8080)
+	protocolTCP := corev1.ProtocolTCP
+	ready := true
+	notReady := false
+	nodeName := "node-1"
+	podRef := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{Kind: "Pod", Namespace: "ns-1", Name: name}
+	}
+	epslices := []*discovery.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "svc-1-abcde",
+				Labels:    map[string]string{discovery.LabelServiceName: "svc-1"},
+			},
+			AddressType: discovery.AddressTypeIPv4,
+			Ports: []discovery.EndpointPort{
+				{Name: &portName, Port: &portNumber, Protocol: &protocolTCP},
+			},
+			Endpoints: []discovery.Endpoint{
+				{
+					Addresses:  []string{"192.168.1.1"},
+					Conditions: discovery.EndpointConditions{Ready: &ready},
+					TargetRef:  podRef("pod-1"),
+					Topology:   map[string]string{corev1.LabelHostname: nodeName},
+				},
+				{
+					Addresses:  []string{"192.168.1.2"},
+					Conditions: discovery.EndpointConditions{Ready: &notReady},
+					TargetRef:  podRef("pod-2"),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "svc-1-fghij",
+				Labels:    map[string]string{discovery.LabelServiceName: "svc-1"},
+			},
+			AddressType: discovery.AddressTypeIPv4,
+			Ports: []discovery.EndpointPort{
+				{Name: &portName, Port: &portNumber, Protocol: &protocolTCP},
+			},
+			Endpoints: []discovery.Endpoint{
+				{
+					Addresses: []string{"192.168.1.3"},
+					TargetRef: podRef("pod-3"),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "svc-1-fqdn",
+				Labels:    map[string]string{discovery.LabelServiceName: "svc-1"},
+			},
+			AddressType: discovery.AddressTypeFQDN,
+			Endpoints: []discovery.Endpoint{
+				{
+					Addresses: []string{"example.com"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "svc-2-abcde",
+				Labels:    map[string]string{discovery.LabelServiceName: "svc-2"},
+			},
+			AddressType: discovery.AddressTypeIPv4,
+		},
+	}
+	manualEps := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-manual",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "192.168.2.1"}},
+				Ports:     []corev1.EndpointPort{{Name: portName, Port: portNumber, Protocol: protocolTCP}},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		svcKey     types.NamespacedName
+		want       *corev1.Endpoints
+		wantExists bool
+	}{
+		{
+			name:   "endpoints built from EndpointSlices of service",
+			svcKey: types.NamespacedName{Namespace: "ns-1", Name: "svc-1"},
+			want: &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "svc-1",
+				},
+				Subsets: []corev1.EndpointSubset{
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "192.168.1.1", NodeName: &nodeName, TargetRef: podRef("pod-1")},
+						},
+						NotReadyAddresses: []corev1.EndpointAddress{
+							{IP: "192.168.1.2", TargetRef: podRef("pod-2")},
+						},
+						Ports: []corev1.EndpointPort{
+							{Name: portName, Port: portNumber, Protocol: protocolTCP},
+						},
+					},
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "192.168.1.3", TargetRef: podRef("pod-3")},
+						},
+						Ports: []corev1.EndpointPort{
+							{Name: portName, Port: portNumber, Protocol: protocolTCP},
+						},
+					},
+				},
+			},
+			wantExists: true,
+		},
+		{
+			name:   "Endpoints of service without EndpointSlices are used",
+			svcKey: types.NamespacedName{Namespace: "ns-1", Name: "svc-manual"},
+			want: &corev1.Endpoints{
+				TypeMeta: metav1.TypeMeta{Kind: "Endpoints", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "ns-1",
+					Name:            "svc-manual",
+					ResourceVersion: "1",
+				},
+				Subsets: manualEps.Subsets,
+			},
+			wantExists: true,
+		},
+		{
+			name:       "EndpointSlices of service in another namespace don't exist",
+			svcKey:     types.NamespacedName{Namespace: "ns-2", Name: "svc-1"},
+			wantExists: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, epslice := range epslices {
+				assert.NoError(t, k8sClient.Create(ctx, epslice.DeepCopy()))
+			}
+			assert.NoError(t, k8sClient.Create(ctx, manualEps.DeepCopy()))
+			repo := NewEndpointSlicesEndpointsRepo(k8sClient)
+			got, exists, err := repo.Get(ctx, tt.svcKey)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExists, exists)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
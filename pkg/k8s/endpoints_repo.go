@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	metricEndpointsCacheSyncLag = "endpoints_cache_sync_lag_seconds"
+)
+
+// EndpointsRepo provides cached access to Endpoints within cluster, which is shared across all TargetGroupBindings.
+type EndpointsRepo interface {
+	// Get returns Endpoints of service specified with svcKey, and whether it exists.
+	// It blocks until the initial sync of repo completes.
+	Get(ctx context.Context, svcKey types.NamespacedName) (*corev1.Endpoints, bool, error)
+}
+
+// NewDefaultEndpointsRepo constructs new defaultEndpointsRepo.
+// * k8sClient must be backed by the manager's cache, so that Endpoints are read from the informer shared with controllers.
+// * endpointsInformer is the manager's informer of Endpoints, which is used to observe the cache sync lag.
+// The cache sync lag is registered to metricsRegisterer if it's not nil.
+func NewDefaultEndpointsRepo(k8sClient client.Client, endpointsInformer cache.Informer, metricsRegisterer prometheus.Registerer, logger logr.Logger) (*defaultEndpointsRepo, error) {
+	syncLag := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    metricEndpointsCacheSyncLag,
+		Help:    "Lag between the last change of Endpoints and it's observed by the endpoints cache",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 15),
+	})
+	if metricsRegisterer != nil {
+		if err := metricsRegisterer.Register(syncLag); err != nil {
+			return nil, err
+		}
+	}
+
+	repo := &defaultEndpointsRepo{
+		k8sClient: k8sClient,
+		informer:  endpointsInformer,
+		syncLag:   syncLag,
+		logger:    logger,
+	}
+	endpointsInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			repo.observeSyncLag(obj, time.Now())
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			repo.observeSyncLag(newObj, time.Now())
+		},
+	})
+	return repo, nil
+}
+
+var _ EndpointsRepo = &defaultEndpointsRepo{}
+
+// default implementation for EndpointsRepo
+type defaultEndpointsRepo struct {
+	k8sClient client.Client
+	informer  cache.Informer
+	syncLag   prometheus.Histogram
+	logger    logr.Logger
+}
+
+// Get returns Endpoints of service specified with svcKey, and whether it exists.
+// k8s Endpoints have same name as k8s Service.
+func (r *defaultEndpointsRepo) Get(ctx context.Context, svcKey types.NamespacedName) (*corev1.Endpoints, bool, error) {
+	eps := &corev1.Endpoints{}
+	if err := r.k8sClient.Get(ctx, svcKey, eps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return eps, true, nil
+}
+
+// observeSyncLag records the lag between the last change of Endpoints triggered and it's observed at now.
+// Endpoints observed during the initial sync are ignored since their changes are triggered long ago.
+func (r *defaultEndpointsRepo) observeSyncLag(obj interface{}, now time.Time) {
+	if !r.informer.HasSynced() {
+		return
+	}
+	eps, ok := obj.(*corev1.Endpoints)
+	if !ok {
+		return
+	}
+	rawTriggerTime, ok := eps.Annotations[corev1.EndpointsLastChangeTriggerTime]
+	if !ok {
+		return
+	}
+	triggerTime, err := time.Parse(time.RFC3339Nano, rawTriggerTime)
+	if err != nil {
+		r.logger.V(1).Info("ignore endpoints with invalid last change trigger time",
+			"endpoints", NamespacedName(eps), "triggerTime", rawTriggerTime)
+		return
+	}
+	r.syncLag.Observe(now.Sub(triggerTime).Seconds())
+}
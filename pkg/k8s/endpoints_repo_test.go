@@ -0,0 +1,171 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultEndpointsRepo_Get(t *testing.T) {
+	eps := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-1",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP: "192.168.1.1",
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		svcKey     types.NamespacedName
+		want       *corev1.Endpoints
+		wantExists bool
+	}{
+		{
+			name:   "endpoints exists",
+			svcKey: types.NamespacedName{Namespace: "ns-1", Name: "svc-1"},
+			want: &corev1.Endpoints{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Endpoints",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "ns-1",
+					Name:            "svc-1",
+					ResourceVersion: "1",
+				},
+				Subsets: eps.Subsets,
+			},
+			wantExists: true,
+		},
+		{
+			name:       "endpoints of service in another namespace don't exist",
+			svcKey:     types.NamespacedName{Namespace: "ns-2", Name: "svc-1"},
+			wantExists: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, eps.DeepCopy()))
+			informer := cache.NewSharedIndexInformer(newFakeEndpointsListWatch(), &corev1.Endpoints{}, 0, cache.Indexers{})
+			repo, err := NewDefaultEndpointsRepo(k8sClient, informer, nil, &log.NullLogger{})
+			assert.NoError(t, err)
+
+			got, gotExists, err := repo.Get(ctx, tt.svcKey)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExists, gotExists)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultEndpointsRepo_observeSyncLag(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 10, 0, time.UTC)
+	tests := []struct {
+		name            string
+		obj             interface{}
+		wantSampleCount uint64
+		wantSampleSum   float64
+	}{
+		{
+			name: "endpoints with last change trigger time",
+			obj: &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						corev1.EndpointsLastChangeTriggerTime: "2021-01-01T00:00:08.5Z",
+					},
+				},
+			},
+			wantSampleCount: 1,
+			wantSampleSum:   1.5,
+		},
+		{
+			name: "endpoints without last change trigger time",
+			obj: &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "svc-1",
+				},
+			},
+			wantSampleCount: 0,
+		},
+		{
+			name: "endpoints with invalid last change trigger time",
+			obj: &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						corev1.EndpointsLastChangeTriggerTime: "yesterday",
+					},
+				},
+			},
+			wantSampleCount: 0,
+		},
+		{
+			name:            "non endpoints object",
+			obj:             &corev1.Pod{},
+			wantSampleCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			informer := cache.NewSharedIndexInformer(newFakeEndpointsListWatch(), &corev1.Endpoints{}, 0, cache.Indexers{})
+			repo, err := NewDefaultEndpointsRepo(nil, informer, nil, &log.NullLogger{})
+			assert.NoError(t, err)
+			stopChan := make(chan struct{})
+			defer close(stopChan)
+			go informer.Run(stopChan)
+			assert.True(t, cache.WaitForCacheSync(stopChan, informer.HasSynced))
+
+			repo.observeSyncLag(tt.obj, now)
+			metric := &dto.Metric{}
+			assert.NoError(t, repo.syncLag.Write(metric))
+			assert.Equal(t, tt.wantSampleCount, metric.GetHistogram().GetSampleCount())
+			assert.InDelta(t, tt.wantSampleSum, metric.GetHistogram().GetSampleSum(), 0.0001)
+		})
+	}
+}
+
+// newFakeEndpointsListWatch constructs a ListWatch backed by fake clientSet with specified endpoints.
+func newFakeEndpointsListWatch(endpointsList ...*corev1.Endpoints) cache.ListerWatcher {
+	objs := make([]runtime.Object, 0, len(endpointsList))
+	for _, eps := range endpointsList {
+		objs = append(objs, eps.DeepCopy())
+	}
+	clientSet := fake.NewSimpleClientset(objs...)
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return clientSet.CoreV1().Endpoints(metav1.NamespaceAll).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return clientSet.CoreV1().Endpoints(metav1.NamespaceAll).Watch(context.Background(), options)
+		},
+	}
+}
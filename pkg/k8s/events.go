@@ -4,12 +4,22 @@ const (
 	// Ingress events
 	IngressEventReasonConflictingIngressClass = "ConflictingIngressClass"
 	IngressEventReasonFailedLoadGroupID       = "FailedLoadGroupID"
+	IngressEventReasonConflictingGroupName    = "ConflictingGroupName"
 	IngressEventReasonFailedAddFinalizer      = "FailedAddFinalizer"
 	IngressEventReasonFailedRemoveFinalizer   = "FailedRemoveFinalizer"
 	IngressEventReasonFailedUpdateStatus      = "FailedUpdateStatus"
 	IngressEventReasonFailedBuildModel        = "FailedBuildModel"
 	IngressEventReasonFailedDeployModel       = "FailedDeployModel"
+	IngressEventReasonDeployInProgress        = "DeployInProgress"
+	IngressEventReasonBackendSGRulesUnmanaged = "BackendSecurityGroupRulesUnmanaged"
 	IngressEventReasonSuccessfullyReconciled  = "SuccessfullyReconciled"
+	IngressEventReasonSkippedWithoutOptIn     = "SkippedWithoutOptIn"
+	IngressEventReasonSuspended               = "Suspended"
+	IngressEventReasonTGBRecreated            = "TargetGroupBindingRecreated"
+	IngressEventReasonCrossNamespaceGroup     = "CrossNamespaceGroupRejected"
+	IngressEventReasonUnknownSSLPolicy        = "UnknownSSLPolicy"
+	IngressEventReasonLBProvisioned           = "LoadBalancerProvisioned"
+	IngressEventReasonMissingBackendService   = "MissingBackendService"
 
 	// Service events
 	ServiceEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
@@ -17,7 +27,12 @@ const (
 	ServiceEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	ServiceEventReasonFailedBuildModel       = "FailedBuildModel"
 	ServiceEventReasonFailedDeployModel      = "FailedDeployModel"
+	ServiceEventReasonDeployInProgress       = "DeployInProgress"
 	ServiceEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	ServiceEventReasonSkippedWithoutOptIn    = "SkippedWithoutOptIn"
+	ServiceEventReasonSkippedUnsupported     = "SkippedUnsupported"
+	ServiceEventReasonSuspended              = "Suspended"
+	ServiceEventReasonLBProvisioned          = "LoadBalancerProvisioned"
 
 	// TargetGroupBinding events
 	TargetGroupBindingEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
@@ -25,5 +40,6 @@ const (
 	TargetGroupBindingEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	TargetGroupBindingEventReasonFailedCleanup          = "FailedCleanup"
 	TargetGroupBindingEventReasonBackendNotFound        = "BackendNotFound"
+	TargetGroupBindingEventReasonMaxTargetsExceeded     = "MaxTargetsExceeded"
 	TargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
 )
@@ -19,15 +19,46 @@ func IsNodeReady(node *corev1.Node) bool {
 // IsNodeSuitableAsTrafficProxy check whether node is suitable as a traffic proxy.
 // mimic the logic of serviceController: https://github.com/kubernetes/kubernetes/blob/b6b494b4484b51df8dc6b692fab234573da30ab4/pkg/controller/service/controller.go#L605
 func IsNodeSuitableAsTrafficProxy(node *corev1.Node) bool {
+	// Marking the node as unsuitable for traffic once it's about to be removed from cluster
+	if IsNodeToBeDeleted(node) {
+		return false
+	}
+
+	return IsNodeReady(node)
+}
+
+// IsNodeToBeDeleted returns whether node is about to be removed from cluster.
+func IsNodeToBeDeleted(node *corev1.Node) bool {
 	// ToBeDeletedByClusterAutoscaler taint is added by cluster autoscaler before removing node from cluster
-	// Marking the node as unsuitable for traffic once the taint is observed on the node
 	for _, taint := range node.Spec.Taints {
 		if taint.Key == toBeDeletedByCATaint {
-			return false
+			return true
 		}
 	}
+	return false
+}
 
-	return IsNodeReady(node)
+// NodeDrainConditions configures the conditions under which nodes are considered draining.
+type NodeDrainConditions struct {
+	// whether cordoned nodes are considered draining.
+	Cordoned bool
+	// nodes tainted with any of these taint keys are considered draining.
+	TaintKeys []string
+}
+
+// IsNodeDraining returns whether node is draining under conditions, e.g. during node maintenance.
+func IsNodeDraining(node *corev1.Node, conditions NodeDrainConditions) bool {
+	if conditions.Cordoned && node.Spec.Unschedulable {
+		return true
+	}
+	for _, taint := range node.Spec.Taints {
+		for _, taintKey := range conditions.TaintKeys {
+			if taint.Key == taintKey {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // GetNodeCondition will get pointer to Node's existing condition.
@@ -41,6 +72,15 @@ func GetNodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType)
 	return nil
 }
 
+// GetNodeZone returns the availability zone of node based on well-known zone labels.
+// returns empty string if zone labels are absent.
+func GetNodeZone(node *corev1.Node) string {
+	if zone, ok := node.Labels[corev1.LabelZoneFailureDomainStable]; ok {
+		return zone
+	}
+	return node.Labels[corev1.LabelZoneFailureDomain]
+}
+
 func ExtractNodeInstanceID(node *corev1.Node) (string, error) {
 	providerID := node.Spec.ProviderID
 	if providerID == "" {
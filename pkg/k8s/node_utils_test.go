@@ -131,6 +131,133 @@ func TestIsNodeSuitableForTraffic(t *testing.T) {
 	}
 }
 
+func TestIsNodeToBeDeleted(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "node without taints",
+			node: &corev1.Node{},
+			want: false,
+		},
+		{
+			name: "node tainted with ToBeDeletedByClusterAutoscaler",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{
+							Key:   toBeDeletedByCATaint,
+							Value: "True",
+						},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsNodeToBeDeleted(tt.node)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsNodeDraining(t *testing.T) {
+	type args struct {
+		node       *corev1.Node
+		conditions NodeDrainConditions
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "cordoned node without drain conditions",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Unschedulable: true,
+					},
+				},
+				conditions: NodeDrainConditions{},
+			},
+			want: false,
+		},
+		{
+			name: "cordoned node when draining cordoned nodes",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Unschedulable: true,
+					},
+				},
+				conditions: NodeDrainConditions{
+					Cordoned: true,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "schedulable node when draining cordoned nodes",
+			args: args{
+				node: &corev1.Node{},
+				conditions: NodeDrainConditions{
+					Cordoned: true,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "node tainted with drain taint",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    "node.example.com/maintenance",
+								Effect: corev1.TaintEffectPreferNoSchedule,
+							},
+						},
+					},
+				},
+				conditions: NodeDrainConditions{
+					TaintKeys: []string{"node.example.com/maintenance"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node tainted with other taint",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    "node.example.com/dedicated",
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+				},
+				conditions: NodeDrainConditions{
+					TaintKeys: []string{"node.example.com/maintenance"},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsNodeDraining(tt.args.node, tt.args.conditions)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestGetNodeCondition(t *testing.T) {
 	type args struct {
 		node          *corev1.Node
@@ -187,6 +314,49 @@ func TestGetNodeCondition(t *testing.T) {
 	}
 }
 
+func TestGetNodeZone(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want string
+	}{
+		{
+			name: "node with stable zone label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"topology.kubernetes.io/zone":            "us-west-2a",
+						"failure-domain.beta.kubernetes.io/zone": "us-west-2b",
+					},
+				},
+			},
+			want: "us-west-2a",
+		},
+		{
+			name: "node with beta zone label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"failure-domain.beta.kubernetes.io/zone": "us-west-2b",
+					},
+				},
+			},
+			want: "us-west-2b",
+		},
+		{
+			name: "node without zone label",
+			node: &corev1.Node{},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetNodeZone(tt.node)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestExtractNodeInstanceID(t *testing.T) {
 	type args struct {
 		node *corev1.Node
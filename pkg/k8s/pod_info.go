@@ -18,10 +18,13 @@ type PodInfo struct {
 	Key types.NamespacedName
 	UID types.UID
 
+	Labels         map[string]string
 	ContainerPorts []corev1.ContainerPort
 	ReadinessGates []corev1.PodReadinessGate
 	Conditions     []corev1.PodCondition
 	PodIP          string
+	PodIPs         []string
+	NodeName       string
 
 	ENIInfos []PodENIInfo
 }
@@ -54,6 +57,12 @@ func (i *PodInfo) IsContainersReady() bool {
 	return exists && containersReadyCond.Status == corev1.ConditionTrue
 }
 
+// IsPodConditionTrue returns whether podInfo has condition of conditionType with True status.
+func (i *PodInfo) IsPodConditionTrue(conditionType corev1.PodConditionType) bool {
+	cond, exists := i.GetPodCondition(conditionType)
+	return exists && cond.Status == corev1.ConditionTrue
+}
+
 // GetPodCondition will get Pod's condition.
 func (i *PodInfo) GetPodCondition(conditionType corev1.PodConditionType) (corev1.PodCondition, bool) {
 	for _, cond := range i.Conditions {
@@ -94,14 +103,21 @@ func buildPodInfo(pod *corev1.Pod) PodInfo {
 	for _, podContainer := range pod.Spec.Containers {
 		containerPorts = append(containerPorts, podContainer.Ports...)
 	}
+	var podIPs []string
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs = append(podIPs, podIP.IP)
+	}
 	return PodInfo{
 		Key: podKey,
 		UID: pod.UID,
 
+		Labels:         pod.Labels,
 		ContainerPorts: containerPorts,
 		ReadinessGates: pod.Spec.ReadinessGates,
 		Conditions:     pod.Status.Conditions,
 		PodIP:          pod.Status.PodIP,
+		PodIPs:         podIPs,
+		NodeName:       pod.Spec.NodeName,
 
 		ENIInfos: podENIInfos,
 	}
@@ -145,6 +145,64 @@ func TestPodInfo_IsContainersReady(t *testing.T) {
 	}
 }
 
+func TestPodInfo_IsPodConditionTrue(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           PodInfo
+		conditionType corev1.PodConditionType
+		want          bool
+	}{
+		{
+			name: "pod have true condition",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   "example.com/warmed-up",
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+			conditionType: "example.com/warmed-up",
+			want:          true,
+		},
+		{
+			name: "pod have false condition",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   "example.com/warmed-up",
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+			conditionType: "example.com/warmed-up",
+			want:          false,
+		},
+		{
+			name: "pod don't have condition",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+			conditionType: "example.com/warmed-up",
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pod.IsPodConditionTrue(tt.conditionType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestPodInfo_GetPodCondition(t *testing.T) {
 	type args struct {
 		conditionType corev1.PodConditionType
@@ -514,6 +572,35 @@ func Test_buildPodInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dualstack case",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "pod-1",
+						UID:       "pod-uuid",
+					},
+					Status: corev1.PodStatus{
+						PodIP: "192.168.1.1",
+						PodIPs: []corev1.PodIP{
+							{
+								IP: "192.168.1.1",
+							},
+							{
+								IP: "2600:1f14:f8c:2700::1",
+							},
+						},
+					},
+				},
+			},
+			want: PodInfo{
+				Key:    types.NamespacedName{Namespace: "my-ns", Name: "pod-1"},
+				UID:    "pod-uuid",
+				PodIP:  "192.168.1.1",
+				PodIPs: []string{"192.168.1.1", "2600:1f14:f8c:2700::1"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sync"
+)
+
+// SuspensionTracker tracks objects whose reconciles are suspended, so that suspension is only reported on transition.
+type SuspensionTracker interface {
+	// MarkSuspended marks the object with key as suspended, and returns whether it's newly suspended.
+	MarkSuspended(key string) bool
+
+	// MarkResumed marks the object with key as not suspended.
+	MarkResumed(key string)
+}
+
+// NewDefaultSuspensionTracker constructs new defaultSuspensionTracker.
+func NewDefaultSuspensionTracker() *defaultSuspensionTracker {
+	return &defaultSuspensionTracker{
+		suspendedKeys: sets.NewString(),
+	}
+}
+
+var _ SuspensionTracker = &defaultSuspensionTracker{}
+
+// default implementation for SuspensionTracker, objects are tracked in memory, so suspension is reported again after restarts.
+type defaultSuspensionTracker struct {
+	mutex         sync.Mutex
+	suspendedKeys sets.String
+}
+
+func (t *defaultSuspensionTracker) MarkSuspended(key string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.suspendedKeys.Has(key) {
+		return false
+	}
+	t.suspendedKeys.Insert(key)
+	return true
+}
+
+func (t *defaultSuspensionTracker) MarkResumed(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.suspendedKeys.Delete(key)
+}
@@ -0,0 +1,16 @@
+package k8s
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_defaultSuspensionTracker(t *testing.T) {
+	tracker := NewDefaultSuspensionTracker()
+	assert.True(t, tracker.MarkSuspended("awesome-ns/awesome-svc"))
+	assert.False(t, tracker.MarkSuspended("awesome-ns/awesome-svc"))
+	assert.True(t, tracker.MarkSuspended("awesome-ns/other-svc"))
+
+	tracker.MarkResumed("awesome-ns/awesome-svc")
+	assert.True(t, tracker.MarkSuspended("awesome-ns/awesome-svc"))
+}
@@ -5,6 +5,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
+const (
+	// AnnotationExplicitOptIn is the annotation that opts Ingresses and Services in to be managed when explicit opt-in is required.
+	AnnotationExplicitOptIn = "elbv2.k8s.aws/managed"
+	// AnnotationSuspend is the annotation that suspends reconciles of Ingresses and Services, e.g. during maintenance.
+	AnnotationSuspend = "elbv2.k8s.aws/suspend"
+)
+
 // NamespacedName returns the namespaced name for k8s objects
 func NamespacedName(obj metav1.Object) types.NamespacedName {
 	return types.NamespacedName{
@@ -12,3 +19,26 @@ func NamespacedName(obj metav1.Object) types.NamespacedName {
 		Name:      obj.GetName(),
 	}
 }
+
+// HasExplicitOptIn checks whether k8s object is explicitly opted in to be managed by this controller.
+func HasExplicitOptIn(obj metav1.Object) bool {
+	return obj.GetAnnotations()[AnnotationExplicitOptIn] == "true"
+}
+
+// BuildResourceMetadataTags builds the tags that record the namespace and name of the Kubernetes resource owning an AWS resource.
+// tags with empty key or value are omitted, e.g. the namespace of explicit IngressGroups.
+func BuildResourceMetadataTags(namespaceTagKey string, nameTagKey string, namespace string, name string) map[string]string {
+	tags := make(map[string]string)
+	if namespaceTagKey != "" && namespace != "" {
+		tags[namespaceTagKey] = namespace
+	}
+	if nameTagKey != "" && name != "" {
+		tags[nameTagKey] = name
+	}
+	return tags
+}
+
+// IsReconcileSuspended checks whether reconciles of k8s object are suspended.
+func IsReconcileSuspended(obj metav1.Object) bool {
+	return obj.GetAnnotations()[AnnotationSuspend] == "true"
+}
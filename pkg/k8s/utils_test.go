@@ -49,3 +49,141 @@ func TestNamespacedName(t *testing.T) {
 		})
 	}
 }
+
+func TestHasExplicitOptIn(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  metav1.Object
+		want bool
+	}{
+		{
+			name: "object with opt-in annotation",
+			obj: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/managed": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "object with opt-in annotation other than true",
+			obj: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/managed": "false",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "object without annotations",
+			obj:  &networking.Ingress{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HasExplicitOptIn(tt.obj)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsReconcileSuspended(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  metav1.Object
+		want bool
+	}{
+		{
+			name: "object with suspend annotation",
+			obj: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/suspend": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "object with suspend annotation other than true",
+			obj: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/suspend": "false",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "object without annotations",
+			obj:  &networking.Ingress{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsReconcileSuspended(tt.obj)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuildResourceMetadataTags(t *testing.T) {
+	type args struct {
+		namespaceTagKey string
+		nameTagKey      string
+		namespace       string
+		name            string
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]string
+	}{
+		{
+			name: "both tag keys configured",
+			args: args{
+				namespaceTagKey: "k8s-namespace",
+				nameTagKey:      "k8s-name",
+				namespace:       "awesome-ns",
+				name:            "awesome-svc",
+			},
+			want: map[string]string{
+				"k8s-namespace": "awesome-ns",
+				"k8s-name":      "awesome-svc",
+			},
+		},
+		{
+			name: "tag keys not configured",
+			args: args{
+				namespace: "awesome-ns",
+				name:      "awesome-svc",
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "empty namespace is omitted",
+			args: args{
+				namespaceTagKey: "k8s-namespace",
+				nameTagKey:      "k8s-name",
+				namespace:       "",
+				name:            "awesome-group",
+			},
+			want: map[string]string{
+				"k8s-name": "awesome-group",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildResourceMetadataTags(tt.args.namespaceTagKey, tt.args.nameTagKey, tt.args.namespace, tt.args.name)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -56,6 +56,9 @@ func (ls *Listener) registerDependencies(stack core.Stack) {
 	for _, dep := range ls.Spec.LoadBalancerARN.Dependencies() {
 		stack.AddDependency(dep, ls)
 	}
+	for _, dep := range actionsDependencies(ls.Spec.DefaultActions) {
+		stack.AddDependency(dep, ls)
+	}
 }
 
 type Protocol string
@@ -237,6 +240,16 @@ type TargetGroupTuple struct {
 	Weight *int64 `json:"weight,omitempty"`
 }
 
+// Information about the health requirement of target groups before switching traffic to them.
+// It's enforced by the controller rather than ELBV2.
+type TargetGroupSwitchConfig struct {
+	// The minimum percentage of healthy targets within target groups before switching traffic to them.
+	MinHealthyPercentage int64 `json:"minHealthyPercentage"`
+
+	// The timeout, in seconds, to wait for target groups to become healthy.
+	TimeoutSeconds int64 `json:"timeoutSeconds"`
+}
+
 // Information about the target group stickiness for a rule.
 type TargetGroupStickinessConfig struct {
 	// Indicates whether target group stickiness is enabled.
@@ -257,6 +270,10 @@ type ForwardActionConfig struct {
 	// The target group stickiness for the rule.
 	// +optional
 	TargetGroupStickinessConfig *TargetGroupStickinessConfig `json:"targetGroupStickinessConfig,omitempty"`
+
+	// [Controller] The health requirement of target groups before switching traffic to them.
+	// +optional
+	SwitchConfig *TargetGroupSwitchConfig `json:"switchConfig,omitempty"`
 }
 
 // Information about an action.
@@ -285,6 +302,21 @@ type Action struct {
 	ForwardConfig *ForwardActionConfig `json:"forwardConfig,omitempty"`
 }
 
+// actionsDependencies returns the resources that actions depend on, i.e. the TargetGroups to forward to.
+// Actions must stop forwarding to TargetGroups before these TargetGroups can be deleted.
+func actionsDependencies(actions []Action) []core.Resource {
+	var deps []core.Resource
+	for _, action := range actions {
+		if action.ForwardConfig == nil {
+			continue
+		}
+		for _, tgt := range action.ForwardConfig.TargetGroups {
+			deps = append(deps, tgt.TargetGroupARN.Dependencies()...)
+		}
+	}
+	return deps
+}
+
 // Information about an SSL server certificate.
 type Certificate struct {
 	// The Amazon Resource Name (ARN) of the certificate.
@@ -324,6 +356,12 @@ type ListenerSpec struct {
 	// +optional
 	Certificates []Certificate `json:"certificates,omitempty"`
 
+	// Whether to keep the current default certificate if it's still within Certificates.
+	// When set, the first certificate is only used as default certificate for new listeners or
+	// when the current default certificate is no longer desired, which avoids client disruption for discovered certificates.
+	// +optional
+	PreserveDefaultCertificate bool `json:"preserveDefaultCertificate,omitempty"`
+
 	// [HTTPS and TLS listeners] The security policy that defines which protocols and ciphers are supported.
 	// +optional
 	SSLPolicy *string `json:"sslPolicy,omitempty"`
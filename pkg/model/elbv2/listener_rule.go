@@ -40,6 +40,9 @@ func (lr *ListenerRule) registerDependencies(stack core.Stack) {
 	for _, dep := range lr.Spec.ListenerARN.Dependencies() {
 		stack.AddDependency(dep, lr)
 	}
+	for _, dep := range actionsDependencies(lr.Spec.Actions) {
+		stack.AddDependency(dep, lr)
+	}
 }
 
 type RuleConditionField string
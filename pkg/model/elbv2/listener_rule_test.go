@@ -0,0 +1,61 @@
+package elbv2
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"testing"
+)
+
+func TestListenerRule_registerDependencies(t *testing.T) {
+	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+	tg1 := NewTargetGroup(stack, "namespace/ingress-svc-1:80", TargetGroupSpec{})
+	tg2 := NewTargetGroup(stack, "namespace/ingress-svc-2:80", TargetGroupSpec{})
+	ls := NewListener(stack, "80", ListenerSpec{
+		LoadBalancerARN: core.LiteralStringToken("lb-arn"),
+		Port:            80,
+		DefaultActions: []Action{
+			{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{TargetGroupARN: tg1.TargetGroupARN()},
+					},
+				},
+			},
+		},
+	})
+	lr := NewListenerRule(stack, "80:1", ListenerRuleSpec{
+		ListenerARN: ls.ListenerARN(),
+		Priority:    1,
+		Actions: []Action{
+			{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{TargetGroupARN: tg1.TargetGroupARN()},
+						{TargetGroupARN: tg2.TargetGroupARN()},
+						{TargetGroupARN: core.LiteralStringToken("external-tg-arn")},
+					},
+				},
+			},
+		},
+	})
+
+	visitOrder := make(map[string]int)
+	err := stack.TopologicalTraversal(&visitRecorder{visitOrder: visitOrder})
+	assert.NoError(t, err)
+	assert.Less(t, visitOrder[tg1.ID()], visitOrder[ls.ID()])
+	assert.Less(t, visitOrder[tg1.ID()], visitOrder[lr.ID()])
+	assert.Less(t, visitOrder[tg2.ID()], visitOrder[lr.ID()])
+	assert.Less(t, visitOrder[ls.ID()], visitOrder[lr.ID()])
+}
+
+// visitRecorder records the order resources are visited in.
+type visitRecorder struct {
+	visitOrder map[string]int
+}
+
+func (r *visitRecorder) Visit(res core.Resource) error {
+	r.visitOrder[res.ID()] = len(r.visitOrder)
+	return nil
+}
@@ -63,6 +63,19 @@ func (lb *LoadBalancer) DNSName() core.StringToken {
 	)
 }
 
+// CanonicalHostedZoneID returns the ID of the Amazon Route 53 hosted zone associated with the load balancer.
+func (lb *LoadBalancer) CanonicalHostedZoneID() core.StringToken {
+	return core.NewResourceFieldStringToken(lb, "status/canonicalHostedZoneID",
+		func(ctx context.Context, res core.Resource, fieldPath string) (s string, err error) {
+			lb := res.(*LoadBalancer)
+			if lb.Status == nil {
+				return "", errors.Errorf("LoadBalancer is not fulfilled yet: %v", lb.ID())
+			}
+			return lb.Status.CanonicalHostedZoneID, nil
+		},
+	)
+}
+
 // register dependencies for LoadBalancer.
 func (lb *LoadBalancer) registerDependencies(stack core.Stack) {
 	for _, sgToken := range lb.Spec.SecurityGroups {
@@ -160,4 +173,7 @@ type LoadBalancerStatus struct {
 
 	// The public DNS name of the load balancer.
 	DNSName string `json:"dnsName"`
+
+	// The ID of the Amazon Route 53 hosted zone associated with the load balancer.
+	CanonicalHostedZoneID string `json:"canonicalHostedZoneID"`
 }
@@ -145,4 +145,8 @@ type TargetGroupSpec struct {
 type TargetGroupStatus struct {
 	// The Amazon Resource Name (ARN) of the target group.
 	TargetGroupARN string `json:"targetGroupARN"`
+
+	// Whether a TargetGroupBinding has been deployed for the target group.
+	// +optional
+	TargetGroupBindingDeployed bool `json:"targetGroupBindingDeployed,omitempty"`
 }
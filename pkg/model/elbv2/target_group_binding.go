@@ -99,6 +99,10 @@ type TargetGroupBindingSpec struct {
 	// node selector for instance type target groups to only register certain nodes
 	// +optional
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// podSelector for ip type target groups to only register certain pods, in addition to the selector of the Service.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
 }
 
 // Template for TargetGroupBinding Custom Resource.
@@ -114,10 +118,23 @@ type TargetGroupBindingTemplate struct {
 type TargetGroupBindingResourceSpec struct {
 	// Describes the TargetGroupBinding Custom Resource that will be created when synthesize this TargetGroupBindingResource.
 	Template TargetGroupBindingTemplate `json:"template"`
+
+	// Whether to skip recreating the TargetGroupBinding Custom Resource if it's missing for an existing TargetGroup,
+	// e.g. it's removed intentionally.
+	// +optional
+	SkipRecreation bool `json:"skipRecreation,omitempty"`
 }
 
 // observed state of TargetGroupBindingResource
 type TargetGroupBindingResourceStatus struct {
 	// reference to the TargetGroupBinding Custom Resource.
 	TargetGroupBindingRef corev1.ObjectReference `json:"targetGroupBindingRef"`
+
+	// Whether the TargetGroupBinding Custom Resource is recreated since it's missing for an existing TargetGroup.
+	// +optional
+	Recreated bool `json:"recreated,omitempty"`
+
+	// Whether recreating the missing TargetGroupBinding Custom Resource is skipped, TargetGroupBindingRef refers to the missing one.
+	// +optional
+	RecreationSkipped bool `json:"recreationSkipped,omitempty"`
 }
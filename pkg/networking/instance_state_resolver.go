@@ -0,0 +1,158 @@
+package networking
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	// instance state changes more frequently than other instance information, thus a short TTL is used.
+	defaultInstanceStateCacheTTL = 1 * time.Minute
+
+	// InstanceStateNotFound is the state resolved for EC2 instances that don't exist, e.g. terminated a while ago.
+	InstanceStateNotFound = "not-found"
+)
+
+// InstanceStateResolver is responsible for resolving the state of EC2 instances.
+type InstanceStateResolver interface {
+	// Resolve returns the state name of EC2 instances by instanceID.
+	// Instances that don't exist are resolved as InstanceStateNotFound, and instances without state are omitted.
+	Resolve(ctx context.Context, instanceIDs []string) (map[string]string, error)
+
+	// CacheTTL returns the duration the resolved states are cached for, states are refreshed after it.
+	CacheTTL() time.Duration
+}
+
+// NewDefaultInstanceStateResolver constructs new defaultInstanceStateResolver.
+func NewDefaultInstanceStateResolver(ec2Client services.EC2, logger logr.Logger) *defaultInstanceStateResolver {
+	return &defaultInstanceStateResolver{
+		ec2Client:          ec2Client,
+		logger:             logger,
+		instanceStateCache: cache.NewExpiring(),
+		instanceStateMutex: sync.RWMutex{},
+		instanceStateTTL:   defaultInstanceStateCacheTTL,
+	}
+}
+
+var _ InstanceStateResolver = &defaultInstanceStateResolver{}
+
+// default implementation for InstanceStateResolver.
+type defaultInstanceStateResolver struct {
+	ec2Client services.EC2
+	logger    logr.Logger
+
+	instanceStateCache *cache.Expiring
+	instanceStateMutex sync.RWMutex
+	instanceStateTTL   time.Duration
+}
+
+func (r *defaultInstanceStateResolver) Resolve(ctx context.Context, instanceIDs []string) (map[string]string, error) {
+	stateByInstanceID := r.fetchInstanceStatesFromCache(instanceIDs)
+	var instanceIDsWithoutState []string
+	for _, instanceID := range sets.NewString(instanceIDs...).List() {
+		if _, exists := stateByInstanceID[instanceID]; !exists {
+			instanceIDsWithoutState = append(instanceIDsWithoutState, instanceID)
+		}
+	}
+	if len(instanceIDsWithoutState) == 0 {
+		return stateByInstanceID, nil
+	}
+
+	stateByInstanceIDViaLookup, err := r.lookupInstanceStates(ctx, instanceIDsWithoutState)
+	if err != nil {
+		return nil, err
+	}
+	r.saveInstanceStatesToCache(stateByInstanceIDViaLookup)
+	for instanceID, state := range stateByInstanceIDViaLookup {
+		stateByInstanceID[instanceID] = state
+	}
+	return stateByInstanceID, nil
+}
+
+func (r *defaultInstanceStateResolver) CacheTTL() time.Duration {
+	return r.instanceStateTTL
+}
+
+// lookupInstanceStates looks up the state of EC2 instances via DescribeInstances.
+// DescribeInstances fails entirely if any instance doesn't exist, in which case instances are looked up one by one.
+func (r *defaultInstanceStateResolver) lookupInstanceStates(ctx context.Context, instanceIDs []string) (map[string]string, error) {
+	stateByInstanceID, err := r.describeInstanceStates(ctx, instanceIDs)
+	if err == nil || !isInstanceNotFoundError(err) {
+		return stateByInstanceID, err
+	}
+	stateByInstanceID = make(map[string]string, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		stateByInstanceIDForOne, err := r.describeInstanceStates(ctx, []string{instanceID})
+		if err != nil {
+			if !isInstanceNotFoundError(err) {
+				return nil, err
+			}
+			r.logger.V(1).Info("instance not found", "instanceID", instanceID)
+			stateByInstanceID[instanceID] = InstanceStateNotFound
+			continue
+		}
+		for id, state := range stateByInstanceIDForOne {
+			stateByInstanceID[id] = state
+		}
+	}
+	return stateByInstanceID, nil
+}
+
+func (r *defaultInstanceStateResolver) describeInstanceStates(ctx context.Context, instanceIDs []string) (map[string]string, error) {
+	req := &ec2sdk.DescribeInstancesInput{
+		InstanceIds: awssdk.StringSlice(instanceIDs),
+	}
+	instances, err := r.ec2Client.DescribeInstancesAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	stateByInstanceID := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		if instance.State == nil {
+			continue
+		}
+		stateByInstanceID[awssdk.StringValue(instance.InstanceId)] = awssdk.StringValue(instance.State.Name)
+	}
+	return stateByInstanceID, nil
+}
+
+func (r *defaultInstanceStateResolver) fetchInstanceStatesFromCache(instanceIDs []string) map[string]string {
+	r.instanceStateMutex.RLock()
+	defer r.instanceStateMutex.RUnlock()
+
+	stateByInstanceID := make(map[string]string, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		if rawCacheItem, exists := r.instanceStateCache.Get(instanceID); exists {
+			stateByInstanceID[instanceID] = rawCacheItem.(string)
+		}
+	}
+	return stateByInstanceID
+}
+
+func (r *defaultInstanceStateResolver) saveInstanceStatesToCache(stateByInstanceID map[string]string) {
+	r.instanceStateMutex.Lock()
+	defer r.instanceStateMutex.Unlock()
+
+	for instanceID, state := range stateByInstanceID {
+		r.instanceStateCache.Set(instanceID, state, r.instanceStateTTL)
+	}
+}
+
+// isInstanceNotFoundError tests whether the error is due to EC2 instances that don't exist.
+func isInstanceNotFoundError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == "InvalidInstanceID.NotFound"
+	}
+	return false
+}
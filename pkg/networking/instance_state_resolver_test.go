@@ -0,0 +1,173 @@
+package networking
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultInstanceStateResolver_Resolve(t *testing.T) {
+	type describeInstancesAsListCall struct {
+		req  *ec2sdk.DescribeInstancesInput
+		resp []*ec2sdk.Instance
+		err  error
+	}
+	tests := []struct {
+		name                         string
+		cachedStates                 map[string]string
+		describeInstancesAsListCalls []describeInstancesAsListCall
+		instanceIDs                  []string
+		want                         map[string]string
+		wantErr                      error
+	}{
+		{
+			name: "all instance states are cached",
+			cachedStates: map[string]string{
+				"i-1": "running",
+				"i-2": "stopped",
+			},
+			instanceIDs: []string{"i-1", "i-2"},
+			want: map[string]string{
+				"i-1": "running",
+				"i-2": "stopped",
+			},
+		},
+		{
+			name: "instance states are resolved via DescribeInstances",
+			cachedStates: map[string]string{
+				"i-1": "running",
+			},
+			describeInstancesAsListCalls: []describeInstancesAsListCall{
+				{
+					req: &ec2sdk.DescribeInstancesInput{
+						InstanceIds: awssdk.StringSlice([]string{"i-2", "i-3"}),
+					},
+					resp: []*ec2sdk.Instance{
+						{
+							InstanceId: awssdk.String("i-2"),
+							State: &ec2sdk.InstanceState{
+								Name: awssdk.String("stopped"),
+							},
+						},
+						{
+							InstanceId: awssdk.String("i-3"),
+							State: &ec2sdk.InstanceState{
+								Name: awssdk.String("pending"),
+							},
+						},
+					},
+				},
+			},
+			instanceIDs: []string{"i-1", "i-2", "i-3", "i-3"},
+			want: map[string]string{
+				"i-1": "running",
+				"i-2": "stopped",
+				"i-3": "pending",
+			},
+		},
+		{
+			name: "instances without state are omitted",
+			describeInstancesAsListCalls: []describeInstancesAsListCall{
+				{
+					req: &ec2sdk.DescribeInstancesInput{
+						InstanceIds: awssdk.StringSlice([]string{"i-1", "i-2"}),
+					},
+					resp: []*ec2sdk.Instance{
+						{
+							InstanceId: awssdk.String("i-1"),
+							State: &ec2sdk.InstanceState{
+								Name: awssdk.String("running"),
+							},
+						},
+						{
+							InstanceId: awssdk.String("i-2"),
+						},
+					},
+				},
+			},
+			instanceIDs: []string{"i-1", "i-2"},
+			want: map[string]string{
+				"i-1": "running",
+			},
+		},
+		{
+			name: "instances not found are looked up one by one",
+			describeInstancesAsListCalls: []describeInstancesAsListCall{
+				{
+					req: &ec2sdk.DescribeInstancesInput{
+						InstanceIds: awssdk.StringSlice([]string{"i-1", "i-2"}),
+					},
+					err: awserr.New("InvalidInstanceID.NotFound", "The instance ID 'i-2' does not exist", nil),
+				},
+				{
+					req: &ec2sdk.DescribeInstancesInput{
+						InstanceIds: awssdk.StringSlice([]string{"i-1"}),
+					},
+					resp: []*ec2sdk.Instance{
+						{
+							InstanceId: awssdk.String("i-1"),
+							State: &ec2sdk.InstanceState{
+								Name: awssdk.String("running"),
+							},
+						},
+					},
+				},
+				{
+					req: &ec2sdk.DescribeInstancesInput{
+						InstanceIds: awssdk.StringSlice([]string{"i-2"}),
+					},
+					err: awserr.New("InvalidInstanceID.NotFound", "The instance ID 'i-2' does not exist", nil),
+				},
+			},
+			instanceIDs: []string{"i-1", "i-2"},
+			want: map[string]string{
+				"i-1": "running",
+				"i-2": InstanceStateNotFound,
+			},
+		},
+		{
+			name: "DescribeInstances fails",
+			describeInstancesAsListCalls: []describeInstancesAsListCall{
+				{
+					req: &ec2sdk.DescribeInstancesInput{
+						InstanceIds: awssdk.StringSlice([]string{"i-1"}),
+					},
+					err: errors.New("some error"),
+				},
+			},
+			instanceIDs: []string{"i-1"},
+			wantErr:     errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := services.NewMockEC2(ctrl)
+			for _, call := range tt.describeInstancesAsListCalls {
+				ec2Client.EXPECT().DescribeInstancesAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := NewDefaultInstanceStateResolver(ec2Client, &log.NullLogger{})
+			r.saveInstanceStatesToCache(tt.cachedStates)
+
+			got, err := r.Resolve(context.Background(), tt.instanceIDs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				// resolved states should be cached.
+				assert.Equal(t, tt.want, r.fetchInstanceStatesFromCache(tt.instanceIDs))
+			}
+		})
+	}
+}
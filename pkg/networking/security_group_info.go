@@ -65,6 +65,23 @@ func (perm *IPPermissionInfo) HashCode() string {
 	return string(payload)
 }
 
+// Description returns the description for the IPPermissionInfo.
+func (perm *IPPermissionInfo) Description() string {
+	if len(perm.Permission.IpRanges) == 1 {
+		return awssdk.StringValue(perm.Permission.IpRanges[0].Description)
+	}
+	if len(perm.Permission.Ipv6Ranges) == 1 {
+		return awssdk.StringValue(perm.Permission.Ipv6Ranges[0].Description)
+	}
+	if len(perm.Permission.PrefixListIds) == 1 {
+		return awssdk.StringValue(perm.Permission.PrefixListIds[0].Description)
+	}
+	if len(perm.Permission.UserIdGroupPairs) == 1 {
+		return awssdk.StringValue(perm.Permission.UserIdGroupPairs[0].Description)
+	}
+	return ""
+}
+
 // NewRawSecurityGroupInfo constructs new SecurityGroupInfo with raw ec2SDK's SecurityGroup object.
 func NewRawSecurityGroupInfo(sdkSG *ec2sdk.SecurityGroup) SecurityGroupInfo {
 	sgID := awssdk.StringValue(sdkSG.GroupId)
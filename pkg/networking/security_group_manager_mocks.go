@@ -97,3 +97,17 @@ func (mr *MockSecurityGroupManagerMockRecorder) RevokeSGIngress(arg0, arg1, arg2
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSGIngress", reflect.TypeOf((*MockSecurityGroupManager)(nil).RevokeSGIngress), arg0, arg1, arg2)
 }
+
+// UpdateSGIngressDescriptions mocks base method.
+func (m *MockSecurityGroupManager) UpdateSGIngressDescriptions(arg0 context.Context, arg1 string, arg2 []IPPermissionInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSGIngressDescriptions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSGIngressDescriptions indicates an expected call of UpdateSGIngressDescriptions.
+func (mr *MockSecurityGroupManagerMockRecorder) UpdateSGIngressDescriptions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSGIngressDescriptions", reflect.TypeOf((*MockSecurityGroupManager)(nil).UpdateSGIngressDescriptions), arg0, arg1, arg2)
+}
@@ -102,6 +102,7 @@ func (r *defaultSecurityGroupReconciler) reconcileIngressWithSGInfo(ctx context.
 		}
 	}
 	permissionsToGrant := diffIPPermissionInfos(desiredPermissions, sgInfo.Ingress)
+	permissionsToUpdateDescription := diffIPPermissionInfoDescriptions(desiredPermissions, sgInfo.Ingress, reconcileOpts.PermissionSelector)
 	if len(permissionsToRevoke) > 0 && !reconcileOpts.AuthorizeOnly {
 		if err := r.sgManager.RevokeSGIngress(ctx, sgInfo.SecurityGroupID, permissionsToRevoke); err != nil {
 			return err
@@ -112,6 +113,11 @@ func (r *defaultSecurityGroupReconciler) reconcileIngressWithSGInfo(ctx context.
 			return err
 		}
 	}
+	if len(permissionsToUpdateDescription) > 0 {
+		if err := r.sgManager.UpdateSGIngressDescriptions(ctx, sgInfo.SecurityGroupID, permissionsToUpdateDescription); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -143,3 +149,24 @@ func diffIPPermissionInfos(source []IPPermissionInfo, target []IPPermissionInfo)
 	}
 	return diffs
 }
+
+// diffIPPermissionInfoDescriptions calculates permissions within source that exists in target with different description.
+// only target permissions that matches the permissionSelector are considered.
+func diffIPPermissionInfoDescriptions(source []IPPermissionInfo, target []IPPermissionInfo, permissionSelector labels.Selector) []IPPermissionInfo {
+	targetByHashCode := make(map[string]IPPermissionInfo, len(target))
+	for _, perm := range target {
+		targetByHashCode[perm.HashCode()] = perm
+	}
+
+	var diffs []IPPermissionInfo
+	for _, perm := range source {
+		targetPerm, exists := targetByHashCode[perm.HashCode()]
+		if !exists || !permissionSelector.Matches(labels.Set(targetPerm.Labels)) {
+			continue
+		}
+		if perm.Description() != targetPerm.Description() {
+			diffs = append(diffs, perm)
+		}
+	}
+	return diffs
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
 	"testing"
 )
 
@@ -215,3 +216,95 @@ func Test_diffIPPermissionInfos(t *testing.T) {
 		})
 	}
 }
+
+func Test_diffIPPermissionInfoDescriptions(t *testing.T) {
+	permissionWithDescription := func(cidr string, description string, permLabels map[string]string) IPPermissionInfo {
+		return IPPermissionInfo{
+			Permission: ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("tcp"),
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IpRanges: []*ec2sdk.IpRange{
+					{
+						CidrIp:      awssdk.String(cidr),
+						Description: awssdk.String(description),
+					},
+				},
+			},
+			Labels: permLabels,
+		}
+	}
+	type args struct {
+		source             []IPPermissionInfo
+		target             []IPPermissionInfo
+		permissionSelector labels.Selector
+	}
+	tests := []struct {
+		name string
+		args args
+		want []IPPermissionInfo
+	}{
+		{
+			name: "descriptions are same",
+			args: args{
+				source: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "k8s ELB ns-1/ing-1", nil),
+				},
+				target: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "k8s ELB ns-1/ing-1", nil),
+				},
+				permissionSelector: labels.Everything(),
+			},
+			want: nil,
+		},
+		{
+			name: "descriptions are different",
+			args: args{
+				source: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "k8s ELB ns-1/ing-1", nil),
+					permissionWithDescription("192.170.0.0/16", "k8s ELB ns-1/ing-1", nil),
+				},
+				target: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "", nil),
+					permissionWithDescription("192.170.0.0/16", "k8s ELB ns-1/ing-1", nil),
+				},
+				permissionSelector: labels.Everything(),
+			},
+			want: []IPPermissionInfo{
+				permissionWithDescription("192.168.0.0/16", "k8s ELB ns-1/ing-1", nil),
+			},
+		},
+		{
+			name: "descriptions are different on permissions that only exists in source",
+			args: args{
+				source: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "k8s ELB ns-1/ing-1", nil),
+				},
+				target: []IPPermissionInfo{
+					permissionWithDescription("192.170.0.0/16", "", nil),
+				},
+				permissionSelector: labels.Everything(),
+			},
+			want: nil,
+		},
+		{
+			name: "descriptions are different on permissions that doesn't match selector",
+			args: args{
+				source: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "k8s ELB ns-1/ing-1", nil),
+				},
+				target: []IPPermissionInfo{
+					permissionWithDescription("192.168.0.0/16", "manually added", map[string]string{"raw/description": "manually added"}),
+				},
+				permissionSelector: labels.SelectorFromSet(labels.Set{"elbv2.k8s.aws/targetGroupBinding": "shared"}),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffIPPermissionInfoDescriptions(tt.args.source, tt.args.target, tt.args.permissionSelector)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sync"
+	"time"
+)
+
+const (
+	// new SSL policies are rarely introduced, cache for 1 hour.
+	defaultSSLPoliciesCacheTTL = 1 * time.Hour
+)
+
+// SSLPolicyValidator is responsible for validating SSL policies against the SSL policies supported by ELBV2.
+type SSLPolicyValidator interface {
+	// Validate returns an error if sslPolicy isn't a known SSL policy.
+	Validate(ctx context.Context, sslPolicy string) error
+}
+
+// UnknownSSLPolicyError is returned by SSLPolicyValidator when the SSL policy isn't known to ELBV2.
+type UnknownSSLPolicyError struct {
+	SSLPolicy string
+}
+
+func (e *UnknownSSLPolicyError) Error() string {
+	return "unknown SSL policy: " + e.SSLPolicy
+}
+
+// NewDefaultSSLPolicyValidator constructs new defaultSSLPolicyValidator.
+func NewDefaultSSLPolicyValidator(elbv2Client services.ELBV2, logger logr.Logger) *defaultSSLPolicyValidator {
+	return &defaultSSLPolicyValidator{
+		elbv2Client:         elbv2Client,
+		sslPoliciesCacheTTL: defaultSSLPoliciesCacheTTL,
+		logger:              logger,
+	}
+}
+
+var _ SSLPolicyValidator = &defaultSSLPolicyValidator{}
+
+// default implementation for SSLPolicyValidator.
+// known SSL policies are cached for sslPoliciesCacheTTL.
+type defaultSSLPolicyValidator struct {
+	elbv2Client services.ELBV2
+
+	sslPolicies          sets.String
+	sslPoliciesExpiry    time.Time
+	sslPoliciesCacheTTL  time.Duration
+	sslPoliciesCacheLock sync.Mutex
+
+	logger logr.Logger
+}
+
+func (v *defaultSSLPolicyValidator) Validate(ctx context.Context, sslPolicy string) error {
+	sslPolicies, err := v.fetchSSLPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	if !sslPolicies.Has(sslPolicy) {
+		return &UnknownSSLPolicyError{SSLPolicy: sslPolicy}
+	}
+	return nil
+}
+
+// fetchSSLPolicies returns the names of known SSL policies, from cache if not expired.
+func (v *defaultSSLPolicyValidator) fetchSSLPolicies(ctx context.Context) (sets.String, error) {
+	v.sslPoliciesCacheLock.Lock()
+	defer v.sslPoliciesCacheLock.Unlock()
+
+	if v.sslPolicies != nil && time.Now().Before(v.sslPoliciesExpiry) {
+		return v.sslPolicies, nil
+	}
+	sslPolicies, err := v.fetchSSLPoliciesFromAWS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.logger.V(1).Info("fetched SSL policies", "count", sslPolicies.Len())
+	v.sslPolicies = sslPolicies
+	v.sslPoliciesExpiry = time.Now().Add(v.sslPoliciesCacheTTL)
+	return sslPolicies, nil
+}
+
+// fetchSSLPoliciesFromAWS will fetch the names of all SSL policies from AWS API.
+func (v *defaultSSLPolicyValidator) fetchSSLPoliciesFromAWS(ctx context.Context) (sets.String, error) {
+	sslPolicies := sets.NewString()
+	req := &elbv2sdk.DescribeSSLPoliciesInput{}
+	for {
+		resp, err := v.elbv2Client.DescribeSSLPoliciesWithContext(ctx, req)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe SSL policies")
+		}
+		for _, policy := range resp.SslPolicies {
+			sslPolicies.Insert(awssdk.StringValue(policy.Name))
+		}
+		if awssdk.StringValue(resp.NextMarker) == "" {
+			break
+		}
+		req.Marker = resp.NextMarker
+	}
+	return sslPolicies, nil
+}
@@ -0,0 +1,137 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultSSLPolicyValidator_Validate(t *testing.T) {
+	type describeSSLPoliciesCall struct {
+		input  *elbv2sdk.DescribeSSLPoliciesInput
+		output *elbv2sdk.DescribeSSLPoliciesOutput
+		err    error
+	}
+	type validateCall struct {
+		sslPolicy string
+		wantErr   error
+	}
+	tests := []struct {
+		name                     string
+		sslPoliciesCacheTTL      time.Duration
+		describeSSLPoliciesCalls []describeSSLPoliciesCall
+		validateCalls            []validateCall
+	}{
+		{
+			name:                "known SSL policies across pages are cached",
+			sslPoliciesCacheTTL: time.Hour,
+			describeSSLPoliciesCalls: []describeSSLPoliciesCall{
+				{
+					input: &elbv2sdk.DescribeSSLPoliciesInput{},
+					output: &elbv2sdk.DescribeSSLPoliciesOutput{
+						SslPolicies: []*elbv2sdk.SslPolicy{
+							{Name: awssdk.String("ELBSecurityPolicy-2016-08")},
+						},
+						NextMarker: awssdk.String("marker"),
+					},
+				},
+				{
+					input: &elbv2sdk.DescribeSSLPoliciesInput{
+						Marker: awssdk.String("marker"),
+					},
+					output: &elbv2sdk.DescribeSSLPoliciesOutput{
+						SslPolicies: []*elbv2sdk.SslPolicy{
+							{Name: awssdk.String("ELBSecurityPolicy-TLS-1-2-2017-01")},
+						},
+					},
+				},
+			},
+			validateCalls: []validateCall{
+				{
+					sslPolicy: "ELBSecurityPolicy-2016-08",
+				},
+				{
+					sslPolicy: "ELBSecurityPolicy-TLS-1-2-2017-01",
+				},
+				{
+					sslPolicy: "ELBSecurityPolicy-Unknown",
+					wantErr:   errors.New("unknown SSL policy: ELBSecurityPolicy-Unknown"),
+				},
+			},
+		},
+		{
+			name:                "SSL policies are fetched again once expired",
+			sslPoliciesCacheTTL: 0,
+			describeSSLPoliciesCalls: []describeSSLPoliciesCall{
+				{
+					input: &elbv2sdk.DescribeSSLPoliciesInput{},
+					output: &elbv2sdk.DescribeSSLPoliciesOutput{
+						SslPolicies: []*elbv2sdk.SslPolicy{
+							{Name: awssdk.String("ELBSecurityPolicy-2016-08")},
+						},
+					},
+				},
+				{
+					input: &elbv2sdk.DescribeSSLPoliciesInput{},
+					output: &elbv2sdk.DescribeSSLPoliciesOutput{
+						SslPolicies: []*elbv2sdk.SslPolicy{
+							{Name: awssdk.String("ELBSecurityPolicy-2016-08")},
+						},
+					},
+				},
+			},
+			validateCalls: []validateCall{
+				{
+					sslPolicy: "ELBSecurityPolicy-2016-08",
+				},
+				{
+					sslPolicy: "ELBSecurityPolicy-2016-08",
+				},
+			},
+		},
+		{
+			name:                "describe SSL policies failed",
+			sslPoliciesCacheTTL: time.Hour,
+			describeSSLPoliciesCalls: []describeSSLPoliciesCall{
+				{
+					input: &elbv2sdk.DescribeSSLPoliciesInput{},
+					err:   errors.New("some error"),
+				},
+			},
+			validateCalls: []validateCall{
+				{
+					sslPolicy: "ELBSecurityPolicy-2016-08",
+					wantErr:   errors.New("failed to describe SSL policies: some error"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeSSLPoliciesCalls {
+				elbv2Client.EXPECT().DescribeSSLPoliciesWithContext(gomock.Any(), call.input).Return(call.output, call.err)
+			}
+			v := NewDefaultSSLPolicyValidator(elbv2Client, &log.NullLogger{})
+			v.sslPoliciesCacheTTL = tt.sslPoliciesCacheTTL
+			for _, call := range tt.validateCalls {
+				err := v.Validate(context.Background(), call.sslPolicy)
+				if call.wantErr != nil {
+					assert.EqualError(t, err, call.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"fmt"
+	"github.com/pkg/errors"
 	"time"
 )
 
@@ -61,3 +62,23 @@ func (e *RequeueNeededAfter) Duration() time.Duration {
 func (e *RequeueNeededAfter) Error() string {
 	return fmt.Sprintf("requeue needed after %v: %v", e.duration, e.reason)
 }
+
+// RequeueNeededReason returns the reason if err instructs to requeue the processing item, either immediately or after some duration.
+func RequeueNeededReason(err error) (string, bool) {
+	var requeueNeeded *RequeueNeeded
+	if errors.As(err, &requeueNeeded) {
+		return requeueNeeded.Reason(), true
+	}
+	var requeueNeededAfter *RequeueNeededAfter
+	if errors.As(err, &requeueNeededAfter) {
+		return requeueNeededAfter.Reason(), true
+	}
+	return "", false
+}
+
+// IsRequeueNeeded checks whether err instructs to requeue the processing item, either immediately or after some duration.
+func IsRequeueNeeded(err error) bool {
+	var requeueNeeded *RequeueNeeded
+	var requeueNeededAfter *RequeueNeededAfter
+	return errors.As(err, &requeueNeeded) || errors.As(err, &requeueNeededAfter)
+}
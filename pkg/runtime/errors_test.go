@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -60,3 +61,66 @@ func TestNewRequeueNeededAfter(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRequeueNeeded(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "RequeueNeeded",
+			err:  NewRequeueNeeded("some reason"),
+			want: true,
+		},
+		{
+			name: "wrapped RequeueNeededAfter",
+			err:  errors.Wrap(NewRequeueNeededAfter("some reason", time.Second), "some context"),
+			want: true,
+		},
+		{
+			name: "other error",
+			err:  errors.New("some error"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRequeueNeeded(tt.err))
+		})
+	}
+}
+
+func TestRequeueNeededReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantOK     bool
+	}{
+		{
+			name:       "RequeueNeeded",
+			err:        NewRequeueNeeded("some reason"),
+			wantReason: "some reason",
+			wantOK:     true,
+		},
+		{
+			name:       "wrapped RequeueNeededAfter",
+			err:        errors.Wrap(NewRequeueNeededAfter("some other reason", time.Second), "some context"),
+			wantReason: "some other reason",
+			wantOK:     true,
+		},
+		{
+			name:   "other error",
+			err:    errors.New("some error"),
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReason, gotOK := RequeueNeededReason(tt.err)
+			assert.Equal(t, tt.wantReason, gotReason)
+			assert.Equal(t, tt.wantOK, gotOK)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"strings"
+)
+
+// MultiNamespacedCacheBuilder builds a cache that restricts namespaced objects to specified namespaces,
+// while cluster-scoped objects(e.g. nodes) are still served from a cluster-wide cache.
+// the cache from controller-runtime's cache.MultiNamespacedCacheBuilder cannot serve cluster-scoped objects.
+func MultiNamespacedCacheBuilder(namespaces []string) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		if opts.Mapper == nil {
+			mapper, err := apiutil.NewDynamicRESTMapper(config)
+			if err != nil {
+				return nil, err
+			}
+			opts.Mapper = mapper
+		}
+		namespacedCache, err := cache.MultiNamespacedCacheBuilder(namespaces)(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		clusterOpts := opts
+		clusterOpts.Namespace = ""
+		clusterCache, err := cache.New(config, clusterOpts)
+		if err != nil {
+			return nil, err
+		}
+		return &multiNamespaceCache{
+			namespaces:      sets.NewString(namespaces...),
+			namespacedCache: namespacedCache,
+			clusterCache:    clusterCache,
+			scheme:          opts.Scheme,
+			mapper:          opts.Mapper,
+		}, nil
+	}
+}
+
+var _ cache.Cache = &multiNamespaceCache{}
+
+// multiNamespaceCache dispatches requests for namespaced objects to namespacedCache,
+// and requests for cluster-scoped objects to clusterCache.
+// namespaced objects outside watched namespaces are treated as non-existent, which matches the behavior of single namespace cache.
+type multiNamespaceCache struct {
+	namespaces      sets.String
+	namespacedCache cache.Cache
+	clusterCache    cache.Cache
+	scheme          *runtime.Scheme
+	mapper          meta.RESTMapper
+}
+
+func (c *multiNamespaceCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	objCache, err := c.cacheForObject(obj, false)
+	if err != nil {
+		return err
+	}
+	if objCache == c.namespacedCache && !c.namespaces.Has(key.Namespace) {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return err
+		}
+		// NotFound errors are expected to carry the plural resource(e.g. services) rather than the kind, same as API server.
+		mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get restMapping for %v", gvk)
+		}
+		return apierrors.NewNotFound(mapping.Resource.GroupResource(), key.Name)
+	}
+	return objCache.Get(ctx, key, obj)
+}
+
+func (c *multiNamespaceCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	objCache, err := c.cacheForObject(list, true)
+	if err != nil {
+		return err
+	}
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if objCache == c.namespacedCache && listOpts.Namespace != "" && !c.namespaces.Has(listOpts.Namespace) {
+		return meta.SetList(list, nil)
+	}
+	return objCache.List(ctx, list, opts...)
+}
+
+func (c *multiNamespaceCache) GetInformer(ctx context.Context, obj runtime.Object) (cache.Informer, error) {
+	objCache, err := c.cacheForObject(obj, false)
+	if err != nil {
+		return nil, err
+	}
+	return objCache.GetInformer(ctx, obj)
+}
+
+func (c *multiNamespaceCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	objCache, err := c.cacheForKind(gvk)
+	if err != nil {
+		return nil, err
+	}
+	return objCache.GetInformerForKind(ctx, gvk)
+}
+
+func (c *multiNamespaceCache) Start(stopCh <-chan struct{}) error {
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- c.clusterCache.Start(stopCh)
+	}()
+	go func() {
+		errCh <- c.namespacedCache.Start(stopCh)
+	}()
+	for i := 0; i < 2; i++ {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *multiNamespaceCache) WaitForCacheSync(stop <-chan struct{}) bool {
+	return c.clusterCache.WaitForCacheSync(stop) && c.namespacedCache.WaitForCacheSync(stop)
+}
+
+func (c *multiNamespaceCache) IndexField(ctx context.Context, obj runtime.Object, field string, extractValue client.IndexerFunc) error {
+	objCache, err := c.cacheForObject(obj, false)
+	if err != nil {
+		return err
+	}
+	return objCache.IndexField(ctx, obj, field, extractValue)
+}
+
+// cacheForObject returns the cache that should serve specified object or list of objects.
+func (c *multiNamespaceCache) cacheForObject(obj runtime.Object, isList bool) (cache.Cache, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	if isList {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	return c.cacheForKind(gvk)
+}
+
+// cacheForKind returns the cache that should serve objects of specified kind.
+func (c *multiNamespaceCache) cacheForKind(gvk schema.GroupVersionKind) (cache.Cache, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get restMapping for %v", gvk)
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.namespacedCache, nil
+	}
+	return c.clusterCache, nil
+}
@@ -0,0 +1,32 @@
+package runtime
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"testing"
+)
+
+func Test_multiNamespaceCache_Get(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	c := &multiNamespaceCache{
+		namespaces:      sets.NewString("awesome-ns"),
+		namespacedCache: &informertest.FakeInformers{},
+		clusterCache:    &informertest.FakeInformers{},
+		scheme:          clientgoscheme.Scheme,
+		mapper:          mapper,
+	}
+
+	svc := &corev1.Service{}
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: "other-ns", Name: "awesome-svc"}, svc)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Equal(t, apierrors.NewNotFound(schema.GroupResource{Resource: "services"}, "awesome-svc"), err)
+}
@@ -0,0 +1,23 @@
+package runtime
+
+import (
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"time"
+)
+
+const (
+	reconcileBaseBackoff = 5 * time.Millisecond
+	// overall retry rate, this is only for retry speed and it's only the overall factor (not per item).
+	reconcileRetryQPS   = 10
+	reconcileRetryBurst = 100
+)
+
+// NewReconcileRateLimiter constructs the rate limiter for controller workqueues.
+// It's same as controller-runtime's default rate limiter, except the per-item exponential backoff is capped by maxBackoff.
+func NewReconcileRateLimiter(maxBackoff time.Duration) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(reconcileBaseBackoff, maxBackoff),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(reconcileRetryQPS), reconcileRetryBurst)},
+	)
+}
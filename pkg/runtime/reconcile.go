@@ -1,13 +1,17 @@
 package runtime
 
 import (
+	"context"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"time"
 )
 
 // HandleReconcileError will handle errors from reconcile handlers, which respects runtime errors.
-func HandleReconcileError(err error, log logr.Logger) (ctrl.Result, error) {
+// If terminalErrorRequeueInterval is positive, terminal errors are requeued after that interval instead of retried with backoff,
+// so that they don't consume the workqueue retries.
+func HandleReconcileError(err error, terminalErrorRequeueInterval time.Duration, log logr.Logger) (ctrl.Result, error) {
 	if err == nil {
 		return ctrl.Result{}, nil
 	}
@@ -24,5 +28,26 @@ func HandleReconcileError(err error, log logr.Logger) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	if terminalErrorRequeueInterval > 0 && IsTerminalError(err) {
+		log.Error(err, "terminal error, requeue after", "duration", terminalErrorRequeueInterval)
+		return ctrl.Result{RequeueAfter: terminalErrorRequeueInterval}, nil
+	}
+
 	return ctrl.Result{}, err
 }
+
+// ReconcileWithTimeout invokes reconcile with a context that's cancelled after timeout if timeout is positive,
+// so that stuck calls are cancelled and the reconcile is retried with backoff like other errors.
+func ReconcileWithTimeout(timeout time.Duration, reconcile func(ctx context.Context) error) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := reconcile(ctx)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return errors.Wrapf(err, "reconcile timed out after %v", timeout)
+	}
+	return err
+}
@@ -1,9 +1,18 @@
 package runtime
 
 import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 	"time"
@@ -11,7 +20,8 @@ import (
 
 func TestHandleReconcileError(t *testing.T) {
 	type args struct {
-		err error
+		err                          error
+		terminalErrorRequeueInterval time.Duration
 	}
 	tests := []struct {
 		name    string
@@ -47,6 +57,34 @@ func TestHandleReconcileError(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "input err is terminal AWS error",
+			args: args{
+				err:                          errors.Wrap(awserr.New("InvalidConfigurationRequest", "invalid configuration", nil), "failed to create listener"),
+				terminalErrorRequeueInterval: 10 * time.Minute,
+			},
+			want: ctrl.Result{
+				RequeueAfter: 10 * time.Minute,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "input err is terminal AWS error without terminal error requeue interval",
+			args: args{
+				err: awserr.New("InvalidConfigurationRequest", "invalid configuration", nil),
+			},
+			want:    ctrl.Result{},
+			wantErr: errors.New("InvalidConfigurationRequest: invalid configuration"),
+		},
+		{
+			name: "input err is transient AWS error",
+			args: args{
+				err:                          awserr.New("Throttling", "rate exceeded", nil),
+				terminalErrorRequeueInterval: 10 * time.Minute,
+			},
+			want:    ctrl.Result{},
+			wantErr: errors.New("Throttling: rate exceeded"),
+		},
 		{
 			name: "input err is other error type",
 			args: args{
@@ -58,7 +96,7 @@ func TestHandleReconcileError(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := HandleReconcileError(tt.args.err, &log.NullLogger{})
+			got, err := HandleReconcileError(tt.args.err, tt.args.terminalErrorRequeueInterval, &log.NullLogger{})
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -68,3 +106,65 @@ func TestHandleReconcileError(t *testing.T) {
 		})
 	}
 }
+
+// slowClient is a client that delays each Get until delay passes or ctx is done.
+type slowClient struct {
+	client.Client
+	delay time.Duration
+}
+
+func (c *slowClient) Get(ctx context.Context, key client.ObjectKey, obj k8sruntime.Object) error {
+	select {
+	case <-time.After(c.delay):
+		return c.Client.Get(ctx, key, obj)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestReconcileWithTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		clientDelay time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "reconcile completes without timeout",
+			timeout:     0,
+			clientDelay: 10 * time.Millisecond,
+		},
+		{
+			name:        "reconcile completes within timeout",
+			timeout:     time.Minute,
+			clientDelay: 10 * time.Millisecond,
+		},
+		{
+			name:        "reconcile times out on slow client",
+			timeout:     10 * time.Millisecond,
+			clientDelay: time.Minute,
+			wantErr:     errors.New("reconcile timed out after 10ms: context deadline exceeded"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := k8sruntime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := &slowClient{
+				Client: testclient.NewFakeClientWithScheme(k8sSchema, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc"},
+				}),
+				delay: tt.clientDelay,
+			}
+			err := ReconcileWithTimeout(tt.timeout, func(ctx context.Context) error {
+				svc := &corev1.Service{}
+				return k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-svc"}, svc)
+			})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
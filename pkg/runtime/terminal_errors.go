@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// terminalAWSErrorCodes are AWS error codes caused by invalid configurations or permissions,
+// which won't be resolved by retry until the configuration is changed.
+// AWS errors with other codes(e.g. throttling, internal failures) are considered transient.
+// AWS errors of resources not found are considered transient as well, since these resources might be created later or
+// not visible yet due to eventual consistency.
+var terminalAWSErrorCodes = sets.NewString(
+	// common
+	"AccessDenied",
+	"AccessDeniedException",
+	"UnauthorizedOperation",
+	"ValidationError",
+	"InvalidParameterValue",
+	"InvalidParameterCombination",
+	// elbv2
+	"SSLPolicyNotFound",
+	"InvalidConfigurationRequest",
+	"InvalidSubnet",
+	"InvalidSecurityGroup",
+	"InvalidScheme",
+	"InvalidLoadBalancerAction",
+	"AvailabilityZoneNotSupported",
+	"UnsupportedProtocol",
+	"IncompatibleProtocols",
+	"TooManyLoadBalancers",
+	"TooManyTargetGroups",
+	"TooManyListeners",
+	"TooManyRules",
+	"TooManyCertificates",
+	"TooManyActions",
+	"TooManyTags",
+	// ec2
+	"SecurityGroupLimitExceeded",
+	"RulesPerSecurityGroupLimitExceeded",
+	// wafv2 & wafRegional & shield
+	"WAFInvalidParameterException",
+)
+
+// IsTerminalError tests whether err is caused by an AWS error that won't be resolved by retry.
+func IsTerminalError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return terminalAWSErrorCodes.Has(awsErr.Code())
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsTerminalError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "terminal AWS error",
+			err:  awserr.New("ValidationError", "invalid parameter", nil),
+			want: true,
+		},
+		{
+			name: "permission AWS error",
+			err:  awserr.New("AccessDenied", "access denied", nil),
+			want: true,
+		},
+		{
+			name: "resource not found AWS error",
+			err:  awserr.New("InvalidGroup.NotFound", "security group not found", nil),
+			want: false,
+		},
+		{
+			name: "certificate not found AWS error",
+			err:  awserr.New("CertificateNotFound", "certificate not found", nil),
+			want: false,
+		},
+		{
+			name: "wrapped terminal AWS error",
+			err:  errors.Wrap(awserr.New("InvalidConfigurationRequest", "invalid configuration", nil), "failed to modify listener"),
+			want: true,
+		},
+		{
+			name: "throttling AWS error",
+			err:  awserr.New("Throttling", "rate exceeded", nil),
+			want: false,
+		},
+		{
+			name: "internal AWS error",
+			err:  awserr.New("InternalFailure", "internal failure", nil),
+			want: false,
+		},
+		{
+			name: "non-AWS error",
+			err:  errors.New("some error"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsTerminalError(tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
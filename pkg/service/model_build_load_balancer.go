@@ -12,7 +12,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
@@ -108,7 +111,11 @@ func (t *defaultModelBuildTask) buildAdditionalResourceTags(_ context.Context) (
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerTags(ctx context.Context) (map[string]string, error) {
-	return t.buildAdditionalResourceTags(ctx)
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return algorithm.MergeStringMap(k8s.BuildResourceMetadataTags(t.resourceNamespaceTagKey, t.resourceNameTagKey, t.service.Namespace, t.service.Name), tags), nil
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme, ec2Subnets []*ec2.Subnet) ([]elbv2model.SubnetMapping, error) {
@@ -127,6 +134,11 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 		} else if len(eipAllocation) != len(ec2Subnets) {
 			return []elbv2model.SubnetMapping{}, errors.Errorf("number of EIP allocations (%d) and subnets (%d) must match", len(eipAllocation), len(ec2Subnets))
 		}
+		if duplicates := findDuplicates(eipAllocation); len(duplicates) != 0 {
+			return []elbv2model.SubnetMapping{}, errors.Errorf("EIP allocations must be unique: %v", duplicates)
+		}
+		// EIP allocations are mapped to subnets in the order of subnets annotation if specified, otherwise in the order of subnetID.
+		ec2Subnets = t.orderSubnetsBySubnetsAnnotation(ec2Subnets)
 	}
 	if ipv4Configured {
 		if scheme == elbv2model.LoadBalancerSchemeInternetFacing {
@@ -163,30 +175,120 @@ func (t *defaultModelBuildTask) getMatchingIPforSubnet(_ context.Context, subnet
 	if err != nil {
 		return "", errors.Wrap(err, "subnet CIDR block could not be parsed")
 	}
+	var matchedIPs []string
 	for _, ipString := range privateIpv4Addresses {
 		ip := net.ParseIP(ipString)
 		if ip == nil {
 			return "", errors.Errorf("cannot parse ip %s", ipString)
 		}
+		if ip.To4() == nil {
+			return "", errors.Errorf("ip %s is not an IPv4 address", ipString)
+		}
 		if ipv4Net.Contains(ip) {
-			return ipString, nil
+			matchedIPs = append(matchedIPs, ipString)
+		}
+	}
+	if len(matchedIPs) == 0 {
+		return "", errors.Errorf("no matching ip for subnet %s", *subnet.SubnetId)
+	}
+	if len(matchedIPs) > 1 {
+		return "", errors.Errorf("multiple matching ips for subnet %s: %v", *subnet.SubnetId, matchedIPs)
+	}
+	return matchedIPs[0], nil
+}
+
+// orderSubnetsBySubnetsAnnotation orders subnets by the order they are specified in subnets annotation.
+// subnets not matched by the annotation are kept at the end in their original order.
+func (t *defaultModelBuildTask) orderSubnetsBySubnetsAnnotation(ec2Subnets []*ec2.Subnet) []*ec2.Subnet {
+	var rawSubnetNameOrIDs []string
+	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSubnets, &rawSubnetNameOrIDs, t.service.Annotations); !exists {
+		return ec2Subnets
+	}
+	orderedSubnets := make([]*ec2.Subnet, 0, len(ec2Subnets))
+	ordered := make(map[*ec2.Subnet]bool, len(ec2Subnets))
+	for _, nameOrID := range rawSubnetNameOrIDs {
+		for _, subnet := range ec2Subnets {
+			if ordered[subnet] || !subnetMatchesNameOrID(subnet, nameOrID) {
+				continue
+			}
+			orderedSubnets = append(orderedSubnets, subnet)
+			ordered[subnet] = true
+			break
+		}
+	}
+	for _, subnet := range ec2Subnets {
+		if !ordered[subnet] {
+			orderedSubnets = append(orderedSubnets, subnet)
+		}
+	}
+	return orderedSubnets
+}
+
+func subnetMatchesNameOrID(subnet *ec2.Subnet, nameOrID string) bool {
+	if aws.StringValue(subnet.SubnetId) == nameOrID {
+		return true
+	}
+	for _, tag := range subnet.Tags {
+		if aws.StringValue(tag.Key) == "Name" && aws.StringValue(tag.Value) == nameOrID {
+			return true
+		}
+	}
+	return false
+}
+
+func findDuplicates(values []string) []string {
+	seen := sets.NewString()
+	duplicates := sets.NewString()
+	for _, value := range values {
+		if seen.Has(value) {
+			duplicates.Insert(value)
 		}
+		seen.Insert(value)
 	}
-	return "", errors.Errorf("no matching ip for subnet %s", *subnet.SubnetId)
+	return duplicates.List()
 }
 
 func (t *defaultModelBuildTask) resolveLoadBalancerSubnets(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]*ec2.Subnet, error) {
+	var ec2Subnets []*ec2.Subnet
+	var err error
 	var rawSubnetNameOrIDs []string
 	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSubnets, &rawSubnetNameOrIDs, t.service.Annotations); exists {
-		return t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, rawSubnetNameOrIDs,
+		ec2Subnets, err = t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, rawSubnetNameOrIDs,
 			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
 			networking.WithSubnetsResolveLBScheme(scheme),
 		)
+	} else {
+		ec2Subnets, err = t.subnetsResolver.ResolveViaDiscovery(ctx,
+			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
+			networking.WithSubnetsResolveLBScheme(scheme),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLoadBalancerSubnetsAZExclusivity(ec2Subnets); err != nil {
+		return nil, err
+	}
+	return ec2Subnets, nil
+}
+
+// validateLoadBalancerSubnetsAZExclusivity validates subnets of network load balancer belong to distinct Availability Zones,
+// as a network load balancer only supports one subnet per Availability Zone. Subnets with unknown Availability Zone are ignored.
+func validateLoadBalancerSubnetsAZExclusivity(ec2Subnets []*ec2.Subnet) error {
+	subnetIDsByAZ := make(map[string][]string)
+	for _, subnet := range ec2Subnets {
+		az := aws.StringValue(subnet.AvailabilityZone)
+		if az == "" {
+			continue
+		}
+		subnetIDsByAZ[az] = append(subnetIDsByAZ[az], aws.StringValue(subnet.SubnetId))
 	}
-	return t.subnetsResolver.ResolveViaDiscovery(ctx,
-		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
-		networking.WithSubnetsResolveLBScheme(scheme),
-	)
+	for _, az := range sets.StringKeySet(subnetIDsByAZ).List() {
+		if subnetIDs := subnetIDsByAZ[az]; len(subnetIDs) > 1 {
+			return errors.Errorf("subnets must be in distinct Availability Zones, multiple subnets in Availability Zone %v: %v", az, subnetIDs)
+		}
+	}
+	return nil
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
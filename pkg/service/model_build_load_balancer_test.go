@@ -220,6 +220,70 @@ func Test_defaultModelBuilderTask_buildSubnetMappings(t *testing.T) {
 			},
 			wantErr: errors.New("number of EIP allocations (1) and subnets (2) must match"),
 		},
+		{
+			name:   "When EIP allocation is configured with subnets annotation",
+			scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					Tags: []*ec2.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("public-b"),
+						},
+					},
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets":         "public-b, subnet-1",
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+					},
+				},
+			},
+			want: []elbv2.SubnetMapping{
+				{
+					SubnetID:     "subnet-2",
+					AllocationID: aws.String("eip1"),
+				},
+				{
+					SubnetID:     "subnet-1",
+					AllocationID: aws.String("eip2"),
+				},
+			},
+		},
+		{
+			name:   "When EIP allocations are duplicated",
+			scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip1",
+					},
+				},
+			},
+			wantErr: errors.New("EIP allocations must be unique: [eip1]"),
+		},
 		{
 			name:   "When PrivateIpv4Addresses is configured",
 			scheme: elbv2.LoadBalancerSchemeInternal,
@@ -449,6 +513,28 @@ func Test_defaultModelBuilderTask_getMatchingIPforSubnet(t *testing.T) {
 			privateIpv4Addresses: []string{"172.100.1.1", "172.200.1.1"},
 			wantErr:              errors.New("no matching ip for subnet subnet-1"),
 		},
+		{
+			name: "When IP is not IPv4",
+			subnet: &ec2.Subnet{
+				SubnetId:         aws.String("subnet-1"),
+				AvailabilityZone: aws.String("us-west-2a"),
+				VpcId:            aws.String("vpc-1"),
+				CidrBlock:        aws.String("172.16.0.0/16"),
+			},
+			privateIpv4Addresses: []string{"2600:1f13::1", "172.16.1.1"},
+			wantErr:              errors.New("ip 2600:1f13::1 is not an IPv4 address"),
+		},
+		{
+			name: "When multiple ips in cidr range",
+			subnet: &ec2.Subnet{
+				SubnetId:         aws.String("subnet-1"),
+				AvailabilityZone: aws.String("us-west-2a"),
+				VpcId:            aws.String("vpc-1"),
+				CidrBlock:        aws.String("172.16.0.0/16"),
+			},
+			privateIpv4Addresses: []string{"172.16.1.1", "172.16.2.1"},
+			wantErr:              errors.New("multiple matching ips for subnet subnet-1: [172.16.1.1 172.16.2.1]"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -479,6 +565,7 @@ func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets(t *testing.T) {
 		scheme                   elbv2.LoadBalancerScheme
 		resolveViaDiscovery      []resolveSubnetResults
 		resolveViaNameOrIDSlilce []resolveSubnetResults
+		wantErr                  error
 	}{
 		{
 			name:   "subnet auto-discovery",
@@ -520,6 +607,83 @@ func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "subnet annotation with single subnet",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc",
+					},
+				},
+			},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-abc"),
+							CidrBlock:        aws.String("192.168.0.0/19"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnet annotation with subnets in distinct AZs",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc, subnet-xyz",
+					},
+				},
+			},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-abc"),
+							CidrBlock:        aws.String("192.168.0.0/19"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-xyz"),
+							CidrBlock:        aws.String("192.168.32.0/19"),
+							AvailabilityZone: aws.String("us-west-2b"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnet annotation with two subnets in same AZ",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc, subnet-xyz",
+					},
+				},
+			},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-abc"),
+							CidrBlock:        aws.String("192.168.0.0/19"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-xyz"),
+							CidrBlock:        aws.String("192.168.32.0/19"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("subnets must be in distinct Availability Zones, multiple subnets in Availability Zone us-west-2a: [subnet-abc subnet-xyz]"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -536,7 +700,12 @@ func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: annotationParser, subnetsResolver: subnetsResolver}
 
-			builder.resolveLoadBalancerSubnets(context.Background(), tt.scheme)
+			_, err := builder.resolveLoadBalancerSubnets(context.Background(), tt.scheme)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
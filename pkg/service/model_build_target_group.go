@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
@@ -11,8 +12,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -23,7 +26,27 @@ import (
 const (
 	tgAttrsProxyProtocolV2Enabled  = "proxy_protocol_v2.enabled"
 	tgAttrsPreserveClientIPEnabled = "preserve_client_ip.enabled"
+	tgAttrsCrossZoneEnabled        = "load_balancing.cross_zone.enabled"
 	healthCheckPortTrafficPort     = "traffic-port"
+
+	tgCrossZoneEnabledUseLoadBalancerConfiguration = "use_load_balancer_configuration"
+
+	tgAttrsTargetFailoverOnDeregistration = "target_failover.on_deregistration"
+	tgAttrsTargetFailoverOnUnhealthy      = "target_failover.on_unhealthy"
+	tgTargetFailoverRebalance             = "rebalance"
+	tgTargetFailoverNoRebalance           = "no_rebalance"
+)
+
+// health check settings supported by NLB target groups.
+const (
+	nlbHealthCheckInterval10Seconds  int64 = 10
+	nlbHealthCheckInterval30Seconds  int64 = 30
+	minNLBHealthCheckTimeoutSeconds  int64 = 2
+	maxNLBHealthCheckTimeoutSeconds  int64 = 120
+	minNLBHealthCheckThresholdCount  int64 = 2
+	maxNLBHealthCheckThresholdCount  int64 = 10
+	minNLBHealthCheckMatcherHTTPCode int64 = 200
+	maxNLBHealthCheckMatcherHTTPCode int64 = 599
 )
 
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev1.ServicePort, tgProtocol elbv2model.Protocol) (*elbv2model.TargetGroup, error) {
@@ -32,7 +55,7 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 	if targetGroup, exists := t.tgByResID[tgResourceID]; exists {
 		return targetGroup, nil
 	}
-	targetType, err := t.buildTargetType(ctx)
+	targetType, err := t.buildTargetType(ctx, port)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +63,22 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 	if err != nil {
 		return nil, err
 	}
-	tgAttrs, err := t.buildTargetGroupAttributes(ctx)
+	shareTargetGroups, err := t.buildShareTargetGroupsFlag(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var tgBackendKey string
+	if shareTargetGroups {
+		tgBackendKey, err = t.buildTargetGroupBackendKey(ctx, port, targetType, tgProtocol, healthCheckConfig)
+		if err != nil {
+			return nil, err
+		}
+		if targetGroup, exists := t.tgByBackendKey[tgBackendKey]; exists {
+			t.tgByResID[tgResourceID] = targetGroup
+			return targetGroup, nil
+		}
+	}
+	tgAttrs, err := t.buildTargetGroupAttributes(ctx, tgProtocol)
 	if err != nil {
 		return nil, err
 	}
@@ -58,9 +96,35 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 		return nil, err
 	}
 	t.tgByResID[tgResourceID] = targetGroup
+	if shareTargetGroups {
+		t.tgByBackendKey[tgBackendKey] = targetGroup
+	}
 	return targetGroup, nil
 }
 
+func (t *defaultModelBuildTask) buildShareTargetGroupsFlag(_ context.Context) (bool, error) {
+	shareTargetGroups := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixShareTargetGroups, &shareTargetGroups, t.service.Annotations); err != nil {
+		return false, err
+	}
+	return shareTargetGroups, nil
+}
+
+// buildTargetGroupBackendKey constructs the key identifying the backend of a TargetGroup,
+// ServicePorts with the same key can be served by the same TargetGroup.
+func (t *defaultModelBuildTask) buildTargetGroupBackendKey(_ context.Context, port corev1.ServicePort, targetType elbv2model.TargetType,
+	tgProtocol elbv2model.Protocol, hc *elbv2model.TargetGroupHealthCheckConfig) (string, error) {
+	backendPort := port.TargetPort.String()
+	if targetType == elbv2model.TargetTypeInstance {
+		backendPort = strconv.Itoa(int(port.NodePort))
+	}
+	rawHealthCheckConfig, err := json.Marshal(hc)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v/%v/%v/%s", targetType, backendPort, tgProtocol, rawHealthCheckConfig), nil
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context, tgProtocol elbv2model.Protocol, targetType elbv2model.TargetType,
 	port corev1.ServicePort, healthCheckConfig *elbv2model.TargetGroupHealthCheckConfig, tgAttrs []elbv2model.TargetGroupAttribute) (elbv2model.TargetGroupSpec, error) {
 	tags, err := t.buildTargetGroupTags(ctx)
@@ -84,10 +148,10 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	if targetType == elbv2model.TargetTypeInstance && t.service.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
 		return t.buildTargetGroupHealthCheckConfigForInstanceModeLocal(ctx)
 	}
-	return t.buildTargetGroupHealthCheckConfigDefault(ctx)
+	return t.buildTargetGroupHealthCheckConfigDefault(ctx, targetType)
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigDefault(ctx context.Context) (*elbv2model.TargetGroupHealthCheckConfig, error) {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigDefault(ctx context.Context, targetType elbv2model.TargetType) (*elbv2model.TargetGroupHealthCheckConfig, error) {
 	healthCheckProtocol, err := t.buildTargetGroupHealthCheckProtocol(ctx, t.defaultHealthCheckProtocol)
 	if err != nil {
 		return nil, err
@@ -96,7 +160,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigDefault(ctx con
 	if healthCheckProtocol != elbv2model.ProtocolTCP {
 		healthCheckPathPtr = t.buildTargetGroupHealthCheckPath(ctx, t.defaultHealthCheckPath)
 	}
-	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, t.defaultHealthCheckPort)
+	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, t.defaultHealthCheckPort, targetType)
 	if err != nil {
 		return nil, err
 	}
@@ -112,11 +176,21 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigDefault(ctx con
 	if err != nil {
 		return nil, err
 	}
+	timeoutSecondsPtr, err := t.buildTargetGroupHealthCheckTimeoutSeconds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matcherPtr, err := t.buildTargetGroupHealthCheckMatcher(ctx, healthCheckProtocol)
+	if err != nil {
+		return nil, err
+	}
 	return &elbv2model.TargetGroupHealthCheckConfig{
 		Port:                    &healthCheckPort,
 		Protocol:                &healthCheckProtocol,
 		Path:                    healthCheckPathPtr,
+		Matcher:                 matcherPtr,
 		IntervalSeconds:         &intervalSeconds,
+		TimeoutSeconds:          timeoutSecondsPtr,
 		HealthyThresholdCount:   &healthyThresholdCount,
 		UnhealthyThresholdCount: &unhealthyThresholdCount,
 	}, nil
@@ -131,10 +205,14 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigForInstanceMode
 	if healthCheckProtocol != elbv2model.ProtocolTCP {
 		healthCheckPathPtr = t.buildTargetGroupHealthCheckPath(ctx, t.defaultHealthCheckPathForInstanceModeLocal)
 	}
-	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, t.defaultHealthCheckPortForInstanceModeLocal)
+	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, t.defaultHealthCheckPortForInstanceModeLocal, elbv2model.TargetTypeInstance)
 	if err != nil {
 		return nil, err
 	}
+	// healthCheckNodePort is allocated by Kubernetes for Local policy, zero means it's not allocated yet.
+	if healthCheckPort.Type == intstr.Int && healthCheckPort.IntVal == 0 {
+		return nil, errors.New("healthCheckNodePort must be allocated for service with Local externalTrafficPolicy")
+	}
 	intervalSeconds, err := t.buildTargetGroupHealthCheckIntervalSeconds(ctx, t.defaultHealthCheckIntervalForInstanceModeLocal)
 	if err != nil {
 		return nil, err
@@ -147,11 +225,21 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigForInstanceMode
 	if err != nil {
 		return nil, err
 	}
+	timeoutSecondsPtr, err := t.buildTargetGroupHealthCheckTimeoutSeconds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matcherPtr, err := t.buildTargetGroupHealthCheckMatcher(ctx, healthCheckProtocol)
+	if err != nil {
+		return nil, err
+	}
 	return &elbv2model.TargetGroupHealthCheckConfig{
 		Port:                    &healthCheckPort,
 		Protocol:                &healthCheckProtocol,
 		Path:                    healthCheckPathPtr,
+		Matcher:                 matcherPtr,
 		IntervalSeconds:         &intervalSeconds,
+		TimeoutSeconds:          timeoutSecondsPtr,
 		HealthyThresholdCount:   &healthyThresholdCount,
 		UnhealthyThresholdCount: &unhealthyThresholdCount,
 	}, nil
@@ -185,7 +273,7 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context, svcPort
 	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([]elbv2model.TargetGroupAttribute, error) {
+func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, tgProtocol elbv2model.Protocol) ([]elbv2model.TargetGroupAttribute, error) {
 	var rawAttributes map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixTargetGroupAttributes, &rawAttributes, t.service.Annotations); err != nil {
 		return nil, err
@@ -193,10 +281,11 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 	if rawAttributes == nil {
 		rawAttributes = make(map[string]string)
 	}
-	if _, ok := rawAttributes[tgAttrsProxyProtocolV2Enabled]; !ok {
+	if _, ok := rawAttributes[tgAttrsProxyProtocolV2Enabled]; !ok && !t.preserveUnmanagedTGAttributes {
 		rawAttributes[tgAttrsProxyProtocolV2Enabled] = strconv.FormatBool(t.defaultProxyProtocolV2Enabled)
 	}
 	proxyV2Annotation := ""
+	crossZoneEnabled := ""
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixProxyProtocol, &proxyV2Annotation, t.service.Annotations); exists {
 		if proxyV2Annotation != "*" {
 			return []elbv2model.TargetGroupAttribute{}, errors.Errorf("invalid value %v for Load Balancer proxy protocol v2 annotation, only value currently supported is *", proxyV2Annotation)
@@ -209,6 +298,32 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 			return nil, errors.Wrapf(err, "failed to parse attribute %v=%v", tgAttrsPreserveClientIPEnabled, rawPreserveIPEnabled)
 		}
 	}
+	if t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixTargetGroupCrossZoneEnabled, &crossZoneEnabled, t.service.Annotations) {
+		rawAttributes[tgAttrsCrossZoneEnabled] = crossZoneEnabled
+	}
+	if rawCrossZoneEnabled, ok := rawAttributes[tgAttrsCrossZoneEnabled]; ok {
+		if err := validateTargetGroupCrossZoneEnabled(tgProtocol, rawCrossZoneEnabled); err != nil {
+			return nil, err
+		}
+	}
+	targetFailoverAnnotations := []struct {
+		annotation string
+		attrKey    string
+	}{
+		{annotation: annotations.SvcLBSuffixTGFailoverOnDeregistration, attrKey: tgAttrsTargetFailoverOnDeregistration},
+		{annotation: annotations.SvcLBSuffixTGFailoverOnUnhealthy, attrKey: tgAttrsTargetFailoverOnUnhealthy},
+	}
+	for _, item := range targetFailoverAnnotations {
+		targetFailover := ""
+		if t.annotationParser.ParseStringAnnotation(item.annotation, &targetFailover, t.service.Annotations) {
+			rawAttributes[item.attrKey] = targetFailover
+		}
+		if rawTargetFailover, ok := rawAttributes[item.attrKey]; ok {
+			if err := validateTargetGroupTargetFailover(tgProtocol, item.attrKey, rawTargetFailover); err != nil {
+				return nil, err
+			}
+		}
+	}
 	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
 	for attrKey, attrValue := range rawAttributes {
 		attributes = append(attributes, elbv2model.TargetGroupAttribute{
@@ -219,6 +334,42 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 	return attributes, nil
 }
 
+// validateTargetGroupCrossZoneEnabled validates the target group level cross-zone setting, which is only supported by NLB target groups.
+func validateTargetGroupCrossZoneEnabled(tgProtocol elbv2model.Protocol, crossZoneEnabled string) error {
+	if !isNLBTargetGroupProtocol(tgProtocol) {
+		return errors.Errorf("target group level cross-zone load balancing is only supported for NLB target groups, protocol: %v", tgProtocol)
+	}
+	switch crossZoneEnabled {
+	case "true", "false", tgCrossZoneEnabledUseLoadBalancerConfiguration:
+		return nil
+	default:
+		return errors.Errorf("invalid value %v for target group cross-zone load balancing, must be true, false or %v", crossZoneEnabled, tgCrossZoneEnabledUseLoadBalancerConfiguration)
+	}
+}
+
+// validateTargetGroupTargetFailover validates the target failover setting specified by attrKey, which is only supported by NLB target groups.
+func validateTargetGroupTargetFailover(tgProtocol elbv2model.Protocol, attrKey string, targetFailover string) error {
+	if !isNLBTargetGroupProtocol(tgProtocol) {
+		return errors.Errorf("attribute %v is only supported for NLB target groups, protocol: %v", attrKey, tgProtocol)
+	}
+	switch targetFailover {
+	case tgTargetFailoverRebalance, tgTargetFailoverNoRebalance:
+		return nil
+	default:
+		return errors.Errorf("invalid value %v for attribute %v, must be %v or %v", targetFailover, attrKey, tgTargetFailoverNoRebalance, tgTargetFailoverRebalance)
+	}
+}
+
+// isNLBTargetGroupProtocol checks whether tgProtocol is a protocol of NLB target groups.
+func isNLBTargetGroupProtocol(tgProtocol elbv2model.Protocol) bool {
+	switch tgProtocol {
+	case elbv2model.ProtocolTCP, elbv2model.ProtocolUDP, elbv2model.ProtocolTLS, elbv2model.ProtocolTCP_UDP:
+		return true
+	default:
+		return false
+	}
+}
+
 func (t *defaultModelBuildTask) buildPreserveClientIPFlag(_ context.Context, targetType elbv2model.TargetType, tgAttrs []elbv2model.TargetGroupAttribute) (bool, error) {
 	for _, attr := range tgAttrs {
 		if attr.Key == tgAttrsPreserveClientIPEnabled {
@@ -254,17 +405,36 @@ func (t *defaultModelBuildTask) buildTargetGroupPort(_ context.Context, targetTy
 	return 1
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Context, defaultHealthCheckPort string) (intstr.IntOrString, error) {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Context, defaultHealthCheckPort string, targetType elbv2model.TargetType) (intstr.IntOrString, error) {
 	rawHealthCheckPort := defaultHealthCheckPort
 	t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCPort, &rawHealthCheckPort, t.service.Annotations)
 	if rawHealthCheckPort == healthCheckPortTrafficPort {
 		return intstr.FromString(rawHealthCheckPort), nil
 	}
 	portVal, err := strconv.ParseInt(rawHealthCheckPort, 10, 64)
-	if err != nil {
+	if err == nil {
+		return intstr.FromInt(int(portVal)), nil
+	}
+	if targetType != elbv2model.TargetTypeIP {
 		return intstr.IntOrString{}, errors.Errorf("health check port \"%v\" not supported", rawHealthCheckPort)
 	}
-	return intstr.FromInt(int(portVal)), nil
+	return t.resolveNamedTargetGroupHealthCheckPort(rawHealthCheckPort)
+}
+
+// resolveNamedTargetGroupHealthCheckPort resolves named health check port for IP targets to the targetPort of the ServicePort with that name.
+func (t *defaultModelBuildTask) resolveNamedTargetGroupHealthCheckPort(portName string) (intstr.IntOrString, error) {
+	portNames := make([]string, 0, len(t.service.Spec.Ports))
+	for _, port := range t.service.Spec.Ports {
+		if port.Name != portName {
+			portNames = append(portNames, port.Name)
+			continue
+		}
+		if port.TargetPort.Type != intstr.Int {
+			return intstr.IntOrString{}, errors.Errorf("health check port \"%v\" cannot be resolved since its targetPort is a named port", portName)
+		}
+		return port.TargetPort, nil
+	}
+	return intstr.IntOrString{}, errors.Errorf("health check port \"%v\" not found, available port names: %v", portName, portNames)
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Context, defaultHealthCheckProtocol elbv2model.Protocol) (elbv2model.Protocol, error) {
@@ -293,15 +463,28 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckIntervalSeconds(_ con
 	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCInterval, &intervalSeconds, t.service.Annotations); err != nil {
 		return 0, err
 	}
+	if intervalSeconds != nlbHealthCheckInterval10Seconds && intervalSeconds != nlbHealthCheckInterval30Seconds {
+		return 0, errors.Errorf("invalid health check interval %v, must be %v or %v seconds",
+			intervalSeconds, nlbHealthCheckInterval10Seconds, nlbHealthCheckInterval30Seconds)
+	}
 	return intervalSeconds, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckTimeoutSeconds(_ context.Context, defaultHealthCheckTimeout int64) (int64, error) {
-	timeoutSeconds := defaultHealthCheckTimeout
-	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCTimeout, &timeoutSeconds, t.service.Annotations); err != nil {
-		return 0, err
+// buildTargetGroupHealthCheckTimeoutSeconds constructs the health check timeout, which is only managed if specified via annotation.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckTimeoutSeconds(_ context.Context) (*int64, error) {
+	var timeoutSeconds int64
+	exists, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCTimeout, &timeoutSeconds, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
 	}
-	return timeoutSeconds, nil
+	if timeoutSeconds < minNLBHealthCheckTimeoutSeconds || timeoutSeconds > maxNLBHealthCheckTimeoutSeconds {
+		return nil, errors.Errorf("invalid health check timeout %v, must be within [%v-%v] seconds",
+			timeoutSeconds, minNLBHealthCheckTimeoutSeconds, maxNLBHealthCheckTimeoutSeconds)
+	}
+	return &timeoutSeconds, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckHealthyThresholdCount(_ context.Context, defaultHealthCheckHealthyThreshold int64) (int64, error) {
@@ -309,6 +492,9 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckHealthyThresholdCount
 	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCHealthyThreshold, &healthyThresholdCount, t.service.Annotations); err != nil {
 		return 0, err
 	}
+	if err := validateTargetGroupHealthCheckThresholdCount(healthyThresholdCount); err != nil {
+		return 0, errors.Wrap(err, "invalid health check healthy threshold")
+	}
 	return healthyThresholdCount, nil
 }
 
@@ -317,14 +503,79 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckUnhealthyThresholdCou
 	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCUnhealthyThreshold, &unhealthyThresholdCount, t.service.Annotations); err != nil {
 		return 0, err
 	}
+	if err := validateTargetGroupHealthCheckThresholdCount(unhealthyThresholdCount); err != nil {
+		return 0, errors.Wrap(err, "invalid health check unhealthy threshold")
+	}
 	return unhealthyThresholdCount, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetType(_ context.Context) (elbv2model.TargetType, error) {
+// validateTargetGroupHealthCheckThresholdCount validates the health check threshold count is within the range supported by NLB.
+func validateTargetGroupHealthCheckThresholdCount(thresholdCount int64) error {
+	if thresholdCount < minNLBHealthCheckThresholdCount || thresholdCount > maxNLBHealthCheckThresholdCount {
+		return errors.Errorf("%v must be within [%v-%v]", thresholdCount, minNLBHealthCheckThresholdCount, maxNLBHealthCheckThresholdCount)
+	}
+	return nil
+}
+
+// buildTargetGroupHealthCheckMatcher constructs the health check success codes, which is only managed if specified via annotation.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, healthCheckProtocol elbv2model.Protocol) (*elbv2model.HealthCheckMatcher, error) {
+	var rawSuccessCodes string
+	if !t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCSuccessCodes, &rawSuccessCodes, t.service.Annotations) {
+		return nil, nil
+	}
+	if healthCheckProtocol == elbv2model.ProtocolTCP {
+		return nil, errors.Errorf("health check success codes are only supported for HTTP and HTTPS health checks, protocol: %v", healthCheckProtocol)
+	}
+	if err := validateTargetGroupHealthCheckSuccessCodes(rawSuccessCodes); err != nil {
+		return nil, errors.Wrapf(err, "invalid health check success codes, HTTP codes must be within [%v-%v]",
+			minNLBHealthCheckMatcherHTTPCode, maxNLBHealthCheckMatcherHTTPCode)
+	}
+	return &elbv2model.HealthCheckMatcher{
+		HTTPCode: &rawSuccessCodes,
+	}, nil
+}
+
+// validateTargetGroupHealthCheckSuccessCodes validates the health check success codes are within the range supported by NLB.
+// codes can be a single value(e.g. "200"), multiple values(e.g. "200,202") or a range of values(e.g. "200-299").
+func validateTargetGroupHealthCheckSuccessCodes(codes string) error {
+	for _, rawCodeRange := range strings.Split(codes, ",") {
+		rawCodeBounds := strings.Split(rawCodeRange, "-")
+		if len(rawCodeBounds) > 2 {
+			return errors.Errorf("invalid code range %q", rawCodeRange)
+		}
+		var codeBounds []int64
+		for _, rawCode := range rawCodeBounds {
+			code, err := strconv.ParseInt(strings.TrimSpace(rawCode), 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid code %q", rawCode)
+			}
+			if code < minNLBHealthCheckMatcherHTTPCode || code > maxNLBHealthCheckMatcherHTTPCode {
+				return errors.Errorf("code %v is out of range", code)
+			}
+			codeBounds = append(codeBounds, code)
+		}
+		if len(codeBounds) == 2 && codeBounds[0] > codeBounds[1] {
+			return errors.Errorf("invalid code range %q, lower bound must not be greater than upper bound", rawCodeRange)
+		}
+	}
+	return nil
+}
+
+func (t *defaultModelBuildTask) buildTargetType(_ context.Context, port corev1.ServicePort) (elbv2model.TargetType, error) {
 	var lbType string
 	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, t.service.Annotations)
-	var lbTargetType string
+	lbTargetType := string(t.defaultTargetType)
 	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixTargetType, &lbTargetType, t.service.Annotations)
+	portTargetType, err := t.buildPortTargetTypeOverride(port)
+	if err != nil {
+		return "", err
+	}
+	if portTargetType != "" {
+		if lbType != LoadBalancerTypeExternal {
+			return "", errors.Errorf("per-port target type is only supported for load balancer type \"%v\"", LoadBalancerTypeExternal)
+		}
+		lbTargetType = portTargetType
+	}
 	if lbType == LoadBalancerTypeNLBIP || (lbType == LoadBalancerTypeExternal && lbTargetType == LoadBalancerTargetTypeIP) {
 		return elbv2model.TargetTypeIP, nil
 	}
@@ -334,12 +585,55 @@ func (t *defaultModelBuildTask) buildTargetType(_ context.Context) (elbv2model.T
 	return "", errors.Errorf("unsupported target type \"%v\" for load balancer type \"%v\"", lbTargetType, lbType)
 }
 
+// buildPortTargetTypeOverride returns the target type override for specific service port, empty if not overridden.
+// the per-port target type can be keyed by either port number or port name.
+func (t *defaultModelBuildTask) buildPortTargetTypeOverride(port corev1.ServicePort) (string, error) {
+	var rawTargetTypeByPort map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixTargetTypePerPort, &rawTargetTypeByPort, t.service.Annotations); err != nil {
+		return "", err
+	}
+	if len(rawTargetTypeByPort) == 0 {
+		return "", nil
+	}
+	knownPorts := sets.NewString()
+	for _, svcPort := range t.service.Spec.Ports {
+		knownPorts.Insert(strconv.Itoa(int(svcPort.Port)))
+		if svcPort.Name != "" {
+			knownPorts.Insert(svcPort.Name)
+		}
+	}
+	for rawPort, rawTargetType := range rawTargetTypeByPort {
+		if !knownPorts.Has(rawPort) {
+			return "", errors.Errorf("unknown service port %v in per-port target type", rawPort)
+		}
+		if rawTargetType != LoadBalancerTargetTypeIP && rawTargetType != LoadBalancerTargetTypeInstance {
+			return "", errors.Errorf("unsupported target type \"%v\" for port %v", rawTargetType, rawPort)
+		}
+	}
+	targetTypeByPortNumber, byPortNumber := rawTargetTypeByPort[strconv.Itoa(int(port.Port))]
+	targetTypeByPortName, byPortName := "", false
+	if port.Name != "" {
+		targetTypeByPortName, byPortName = rawTargetTypeByPort[port.Name]
+	}
+	if byPortNumber && byPortName && targetTypeByPortNumber != targetTypeByPortName {
+		return "", errors.Errorf("conflicting per-port target type for port %v: %v | %v", port.Port, targetTypeByPortNumber, targetTypeByPortName)
+	}
+	if byPortNumber {
+		return targetTypeByPortNumber, nil
+	}
+	return targetTypeByPortName, nil
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupResourceID(svcKey types.NamespacedName, port intstr.IntOrString) string {
 	return fmt.Sprintf("%s/%s:%s", svcKey.Namespace, svcKey.Name, port.String())
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupTags(ctx context.Context) (map[string]string, error) {
-	return t.buildAdditionalResourceTags(ctx)
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return algorithm.MergeStringMap(k8s.BuildResourceMetadataTags(t.resourceNamespaceTagKey, t.resourceNameTagKey, t.service.Namespace, t.service.Name), tags), nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, targetGroup *elbv2model.TargetGroup, preserveClientIP bool,
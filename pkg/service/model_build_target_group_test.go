@@ -19,10 +19,12 @@ import (
 
 func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 	tests := []struct {
-		testName  string
-		svc       *corev1.Service
-		wantError bool
-		wantValue []elbv2.TargetGroupAttribute
+		testName                      string
+		svc                           *corev1.Service
+		tgProtocol                    elbv2.Protocol
+		preserveUnmanagedTGAttributes bool
+		wantError                     bool
+		wantValue                     []elbv2.TargetGroupAttribute
 	}{
 		{
 			testName: "Default values",
@@ -56,6 +58,35 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "Default values with unmanaged attributes preserved",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			preserveUnmanagedTGAttributes: true,
+			wantError:                     false,
+			wantValue:                     []elbv2.TargetGroupAttribute{},
+		},
+		{
+			testName: "Proxy V2 enabled with unmanaged attributes preserved",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol": "*",
+					},
+				},
+			},
+			preserveUnmanagedTGAttributes: true,
+			wantError:                     false,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "true",
+				},
+			},
+		},
 		{
 			testName: "Invalid value",
 			svc: &corev1.Service{
@@ -124,6 +155,190 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			testName: "target group cross-zone annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-enabled": "true",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTCP,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsCrossZoneEnabled,
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "target group cross-zone annotation overrides target group attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes":         tgAttrsCrossZoneEnabled + "=true",
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-enabled": "use_load_balancer_configuration",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolUDP,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsCrossZoneEnabled,
+					Value: "use_load_balancer_configuration",
+				},
+			},
+		},
+		{
+			testName: "target group cross-zone invalid value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-enabled": "sometimes",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTCP,
+			wantError:  true,
+		},
+		{
+			testName: "target group cross-zone value accepted by strconv.ParseBool",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-enabled": "1",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTCP,
+			wantError:  true,
+		},
+		{
+			testName: "target group cross-zone on non-NLB target group",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsCrossZoneEnabled + "=false",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolHTTP,
+			wantError:  true,
+		},
+		{
+			testName: "target failover annotations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-failover-on-deregistration": "rebalance",
+						"service.beta.kubernetes.io/aws-load-balancer-target-failover-on-unhealthy":      "no_rebalance",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTCP,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsTargetFailoverOnDeregistration,
+					Value: "rebalance",
+				},
+				{
+					Key:   tgAttrsTargetFailoverOnUnhealthy,
+					Value: "no_rebalance",
+				},
+			},
+		},
+		{
+			testName: "target failover annotation overrides target group attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes":           tgAttrsTargetFailoverOnDeregistration + "=no_rebalance",
+						"service.beta.kubernetes.io/aws-load-balancer-target-failover-on-deregistration": "rebalance",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTLS,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsTargetFailoverOnDeregistration,
+					Value: "rebalance",
+				},
+			},
+		},
+		{
+			testName: "target failover via target group attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsTargetFailoverOnUnhealthy + "=rebalance",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolUDP,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsTargetFailoverOnUnhealthy,
+					Value: "rebalance",
+				},
+			},
+		},
+		{
+			testName: "target failover invalid value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-failover-on-unhealthy": "failover",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTCP,
+			wantError:  true,
+		},
+		{
+			testName: "target failover invalid value via target group attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsTargetFailoverOnDeregistration + "=Rebalance",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolTCP,
+			wantError:  true,
+		},
+		{
+			testName: "target failover on non-NLB target group",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-failover-on-deregistration": "rebalance",
+					},
+				},
+			},
+			tgProtocol: elbv2.ProtocolHTTP,
+			wantError:  true,
+		},
 		{
 			testName: "IP enabled attribute parse error",
 			svc: &corev1.Service{
@@ -140,10 +355,11 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 		t.Run(tt.testName, func(t *testing.T) {
 			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			builder := &defaultModelBuildTask{
-				service:          tt.svc,
-				annotationParser: parser,
+				service:                       tt.svc,
+				annotationParser:              parser,
+				preserveUnmanagedTGAttributes: tt.preserveUnmanagedTGAttributes,
 			}
-			tgAttrs, err := builder.buildTargetGroupAttributes(context.Background())
+			tgAttrs, err := builder.buildTargetGroupAttributes(context.Background(), tt.tgProtocol)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -208,6 +424,7 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
 				Path:                    aws.String("/healthz"),
 				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(30),
 				HealthyThresholdCount:   aws.Int64(2),
 				UnhealthyThresholdCount: aws.Int64(2),
 			},
@@ -307,6 +524,56 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 			},
 			targetType: elbv2.TargetTypeInstance,
 		},
+		{
+			testName: "traffic policy cluster, target type Instance, default healthcheck",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeCluster,
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeInstance,
+		},
+		{
+			testName: "traffic policy local, target type Instance, healthCheckNodePort not allocated",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+				},
+			},
+			targetType: elbv2.TargetTypeInstance,
+			wantError:  true,
+		},
+		{
+			testName: "traffic policy local, target type Instance, healthCheckNodePort not allocated, override port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "traffic-port",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolHTTP))),
+				Path:                    aws.String("/healthz"),
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(2),
+				UnhealthyThresholdCount: aws.Int64(2),
+			},
+			targetType: elbv2.TargetTypeInstance,
+		},
 		{
 			testName: "traffic policy local, target type Instance, override default",
 			svc: &corev1.Service{
@@ -331,11 +598,114 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 				Port:                    &port8888,
 				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
 				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(30),
 				HealthyThresholdCount:   aws.Int64(5),
 				UnhealthyThresholdCount: aws.Int64(5),
 			},
 			targetType: elbv2.TargetTypeInstance,
 		},
+		{
+			testName: "with success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":      "HTTPS",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval":      "30",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200-299,302",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: (*elbv2.Protocol)(aws.String("HTTPS")),
+				Path:     aws.String("/"),
+				Matcher: &elbv2.HealthCheckMatcher{
+					HTTPCode: aws.String("200-299,302"),
+				},
+				IntervalSeconds:         aws.Int64(30),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "success codes with TCP health check",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
+		{
+			testName: "success codes out of range",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":      "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200-600",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
+		{
+			testName: "unsupported interval",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "15",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
+		{
+			testName: "timeout out of range",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout": "1",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
+		{
+			testName: "healthy threshold out of range",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-healthy-threshold": "11",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
+		{
+			testName: "unhealthy threshold out of range, traffic policy local",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-unhealthy-threshold": "1",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+					HealthCheckNodePort:   31223,
+				},
+			},
+			targetType: elbv2.TargetTypeInstance,
+			wantError:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -940,10 +1310,12 @@ func Test_defaultModelBuilder_buildPreserveClientIPFlag(t *testing.T) {
 func Test_defaultModelBuilder_buildTargetType(t *testing.T) {
 
 	tests := []struct {
-		testName string
-		svc      *corev1.Service
-		want     elbv2.TargetType
-		wantErr  error
+		testName          string
+		svc               *corev1.Service
+		port              corev1.ServicePort
+		defaultTargetType elbv2.TargetType
+		want              elbv2.TargetType
+		wantErr           error
 	}{
 		{
 			testName: "empty annotation",
@@ -996,6 +1368,165 @@ func Test_defaultModelBuilder_buildTargetType(t *testing.T) {
 			},
 			wantErr: errors.New("unsupported target type \"\" for load balancer type \"external\""),
 		},
+		{
+			testName: "external, no target type, default target type ip",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "external",
+					},
+				},
+			},
+			defaultTargetType: elbv2.TargetTypeIP,
+			want:              elbv2.TargetTypeIP,
+		},
+		{
+			testName: "external, target instance overrides default target type ip",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":            "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "instance",
+					},
+				},
+			},
+			defaultTargetType: elbv2.TargetTypeIP,
+			want:              elbv2.TargetTypeInstance,
+		},
+		{
+			testName: "external, per-port target type by port number",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type":          "ip",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "80=instance",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+						{Name: "https", Port: 443},
+					},
+				},
+			},
+			port: corev1.ServicePort{Name: "http", Port: 80},
+			want: elbv2.TargetTypeInstance,
+		},
+		{
+			testName: "external, per-port target type by port name",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type":          "instance",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "https=ip",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+						{Name: "https", Port: 443},
+					},
+				},
+			},
+			port: corev1.ServicePort{Name: "https", Port: 443},
+			want: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "external, per-port target type for other port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type":          "instance",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "https=ip",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+						{Name: "https", Port: 443},
+					},
+				},
+			},
+			port: corev1.ServicePort{Name: "http", Port: 80},
+			want: elbv2.TargetTypeInstance,
+		},
+		{
+			testName: "external, per-port target type for unknown port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type":          "instance",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "8080=ip",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+					},
+				},
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("unknown service port 8080 in per-port target type"),
+		},
+		{
+			testName: "external, per-port target type with invalid value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type":          "instance",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "80=lambda",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+					},
+				},
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("unsupported target type \"lambda\" for port 80"),
+		},
+		{
+			testName: "external, per-port target type conflicts between port number and name",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "80=ip,http=instance",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+					},
+				},
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("conflicting per-port target type for port 80: ip | instance"),
+		},
+		{
+			testName: "nlb-ip, per-port target type",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type-per-port": "80=instance",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+					},
+				},
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("per-port target type is only supported for load balancer type \"external\""),
+		},
 		{
 			testName: "external, some other target type",
 			svc: &corev1.Service{
@@ -1013,10 +1544,11 @@ func Test_defaultModelBuilder_buildTargetType(t *testing.T) {
 		t.Run(tt.testName, func(t *testing.T) {
 			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			builder := &defaultModelBuildTask{
-				annotationParser: parser,
-				service:          tt.svc,
+				annotationParser:  parser,
+				service:           tt.svc,
+				defaultTargetType: tt.defaultTargetType,
 			}
-			got, err := builder.buildTargetType(context.Background())
+			got, err := builder.buildTargetType(context.Background(), tt.port)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -1107,6 +1639,7 @@ func Test_defaultModelBuilder_buildTargetGroupHealthCheckPort(t *testing.T) {
 		testName    string
 		svc         *corev1.Service
 		defaultPort string
+		targetType  elbv2.TargetType
 		want        intstr.IntOrString
 		wantErr     error
 	}{
@@ -1152,6 +1685,104 @@ func Test_defaultModelBuilder_buildTargetGroupHealthCheckPort(t *testing.T) {
 			defaultPort: "abs",
 			wantErr:     errors.New("health check port \"abs\" not supported"),
 		},
+		{
+			testName: "named port for ip target",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "health",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http",
+							Port:       80,
+							TargetPort: intstr.FromInt(8080),
+						},
+						{
+							Name:       "health",
+							Port:       8081,
+							TargetPort: intstr.FromInt(15021),
+						},
+					},
+				},
+			},
+			defaultPort: "traffic-port",
+			targetType:  elbv2.TargetTypeIP,
+			want:        intstr.FromInt(15021),
+		},
+		{
+			testName: "named port for ip target not found",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "health",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http",
+							Port:       80,
+							TargetPort: intstr.FromInt(8080),
+						},
+						{
+							Name:       "https",
+							Port:       443,
+							TargetPort: intstr.FromInt(8443),
+						},
+					},
+				},
+			},
+			defaultPort: "traffic-port",
+			targetType:  elbv2.TargetTypeIP,
+			wantErr:     errors.New("health check port \"health\" not found, available port names: [http https]"),
+		},
+		{
+			testName: "named port for ip target with named targetPort",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "health",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "health",
+							Port:       8081,
+							TargetPort: intstr.FromString("health"),
+						},
+					},
+				},
+			},
+			defaultPort: "traffic-port",
+			targetType:  elbv2.TargetTypeIP,
+			wantErr:     errors.New("health check port \"health\" cannot be resolved since its targetPort is a named port"),
+		},
+		{
+			testName: "named port for instance target",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "health",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "health",
+							Port:       8081,
+							TargetPort: intstr.FromInt(15021),
+						},
+					},
+				},
+			},
+			defaultPort: "traffic-port",
+			targetType:  elbv2.TargetTypeInstance,
+			wantErr:     errors.New("health check port \"health\" not supported"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -1161,7 +1792,7 @@ func Test_defaultModelBuilder_buildTargetGroupHealthCheckPort(t *testing.T) {
 				service:                tt.svc,
 				defaultHealthCheckPort: tt.defaultPort,
 			}
-			got, err := builder.buildTargetGroupHealthCheckPort(context.Background(), tt.defaultPort)
+			got, err := builder.buildTargetGroupHealthCheckPort(context.Background(), tt.defaultPort, tt.targetType)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
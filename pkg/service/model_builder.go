@@ -28,13 +28,20 @@ type ModelBuilder interface {
 
 // NewDefaultModelBuilder construct a new defaultModelBuilder
 func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver networking.SubnetsResolver, clusterName string,
-	defaultTags map[string]string, defaultSSLPolicy string) *defaultModelBuilder {
+	defaultTags map[string]string, defaultSSLPolicy string, defaultTargetType elbv2model.TargetType,
+	resourceNamespaceTagKey string, resourceNameTagKey string, requireExplicitOptIn bool, preserveUnmanagedTGAttributes bool) *defaultModelBuilder {
 	return &defaultModelBuilder{
-		annotationParser: annotationParser,
-		subnetsResolver:  subnetsResolver,
-		clusterName:      clusterName,
-		defaultTags:      defaultTags,
-		defaultSSLPolicy: defaultSSLPolicy,
+		annotationParser:        annotationParser,
+		subnetsResolver:         subnetsResolver,
+		clusterName:             clusterName,
+		defaultTags:             defaultTags,
+		defaultSSLPolicy:        defaultSSLPolicy,
+		defaultTargetType:       defaultTargetType,
+		resourceNamespaceTagKey: resourceNamespaceTagKey,
+		resourceNameTagKey:      resourceNameTagKey,
+		requireExplicitOptIn:    requireExplicitOptIn,
+
+		preserveUnmanagedTGAttributes: preserveUnmanagedTGAttributes,
 	}
 }
 
@@ -46,6 +53,17 @@ type defaultModelBuilder struct {
 	clusterName      string
 	defaultTags      map[string]string
 	defaultSSLPolicy string
+	// default target type for services with load balancer type external but without target type annotation.
+	defaultTargetType elbv2model.TargetType
+
+	resourceNamespaceTagKey string
+	resourceNameTagKey      string
+
+	// whether services must be explicitly opted in to be managed.
+	requireExplicitOptIn bool
+
+	// whether target group attributes not specified via annotations are left at their current values instead of defaults.
+	preserveUnmanagedTGAttributes bool
 }
 
 func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error) {
@@ -55,12 +73,14 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		annotationParser: b.annotationParser,
 		subnetsResolver:  b.subnetsResolver,
 
-		service:   service,
-		stack:     stack,
-		tgByResID: make(map[string]*elbv2model.TargetGroup),
+		service:        service,
+		stack:          stack,
+		tgByResID:      make(map[string]*elbv2model.TargetGroup),
+		tgByBackendKey: make(map[string]*elbv2model.TargetGroup),
 
 		defaultTags:                          b.defaultTags,
 		defaultSSLPolicy:                     b.defaultSSLPolicy,
+		defaultTargetType:                    b.defaultTargetType,
 		defaultAccessLogS3Enabled:            false,
 		defaultAccessLogsS3Bucket:            "",
 		defaultAccessLogsS3Prefix:            "",
@@ -82,6 +102,12 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		defaultHealthCheckTimeoutForInstanceModeLocal:            6,
 		defaultHealthCheckHealthyThresholdForInstanceModeLocal:   2,
 		defaultHealthCheckUnhealthyThresholdForInstanceModeLocal: 2,
+
+		resourceNamespaceTagKey: b.resourceNamespaceTagKey,
+		resourceNameTagKey:      b.resourceNameTagKey,
+		requireExplicitOptIn:    b.requireExplicitOptIn,
+
+		preserveUnmanagedTGAttributes: b.preserveUnmanagedTGAttributes,
 	}
 
 	if err := task.run(ctx); err != nil {
@@ -97,13 +123,15 @@ type defaultModelBuildTask struct {
 
 	service *corev1.Service
 
-	stack        core.Stack
-	loadBalancer *elbv2model.LoadBalancer
-	tgByResID    map[string]*elbv2model.TargetGroup
-	ec2Subnets   []*ec2.Subnet
+	stack          core.Stack
+	loadBalancer   *elbv2model.LoadBalancer
+	tgByResID      map[string]*elbv2model.TargetGroup
+	tgByBackendKey map[string]*elbv2model.TargetGroup
+	ec2Subnets     []*ec2.Subnet
 
 	defaultTags                          map[string]string
 	defaultSSLPolicy                     string
+	defaultTargetType                    elbv2model.TargetType
 	defaultAccessLogS3Enabled            bool
 	defaultAccessLogsS3Bucket            string
 	defaultAccessLogsS3Prefix            string
@@ -126,10 +154,21 @@ type defaultModelBuildTask struct {
 	defaultHealthCheckTimeoutForInstanceModeLocal            int64
 	defaultHealthCheckHealthyThresholdForInstanceModeLocal   int64
 	defaultHealthCheckUnhealthyThresholdForInstanceModeLocal int64
+
+	// tag keys used to record the owning service on AWS resources, empty means disabled.
+	resourceNamespaceTagKey string
+	resourceNameTagKey      string
+
+	// whether services must be explicitly opted in to be managed.
+	requireExplicitOptIn bool
+
+	// whether target group attributes not specified via annotations are left at their current values instead of defaults.
+	preserveUnmanagedTGAttributes bool
 }
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
-	if !t.service.DeletionTimestamp.IsZero() {
+	if !t.service.DeletionTimestamp.IsZero() || !IsServiceSupported(t.service, t.annotationParser) ||
+		!IsServiceOptedIn(t.service, t.requireExplicitOptIn) {
 		return nil
 	}
 	err := t.buildModel(ctx)
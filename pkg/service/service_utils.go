@@ -0,0 +1,24 @@
+package service
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+// IsServiceSupported checks whether the service should be managed by this controller.
+// Only LoadBalancer Services with load balancer type "nlb-ip" or "external" are managed, others are left to the in-tree controller.
+func IsServiceSupported(svc *corev1.Service, annotationParser annotations.Parser) bool {
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return false
+	}
+	lbType := ""
+	_ = annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, svc.Annotations)
+	return lbType == LoadBalancerTypeNLBIP || lbType == LoadBalancerTypeExternal
+}
+
+// IsServiceOptedIn checks whether the service is allowed to be managed by this controller.
+// Services must be explicitly opted in when requireExplicitOptIn is set.
+func IsServiceOptedIn(svc *corev1.Service, requireExplicitOptIn bool) bool {
+	return !requireExplicitOptIn || k8s.HasExplicitOptIn(svc)
+}
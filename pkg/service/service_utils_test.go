@@ -0,0 +1,145 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"testing"
+)
+
+func TestIsServiceSupported(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  *corev1.Service
+		want bool
+	}{
+		{
+			name: "nlb-ip LoadBalancer service",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "external LoadBalancer service with target type",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":            "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "external LoadBalancer service without target type",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "external",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "nlb LoadBalancer service handled by in-tree controller",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "LoadBalancer service without annotation",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "external NodePort service",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "external",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			got := IsServiceSupported(tt.svc, annotationParser)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsServiceOptedIn(t *testing.T) {
+	tests := []struct {
+		name                 string
+		svc                  *corev1.Service
+		requireExplicitOptIn bool
+		want                 bool
+	}{
+		{
+			name:                 "explicit opt-in not required",
+			svc:                  &corev1.Service{},
+			requireExplicitOptIn: false,
+			want:                 true,
+		},
+		{
+			name: "explicit opt-in required, service opted in",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/managed": "true",
+					},
+				},
+			},
+			requireExplicitOptIn: true,
+			want:                 true,
+		},
+		{
+			name:                 "explicit opt-in required, service not opted in",
+			svc:                  &corev1.Service{},
+			requireExplicitOptIn: true,
+			want:                 false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsServiceOptedIn(tt.svc, tt.requireExplicitOptIn)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
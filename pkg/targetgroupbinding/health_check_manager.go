@@ -0,0 +1,124 @@
+package targetgroupbinding
+
+import (
+	"context"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+// HealthCheckManager manages the health check configuration on TargetGroups owned by TargetGroupBinding.
+type HealthCheckManager interface {
+	// Reconcile reconciles the health check configuration on TargetGroups of TargetGroupBinding if it's opted in.
+	Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
+}
+
+// NewDefaultHealthCheckManager constructs defaultHealthCheckManager.
+func NewDefaultHealthCheckManager(elbv2Client services.ELBV2, logger logr.Logger) *defaultHealthCheckManager {
+	return &defaultHealthCheckManager{
+		elbv2Client: elbv2Client,
+		logger:      logger,
+	}
+}
+
+var _ HealthCheckManager = &defaultHealthCheckManager{}
+
+// default implementation for HealthCheckManager.
+type defaultHealthCheckManager struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+}
+
+func (m *defaultHealthCheckManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if !awssdk.BoolValue(tgb.Spec.HealthCheckReconcile) || tgb.Spec.HealthCheck == nil {
+		return nil
+	}
+	for _, portMapping := range buildTargetGroupPortMappings(tgb) {
+		if err := m.reconcileTargetGroup(ctx, portMapping.targetGroupARN, *tgb.Spec.HealthCheck); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultHealthCheckManager) reconcileTargetGroup(ctx context.Context, tgARN string, healthCheck elbv2api.TargetGroupHealthCheck) error {
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	}
+	resp, err := m.elbv2Client.DescribeTargetGroupsWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(resp.TargetGroups) == 0 {
+		return errors.Errorf("targetGroup not found: %v", tgARN)
+	}
+	if !isTargetGroupHealthCheckDrifted(healthCheck, resp.TargetGroups[0]) {
+		return nil
+	}
+
+	modifyReq := buildSDKModifyTargetGroupHealthCheckInput(tgARN, healthCheck)
+	m.logger.Info("modifying targetGroup healthCheck",
+		"arn", tgARN,
+		"change", modifyReq)
+	if _, err := m.elbv2Client.ModifyTargetGroupWithContext(ctx, modifyReq); err != nil {
+		return err
+	}
+	m.logger.Info("modified targetGroup healthCheck",
+		"arn", tgARN)
+	return nil
+}
+
+// isTargetGroupHealthCheckDrifted checks whether any specified field of healthCheck differs from the TargetGroup.
+func isTargetGroupHealthCheckDrifted(healthCheck elbv2api.TargetGroupHealthCheck, sdkTG *elbv2sdk.TargetGroup) bool {
+	if healthCheck.Port != nil && healthCheck.Port.String() != awssdk.StringValue(sdkTG.HealthCheckPort) {
+		return true
+	}
+	if healthCheck.Protocol != nil && string(*healthCheck.Protocol) != awssdk.StringValue(sdkTG.HealthCheckProtocol) {
+		return true
+	}
+	if healthCheck.Path != nil && awssdk.StringValue(healthCheck.Path) != awssdk.StringValue(sdkTG.HealthCheckPath) {
+		return true
+	}
+	if healthCheck.HTTPCode != nil && (sdkTG.Matcher == nil || awssdk.StringValue(healthCheck.HTTPCode) != awssdk.StringValue(sdkTG.Matcher.HttpCode)) {
+		return true
+	}
+	if healthCheck.IntervalSeconds != nil && awssdk.Int64Value(healthCheck.IntervalSeconds) != awssdk.Int64Value(sdkTG.HealthCheckIntervalSeconds) {
+		return true
+	}
+	if healthCheck.TimeoutSeconds != nil && awssdk.Int64Value(healthCheck.TimeoutSeconds) != awssdk.Int64Value(sdkTG.HealthCheckTimeoutSeconds) {
+		return true
+	}
+	if healthCheck.HealthyThresholdCount != nil && awssdk.Int64Value(healthCheck.HealthyThresholdCount) != awssdk.Int64Value(sdkTG.HealthyThresholdCount) {
+		return true
+	}
+	if healthCheck.UnhealthyThresholdCount != nil && awssdk.Int64Value(healthCheck.UnhealthyThresholdCount) != awssdk.Int64Value(sdkTG.UnhealthyThresholdCount) {
+		return true
+	}
+	return false
+}
+
+func buildSDKModifyTargetGroupHealthCheckInput(tgARN string, healthCheck elbv2api.TargetGroupHealthCheck) *elbv2sdk.ModifyTargetGroupInput {
+	req := &elbv2sdk.ModifyTargetGroupInput{
+		TargetGroupArn:             awssdk.String(tgARN),
+		HealthCheckEnabled:         awssdk.Bool(true),
+		HealthCheckPath:            healthCheck.Path,
+		HealthCheckIntervalSeconds: healthCheck.IntervalSeconds,
+		HealthCheckTimeoutSeconds:  healthCheck.TimeoutSeconds,
+		HealthyThresholdCount:      healthCheck.HealthyThresholdCount,
+		UnhealthyThresholdCount:    healthCheck.UnhealthyThresholdCount,
+	}
+	if healthCheck.Port != nil {
+		req.HealthCheckPort = awssdk.String(healthCheck.Port.String())
+	}
+	if healthCheck.Protocol != nil {
+		req.HealthCheckProtocol = awssdk.String(string(*healthCheck.Protocol))
+	}
+	if healthCheck.HTTPCode != nil {
+		req.Matcher = &elbv2sdk.Matcher{HttpCode: healthCheck.HTTPCode}
+	}
+	return req
+}
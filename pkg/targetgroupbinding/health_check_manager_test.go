@@ -0,0 +1,169 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultHealthCheckManager_Reconcile(t *testing.T) {
+	port8080 := intstr.FromInt(8080)
+	protocolHTTP := elbv2api.TargetGroupHealthCheckProtocolHTTP
+	healthCheck := &elbv2api.TargetGroupHealthCheck{
+		Port:            &port8080,
+		Protocol:        &protocolHTTP,
+		Path:            awssdk.String("/healthz"),
+		HTTPCode:        awssdk.String("200-399"),
+		IntervalSeconds: awssdk.Int64(10),
+	}
+	type describeTargetGroupsWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp *elbv2sdk.DescribeTargetGroupsOutput
+	}
+	type modifyTargetGroupWithContextCall struct {
+		req *elbv2sdk.ModifyTargetGroupInput
+	}
+	tests := []struct {
+		name                                 string
+		tgb                                  *elbv2api.TargetGroupBinding
+		describeTargetGroupsWithContextCalls []describeTargetGroupsWithContextCall
+		modifyTargetGroupWithContextCalls    []modifyTargetGroupWithContextCall
+		wantErr                              error
+	}{
+		{
+			name: "healthCheck is left untouched when healthCheckReconcile is unspecified",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					HealthCheck:    healthCheck,
+				},
+			},
+		},
+		{
+			name: "healthCheck is left untouched when healthCheckReconcile is false",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:       "tg-1",
+					HealthCheckReconcile: awssdk.Bool(false),
+					HealthCheck:          healthCheck,
+				},
+			},
+		},
+		{
+			name: "drifted healthCheck is modified on all TargetGroups when healthCheckReconcile is true",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+						{
+							TargetGroupARN: "tg-2",
+							Port:           intstr.FromInt(443),
+						},
+					},
+					HealthCheckReconcile: awssdk.Bool(true),
+					HealthCheck:          healthCheck,
+				},
+			},
+			describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetGroupsInput{
+						TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+					},
+					resp: &elbv2sdk.DescribeTargetGroupsOutput{
+						TargetGroups: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:             awssdk.String("tg-1"),
+								HealthCheckPort:            awssdk.String("traffic-port"),
+								HealthCheckProtocol:        awssdk.String("HTTP"),
+								HealthCheckPath:            awssdk.String("/"),
+								Matcher:                    &elbv2sdk.Matcher{HttpCode: awssdk.String("200")},
+								HealthCheckIntervalSeconds: awssdk.Int64(30),
+								HealthCheckTimeoutSeconds:  awssdk.Int64(5),
+							},
+						},
+					},
+				},
+				{
+					req: &elbv2sdk.DescribeTargetGroupsInput{
+						TargetGroupArns: awssdk.StringSlice([]string{"tg-2"}),
+					},
+					resp: &elbv2sdk.DescribeTargetGroupsOutput{
+						TargetGroups: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:             awssdk.String("tg-2"),
+								HealthCheckPort:            awssdk.String("8080"),
+								HealthCheckProtocol:        awssdk.String("HTTP"),
+								HealthCheckPath:            awssdk.String("/healthz"),
+								Matcher:                    &elbv2sdk.Matcher{HttpCode: awssdk.String("200-399")},
+								HealthCheckIntervalSeconds: awssdk.Int64(10),
+								HealthCheckTimeoutSeconds:  awssdk.Int64(5),
+							},
+						},
+					},
+				},
+			},
+			modifyTargetGroupWithContextCalls: []modifyTargetGroupWithContextCall{
+				{
+					req: &elbv2sdk.ModifyTargetGroupInput{
+						TargetGroupArn:             awssdk.String("tg-1"),
+						HealthCheckEnabled:         awssdk.Bool(true),
+						HealthCheckPort:            awssdk.String("8080"),
+						HealthCheckProtocol:        awssdk.String("HTTP"),
+						HealthCheckPath:            awssdk.String("/healthz"),
+						Matcher:                    &elbv2sdk.Matcher{HttpCode: awssdk.String("200-399")},
+						HealthCheckIntervalSeconds: awssdk.Int64(10),
+					},
+				},
+			},
+		},
+		{
+			name: "TargetGroup not found",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:       "tg-1",
+					HealthCheckReconcile: awssdk.Bool(true),
+					HealthCheck:          healthCheck,
+				},
+			},
+			describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetGroupsInput{
+						TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+					},
+					resp: &elbv2sdk.DescribeTargetGroupsOutput{},
+				},
+			},
+			wantErr: errors.New("targetGroup not found: tg-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetGroupsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.modifyTargetGroupWithContextCalls {
+				elbv2Client.EXPECT().ModifyTargetGroupWithContext(gomock.Any(), call.req).Return(&elbv2sdk.ModifyTargetGroupOutput{}, nil)
+			}
+			m := NewDefaultHealthCheckManager(elbv2Client, &log.NullLogger{})
+			err := m.Reconcile(context.Background(), tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -18,14 +18,20 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
 	tgbNetworkingIPPermissionLabelKey   = "elbv2.k8s.aws/targetGroupBinding"
 	tgbNetworkingIPPermissionLabelValue = "shared"
+
+	// cleanupRequeueDuration is the interval to retry cleanup of a TargetGroupBinding's networking
+	// until ingress permissions for all other TargetGroupBindings are computed.
+	cleanupRequeueDuration = 5 * time.Second
 )
 
 // NetworkingManager manages the networking for targetGroupBindings.
@@ -95,7 +101,8 @@ func (m *defaultNetworkingManager) ReconcileForPodEndpoints(ctx context.Context,
 			return err
 		}
 	}
-	return m.reconcileWithIngressPermissionsPerSG(ctx, tgb, ingressPermissionsPerSG)
+	_, err := m.reconcileWithIngressPermissionsPerSG(ctx, tgb, ingressPermissionsPerSG)
+	return err
 }
 
 func (m *defaultNetworkingManager) ReconcileForNodePortEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.NodePortEndpoint) error {
@@ -107,11 +114,34 @@ func (m *defaultNetworkingManager) ReconcileForNodePortEndpoints(ctx context.Con
 			return err
 		}
 	}
-	return m.reconcileWithIngressPermissionsPerSG(ctx, tgb, ingressPermissionsPerSG)
+	_, err := m.reconcileWithIngressPermissionsPerSG(ctx, tgb, ingressPermissionsPerSG)
+	return err
 }
 
 func (m *defaultNetworkingManager) Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	return m.reconcileWithIngressPermissionsPerSG(ctx, tgb, nil)
+	// endpoint SGs of this TargetGroupBinding might not be tracked(e.g. after controller restart),
+	// so we discover them by the peers referenced in inbound rules to ensure the rules we created are revoked.
+	// only securityGroups tagged for this cluster are discovered, so that securityGroups of other clusters within VPC are left untouched.
+	if tgb.Spec.Networking != nil {
+		endpointSGs, err := m.fetchEndpointSGsWithIngressFromPeers(ctx, *tgb.Spec.Networking)
+		if err != nil {
+			return err
+		}
+		m.mutex.Lock()
+		m.trackEndpointSGs(ctx, endpointSGs...)
+		m.mutex.Unlock()
+	}
+
+	computedForAllTGBs, err := m.reconcileWithIngressPermissionsPerSG(ctx, tgb, nil)
+	if err != nil {
+		return err
+	}
+	// rules are shared across TargetGroupBindings, we can only revoke them safely once we know the ingress permissions
+	// needed by all other TargetGroupBindings.
+	if tgb.Spec.Networking != nil && !computedForAllTGBs {
+		return runtime.NewRequeueNeededAfter("wait ingress permissions computed for all TargetGroupBindings", cleanupRequeueDuration)
+	}
+	return nil
 }
 
 func (m *defaultNetworkingManager) computeIngressPermissionsPerSGWithPodEndpoints(ctx context.Context, tgbNetworking elbv2api.TargetGroupBindingNetworking, endpoints []backend.PodEndpoint) (map[string][]networking.IPPermissionInfo, error) {
@@ -176,7 +206,9 @@ func (m *defaultNetworkingManager) computeIngressPermissionsPerSGWithNodePortEnd
 	return permissionsPerSG, nil
 }
 
-func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx context.Context, tgb *elbv2api.TargetGroupBinding, ingressPermissionsPerSG map[string][]networking.IPPermissionInfo) error {
+// reconcileWithIngressPermissionsPerSG reconciles the ingress permissions with computed ingressPermissionsPerSG for specified TargetGroupBinding.
+// returns whether we have ingressPermissionsPerSG computed for all TargetGroupBindings, unneeded permissions are only revoked in that case.
+func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx context.Context, tgb *elbv2api.TargetGroupBinding, ingressPermissionsPerSG map[string][]networking.IPPermissionInfo) (bool, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -187,7 +219,7 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 
 	tgbsWithNetworking, err := m.fetchTGBsWithNetworking(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	computedForAllTGBs := m.consolidateIngressPermissionsPerSGByTGB(ctx, tgbsWithNetworking)
 	aggregatedIngressPermissionsPerSG := m.computeAggregatedIngressPermissionsPerSG(ctx)
@@ -197,17 +229,17 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 		if err := m.sgReconciler.ReconcileIngress(ctx, sgID, permissions,
 			networking.WithPermissionSelector(permissionSelector),
 			networking.WithAuthorizeOnly(!computedForAllTGBs)); err != nil {
-			return err
+			return false, err
 		}
 	}
 
 	if computedForAllTGBs {
 		if err := m.gcIngressPermissionsFromUnusedEndpointSGs(ctx, aggregatedIngressPermissionsPerSG); err != nil {
-			return err
+			return false, err
 		}
 	}
 
-	return nil
+	return computedForAllTGBs, nil
 }
 
 // consolidateIngressPermissionsPerSGByTGB will consolidate the ingressPermissionsPerSGByTGB based on all tgbs with networking rules in cluster.
@@ -393,6 +425,64 @@ func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx
 	return nil
 }
 
+// fetchEndpointSGsWithIngressFromPeers returns securityGroups within VPC tagged for this cluster that have inbound rules from peers of tgbNetworking.
+func (m *defaultNetworkingManager) fetchEndpointSGsWithIngressFromPeers(ctx context.Context, tgbNetworking elbv2api.TargetGroupBindingNetworking) ([]string, error) {
+	peerGroupIDs := sets.NewString()
+	peerCIDRs := sets.NewString()
+	peerCIDRv6s := sets.NewString()
+	for _, rule := range tgbNetworking.Ingress {
+		for _, rulePeer := range rule.From {
+			if rulePeer.SecurityGroup != nil {
+				peerGroupIDs.Insert(rulePeer.SecurityGroup.GroupID)
+			}
+			if rulePeer.IPBlock != nil {
+				if strings.Contains(rulePeer.IPBlock.CIDR, ":") {
+					peerCIDRv6s.Insert(rulePeer.IPBlock.CIDR)
+				} else {
+					peerCIDRs.Insert(rulePeer.IPBlock.CIDR)
+				}
+			}
+		}
+	}
+
+	clusterResourceTagKey := fmt.Sprintf("kubernetes.io/cluster/%s", m.clusterName)
+	endpointSGs := sets.NewString()
+	for _, peerFilter := range []struct {
+		name   string
+		values sets.String
+	}{
+		{name: "ip-permission.group-id", values: peerGroupIDs},
+		{name: "ip-permission.cidr", values: peerCIDRs},
+		{name: "ip-permission.ipv6-cidr", values: peerCIDRv6s},
+	} {
+		if len(peerFilter.values) == 0 {
+			continue
+		}
+		req := &ec2sdk.DescribeSecurityGroupsInput{
+			Filters: []*ec2sdk.Filter{
+				{
+					Name:   awssdk.String(peerFilter.name),
+					Values: awssdk.StringSlice(peerFilter.values.List()),
+				},
+				{
+					Name:   awssdk.String("tag:" + clusterResourceTagKey),
+					Values: awssdk.StringSlice([]string{"owned", "shared"}),
+				},
+				{
+					Name:   awssdk.String("vpc-id"),
+					Values: awssdk.StringSlice([]string{m.vpcID}),
+				},
+			},
+		}
+		sgInfoByID, err := m.sgManager.FetchSGInfosByRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		endpointSGs.Insert(sets.StringKeySet(sgInfoByID).UnsortedList()...)
+	}
+	return endpointSGs.List(), nil
+}
+
 // fetchTGBsWithNetworking returns all targetGroupsBindings with networking rules in cluster.
 func (m *defaultNetworkingManager) fetchTGBsWithNetworking(ctx context.Context) (map[types.NamespacedName]*elbv2api.TargetGroupBinding, error) {
 	tgbList := &elbv2api.TargetGroupBindingList{}
@@ -5,13 +5,20 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -859,3 +866,209 @@ func Test_defaultNetworkingManager_computeAggregatedIngressPermissionsPerSG(t *t
 		})
 	}
 }
+
+func Test_defaultNetworkingManager_Cleanup(t *testing.T) {
+	sgPeerNetworking := &elbv2api.TargetGroupBindingNetworking{
+		Ingress: []elbv2api.NetworkingIngressRule{
+			{
+				From: []elbv2api.NetworkingPeer{
+					{
+						SecurityGroup: &elbv2api.SecurityGroup{
+							GroupID: "sg-peer",
+						},
+					},
+				},
+				Ports: []elbv2api.NetworkingPort{
+					{
+						Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
+					},
+				},
+			},
+		},
+	}
+	sharedPermission := networking.NewGroupIDIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "sg-peer",
+		map[string]string{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue})
+	tgb1 := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "tgb-1",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+			Networking:     sgPeerNetworking,
+		},
+	}
+	tgb2 := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "tgb-2",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-2",
+			Networking:     sgPeerNetworking,
+		},
+	}
+	peerDiscoveryReq := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("ip-permission.group-id"),
+				Values: awssdk.StringSlice([]string{"sg-peer"}),
+			},
+			{
+				Name:   awssdk.String("tag:kubernetes.io/cluster/cluster-1"),
+				Values: awssdk.StringSlice([]string{"owned", "shared"}),
+			},
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-1"}),
+			},
+		},
+	}
+	clusterTagDiscoveryReq := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("tag:kubernetes.io/cluster/cluster-1"),
+				Values: awssdk.StringSlice([]string{"owned", "shared"}),
+			},
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-1"}),
+			},
+		},
+	}
+	type fetchSGInfosByRequestCall struct {
+		req  *ec2sdk.DescribeSecurityGroupsInput
+		resp map[string]networking.SecurityGroupInfo
+	}
+	type reconcileIngressCall struct {
+		sgID               string
+		desiredPermissions []networking.IPPermissionInfo
+		optionsCount       int
+	}
+	tests := []struct {
+		name                         string
+		tgb                          *elbv2api.TargetGroupBinding
+		otherTGBs                    []*elbv2api.TargetGroupBinding
+		ingressPermissionsPerSGByTGB map[types.NamespacedName]map[string][]networking.IPPermissionInfo
+		fetchSGInfosByRequestCalls   []fetchSGInfosByRequestCall
+		reconcileIngressCalls        []reconcileIngressCall
+		wantErr                      error
+	}{
+		{
+			name: "orphaned rules on untracked endpoint SG are revoked when binding is deleted",
+			tgb:  tgb1,
+			fetchSGInfosByRequestCalls: []fetchSGInfosByRequestCall{
+				{
+					req: peerDiscoveryReq,
+					resp: map[string]networking.SecurityGroupInfo{
+						"sg-endpoint": {
+							SecurityGroupID: "sg-endpoint",
+							Ingress:         []networking.IPPermissionInfo{sharedPermission},
+						},
+					},
+				},
+				{
+					req:  clusterTagDiscoveryReq,
+					resp: map[string]networking.SecurityGroupInfo{},
+				},
+			},
+			reconcileIngressCalls: []reconcileIngressCall{
+				{
+					sgID:               "sg-endpoint",
+					desiredPermissions: nil,
+					optionsCount:       1,
+				},
+			},
+		},
+		{
+			name:      "rules still needed by other bindings are kept when binding is deleted",
+			tgb:       tgb1,
+			otherTGBs: []*elbv2api.TargetGroupBinding{tgb2},
+			ingressPermissionsPerSGByTGB: map[types.NamespacedName]map[string][]networking.IPPermissionInfo{
+				k8s.NamespacedName(tgb2): {
+					"sg-endpoint": {sharedPermission},
+				},
+			},
+			fetchSGInfosByRequestCalls: []fetchSGInfosByRequestCall{
+				{
+					req: peerDiscoveryReq,
+					resp: map[string]networking.SecurityGroupInfo{
+						"sg-endpoint": {
+							SecurityGroupID: "sg-endpoint",
+							Ingress:         []networking.IPPermissionInfo{sharedPermission},
+						},
+					},
+				},
+				{
+					req:  clusterTagDiscoveryReq,
+					resp: map[string]networking.SecurityGroupInfo{},
+				},
+			},
+			reconcileIngressCalls: []reconcileIngressCall{
+				{
+					sgID:               "sg-endpoint",
+					desiredPermissions: []networking.IPPermissionInfo{sharedPermission},
+					optionsCount:       2,
+				},
+			},
+		},
+		{
+			name:      "cleanup is requeued when other bindings are not computed yet",
+			tgb:       tgb1,
+			otherTGBs: []*elbv2api.TargetGroupBinding{tgb2},
+			fetchSGInfosByRequestCalls: []fetchSGInfosByRequestCall{
+				{
+					req: peerDiscoveryReq,
+					resp: map[string]networking.SecurityGroupInfo{
+						"sg-endpoint": {
+							SecurityGroupID: "sg-endpoint",
+							Ingress:         []networking.IPPermissionInfo{sharedPermission},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("requeue needed after 5s: wait ingress permissions computed for all TargetGroupBindings"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, tgb := range append([]*elbv2api.TargetGroupBinding{tt.tgb}, tt.otherTGBs...) {
+				err := k8sClient.Create(ctx, tgb.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			sgManager := networking.NewMockSecurityGroupManager(ctrl)
+			for _, call := range tt.fetchSGInfosByRequestCalls {
+				sgManager.EXPECT().FetchSGInfosByRequest(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			sgReconciler := networking.NewMockSecurityGroupReconciler(ctrl)
+			for _, call := range tt.reconcileIngressCalls {
+				opts := make([]interface{}, 0, call.optionsCount)
+				for i := 0; i < call.optionsCount; i++ {
+					opts = append(opts, gomock.Any())
+				}
+				sgReconciler.EXPECT().ReconcileIngress(gomock.Any(), call.sgID, call.desiredPermissions, opts...).Return(nil)
+			}
+
+			m := NewDefaultNetworkingManager(k8sClient, nil, nil, sgManager, sgReconciler, "vpc-1", "cluster-1", &log.NullLogger{})
+			for tgbKey, ingressPermissionsPerSG := range tt.ingressPermissionsPerSGByTGB {
+				m.ingressPermissionsPerSGByTGB[tgbKey] = ingressPermissionsPerSG
+				m.trackedEndpointSGs.Insert(sets.StringKeySet(ingressPermissionsPerSG).List()...)
+			}
+			err := m.Cleanup(ctx, tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
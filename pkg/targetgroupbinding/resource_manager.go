@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sort"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -16,6 +19,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -27,7 +31,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const defaultTargetHealthRequeueDuration = 15 * time.Second
+const (
+	maxTargetsExceededReasonWithinLimit = "WithinLimit"
+	maxTargetsExceededReasonRefused     = "Refused"
+	maxTargetsExceededReasonTruncated   = "Truncated"
+
+	// requeue interval to deregister remaining targets once deregistration is throttled by deregistrationRateLimit.
+	deregistrationThrottledRequeueDuration = 10 * time.Second
+)
 
 // ResourceManager manages the TargetGroupBinding resource.
 type ResourceManager interface {
@@ -37,21 +48,34 @@ type ResourceManager interface {
 
 // NewDefaultResourceManager constructs new defaultResourceManager.
 func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2,
-	podInfoRepo k8s.PodInfoRepo, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
-	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
-	vpcID string, clusterName string, eventRecorder record.EventRecorder, logger logr.Logger) *defaultResourceManager {
+	podInfoRepo k8s.PodInfoRepo, endpointsRepo k8s.EndpointsRepo, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
+	instanceStateResolver networking.InstanceStateResolver, sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
+	vpcID string, clusterName string, enableEndpointZoneStatus bool, tagLabelPrefix string,
+	unhealthyTargetsRequeueDuration time.Duration, healthyTargetsRequeueDuration time.Duration, nodeStartupGracePeriod time.Duration,
+	nodeDrainConditions k8s.NodeDrainConditions, registerRunningInstancesOnly bool, eventRecorder record.EventRecorder, logger logr.Logger) *defaultResourceManager {
 	targetsManager := NewCachedTargetsManager(elbv2Client, logger)
-	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
+	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, endpointsRepo, logger)
 	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, logger)
+	tagsManager := NewDefaultTagsManager(elbv2Client, tagLabelPrefix, logger)
+	healthCheckManager := NewDefaultHealthCheckManager(elbv2Client, logger)
 	return &defaultResourceManager{
-		k8sClient:         k8sClient,
-		targetsManager:    targetsManager,
-		endpointResolver:  endpointResolver,
-		networkingManager: networkingManager,
-		eventRecorder:     eventRecorder,
-		logger:            logger,
-
-		targetHealthRequeueDuration: defaultTargetHealthRequeueDuration,
+		k8sClient:          k8sClient,
+		targetsManager:     targetsManager,
+		endpointResolver:   endpointResolver,
+		networkingManager:  networkingManager,
+		tagsManager:        tagsManager,
+		healthCheckManager: healthCheckManager,
+		eventRecorder:      eventRecorder,
+		logger:             logger,
+
+		instanceStateResolver:        instanceStateResolver,
+		registerRunningInstancesOnly: registerRunningInstancesOnly,
+
+		unhealthyTargetsRequeueDuration: unhealthyTargetsRequeueDuration,
+		healthyTargetsRequeueDuration:   healthyTargetsRequeueDuration,
+		nodeStartupGracePeriod:          nodeStartupGracePeriod,
+		nodeDrainConditions:             nodeDrainConditions,
+		enableEndpointZoneStatus:        enableEndpointZoneStatus,
 	}
 }
 
@@ -59,20 +83,42 @@ var _ ResourceManager = &defaultResourceManager{}
 
 // default implementation for ResourceManager.
 type defaultResourceManager struct {
-	k8sClient         client.Client
-	targetsManager    TargetsManager
-	endpointResolver  backend.EndpointResolver
-	networkingManager NetworkingManager
-	eventRecorder     record.EventRecorder
-	logger            logr.Logger
-
-	targetHealthRequeueDuration time.Duration
+	k8sClient          client.Client
+	targetsManager     TargetsManager
+	endpointResolver   backend.EndpointResolver
+	networkingManager  NetworkingManager
+	tagsManager        TagsManager
+	healthCheckManager HealthCheckManager
+	eventRecorder      record.EventRecorder
+	logger             logr.Logger
+
+	// instanceStateResolver resolves EC2 instance states, so that only running instances are registered as targets.
+	instanceStateResolver networking.InstanceStateResolver
+	// whether only nodes whose EC2 instances are in running state are registered as instance targets.
+	registerRunningInstancesOnly bool
+
+	// requeue interval to monitor targetHealth while any pod with targetHealth readiness gate isn't healthy.
+	unhealthyTargetsRequeueDuration time.Duration
+	// requeue interval to monitor targetHealth once all pods with targetHealth readiness gate are healthy, zero means no requeue.
+	healthyTargetsRequeueDuration time.Duration
+	// grace period since node creation, within which instance targets of nodes not ready yet are not deregistered, zero means disabled.
+	nodeStartupGracePeriod time.Duration
+	// conditions under which nodes are draining, whose instance targets are deregistered.
+	nodeDrainConditions k8s.NodeDrainConditions
+	// experimental: whether to populate the distribution of endpoints across availability zones in TargetGroupBinding's status.
+	enableEndpointZoneStatus bool
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if tgb.Spec.TargetType == nil {
 		return errors.Errorf("targetType is not specified: %v", k8s.NamespacedName(tgb).String())
 	}
+	if err := m.tagsManager.Reconcile(ctx, tgb); err != nil {
+		return err
+	}
+	if err := m.healthCheckManager.Reconcile(ctx, tgb); err != nil {
+		return err
+	}
 	if *tgb.Spec.TargetType == elbv2api.TargetTypeIP {
 		return m.reconcileWithIPTargetType(ctx, tgb)
 	}
@@ -96,31 +142,92 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 	resolveOpts := []backend.EndpointResolveOption{
 		backend.WithPodReadinessGate(targetHealthCondType),
 	}
-	endpoints, containsPotentialReadyEndpoints, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
-	if err != nil {
-		if errors.Is(err, backend.ErrNotFound) {
-			m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonBackendNotFound, err.Error())
-			return m.Cleanup(ctx, tgb)
+	if tgb.Spec.PodSelector != nil {
+		podSelector, err := metav1.LabelSelectorAsSelector(tgb.Spec.PodSelector)
+		if err != nil {
+			return err
 		}
-		return err
+		resolveOpts = append(resolveOpts, backend.WithPodSelector(podSelector))
+	}
+	if tgb.Spec.PodReadinessConditionType != nil {
+		resolveOpts = append(resolveOpts, backend.WithPodReadinessConditionType(*tgb.Spec.PodReadinessConditionType))
+	}
+	if tgb.Spec.IPAddressType != nil {
+		resolveOpts = append(resolveOpts, backend.WithPodIPFamilies(buildPodIPFamilies(*tgb.Spec.IPAddressType)))
+	}
+	portMappings := buildTargetGroupPortMappings(tgb)
+	endpointsPerPortMapping := make([][]backend.PodEndpoint, 0, len(portMappings))
+	var allEndpoints []backend.PodEndpoint
+	var exceededTGARNs []string
+	backendNotFound := make([]bool, len(portMappings))
+	containsPotentialReadyEndpoints := false
+	for index, portMapping := range portMappings {
+		endpoints, portContainsPotentialReadyEndpoints, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, portMapping.servicePort, resolveOpts...)
+		if err != nil {
+			if errors.Is(err, backend.ErrNotFound) {
+				m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonBackendNotFound, err.Error())
+				if err := m.cleanupTargetGroupTargets(ctx, portMapping.targetGroupARN); err != nil {
+					return err
+				}
+				backendNotFound[index] = true
+				endpointsPerPortMapping = append(endpointsPerPortMapping, nil)
+				continue
+			}
+			return err
+		}
+		if portMapping.targetPort != nil {
+			endpoints, err = overridePodEndpointsPort(endpoints, *portMapping.targetPort)
+			if err != nil {
+				return err
+			}
+		}
+		endpoints, exceeded, err := m.limitPodEndpoints(ctx, tgb, portMapping.targetGroupARN, endpoints)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			exceededTGARNs = append(exceededTGARNs, portMapping.targetGroupARN)
+		}
+		endpointsPerPortMapping = append(endpointsPerPortMapping, endpoints)
+		allEndpoints = append(allEndpoints, endpoints...)
+		containsPotentialReadyEndpoints = containsPotentialReadyEndpoints || portContainsPotentialReadyEndpoints
 	}
-
-	tgARN := tgb.Spec.TargetGroupARN
-	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
-	if err != nil {
+	if allBackendNotFound(backendNotFound) {
+		return m.networkingManager.Cleanup(ctx, tgb)
+	}
+	if err := m.reconcileMaxTargets(ctx, tgb, exceededTGARNs); err != nil {
 		return err
 	}
-	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(endpoints, notDrainingTargets)
 
-	if err := m.networkingManager.ReconcileForPodEndpoints(ctx, tgb, endpoints); err != nil {
+	if err := m.networkingManager.ReconcileForPodEndpoints(ctx, tgb, allEndpoints); err != nil {
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
-		return err
+	var matchedEndpointAndTargets []podEndpointAndTargetPair
+	var unmatchedEndpoints []backend.PodEndpoint
+	deregistrationThrottled := false
+	for index, portMapping := range portMappings {
+		if backendNotFound[index] {
+			continue
+		}
+		portMatchedEndpointAndTargets, portUnmatchedEndpoints, portDeregistrationThrottled, err := m.reconcilePodEndpointTargets(ctx, tgb, portMapping.targetGroupARN, endpointsPerPortMapping[index])
+		if err != nil {
+			return err
+		}
+		deregistrationThrottled = deregistrationThrottled || portDeregistrationThrottled
+		// the targetHealth pod condition only reflects the targets within the primary TargetGroup.
+		if index == 0 {
+			matchedEndpointAndTargets = portMatchedEndpointAndTargets
+			unmatchedEndpoints = portUnmatchedEndpoints
+		}
 	}
-	if err := m.registerPodEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
-		return err
+	if m.enableEndpointZoneStatus {
+		zones, err := m.resolvePodEndpointZones(ctx, endpointsPerPortMapping[0])
+		if err != nil {
+			return err
+		}
+		if err := m.updateZoneEndpointsStatus(ctx, tgb, zones); err != nil {
+			return err
+		}
 	}
 
 	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
@@ -128,21 +235,39 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return err
 	}
 
+	if deregistrationThrottled {
+		return runtime.NewRequeueNeededAfter("throttle deregistration", deregistrationThrottledRequeueDuration)
+	}
+
+	anyPodHasReadinessGate := containsPodsWithReadinessGate(targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
+	targetHealthRequeueDuration := m.computeTargetHealthRequeueDuration(anyPodHasReadinessGate, anyPodNeedFurtherProbe)
 	if anyPodNeedFurtherProbe {
-		if containsTargetsInInitialState(matchedEndpointAndTargets) || len(unmatchedEndpoints) != 0 {
-			return runtime.NewRequeueNeededAfter("monitor targetHealth", m.targetHealthRequeueDuration)
-		}
-		return runtime.NewRequeueNeeded("monitor targetHealth")
+		return runtime.NewRequeueNeededAfter("monitor targetHealth", targetHealthRequeueDuration)
 	}
 
 	if containsPotentialReadyEndpoints {
 		return runtime.NewRequeueNeeded("monitor potential ready endpoints")
 	}
 
-	_ = drainingTargets
+	// keep monitoring targetHealth in a longer interval once healthy, so that pod conditions reflect later health changes.
+	if targetHealthRequeueDuration > 0 {
+		return runtime.NewRequeueNeededAfter("monitor targetHealth", targetHealthRequeueDuration)
+	}
 	return nil
 }
 
+// computeTargetHealthRequeueDuration computes the duration to requeue for monitoring targetHealth of pods with targetHealth readiness gate.
+// returns zero if no requeue is needed.
+func (m *defaultResourceManager) computeTargetHealthRequeueDuration(anyPodHasReadinessGate bool, anyPodNeedFurtherProbe bool) time.Duration {
+	if !anyPodHasReadinessGate {
+		return 0
+	}
+	if anyPodNeedFurtherProbe {
+		return m.unhealthyTargetsRequeueDuration
+	}
+	return m.healthyTargetsRequeueDuration
+}
+
 func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
 	nodeSelector, err := backend.GetTrafficProxyNodeSelector(tgb)
@@ -151,44 +276,235 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 	}
 
 	resolveOpts := []backend.EndpointResolveOption{backend.WithNodeSelector(nodeSelector)}
-	endpoints, err := m.endpointResolver.ResolveNodePortEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
-	if err != nil {
-		if errors.Is(err, backend.ErrNotFound) {
-			m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonBackendNotFound, err.Error())
-			return m.Cleanup(ctx, tgb)
+	if m.nodeStartupGracePeriod > 0 {
+		resolveOpts = append(resolveOpts, backend.WithNodeStartupGracePeriod(m.nodeStartupGracePeriod))
+	}
+	resolveOpts = append(resolveOpts, backend.WithNodeDrainConditions(m.nodeDrainConditions))
+	portMappings := buildTargetGroupPortMappings(tgb)
+	endpointsPerPortMapping := make([][]backend.NodePortEndpoint, 0, len(portMappings))
+	var allEndpoints []backend.NodePortEndpoint
+	var exceededTGARNs []string
+	backendNotFound := make([]bool, len(portMappings))
+	containsNotRunningInstances := false
+	for index, portMapping := range portMappings {
+		endpoints, err := m.endpointResolver.ResolveNodePortEndpoints(ctx, svcKey, portMapping.servicePort, resolveOpts...)
+		if err != nil {
+			if errors.Is(err, backend.ErrNotFound) {
+				m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonBackendNotFound, err.Error())
+				if err := m.cleanupTargetGroupTargets(ctx, portMapping.targetGroupARN); err != nil {
+					return err
+				}
+				backendNotFound[index] = true
+				endpointsPerPortMapping = append(endpointsPerPortMapping, nil)
+				continue
+			}
+			return err
 		}
+		runningEndpoints, err := m.filterRunningInstanceEndpoints(ctx, endpoints)
+		if err != nil {
+			return err
+		}
+		containsNotRunningInstances = containsNotRunningInstances || len(runningEndpoints) != len(endpoints)
+		endpoints, exceeded, err := m.limitNodePortEndpoints(ctx, tgb, portMapping.targetGroupARN, runningEndpoints)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			exceededTGARNs = append(exceededTGARNs, portMapping.targetGroupARN)
+		}
+		endpointsPerPortMapping = append(endpointsPerPortMapping, endpoints)
+		allEndpoints = append(allEndpoints, endpoints...)
+	}
+	if allBackendNotFound(backendNotFound) {
+		return m.networkingManager.Cleanup(ctx, tgb)
+	}
+	if err := m.reconcileMaxTargets(ctx, tgb, exceededTGARNs); err != nil {
 		return err
 	}
-	tgARN := tgb.Spec.TargetGroupARN
+
+	if err := m.networkingManager.ReconcileForNodePortEndpoints(ctx, tgb, allEndpoints); err != nil {
+		return err
+	}
+	deregistrationThrottled := false
+	for index, portMapping := range portMappings {
+		if backendNotFound[index] {
+			continue
+		}
+		portDeregistrationThrottled, err := m.reconcileNodePortEndpointTargets(ctx, tgb, portMapping.targetGroupARN, endpointsPerPortMapping[index])
+		if err != nil {
+			return err
+		}
+		deregistrationThrottled = deregistrationThrottled || portDeregistrationThrottled
+	}
+	if m.enableEndpointZoneStatus {
+		zones := make([]string, 0, len(endpointsPerPortMapping[0]))
+		for _, endpoint := range endpointsPerPortMapping[0] {
+			zones = append(zones, k8s.GetNodeZone(endpoint.Node))
+		}
+		if err := m.updateZoneEndpointsStatus(ctx, tgb, zones); err != nil {
+			return err
+		}
+	}
+	if deregistrationThrottled {
+		return runtime.NewRequeueNeededAfter("throttle deregistration", deregistrationThrottledRequeueDuration)
+	}
+	// instances not running yet(e.g. warmed instances in warm pools) are registered once they are running.
+	if containsNotRunningInstances {
+		return runtime.NewRequeueNeededAfter("monitor instance state", m.instanceStateResolver.CacheTTL())
+	}
+	// targets of nodes still not ready are deregistered once their startup grace period expires.
+	if requeueDuration := m.computeNodeStartupGraceRequeueDuration(allEndpoints); requeueDuration > 0 {
+		return runtime.NewRequeueNeededAfter("monitor node startup", requeueDuration)
+	}
+	return nil
+}
+
+// computeNodeStartupGraceRequeueDuration computes the duration until the earliest startup grace period of nodes not ready expires.
+// returns zero if all nodes are ready.
+func (m *defaultResourceManager) computeNodeStartupGraceRequeueDuration(endpoints []backend.NodePortEndpoint) time.Duration {
+	var requeueDuration time.Duration
+	for _, endpoint := range endpoints {
+		if k8s.IsNodeReady(endpoint.Node) {
+			continue
+		}
+		remainingGracePeriod := m.nodeStartupGracePeriod - time.Since(endpoint.Node.CreationTimestamp.Time)
+		if remainingGracePeriod <= 0 {
+			remainingGracePeriod = time.Second
+		}
+		if requeueDuration == 0 || remainingGracePeriod < requeueDuration {
+			requeueDuration = remainingGracePeriod
+		}
+	}
+	return requeueDuration
+}
+
+// filterRunningInstanceEndpoints filters the nodePort endpoints whose EC2 instances are in running state.
+// all endpoints are kept unless registerRunningInstancesOnly is enabled.
+func (m *defaultResourceManager) filterRunningInstanceEndpoints(ctx context.Context, endpoints []backend.NodePortEndpoint) ([]backend.NodePortEndpoint, error) {
+	if !m.registerRunningInstancesOnly || len(endpoints) == 0 {
+		return endpoints, nil
+	}
+	instanceIDs := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		instanceIDs = append(instanceIDs, endpoint.InstanceID)
+	}
+	stateByInstanceID, err := m.instanceStateResolver.Resolve(ctx, instanceIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve instance states")
+	}
+	runningEndpoints := make([]backend.NodePortEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if stateByInstanceID[endpoint.InstanceID] != ec2sdk.InstanceStateNameRunning {
+			m.logger.V(1).Info("ignoring instance not in running state", "instanceID", endpoint.InstanceID,
+				"state", stateByInstanceID[endpoint.InstanceID])
+			continue
+		}
+		runningEndpoints = append(runningEndpoints, endpoint)
+	}
+	return runningEndpoints, nil
+}
+
+// reconcilePodEndpointTargets reconciles the targets within TargetGroup to match pod endpoints.
+// returns the endpoints matched with existing targets, the endpoints newly registered,
+// and whether deregistration is throttled by deregistrationRateLimit.
+func (m *defaultResourceManager) reconcilePodEndpointTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, tgARN string, endpoints []backend.PodEndpoint) ([]podEndpointAndTargetPair, []backend.PodEndpoint, bool, error) {
 	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
 	if err != nil {
-		return err
+		return nil, nil, false, err
 	}
-	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
+	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
+	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(endpoints, notDrainingTargets)
+	unmatchedTargets, deregistrationThrottled := limitDeregistrationTargets(tgb, unmatchedTargets)
+	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
+		return nil, nil, false, err
+	}
+	if err := m.registerPodEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
+		return nil, nil, false, err
+	}
+	return matchedEndpointAndTargets, unmatchedEndpoints, deregistrationThrottled, nil
+}
 
-	if err := m.networkingManager.ReconcileForNodePortEndpoints(ctx, tgb, endpoints); err != nil {
-		return err
+// reconcileNodePortEndpointTargets reconciles the targets within TargetGroup to match nodePort endpoints.
+// returns whether deregistration is throttled by deregistrationRateLimit.
+func (m *defaultResourceManager) reconcileNodePortEndpointTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, tgARN string, endpoints []backend.NodePortEndpoint) (bool, error) {
+	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	if err != nil {
+		return false, err
 	}
+	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
+	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
+	unmatchedTargets, deregistrationThrottled := limitDeregistrationTargets(tgb, unmatchedTargets)
 	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
-		return err
+		return false, err
 	}
+	// nodes not ready yet are only kept as targets within their startup grace period, but never newly registered.
+	unmatchedEndpoints = filterReadyNodePortEndpoints(unmatchedEndpoints)
 	if err := m.registerNodePortEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
-		return err
+		return false, err
 	}
-	_ = drainingTargets
-	return nil
+	return deregistrationThrottled, nil
+}
+
+// limitPodEndpoints limits the pod endpoints to register into TargetGroup according to maxTargets.
+// returns the endpoints kept, along with whether the endpoints exceed maxTargets.
+func (m *defaultResourceManager) limitPodEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, tgARN string, endpoints []backend.PodEndpoint) ([]backend.PodEndpoint, bool, error) {
+	count, exceeded := limitTargetCount(tgb, len(endpoints))
+	if !exceeded {
+		return endpoints, false, nil
+	}
+	registeredTargetUIDs, err := m.listRegisteredTargetUIDs(ctx, tgARN)
+	if err != nil {
+		return nil, false, err
+	}
+	return sortPodEndpointsForLimit(endpoints, registeredTargetUIDs)[:count], true, nil
+}
+
+// limitNodePortEndpoints limits the nodePort endpoints to register into TargetGroup according to maxTargets.
+// returns the endpoints kept, along with whether the endpoints exceed maxTargets.
+func (m *defaultResourceManager) limitNodePortEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, tgARN string, endpoints []backend.NodePortEndpoint) ([]backend.NodePortEndpoint, bool, error) {
+	count, exceeded := limitTargetCount(tgb, len(endpoints))
+	if !exceeded {
+		return endpoints, false, nil
+	}
+	registeredTargetUIDs, err := m.listRegisteredTargetUIDs(ctx, tgARN)
+	if err != nil {
+		return nil, false, err
+	}
+	return sortNodePortEndpointsForLimit(endpoints, registeredTargetUIDs)[:count], true, nil
+}
+
+// listRegisteredTargetUIDs returns the UIDs of targets registered within TargetGroup, draining targets are excluded.
+func (m *defaultResourceManager) listRegisteredTargetUIDs(ctx context.Context, tgARN string) (sets.String, error) {
+	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	if err != nil {
+		return nil, err
+	}
+	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
+	targetUIDs := sets.NewString()
+	for _, target := range notDrainingTargets {
+		targetUIDs.Insert(fmt.Sprintf("%v:%v", awssdk.StringValue(target.Target.Id), awssdk.Int64Value(target.Target.Port)))
+	}
+	return targetUIDs, nil
 }
 
 func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	targets, err := m.targetsManager.ListTargets(ctx, tgb.Spec.TargetGroupARN)
+	for _, portMapping := range buildTargetGroupPortMappings(tgb) {
+		if err := m.cleanupTargetGroupTargets(ctx, portMapping.targetGroupARN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultResourceManager) cleanupTargetGroupTargets(ctx context.Context, tgARN string) error {
+	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
 	if err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgb.Spec.TargetGroupARN, targets); err != nil {
+	if err := m.deregisterTargets(ctx, tgARN, targets); err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
@@ -203,25 +519,33 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 	matchedEndpointAndTargets []podEndpointAndTargetPair, unmatchedEndpoints []backend.PodEndpoint) (bool, error) {
 	anyPodNeedFurtherProbe := false
 
-	for _, endpointAndTarget := range matchedEndpointAndTargets {
-		pod := endpointAndTarget.endpoint.Pod
-		targetHealth := endpointAndTarget.target.TargetHealth
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
-		if err != nil {
-			return false, err
+	// a pod has a target per IP family with multiple IP families, its condition reflects the least healthy of them.
+	var pods []k8s.PodInfo
+	targetHealthByPodKey := make(map[types.NamespacedName]*elbv2sdk.TargetHealth)
+	addPodTargetHealth := func(pod k8s.PodInfo, targetHealth *elbv2sdk.TargetHealth) {
+		existingTargetHealth, exists := targetHealthByPodKey[pod.Key]
+		if !exists {
+			pods = append(pods, pod)
+			targetHealthByPodKey[pod.Key] = targetHealth
+			return
 		}
-		if needFurtherProbe {
-			anyPodNeedFurtherProbe = true
+		if existingTargetHealth != nil && awssdk.StringValue(existingTargetHealth.State) == elbv2sdk.TargetHealthStateEnumHealthy {
+			targetHealthByPodKey[pod.Key] = targetHealth
 		}
 	}
-
+	for _, endpointAndTarget := range matchedEndpointAndTargets {
+		addPodTargetHealth(endpointAndTarget.endpoint.Pod, endpointAndTarget.target.TargetHealth)
+	}
 	for _, endpoint := range unmatchedEndpoints {
-		pod := endpoint.Pod
-		targetHealth := &elbv2sdk.TargetHealth{
+		addPodTargetHealth(endpoint.Pod, &elbv2sdk.TargetHealth{
 			State:       awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
 			Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
 			Description: awssdk.String("Target registration is in progress"),
-		}
+		})
+	}
+
+	for _, pod := range pods {
+		targetHealth := targetHealthByPodKey[pod.Key]
 		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
 		if err != nil {
 			return false, err
@@ -295,6 +619,66 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 	return needFurtherProbe, nil
 }
 
+// resolvePodEndpointZones resolves the availability zone for each pod endpoint based on the node it's running on.
+// the zone will be empty if it cannot be determined.
+func (m *defaultResourceManager) resolvePodEndpointZones(ctx context.Context, endpoints []backend.PodEndpoint) ([]string, error) {
+	zoneByNodeName := make(map[string]string)
+	zones := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		nodeName := endpoint.Pod.NodeName
+		zone, resolved := zoneByNodeName[nodeName]
+		if !resolved && nodeName != "" {
+			node := &corev1.Node{}
+			if err := m.k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+			} else {
+				zone = k8s.GetNodeZone(node)
+			}
+			zoneByNodeName[nodeName] = zone
+		}
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+// updateZoneEndpointsStatus updates the distribution of endpoints across availability zones in TargetGroupBinding's status.
+func (m *defaultResourceManager) updateZoneEndpointsStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, zones []string) error {
+	zoneEndpoints := buildZoneEndpoints(zones)
+	if reflect.DeepEqual(tgb.Status.ZoneEndpoints, zoneEndpoints) {
+		return nil
+	}
+	tgbOld := tgb.DeepCopy()
+	tgb.Status.ZoneEndpoints = zoneEndpoints
+	if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
+// reconcileMaxTargets updates the MaxTargetsExceeded condition in TargetGroupBinding's status,
+// and refuses to reconcile targets if they exceed maxTargets with Refuse policy.
+func (m *defaultResourceManager) reconcileMaxTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, exceededTGARNs []string) error {
+	condition := buildMaxTargetsExceededCondition(tgb, exceededTGARNs)
+	conditions := mergeTargetGroupBindingCondition(tgb.Status.Conditions, elbv2api.TargetGroupBindingConditionMaxTargetsExceeded, condition)
+	if !reflect.DeepEqual(tgb.Status.Conditions, conditions) {
+		tgbOld := tgb.DeepCopy()
+		tgb.Status.Conditions = conditions
+		if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+			return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+		}
+	}
+	if len(exceededTGARNs) == 0 {
+		return nil
+	}
+	m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonMaxTargetsExceeded, condition.Message)
+	if condition.Reason == maxTargetsExceededReasonRefused {
+		return errors.New(condition.Message)
+	}
+	return nil
+}
+
 func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgARN string, targets []TargetInfo) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(targets))
 	for _, target := range targets {
@@ -325,11 +709,36 @@ func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context,
 	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
 }
 
+// overridePodEndpointsPort overrides the port of pod endpoints with targetPort resolved against pod's container ports.
+func overridePodEndpointsPort(endpoints []backend.PodEndpoint, targetPort intstr.IntOrString) ([]backend.PodEndpoint, error) {
+	overriddenEndpoints := make([]backend.PodEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		port, err := endpoint.Pod.LookupContainerPort(targetPort)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve targetPort")
+		}
+		endpoint.Port = port
+		overriddenEndpoints = append(overriddenEndpoints, endpoint)
+	}
+	return overriddenEndpoints, nil
+}
+
 type podEndpointAndTargetPair struct {
 	endpoint backend.PodEndpoint
 	target   TargetInfo
 }
 
+// filterReadyNodePortEndpoints filters the nodePort endpoints whose nodes are ready.
+func filterReadyNodePortEndpoints(endpoints []backend.NodePortEndpoint) []backend.NodePortEndpoint {
+	readyEndpoints := make([]backend.NodePortEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if k8s.IsNodeReady(endpoint.Node) {
+			readyEndpoints = append(readyEndpoints, endpoint)
+		}
+	}
+	return readyEndpoints
+}
+
 func partitionTargetsByDrainingStatus(targets []TargetInfo) ([]TargetInfo, []TargetInfo) {
 	var notDrainingTargets []TargetInfo
 	var drainingTargets []TargetInfo
@@ -343,9 +752,17 @@ func partitionTargetsByDrainingStatus(targets []TargetInfo) ([]TargetInfo, []Tar
 	return notDrainingTargets, drainingTargets
 }
 
-func containsTargetsInInitialState(matchedEndpointAndTargets []podEndpointAndTargetPair) bool {
+// containsPodsWithReadinessGate checks whether any pod of the endpoints has the targetHealth readiness gate.
+func containsPodsWithReadinessGate(targetHealthCondType corev1.PodConditionType,
+	matchedEndpointAndTargets []podEndpointAndTargetPair, unmatchedEndpoints []backend.PodEndpoint) bool {
+	readinessGates := []corev1.PodConditionType{targetHealthCondType}
 	for _, endpointAndTarget := range matchedEndpointAndTargets {
-		if endpointAndTarget.target.IsInitial() {
+		if endpointAndTarget.endpoint.Pod.HasAnyOfReadinessGates(readinessGates) {
+			return true
+		}
+	}
+	for _, endpoint := range unmatchedEndpoints {
+		if endpoint.Pod.HasAnyOfReadinessGates(readinessGates) {
 			return true
 		}
 	}
@@ -425,6 +842,138 @@ func matchNodePortEndpointWithTargets(endpoints []backend.NodePortEndpoint, targ
 	return matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets
 }
 
+// limitTargetCount returns the number of targets allowed to be registered into a TargetGroup according to maxTargets,
+// along with whether the targets exceed maxTargets.
+func limitTargetCount(tgb *elbv2api.TargetGroupBinding, targetCount int) (int, bool) {
+	if tgb.Spec.MaxTargets == nil || int64(targetCount) <= *tgb.Spec.MaxTargets {
+		return targetCount, false
+	}
+	return int(*tgb.Spec.MaxTargets), true
+}
+
+// sortPodEndpointsForLimit sorts pod endpoints in the order to be kept when limited by maxTargets.
+// endpoints already registered as targets come first so that registered targets aren't churned, then endpoints are ordered by IP and port
+// so that the same endpoints are kept across reconciles.
+func sortPodEndpointsForLimit(endpoints []backend.PodEndpoint, registeredTargetUIDs sets.String) []backend.PodEndpoint {
+	sortedEndpoints := append([]backend.PodEndpoint(nil), endpoints...)
+	sort.SliceStable(sortedEndpoints, func(i, j int) bool {
+		iRegistered := registeredTargetUIDs.Has(fmt.Sprintf("%v:%v", sortedEndpoints[i].IP, sortedEndpoints[i].Port))
+		jRegistered := registeredTargetUIDs.Has(fmt.Sprintf("%v:%v", sortedEndpoints[j].IP, sortedEndpoints[j].Port))
+		if iRegistered != jRegistered {
+			return iRegistered
+		}
+		if sortedEndpoints[i].IP != sortedEndpoints[j].IP {
+			return sortedEndpoints[i].IP < sortedEndpoints[j].IP
+		}
+		return sortedEndpoints[i].Port < sortedEndpoints[j].Port
+	})
+	return sortedEndpoints
+}
+
+// sortNodePortEndpointsForLimit sorts nodePort endpoints in the order to be kept when limited by maxTargets.
+// endpoints already registered as targets come first so that registered targets aren't churned, then endpoints are ordered by instanceID and port
+// so that the same endpoints are kept across reconciles.
+func sortNodePortEndpointsForLimit(endpoints []backend.NodePortEndpoint, registeredTargetUIDs sets.String) []backend.NodePortEndpoint {
+	sortedEndpoints := append([]backend.NodePortEndpoint(nil), endpoints...)
+	sort.SliceStable(sortedEndpoints, func(i, j int) bool {
+		iRegistered := registeredTargetUIDs.Has(fmt.Sprintf("%v:%v", sortedEndpoints[i].InstanceID, sortedEndpoints[i].Port))
+		jRegistered := registeredTargetUIDs.Has(fmt.Sprintf("%v:%v", sortedEndpoints[j].InstanceID, sortedEndpoints[j].Port))
+		if iRegistered != jRegistered {
+			return iRegistered
+		}
+		if sortedEndpoints[i].InstanceID != sortedEndpoints[j].InstanceID {
+			return sortedEndpoints[i].InstanceID < sortedEndpoints[j].InstanceID
+		}
+		return sortedEndpoints[i].Port < sortedEndpoints[j].Port
+	})
+	return sortedEndpoints
+}
+
+// limitDeregistrationTargets returns the targets allowed to be deregistered from a TargetGroup in one reconcile according to deregistrationRateLimit,
+// along with whether the deregistration is throttled.
+func limitDeregistrationTargets(tgb *elbv2api.TargetGroupBinding, targets []TargetInfo) ([]TargetInfo, bool) {
+	if tgb.Spec.DeregistrationRateLimit == nil || int64(len(targets)) <= *tgb.Spec.DeregistrationRateLimit {
+		return targets, false
+	}
+	return targets[:*tgb.Spec.DeregistrationRateLimit], true
+}
+
+// buildMaxTargetsExceededCondition builds the MaxTargetsExceeded condition for TargetGroupBinding, it's nil if maxTargets is unspecified.
+func buildMaxTargetsExceededCondition(tgb *elbv2api.TargetGroupBinding, exceededTGARNs []string) *elbv2api.TargetGroupBindingCondition {
+	if tgb.Spec.MaxTargets == nil {
+		return nil
+	}
+	if len(exceededTGARNs) == 0 {
+		return &elbv2api.TargetGroupBindingCondition{
+			Type:   elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+			Status: corev1.ConditionFalse,
+			Reason: maxTargetsExceededReasonWithinLimit,
+		}
+	}
+	policy := elbv2api.MaxTargetsPolicyRefuse
+	if tgb.Spec.MaxTargetsPolicy != nil {
+		policy = *tgb.Spec.MaxTargetsPolicy
+	}
+	reason := maxTargetsExceededReasonRefused
+	if policy == elbv2api.MaxTargetsPolicyTruncate {
+		reason = maxTargetsExceededReasonTruncated
+	}
+	return &elbv2api.TargetGroupBindingCondition{
+		Type:    elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: fmt.Sprintf("targets exceed maxTargets %v for targetGroups: %v", *tgb.Spec.MaxTargets, exceededTGARNs),
+	}
+}
+
+// mergeTargetGroupBindingCondition merges the condition of specified type into conditions, the condition is removed if it's nil.
+// the lastTransitionTime is kept unless the condition status changes.
+func mergeTargetGroupBindingCondition(conditions []elbv2api.TargetGroupBindingCondition, conditionType elbv2api.TargetGroupBindingConditionType,
+	condition *elbv2api.TargetGroupBindingCondition) []elbv2api.TargetGroupBindingCondition {
+	var mergedConditions []elbv2api.TargetGroupBindingCondition
+	var existingCondition *elbv2api.TargetGroupBindingCondition
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			existingCondition = &conditions[i]
+			continue
+		}
+		mergedConditions = append(mergedConditions, conditions[i])
+	}
+	if condition == nil {
+		return mergedConditions
+	}
+	mergedCondition := *condition
+	if existingCondition != nil && existingCondition.Status == condition.Status {
+		mergedCondition.LastTransitionTime = existingCondition.LastTransitionTime
+	} else {
+		now := metav1.Now()
+		mergedCondition.LastTransitionTime = &now
+	}
+	return append(mergedConditions, mergedCondition)
+}
+
+// buildZoneEndpoints builds the number of endpoints per availability zone, sorted by zone.
+func buildZoneEndpoints(zones []string) []elbv2api.ZoneEndpoints {
+	if len(zones) == 0 {
+		return nil
+	}
+	countByZone := make(map[string]int32)
+	for _, zone := range zones {
+		countByZone[zone]++
+	}
+	zoneEndpoints := make([]elbv2api.ZoneEndpoints, 0, len(countByZone))
+	for zone, count := range countByZone {
+		zoneEndpoints = append(zoneEndpoints, elbv2api.ZoneEndpoints{
+			Zone:  zone,
+			Count: count,
+		})
+	}
+	sort.Slice(zoneEndpoints, func(i, j int) bool {
+		return zoneEndpoints[i].Zone < zoneEndpoints[j].Zone
+	})
+	return zoneEndpoints
+}
+
 func buildPodConditionPatch(pod k8s.PodInfo, condition corev1.PodCondition) (client.Patch, error) {
 	oldData, err := json.Marshal(corev1.Pod{
 		Status: corev1.PodStatus{
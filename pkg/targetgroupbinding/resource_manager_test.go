@@ -3,20 +3,30 @@ package targetgroupbinding
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing.T) {
@@ -408,9 +418,222 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 	}
 }
 
-func Test_containsTargetsInInitialState(t *testing.T) {
+func Test_defaultResourceManager_updateTargetHealthPodCondition(t *testing.T) {
+	targetHealthCondType := corev1.PodConditionType("target-health.elbv2.k8s.aws/my-tgb")
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-pod",
+			UID:       "my-pod-uuid",
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{
+				{
+					ConditionType: targetHealthCondType,
+				},
+			},
+		},
+	}
+	podInfo := k8s.PodInfo{
+		Key:            types.NamespacedName{Namespace: "default", Name: "my-pod"},
+		UID:            "my-pod-uuid",
+		ReadinessGates: pod.Spec.ReadinessGates,
+		PodIPs:         []string{"192.168.1.1", "2600:1f14:f8c:2700::1"},
+	}
+	healthyTargetHealth := &elbv2sdk.TargetHealth{
+		State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+	}
+	unhealthyTargetHealth := &elbv2sdk.TargetHealth{
+		State:  awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+		Reason: awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+	}
 	type args struct {
 		matchedEndpointAndTargets []podEndpointAndTargetPair
+		unmatchedEndpoints        []backend.PodEndpoint
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           bool
+		wantCondStatus corev1.ConditionStatus
+		wantCondReason string
+	}{
+		{
+			name: "targets of all IP families are healthy",
+			args: args{
+				matchedEndpointAndTargets: []podEndpointAndTargetPair{
+					{
+						endpoint: backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Pod: podInfo},
+						target:   TargetInfo{TargetHealth: healthyTargetHealth},
+					},
+					{
+						endpoint: backend.PodEndpoint{IP: "2600:1f14:f8c:2700::1", Port: 8080, Pod: podInfo},
+						target:   TargetInfo{TargetHealth: healthyTargetHealth},
+					},
+				},
+			},
+			want:           false,
+			wantCondStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "target of one IP family is unhealthy",
+			args: args{
+				matchedEndpointAndTargets: []podEndpointAndTargetPair{
+					{
+						endpoint: backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Pod: podInfo},
+						target:   TargetInfo{TargetHealth: unhealthyTargetHealth},
+					},
+					{
+						endpoint: backend.PodEndpoint{IP: "2600:1f14:f8c:2700::1", Port: 8080, Pod: podInfo},
+						target:   TargetInfo{TargetHealth: healthyTargetHealth},
+					},
+				},
+			},
+			want:           true,
+			wantCondStatus: corev1.ConditionFalse,
+			wantCondReason: elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks,
+		},
+		{
+			name: "target of one IP family is not registered yet",
+			args: args{
+				matchedEndpointAndTargets: []podEndpointAndTargetPair{
+					{
+						endpoint: backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Pod: podInfo},
+						target:   TargetInfo{TargetHealth: healthyTargetHealth},
+					},
+				},
+				unmatchedEndpoints: []backend.PodEndpoint{
+					{IP: "2600:1f14:f8c:2700::1", Port: 8080, Pod: podInfo},
+				},
+			},
+			want:           true,
+			wantCondStatus: corev1.ConditionFalse,
+			wantCondReason: elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			m := &defaultResourceManager{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+
+			ctx := context.Background()
+			err := k8sClient.Create(ctx, pod.DeepCopy())
+			assert.NoError(t, err)
+
+			got, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, tt.args.matchedEndpointAndTargets, tt.args.unmatchedEndpoints)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			updatedPod := &corev1.Pod{}
+			err = m.k8sClient.Get(ctx, podInfo.Key, updatedPod)
+			assert.NoError(t, err)
+			updatedPodInfo := k8s.PodInfo{Conditions: updatedPod.Status.Conditions}
+			cond, exists := updatedPodInfo.GetPodCondition(targetHealthCondType)
+			assert.True(t, exists)
+			assert.Equal(t, tt.wantCondStatus, cond.Status)
+			assert.Equal(t, tt.wantCondReason, cond.Reason)
+		})
+	}
+}
+
+func Test_defaultResourceManager_computeTargetHealthRequeueDuration(t *testing.T) {
+	type fields struct {
+		unhealthyTargetsRequeueDuration time.Duration
+		healthyTargetsRequeueDuration   time.Duration
+	}
+	type args struct {
+		anyPodHasReadinessGate bool
+		anyPodNeedFurtherProbe bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   time.Duration
+	}{
+		{
+			name: "pods with readinessGate not healthy",
+			fields: fields{
+				unhealthyTargetsRequeueDuration: 15 * time.Second,
+				healthyTargetsRequeueDuration:   5 * time.Minute,
+			},
+			args: args{
+				anyPodHasReadinessGate: true,
+				anyPodNeedFurtherProbe: true,
+			},
+			want: 15 * time.Second,
+		},
+		{
+			name: "pods with readinessGate all healthy",
+			fields: fields{
+				unhealthyTargetsRequeueDuration: 15 * time.Second,
+				healthyTargetsRequeueDuration:   5 * time.Minute,
+			},
+			args: args{
+				anyPodHasReadinessGate: true,
+				anyPodNeedFurtherProbe: false,
+			},
+			want: 5 * time.Minute,
+		},
+		{
+			name: "pods with readinessGate all healthy and healthy requeue disabled",
+			fields: fields{
+				unhealthyTargetsRequeueDuration: 15 * time.Second,
+				healthyTargetsRequeueDuration:   0,
+			},
+			args: args{
+				anyPodHasReadinessGate: true,
+				anyPodNeedFurtherProbe: false,
+			},
+			want: 0,
+		},
+		{
+			name: "no pods with readinessGate",
+			fields: fields{
+				unhealthyTargetsRequeueDuration: 15 * time.Second,
+				healthyTargetsRequeueDuration:   5 * time.Minute,
+			},
+			args: args{
+				anyPodHasReadinessGate: false,
+				anyPodNeedFurtherProbe: false,
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				unhealthyTargetsRequeueDuration: tt.fields.unhealthyTargetsRequeueDuration,
+				healthyTargetsRequeueDuration:   tt.fields.healthyTargetsRequeueDuration,
+			}
+			got := m.computeTargetHealthRequeueDuration(tt.args.anyPodHasReadinessGate, tt.args.anyPodNeedFurtherProbe)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_containsPodsWithReadinessGate(t *testing.T) {
+	targetHealthCondType := corev1.PodConditionType("target-health.elbv2.k8s.aws/my-tgb")
+	podWithReadinessGate := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: "default", Name: "pod-1"},
+		ReadinessGates: []corev1.PodReadinessGate{
+			{
+				ConditionType: targetHealthCondType,
+			},
+		},
+	}
+	podWithoutReadinessGate := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: "default", Name: "pod-2"},
+	}
+	type args struct {
+		matchedEndpointAndTargets []podEndpointAndTargetPair
+		unmatchedEndpoints        []backend.PodEndpoint
 	}
 	tests := []struct {
 		name string
@@ -418,32 +641,39 @@ func Test_containsTargetsInInitialState(t *testing.T) {
 		want bool
 	}{
 		{
-			name: "contains initial targets",
+			name: "matched endpoint with readinessGate",
 			args: args{
 				matchedEndpointAndTargets: []podEndpointAndTargetPair{
 					{
-						target: TargetInfo{
-							TargetHealth: &elbv2sdk.TargetHealth{
-								State:       awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
-								Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
-								Description: awssdk.String("Target registration is in progress"),
-							},
-						},
+						endpoint: backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Pod: podWithoutReadinessGate},
+					},
+					{
+						endpoint: backend.PodEndpoint{IP: "192.168.1.2", Port: 8080, Pod: podWithReadinessGate},
 					},
 				},
 			},
 			want: true,
 		},
 		{
-			name: "contains no initial targets",
+			name: "unmatched endpoint with readinessGate",
 			args: args{
 				matchedEndpointAndTargets: []podEndpointAndTargetPair{
 					{
-						target: TargetInfo{
-							TargetHealth: &elbv2sdk.TargetHealth{
-								State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
-							},
-						},
+						endpoint: backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Pod: podWithoutReadinessGate},
+					},
+				},
+				unmatchedEndpoints: []backend.PodEndpoint{
+					{IP: "192.168.1.2", Port: 8080, Pod: podWithReadinessGate},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "no endpoint with readinessGate",
+			args: args{
+				matchedEndpointAndTargets: []podEndpointAndTargetPair{
+					{
+						endpoint: backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Pod: podWithoutReadinessGate},
 					},
 				},
 			},
@@ -452,7 +682,7 @@ func Test_containsTargetsInInitialState(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := containsTargetsInInitialState(tt.args.matchedEndpointAndTargets)
+			got := containsPodsWithReadinessGate(targetHealthCondType, tt.args.matchedEndpointAndTargets, tt.args.unmatchedEndpoints)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -500,3 +730,718 @@ func Test_buildPodConditionPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildZoneEndpoints(t *testing.T) {
+	type args struct {
+		zones []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []elbv2api.ZoneEndpoints
+	}{
+		{
+			name: "no endpoints",
+			args: args{
+				zones: nil,
+			},
+			want: nil,
+		},
+		{
+			name: "endpoints across multiple zones",
+			args: args{
+				zones: []string{"us-west-2b", "us-west-2a", "us-west-2b", "", "us-west-2c", "us-west-2b"},
+			},
+			want: []elbv2api.ZoneEndpoints{
+				{
+					Zone:  "",
+					Count: 1,
+				},
+				{
+					Zone:  "us-west-2a",
+					Count: 1,
+				},
+				{
+					Zone:  "us-west-2b",
+					Count: 3,
+				},
+				{
+					Zone:  "us-west-2c",
+					Count: 1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildZoneEndpoints(tt.args.zones)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_overridePodEndpointsPort(t *testing.T) {
+	pod := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: "default", Name: "pod-1"},
+		ContainerPorts: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: 8080,
+			},
+			{
+				Name:          "proxy",
+				ContainerPort: 15001,
+			},
+		},
+	}
+	type args struct {
+		endpoints  []backend.PodEndpoint
+		targetPort intstr.IntOrString
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []backend.PodEndpoint
+		wantErr error
+	}{
+		{
+			name: "override with named port",
+			args: args{
+				endpoints: []backend.PodEndpoint{
+					{IP: "192.168.1.1", Port: 8080, Pod: pod},
+				},
+				targetPort: intstr.FromString("proxy"),
+			},
+			want: []backend.PodEndpoint{
+				{IP: "192.168.1.1", Port: 15001, Pod: pod},
+			},
+		},
+		{
+			name: "override with numerical port",
+			args: args{
+				endpoints: []backend.PodEndpoint{
+					{IP: "192.168.1.1", Port: 8080, Pod: pod},
+				},
+				targetPort: intstr.FromInt(9090),
+			},
+			want: []backend.PodEndpoint{
+				{IP: "192.168.1.1", Port: 9090, Pod: pod},
+			},
+		},
+		{
+			name: "named port not found on pod",
+			args: args{
+				endpoints: []backend.PodEndpoint{
+					{IP: "192.168.1.1", Port: 8080, Pod: pod},
+				},
+				targetPort: intstr.FromString("admin"),
+			},
+			wantErr: errors.New("failed to resolve targetPort: unable to find port admin on pod default/pod-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := overridePodEndpointsPort(tt.args.endpoints, tt.args.targetPort)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_limitTargetCount(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxTargets   *int64
+		targetCount  int
+		wantCount    int
+		wantExceeded bool
+	}{
+		{
+			name:         "maxTargets unspecified",
+			maxTargets:   nil,
+			targetCount:  1000,
+			wantCount:    1000,
+			wantExceeded: false,
+		},
+		{
+			name:         "targets within maxTargets",
+			maxTargets:   awssdk.Int64(3),
+			targetCount:  3,
+			wantCount:    3,
+			wantExceeded: false,
+		},
+		{
+			name:         "targets exceed maxTargets",
+			maxTargets:   awssdk.Int64(3),
+			targetCount:  5,
+			wantCount:    3,
+			wantExceeded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgb := &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					MaxTargets: tt.maxTargets,
+				},
+			}
+			gotCount, gotExceeded := limitTargetCount(tgb, tt.targetCount)
+			assert.Equal(t, tt.wantCount, gotCount)
+			assert.Equal(t, tt.wantExceeded, gotExceeded)
+		})
+	}
+}
+
+func Test_sortPodEndpointsForLimit(t *testing.T) {
+	tests := []struct {
+		name                 string
+		endpoints            []backend.PodEndpoint
+		registeredTargetUIDs sets.String
+		want                 []backend.PodEndpoint
+	}{
+		{
+			name: "no endpoints registered",
+			endpoints: []backend.PodEndpoint{
+				{IP: "192.168.1.3", Port: 8080},
+				{IP: "192.168.1.1", Port: 8443},
+				{IP: "192.168.1.1", Port: 8080},
+			},
+			registeredTargetUIDs: sets.NewString(),
+			want: []backend.PodEndpoint{
+				{IP: "192.168.1.1", Port: 8080},
+				{IP: "192.168.1.1", Port: 8443},
+				{IP: "192.168.1.3", Port: 8080},
+			},
+		},
+		{
+			name: "registered endpoints come first",
+			endpoints: []backend.PodEndpoint{
+				{IP: "192.168.1.1", Port: 8080},
+				{IP: "192.168.1.2", Port: 8080},
+				{IP: "192.168.1.3", Port: 8080},
+				{IP: "192.168.1.4", Port: 8080},
+			},
+			registeredTargetUIDs: sets.NewString("192.168.1.4:8080", "192.168.1.2:8080", "192.168.1.1:9090"),
+			want: []backend.PodEndpoint{
+				{IP: "192.168.1.2", Port: 8080},
+				{IP: "192.168.1.4", Port: 8080},
+				{IP: "192.168.1.1", Port: 8080},
+				{IP: "192.168.1.3", Port: 8080},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortPodEndpointsForLimit(tt.endpoints, tt.registeredTargetUIDs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_sortNodePortEndpointsForLimit(t *testing.T) {
+	tests := []struct {
+		name                 string
+		endpoints            []backend.NodePortEndpoint
+		registeredTargetUIDs sets.String
+		want                 []backend.NodePortEndpoint
+	}{
+		{
+			name: "no endpoints registered",
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-3", Port: 30080},
+				{InstanceID: "i-1", Port: 30443},
+				{InstanceID: "i-1", Port: 30080},
+			},
+			registeredTargetUIDs: sets.NewString(),
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-1", Port: 30443},
+				{InstanceID: "i-3", Port: 30080},
+			},
+		},
+		{
+			name: "registered endpoints come first",
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-2", Port: 30080},
+				{InstanceID: "i-3", Port: 30080},
+			},
+			registeredTargetUIDs: sets.NewString("i-3:30080"),
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-3", Port: 30080},
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-2", Port: 30080},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortNodePortEndpointsForLimit(tt.endpoints, tt.registeredTargetUIDs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_limitDeregistrationTargets(t *testing.T) {
+	targets := []TargetInfo{
+		{Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)}},
+		{Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)}},
+		{Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-3"), Port: awssdk.Int64(30080)}},
+	}
+	tests := []struct {
+		name                    string
+		deregistrationRateLimit *int64
+		targets                 []TargetInfo
+		want                    []TargetInfo
+		wantThrottled           bool
+	}{
+		{
+			name:                    "deregistrationRateLimit unspecified",
+			deregistrationRateLimit: nil,
+			targets:                 targets,
+			want:                    targets,
+			wantThrottled:           false,
+		},
+		{
+			name:                    "targets within deregistrationRateLimit",
+			deregistrationRateLimit: awssdk.Int64(3),
+			targets:                 targets,
+			want:                    targets,
+			wantThrottled:           false,
+		},
+		{
+			name:                    "targets exceed deregistrationRateLimit",
+			deregistrationRateLimit: awssdk.Int64(2),
+			targets:                 targets,
+			want:                    targets[:2],
+			wantThrottled:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgb := &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					DeregistrationRateLimit: tt.deregistrationRateLimit,
+				},
+			}
+			got, gotThrottled := limitDeregistrationTargets(tgb, tt.targets)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantThrottled, gotThrottled)
+		})
+	}
+}
+
+// fakeTargetsManager is a TargetsManager that keeps targets in memory and records deregistered targets.
+type fakeTargetsManager struct {
+	targets             []TargetInfo
+	deregisteredTargets [][]elbv2sdk.TargetDescription
+}
+
+func (m *fakeTargetsManager) RegisterTargets(_ context.Context, _ string, targets []elbv2sdk.TargetDescription) error {
+	for _, target := range targets {
+		m.targets = append(m.targets, TargetInfo{Target: target})
+	}
+	return nil
+}
+
+func (m *fakeTargetsManager) DeregisterTargets(_ context.Context, _ string, targets []elbv2sdk.TargetDescription) error {
+	deregisteredIDs := sets.NewString()
+	for _, target := range targets {
+		deregisteredIDs.Insert(awssdk.StringValue(target.Id))
+	}
+	var remainingTargets []TargetInfo
+	for _, target := range m.targets {
+		if !deregisteredIDs.Has(awssdk.StringValue(target.Target.Id)) {
+			remainingTargets = append(remainingTargets, target)
+		}
+	}
+	m.targets = remainingTargets
+	m.deregisteredTargets = append(m.deregisteredTargets, targets)
+	return nil
+}
+
+func (m *fakeTargetsManager) ListTargets(_ context.Context, _ string) ([]TargetInfo, error) {
+	return cloneTargetInfoSlice(m.targets), nil
+}
+
+func Test_defaultResourceManager_reconcileNodePortEndpointTargets_deregistrationRateLimit(t *testing.T) {
+	healthyTarget := func(instanceID string) TargetInfo {
+		return TargetInfo{
+			Target:       elbv2sdk.TargetDescription{Id: awssdk.String(instanceID), Port: awssdk.Int64(30080)},
+			TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+		}
+	}
+	tgb := &elbv2api.TargetGroupBinding{
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN:          "tg-1",
+			DeregistrationRateLimit: awssdk.Int64(2),
+		},
+	}
+	targetsManager := &fakeTargetsManager{
+		targets: []TargetInfo{
+			healthyTarget("i-1"),
+			healthyTarget("i-2"),
+			healthyTarget("i-3"),
+			healthyTarget("i-4"),
+			healthyTarget("i-5"),
+		},
+	}
+	m := &defaultResourceManager{
+		targetsManager: targetsManager,
+		logger:         &log.NullLogger{},
+	}
+
+	// scaling down to zero endpoints deregisters at most deregistrationRateLimit targets per reconcile.
+	wantDeregisteredCounts := []int{2, 2, 1}
+	wantThrottled := []bool{true, true, false}
+	for i := range wantDeregisteredCounts {
+		throttled, err := m.reconcileNodePortEndpointTargets(context.Background(), tgb, "tg-1", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, wantThrottled[i], throttled)
+		assert.Len(t, targetsManager.deregisteredTargets[i], wantDeregisteredCounts[i])
+	}
+	assert.Empty(t, targetsManager.targets)
+}
+
+func Test_buildMaxTargetsExceededCondition(t *testing.T) {
+	truncatePolicy := elbv2api.MaxTargetsPolicyTruncate
+	tests := []struct {
+		name           string
+		spec           elbv2api.TargetGroupBindingSpec
+		exceededTGARNs []string
+		want           *elbv2api.TargetGroupBindingCondition
+	}{
+		{
+			name:           "maxTargets unspecified",
+			spec:           elbv2api.TargetGroupBindingSpec{},
+			exceededTGARNs: nil,
+			want:           nil,
+		},
+		{
+			name: "targets within maxTargets",
+			spec: elbv2api.TargetGroupBindingSpec{
+				MaxTargets: awssdk.Int64(3),
+			},
+			exceededTGARNs: nil,
+			want: &elbv2api.TargetGroupBindingCondition{
+				Type:   elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "WithinLimit",
+			},
+		},
+		{
+			name: "targets exceed maxTargets with default policy",
+			spec: elbv2api.TargetGroupBindingSpec{
+				MaxTargets: awssdk.Int64(3),
+			},
+			exceededTGARNs: []string{"tg-1"},
+			want: &elbv2api.TargetGroupBindingCondition{
+				Type:    elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Refused",
+				Message: "targets exceed maxTargets 3 for targetGroups: [tg-1]",
+			},
+		},
+		{
+			name: "targets exceed maxTargets with Truncate policy",
+			spec: elbv2api.TargetGroupBindingSpec{
+				MaxTargets:       awssdk.Int64(3),
+				MaxTargetsPolicy: &truncatePolicy,
+			},
+			exceededTGARNs: []string{"tg-1", "tg-2"},
+			want: &elbv2api.TargetGroupBindingCondition{
+				Type:    elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Truncated",
+				Message: "targets exceed maxTargets 3 for targetGroups: [tg-1 tg-2]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgb := &elbv2api.TargetGroupBinding{
+				Spec: tt.spec,
+			}
+			got := buildMaxTargetsExceededCondition(tgb, tt.exceededTGARNs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mergeTargetGroupBindingCondition(t *testing.T) {
+	transitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	tests := []struct {
+		name                  string
+		conditions            []elbv2api.TargetGroupBindingCondition
+		condition             *elbv2api.TargetGroupBindingCondition
+		want                  []elbv2api.TargetGroupBindingCondition
+		wantNewTransitionTime bool
+	}{
+		{
+			name:       "remove absent condition",
+			conditions: nil,
+			condition:  nil,
+			want:       nil,
+		},
+		{
+			name: "remove existing condition",
+			conditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:               elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: &transitionTime,
+				},
+			},
+			condition: nil,
+			want:      nil,
+		},
+		{
+			name: "keep lastTransitionTime if status unchanged",
+			conditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:               elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+					Status:             corev1.ConditionTrue,
+					Reason:             "Refused",
+					Message:            "targets exceed maxTargets 3 for targetGroups: [tg-1]",
+					LastTransitionTime: &transitionTime,
+				},
+			},
+			condition: &elbv2api.TargetGroupBindingCondition{
+				Type:    elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Refused",
+				Message: "targets exceed maxTargets 3 for targetGroups: [tg-1 tg-2]",
+			},
+			want: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:               elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+					Status:             corev1.ConditionTrue,
+					Reason:             "Refused",
+					Message:            "targets exceed maxTargets 3 for targetGroups: [tg-1 tg-2]",
+					LastTransitionTime: &transitionTime,
+				},
+			},
+		},
+		{
+			name: "update lastTransitionTime if status changed",
+			conditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:               elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+					Status:             corev1.ConditionTrue,
+					Reason:             "Refused",
+					LastTransitionTime: &transitionTime,
+				},
+			},
+			condition: &elbv2api.TargetGroupBindingCondition{
+				Type:   elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "WithinLimit",
+			},
+			want: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:   elbv2api.TargetGroupBindingConditionMaxTargetsExceeded,
+					Status: corev1.ConditionFalse,
+					Reason: "WithinLimit",
+				},
+			},
+			wantNewTransitionTime: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeTargetGroupBindingCondition(tt.conditions, elbv2api.TargetGroupBindingConditionMaxTargetsExceeded, tt.condition)
+			if tt.wantNewTransitionTime {
+				for i := range got {
+					assert.True(t, got[i].LastTransitionTime.After(transitionTime.Time))
+					got[i].LastTransitionTime = nil
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultResourceManager_filterRunningInstanceEndpoints(t *testing.T) {
+	tests := []struct {
+		name                         string
+		registerRunningInstancesOnly bool
+		endpoints                    []backend.NodePortEndpoint
+		describeInstancesResp        []*ec2sdk.Instance
+		wantDescribeInstancesCall    bool
+		want                         []backend.NodePortEndpoint
+	}{
+		{
+			name:                         "no endpoints",
+			registerRunningInstancesOnly: true,
+			endpoints:                    nil,
+			want:                         nil,
+		},
+		{
+			name: "all endpoints are kept if not registering running instances only",
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-2", Port: 30080},
+			},
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-2", Port: 30080},
+			},
+		},
+		{
+			name:                         "only endpoints of running instances are kept",
+			registerRunningInstancesOnly: true,
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-2", Port: 30080},
+				{InstanceID: "i-3", Port: 30080},
+			},
+			describeInstancesResp: []*ec2sdk.Instance{
+				{
+					InstanceId: awssdk.String("i-1"),
+					State:      &ec2sdk.InstanceState{Name: awssdk.String(ec2sdk.InstanceStateNameRunning)},
+				},
+				{
+					InstanceId: awssdk.String("i-2"),
+					State:      &ec2sdk.InstanceState{Name: awssdk.String(ec2sdk.InstanceStateNameStopped)},
+				},
+				{
+					InstanceId: awssdk.String("i-3"),
+					State:      &ec2sdk.InstanceState{Name: awssdk.String(ec2sdk.InstanceStateNameRunning)},
+				},
+			},
+			wantDescribeInstancesCall: true,
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080},
+				{InstanceID: "i-3", Port: 30080},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := services.NewMockEC2(ctrl)
+			if tt.wantDescribeInstancesCall {
+				ec2Client.EXPECT().DescribeInstancesAsList(gomock.Any(), gomock.Any()).Return(tt.describeInstancesResp, nil)
+			}
+			m := &defaultResourceManager{
+				instanceStateResolver:        networking.NewDefaultInstanceStateResolver(ec2Client, &log.NullLogger{}),
+				registerRunningInstancesOnly: tt.registerRunningInstancesOnly,
+				logger:                       &log.NullLogger{},
+			}
+			got, err := m.filterRunningInstanceEndpoints(context.Background(), tt.endpoints)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultResourceManager_computeNodeStartupGraceRequeueDuration(t *testing.T) {
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", CreationTimestamp: metav1.NewTime(time.Now())},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReadyNewNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2", CreationTimestamp: metav1.NewTime(time.Now().Add(-1 * time.Minute))},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+		},
+	}
+	notReadyExpiredNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-3", CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+		},
+	}
+	tests := []struct {
+		name      string
+		endpoints []backend.NodePortEndpoint
+		wantMin   time.Duration
+		wantMax   time.Duration
+	}{
+		{
+			name:      "no endpoints",
+			endpoints: nil,
+		},
+		{
+			name:      "all nodes are ready",
+			endpoints: []backend.NodePortEndpoint{{InstanceID: "i-1", Node: readyNode}},
+		},
+		{
+			name: "nodes not ready within grace period",
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Node: readyNode},
+				{InstanceID: "i-2", Node: notReadyNewNode},
+			},
+			wantMin: 3*time.Minute + 50*time.Second,
+			wantMax: 4 * time.Minute,
+		},
+		{
+			name: "nodes not ready with grace period expired",
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-2", Node: notReadyNewNode},
+				{InstanceID: "i-3", Node: notReadyExpiredNode},
+			},
+			wantMin: time.Second,
+			wantMax: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				nodeStartupGracePeriod: 5 * time.Minute,
+			}
+			got := m.computeNodeStartupGraceRequeueDuration(tt.endpoints)
+			assert.True(t, got >= tt.wantMin && got <= tt.wantMax, "got: %v", got)
+		})
+	}
+}
+
+func Test_filterReadyNodePortEndpoints(t *testing.T) {
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReadyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+		},
+	}
+	tests := []struct {
+		name      string
+		endpoints []backend.NodePortEndpoint
+		want      []backend.NodePortEndpoint
+	}{
+		{
+			name:      "no endpoints",
+			endpoints: nil,
+			want:      []backend.NodePortEndpoint{},
+		},
+		{
+			name: "only endpoints of ready nodes are kept",
+			endpoints: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080, Node: readyNode},
+				{InstanceID: "i-2", Port: 30080, Node: notReadyNode},
+			},
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-1", Port: 30080, Node: readyNode},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterReadyNodePortEndpoints(tt.endpoints)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
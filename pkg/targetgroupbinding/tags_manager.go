@@ -0,0 +1,94 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+)
+
+const (
+	// AWS tag keys can be up to 128 characters, and values up to 256 characters.
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+var (
+	// AWS tags can only contain letters, numbers, spaces and _ . : / = + - @
+	invalidTagCharsRegex = regexp.MustCompile(`[^\p{L}\p{Z}\p{N}_.:/=+\-@]`)
+)
+
+// TagsManager manages the tags on TargetGroups that are propagated from TargetGroupBinding labels.
+type TagsManager interface {
+	// Reconcile reconciles the tags on TargetGroups of TargetGroupBinding to match its labels with configured prefix.
+	Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
+}
+
+// NewDefaultTagsManager constructs defaultTagsManager.
+// Labels with labelPrefix are propagated as tags, and it's disabled if labelPrefix is empty.
+func NewDefaultTagsManager(elbv2Client services.ELBV2, labelPrefix string, logger logr.Logger) *defaultTagsManager {
+	// only tags propagated from labels are removed, other tags on TargetGroups are left untouched.
+	taggingManager := elbv2deploy.NewDefaultTaggingManager(elbv2Client, []string{sanitizeTagKey(labelPrefix)}, logger)
+	return &defaultTagsManager{
+		taggingManager: taggingManager,
+		labelPrefix:    labelPrefix,
+		logger:         logger,
+	}
+}
+
+var _ TagsManager = &defaultTagsManager{}
+
+// default implementation for TagsManager.
+type defaultTagsManager struct {
+	taggingManager elbv2deploy.TaggingManager
+	labelPrefix    string
+	logger         logr.Logger
+}
+
+func (m *defaultTagsManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if len(m.labelPrefix) == 0 {
+		return nil
+	}
+	desiredTags := buildTargetGroupTagsFromLabels(tgb, m.labelPrefix)
+	for _, portMapping := range buildTargetGroupPortMappings(tgb) {
+		if err := m.taggingManager.ReconcileTags(ctx, portMapping.targetGroupARN, desiredTags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTargetGroupTagsFromLabels builds the TargetGroup tags from TargetGroupBinding labels with specified prefix.
+func buildTargetGroupTagsFromLabels(tgb *elbv2api.TargetGroupBinding, labelPrefix string) map[string]string {
+	tags := make(map[string]string)
+	for key, value := range tgb.Labels {
+		if !strings.HasPrefix(key, labelPrefix) {
+			continue
+		}
+		tags[sanitizeTagKey(key)] = sanitizeTagValue(value)
+	}
+	return tags
+}
+
+// sanitizeTagKey sanitizes the tag key to satisfy AWS tag key constraints.
+func sanitizeTagKey(key string) string {
+	return sanitizeTag(key, maxTagKeyLength)
+}
+
+// sanitizeTagValue sanitizes the tag value to satisfy AWS tag value constraints.
+func sanitizeTagValue(value string) string {
+	return sanitizeTag(value, maxTagValueLength)
+}
+
+// sanitizeTag replaces characters not allowed in AWS tags with "_", and truncates it to maxLength characters.
+func sanitizeTag(tag string, maxLength int) string {
+	sanitized := []rune(invalidTagCharsRegex.ReplaceAllString(tag, "_"))
+	if len(sanitized) > maxLength {
+		sanitized = sanitized[:maxLength]
+	}
+	return string(sanitized)
+}
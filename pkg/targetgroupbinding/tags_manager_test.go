@@ -0,0 +1,271 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultTagsManager_Reconcile(t *testing.T) {
+	type describeTagsWithContextCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+	}
+	type addTagsWithContextCall struct {
+		req *elbv2sdk.AddTagsInput
+	}
+	type removeTagsWithContextCall struct {
+		req *elbv2sdk.RemoveTagsInput
+	}
+	tests := []struct {
+		name                         string
+		labelPrefix                  string
+		tgb                          *elbv2api.TargetGroupBinding
+		describeTagsWithContextCalls []describeTagsWithContextCall
+		addTagsWithContextCalls      []addTagsWithContextCall
+		removeTagsWithContextCalls   []removeTagsWithContextCall
+	}{
+		{
+			name:        "disabled without labelPrefix",
+			labelPrefix: "",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"cost.example.com/team": "awesome-team",
+					},
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+		},
+		{
+			name:        "labels with prefix are added and stale tags with prefix are removed",
+			labelPrefix: "cost.example.com/",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"cost.example.com/team": "awesome-team",
+						"app":                   "awesome-app",
+					},
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			describeTagsWithContextCalls: []describeTagsWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-1"}),
+					},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{
+								ResourceArn: awssdk.String("tg-1"),
+								Tags: []*elbv2sdk.Tag{
+									{
+										Key:   awssdk.String("cost.example.com/project"),
+										Value: awssdk.String("awesome-project"),
+									},
+									{
+										Key:   awssdk.String("owner"),
+										Value: awssdk.String("someone"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			addTagsWithContextCalls: []addTagsWithContextCall{
+				{
+					req: &elbv2sdk.AddTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-1"}),
+						Tags: []*elbv2sdk.Tag{
+							{
+								Key:   awssdk.String("cost.example.com/team"),
+								Value: awssdk.String("awesome-team"),
+							},
+						},
+					},
+				},
+			},
+			removeTagsWithContextCalls: []removeTagsWithContextCall{
+				{
+					req: &elbv2sdk.RemoveTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-1"}),
+						TagKeys:      awssdk.StringSlice([]string{"cost.example.com/project"}),
+					},
+				},
+			},
+		},
+		{
+			name:        "tags are reconciled on additional TargetGroups",
+			labelPrefix: "cost.example.com/",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"cost.example.com/team": "awesome-team",
+					},
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+						{
+							TargetGroupARN: "tg-2",
+						},
+					},
+				},
+			},
+			describeTagsWithContextCalls: []describeTagsWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-1"}),
+					},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{
+								ResourceArn: awssdk.String("tg-1"),
+								Tags: []*elbv2sdk.Tag{
+									{
+										Key:   awssdk.String("cost.example.com/team"),
+										Value: awssdk.String("awesome-team"),
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					req: &elbv2sdk.DescribeTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-2"}),
+					},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{
+								ResourceArn: awssdk.String("tg-2"),
+							},
+						},
+					},
+				},
+			},
+			addTagsWithContextCalls: []addTagsWithContextCall{
+				{
+					req: &elbv2sdk.AddTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-2"}),
+						Tags: []*elbv2sdk.Tag{
+							{
+								Key:   awssdk.String("cost.example.com/team"),
+								Value: awssdk.String("awesome-team"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTagsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.addTagsWithContextCalls {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.AddTagsOutput{}, nil)
+			}
+			for _, call := range tt.removeTagsWithContextCalls {
+				elbv2Client.EXPECT().RemoveTagsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RemoveTagsOutput{}, nil)
+			}
+
+			m := NewDefaultTagsManager(elbv2Client, tt.labelPrefix, &log.NullLogger{})
+			err := m.Reconcile(context.Background(), tt.tgb)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_buildTargetGroupTagsFromLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		labelPrefix string
+		want        map[string]string
+	}{
+		{
+			name:        "no labels",
+			labels:      nil,
+			labelPrefix: "cost.example.com/",
+			want:        map[string]string{},
+		},
+		{
+			name: "only labels with prefix are propagated",
+			labels: map[string]string{
+				"cost.example.com/team":    "awesome-team",
+				"cost.example.com/project": "awesome-project",
+				"app":                      "awesome-app",
+			},
+			labelPrefix: "cost.example.com/",
+			want: map[string]string{
+				"cost.example.com/team":    "awesome-team",
+				"cost.example.com/project": "awesome-project",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: tt.labels,
+				},
+			}
+			got := buildTargetGroupTagsFromLabels(tgb, tt.labelPrefix)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_sanitizeTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tag       string
+		maxLength int
+		want      string
+	}{
+		{
+			name:      "valid tag",
+			tag:       "cost.example.com/team_a-1",
+			maxLength: 128,
+			want:      "cost.example.com/team_a-1",
+		},
+		{
+			name:      "tag with invalid characters",
+			tag:       "cost.example.com/team#a*1",
+			maxLength: 128,
+			want:      "cost.example.com/team_a_1",
+		},
+		{
+			name:      "tag exceeds max length",
+			tag:       "cost.example.com/" + strings.Repeat("a", 200),
+			maxLength: 128,
+			want:      "cost.example.com/" + strings.Repeat("a", 111),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeTag(tt.tag, tt.maxLength)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+const (
+	metricHealthyTargets   = "targetgroupbinding_healthy_targets"
+	metricUnhealthyTargets = "targetgroupbinding_unhealthy_targets"
+
+	labelNamespace      = "namespace"
+	labelName           = "name"
+	labelTargetGroupARN = "target_group_arn"
+)
+
+// TargetHealthMetricsCollector exports the count of healthy and unhealthy targets for each TargetGroupBinding.
+type TargetHealthMetricsCollector interface {
+	// Observe polls the health of targets in TargetGroups of tgb and records their counts.
+	Observe(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
+
+	// Forget removes the recorded counts for tgb.
+	Forget(tgbKey types.NamespacedName)
+}
+
+// NewDefaultTargetHealthMetricsCollector constructs new defaultTargetHealthMetricsCollector.
+// Metrics are registered to metricsRegisterer if it's not nil.
+func NewDefaultTargetHealthMetricsCollector(elbv2Client services.ELBV2, metricsRegisterer prometheus.Registerer) (*defaultTargetHealthMetricsCollector, error) {
+	healthyTargets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricHealthyTargets,
+		Help: "Number of healthy targets in TargetGroup, per TargetGroupBinding",
+	}, []string{labelNamespace, labelName, labelTargetGroupARN})
+	unhealthyTargets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricUnhealthyTargets,
+		Help: "Number of unhealthy targets in TargetGroup, per TargetGroupBinding",
+	}, []string{labelNamespace, labelName, labelTargetGroupARN})
+	if metricsRegisterer != nil {
+		for _, collector := range []prometheus.Collector{healthyTargets, unhealthyTargets} {
+			if err := metricsRegisterer.Register(collector); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &defaultTargetHealthMetricsCollector{
+		elbv2Client:         elbv2Client,
+		healthyTargets:      healthyTargets,
+		unhealthyTargets:    unhealthyTargets,
+		observedTGARNsByTGB: make(map[types.NamespacedName]sets.String),
+	}, nil
+}
+
+var _ TargetHealthMetricsCollector = &defaultTargetHealthMetricsCollector{}
+
+// defaultTargetHealthMetricsCollector is the default implementation for TargetHealthMetricsCollector.
+type defaultTargetHealthMetricsCollector struct {
+	elbv2Client      services.ELBV2
+	healthyTargets   *prometheus.GaugeVec
+	unhealthyTargets *prometheus.GaugeVec
+
+	// observedTGARNsByTGB tracks the TargetGroups recorded for each TargetGroupBinding,
+	// so that counts of TargetGroups no longer bound can be removed.
+	observedTGARNsByTGB      map[types.NamespacedName]sets.String
+	observedTGARNsByTGBMutex sync.Mutex
+}
+
+func (c *defaultTargetHealthMetricsCollector) Observe(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	tgbKey := k8s.NamespacedName(tgb)
+	healthyCountByTGARN := make(map[string]int)
+	unhealthyCountByTGARN := make(map[string]int)
+	for _, portMapping := range buildTargetGroupPortMappings(tgb) {
+		req := &elbv2sdk.DescribeTargetHealthInput{
+			TargetGroupArn: awssdk.String(portMapping.targetGroupARN),
+		}
+		resp, err := c.elbv2Client.DescribeTargetHealthWithContext(ctx, req)
+		if err != nil {
+			return err
+		}
+		healthyCount, unhealthyCount := 0, 0
+		for _, elem := range resp.TargetHealthDescriptions {
+			if elem.TargetHealth == nil {
+				continue
+			}
+			switch awssdk.StringValue(elem.TargetHealth.State) {
+			case elbv2sdk.TargetHealthStateEnumHealthy:
+				healthyCount++
+			case elbv2sdk.TargetHealthStateEnumUnhealthy:
+				unhealthyCount++
+			}
+		}
+		healthyCountByTGARN[portMapping.targetGroupARN] = healthyCount
+		unhealthyCountByTGARN[portMapping.targetGroupARN] = unhealthyCount
+	}
+
+	c.observedTGARNsByTGBMutex.Lock()
+	defer c.observedTGARNsByTGBMutex.Unlock()
+	tgARNs := sets.StringKeySet(healthyCountByTGARN)
+	for _, tgARN := range c.observedTGARNsByTGB[tgbKey].Difference(tgARNs).List() {
+		c.deleteCounts(tgbKey, tgARN)
+	}
+	for _, tgARN := range tgARNs.List() {
+		c.healthyTargets.With(c.buildLabels(tgbKey, tgARN)).Set(float64(healthyCountByTGARN[tgARN]))
+		c.unhealthyTargets.With(c.buildLabels(tgbKey, tgARN)).Set(float64(unhealthyCountByTGARN[tgARN]))
+	}
+	c.observedTGARNsByTGB[tgbKey] = tgARNs
+	return nil
+}
+
+func (c *defaultTargetHealthMetricsCollector) Forget(tgbKey types.NamespacedName) {
+	c.observedTGARNsByTGBMutex.Lock()
+	defer c.observedTGARNsByTGBMutex.Unlock()
+	for _, tgARN := range c.observedTGARNsByTGB[tgbKey].List() {
+		c.deleteCounts(tgbKey, tgARN)
+	}
+	delete(c.observedTGARNsByTGB, tgbKey)
+}
+
+func (c *defaultTargetHealthMetricsCollector) deleteCounts(tgbKey types.NamespacedName, tgARN string) {
+	c.healthyTargets.Delete(c.buildLabels(tgbKey, tgARN))
+	c.unhealthyTargets.Delete(c.buildLabels(tgbKey, tgARN))
+}
+
+func (c *defaultTargetHealthMetricsCollector) buildLabels(tgbKey types.NamespacedName, tgARN string) prometheus.Labels {
+	return prometheus.Labels{
+		labelNamespace:      tgbKey.Namespace,
+		labelName:           tgbKey.Name,
+		labelTargetGroupARN: tgARN,
+	}
+}
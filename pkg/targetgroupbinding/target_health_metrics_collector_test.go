@@ -0,0 +1,204 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+func buildTargetHealthDescriptions(states ...string) []*elbv2sdk.TargetHealthDescription {
+	var descriptions []*elbv2sdk.TargetHealthDescription
+	for _, state := range states {
+		descriptions = append(descriptions, &elbv2sdk.TargetHealthDescription{
+			Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(8080)},
+			TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(state)},
+		})
+	}
+	return descriptions
+}
+
+func Test_defaultTargetHealthMetricsCollector_Observe(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		tgARN string
+		resp  *elbv2sdk.DescribeTargetHealthOutput
+		err   error
+	}
+	type targetCounts struct {
+		healthy   float64
+		unhealthy float64
+	}
+	tests := []struct {
+		name                                 string
+		tgb                                  *elbv2api.TargetGroupBinding
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		want                                 map[string]targetCounts
+		wantErr                              error
+	}{
+		{
+			name: "counts healthy and unhealthy targets for each TargetGroup",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tgb"},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+						{
+							TargetGroupARN: "tg-2",
+							Port:           intstr.FromInt(443),
+						},
+					},
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					tgARN: "tg-1",
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: buildTargetHealthDescriptions(
+							elbv2sdk.TargetHealthStateEnumHealthy,
+							elbv2sdk.TargetHealthStateEnumHealthy,
+							elbv2sdk.TargetHealthStateEnumUnhealthy,
+							elbv2sdk.TargetHealthStateEnumInitial,
+							elbv2sdk.TargetHealthStateEnumDraining,
+						),
+					},
+				},
+				{
+					tgARN: "tg-2",
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: buildTargetHealthDescriptions(
+							elbv2sdk.TargetHealthStateEnumUnhealthy,
+						),
+					},
+				},
+			},
+			want: map[string]targetCounts{
+				"tg-1": {healthy: 2, unhealthy: 1},
+				"tg-2": {healthy: 0, unhealthy: 1},
+			},
+		},
+		{
+			name: "TargetGroup without targets",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tgb"},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					tgARN: "tg-1",
+					resp:  &elbv2sdk.DescribeTargetHealthOutput{},
+				},
+			},
+			want: map[string]targetCounts{
+				"tg-1": {healthy: 0, unhealthy: 0},
+			},
+		},
+		{
+			name: "failed to describe target health",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tgb"},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					tgARN: "tg-1",
+					err:   errors.New("some aws error"),
+				},
+			},
+			wantErr: errors.New("some aws error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+					TargetGroupArn: awssdk.String(call.tgARN),
+				}).Return(call.resp, call.err)
+			}
+			c, err := NewDefaultTargetHealthMetricsCollector(elbv2Client, prometheus.NewRegistry())
+			assert.NoError(t, err)
+			err = c.Observe(context.Background(), tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			for tgARN, counts := range tt.want {
+				gotHealthy := testutil.ToFloat64(c.healthyTargets.WithLabelValues("default", "tgb", tgARN))
+				gotUnhealthy := testutil.ToFloat64(c.unhealthyTargets.WithLabelValues("default", "tgb", tgARN))
+				assert.Equal(t, counts, targetCounts{healthy: gotHealthy, unhealthy: gotUnhealthy}, tgARN)
+			}
+		})
+	}
+}
+
+func Test_defaultTargetHealthMetricsCollector_Forget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	elbv2Client := services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: buildTargetHealthDescriptions(elbv2sdk.TargetHealthStateEnumHealthy),
+	}, nil).AnyTimes()
+	registry := prometheus.NewRegistry()
+	c, err := NewDefaultTargetHealthMetricsCollector(elbv2Client, registry)
+	assert.NoError(t, err)
+
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tgb"},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+			AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+				{
+					TargetGroupARN: "tg-2",
+					Port:           intstr.FromInt(443),
+				},
+			},
+		},
+	}
+	otherTGB := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other-tgb"},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-3",
+		},
+	}
+	assert.NoError(t, c.Observe(context.Background(), tgb))
+	assert.NoError(t, c.Observe(context.Background(), otherTGB))
+	assert.Equal(t, 6, countGatheredMetrics(t, registry))
+
+	// counts of TargetGroups no longer bound are removed once observed again.
+	tgb.Spec.AdditionalTargetGroups = nil
+	assert.NoError(t, c.Observe(context.Background(), tgb))
+	assert.Equal(t, 4, countGatheredMetrics(t, registry))
+
+	c.Forget(types.NamespacedName{Namespace: "default", Name: "tgb"})
+	assert.Equal(t, 2, countGatheredMetrics(t, registry))
+}
+
+func countGatheredMetrics(t *testing.T, gatherer prometheus.Gatherer) int {
+	metricFamilies, err := gatherer.Gather()
+	assert.NoError(t, err)
+	count := 0
+	for _, metricFamily := range metricFamilies {
+		count += len(metricFamily.GetMetric())
+	}
+	return count
+}
@@ -5,6 +5,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 )
 
@@ -35,3 +36,50 @@ func buildServiceReferenceKey(tgb *elbv2api.TargetGroupBinding, svcRef elbv2api.
 		Name:      svcRef.Name,
 	}
 }
+
+// targetGroupPortMapping maps a ServicePort to the TargetGroup its endpoints are registered into.
+type targetGroupPortMapping struct {
+	targetGroupARN string
+	servicePort    intstr.IntOrString
+	// targetPort overrides the port registered for ip targets if non-nil.
+	targetPort *intstr.IntOrString
+}
+
+// buildTargetGroupPortMappings builds the targetGroupPortMappings for TargetGroupBinding, the primary TargetGroup comes first.
+func buildTargetGroupPortMappings(tgb *elbv2api.TargetGroupBinding) []targetGroupPortMapping {
+	portMappings := make([]targetGroupPortMapping, 0, 1+len(tgb.Spec.AdditionalTargetGroups))
+	portMappings = append(portMappings, targetGroupPortMapping{
+		targetGroupARN: tgb.Spec.TargetGroupARN,
+		servicePort:    tgb.Spec.ServiceRef.Port,
+		targetPort:     tgb.Spec.TargetPort,
+	})
+	for _, additionalTG := range tgb.Spec.AdditionalTargetGroups {
+		portMappings = append(portMappings, targetGroupPortMapping{
+			targetGroupARN: additionalTG.TargetGroupARN,
+			servicePort:    additionalTG.Port,
+		})
+	}
+	return portMappings
+}
+
+// allBackendNotFound checks whether the backend of every TargetGroup is not found.
+func allBackendNotFound(backendNotFound []bool) bool {
+	for _, notFound := range backendNotFound {
+		if !notFound {
+			return false
+		}
+	}
+	return true
+}
+
+// buildPodIPFamilies builds the IP families of pod IPs to register for TargetGroups of ipAddressType.
+func buildPodIPFamilies(ipAddressType elbv2api.TargetGroupIPAddressType) []corev1.IPFamily {
+	switch ipAddressType {
+	case elbv2api.TargetGroupIPAddressTypeIPV6:
+		return []corev1.IPFamily{corev1.IPv6Protocol}
+	case elbv2api.TargetGroupIPAddressTypeDualStack:
+		return []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	default:
+		return []corev1.IPFamily{corev1.IPv4Protocol}
+	}
+}
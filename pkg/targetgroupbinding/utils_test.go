@@ -0,0 +1,148 @@
+package targetgroupbinding
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"testing"
+)
+
+func Test_buildTargetGroupPortMappings(t *testing.T) {
+	targetPort := intstr.FromString("proxy")
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	tests := []struct {
+		name string
+		args args
+		want []targetGroupPortMapping
+	}{
+		{
+			name: "only primary TargetGroup",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			want: []targetGroupPortMapping{
+				{
+					targetGroupARN: "tg-1",
+					servicePort:    intstr.FromInt(80),
+				},
+			},
+		},
+		{
+			name: "primary TargetGroup with additional TargetGroups",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+						TargetPort: &targetPort,
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromInt(443),
+							},
+							{
+								TargetGroupARN: "tg-3",
+								Port:           intstr.FromString("metrics"),
+							},
+						},
+					},
+				},
+			},
+			want: []targetGroupPortMapping{
+				{
+					targetGroupARN: "tg-1",
+					servicePort:    intstr.FromInt(80),
+					targetPort:     &targetPort,
+				},
+				{
+					targetGroupARN: "tg-2",
+					servicePort:    intstr.FromInt(443),
+				},
+				{
+					targetGroupARN: "tg-3",
+					servicePort:    intstr.FromString("metrics"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildTargetGroupPortMappings(tt.args.tgb)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildPodIPFamilies(t *testing.T) {
+	tests := []struct {
+		name          string
+		ipAddressType elbv2api.TargetGroupIPAddressType
+		want          []corev1.IPFamily
+	}{
+		{
+			name:          "ipv4",
+			ipAddressType: elbv2api.TargetGroupIPAddressTypeIPV4,
+			want:          []corev1.IPFamily{corev1.IPv4Protocol},
+		},
+		{
+			name:          "ipv6",
+			ipAddressType: elbv2api.TargetGroupIPAddressTypeIPV6,
+			want:          []corev1.IPFamily{corev1.IPv6Protocol},
+		},
+		{
+			name:          "dualstack",
+			ipAddressType: elbv2api.TargetGroupIPAddressTypeDualStack,
+			want:          []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPodIPFamilies(tt.ipAddressType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_allBackendNotFound(t *testing.T) {
+	tests := []struct {
+		name            string
+		backendNotFound []bool
+		want            bool
+	}{
+		{
+			name:            "backend of all TargetGroups not found",
+			backendNotFound: []bool{true, true},
+			want:            true,
+		},
+		{
+			name:            "backend of some TargetGroups not found",
+			backendNotFound: []bool{true, false},
+			want:            false,
+		},
+		{
+			name:            "backend of all TargetGroups found",
+			backendNotFound: []bool{false, false},
+			want:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allBackendNotFound(tt.backendNotFound)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
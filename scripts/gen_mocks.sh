@@ -7,7 +7,9 @@
 ~/go/bin/mockgen -package=webhook -destination=./pkg/webhook/validator_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/webhook Validator
 ~/go/bin/mockgen -package=k8s -destination=./pkg/k8s/finalizer_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/k8s FinalizerManager
 ~/go/bin/mockgen -package=k8s -destination=./pkg/k8s/pod_info_repo_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/k8s PodInfoRepo
+~/go/bin/mockgen -package=k8s -destination=./pkg/k8s/endpoints_repo_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/k8s EndpointsRepo
 ~/go/bin/mockgen -package=networking -destination=./pkg/networking/security_group_manager_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/networking SecurityGroupManager
+~/go/bin/mockgen -package=networking -destination=./pkg/networking/security_group_reconciler_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/networking SecurityGroupReconciler
 ~/go/bin/mockgen -package=networking -destination=./pkg/networking/subnet_resolver_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/networking SubnetsResolver
 ~/go/bin/mockgen -package=networking -destination=./pkg/networking/az_info_provider_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/networking AZInfoProvider
 ~/go/bin/mockgen -package=ingress -destination=./pkg/ingress/cert_discovery_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/ingress CertDiscovery
@@ -0,0 +1,58 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+// the TargetType of TargetGroup is immutable, so it's safe to cache it for a relatively long time.
+const defaultTargetTypeByTGARNCacheTTL = 30 * time.Minute
+
+// TargetTypeResolver resolves the TargetType of TargetGroups.
+type TargetTypeResolver interface {
+	// Resolve returns the TargetType of TargetGroup in AWS SDK format.
+	Resolve(ctx context.Context, tgARN string) (string, error)
+}
+
+// NewDefaultTargetTypeResolver constructs new defaultTargetTypeResolver.
+func NewDefaultTargetTypeResolver(elbv2Client services.ELBV2) *defaultTargetTypeResolver {
+	return &defaultTargetTypeResolver{
+		elbv2Client:               elbv2Client,
+		targetTypeByTGARNCache:    cache.NewExpiring(),
+		targetTypeByTGARNCacheTTL: defaultTargetTypeByTGARNCacheTTL,
+	}
+}
+
+var _ TargetTypeResolver = &defaultTargetTypeResolver{}
+
+// defaultTargetTypeResolver resolves TargetType via DescribeTargetGroups API, with results cached to limit API calls.
+type defaultTargetTypeResolver struct {
+	elbv2Client services.ELBV2
+
+	targetTypeByTGARNCache    *cache.Expiring
+	targetTypeByTGARNCacheTTL time.Duration
+}
+
+func (r *defaultTargetTypeResolver) Resolve(ctx context.Context, tgARN string) (string, error) {
+	if rawCacheItem, exists := r.targetTypeByTGARNCache.Get(tgARN); exists {
+		return rawCacheItem.(string), nil
+	}
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	}
+	tgList, err := r.elbv2Client.DescribeTargetGroupsAsList(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if len(tgList) != 1 {
+		return "", errors.Errorf("expecting a single targetGroup but got %v", len(tgList))
+	}
+	targetType := awssdk.StringValue(tgList[0].TargetType)
+	r.targetTypeByTGARNCache.Set(tgARN, targetType, r.targetTypeByTGARNCacheTTL)
+	return targetType, nil
+}
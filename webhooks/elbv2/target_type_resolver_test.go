@@ -0,0 +1,133 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"testing"
+)
+
+func Test_defaultTargetTypeResolver_Resolve(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+
+	type fields struct {
+		describeTargetGroupsAsListCalls []describeTargetGroupsAsListCall
+	}
+	type args struct {
+		tgARN string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "standard case - instance targetType",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetType: awssdk.String("instance"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-1",
+			},
+			want: "instance",
+		},
+		{
+			name: "standard case - ip targetType",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetType: awssdk.String("ip"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-1",
+			},
+			want: "ip",
+		},
+		{
+			name: "some error during describeTargetGroupCall",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						err: errors.New("targetGroup not found"),
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-1",
+			},
+			wantErr: errors.New("targetGroup not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			r := NewDefaultTargetTypeResolver(elbv2Client)
+			got, err := r.Resolve(context.Background(), tt.args.tgARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultTargetTypeResolver_Resolve_cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	elbv2Client := services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+	}).Return([]*elbv2sdk.TargetGroup{
+		{
+			TargetType: awssdk.String("ip"),
+		},
+	}, nil).Times(1)
+
+	r := NewDefaultTargetTypeResolver(elbv2Client)
+	for i := 0; i < 3; i++ {
+		got, err := r.Resolve(context.Background(), "tg-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "ip", got)
+	}
+}
@@ -158,8 +158,8 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 			}
 
 			m := &targetGroupBindingMutator{
-				elbv2Client: elbv2Client,
-				logger:      &log.NullLogger{},
+				targetTypeResolver: NewDefaultTargetTypeResolver(elbv2Client),
+				logger:             &log.NullLogger{},
 			}
 			got, err := m.MutateCreate(context.Background(), tt.args.obj)
 			if tt.wantErr != nil {
@@ -171,107 +171,3 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 		})
 	}
 }
-
-func Test_targetGroupBindingMutator_obtainSDKTargetTypeFromAWS(t *testing.T) {
-	type describeTargetGroupsAsListCall struct {
-		req  *elbv2sdk.DescribeTargetGroupsInput
-		resp []*elbv2sdk.TargetGroup
-		err  error
-	}
-
-	type fields struct {
-		describeTargetGroupsAsListCalls []describeTargetGroupsAsListCall
-	}
-	type args struct {
-		tgARN string
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    string
-		wantErr error
-	}{
-		{
-			name: "standard case - instance targetType",
-			fields: fields{
-				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
-					{
-						req: &elbv2sdk.DescribeTargetGroupsInput{
-							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
-						},
-						resp: []*elbv2sdk.TargetGroup{
-							{
-								TargetType: awssdk.String("instance"),
-							},
-						},
-					},
-				},
-			},
-			args: args{
-				tgARN: "tg-1",
-			},
-			want: "instance",
-		},
-		{
-			name: "standard case - ip targetType",
-			fields: fields{
-				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
-					{
-						req: &elbv2sdk.DescribeTargetGroupsInput{
-							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
-						},
-						resp: []*elbv2sdk.TargetGroup{
-							{
-								TargetType: awssdk.String("ip"),
-							},
-						},
-					},
-				},
-			},
-			args: args{
-				tgARN: "tg-1",
-			},
-			want: "ip",
-		},
-		{
-			name: "some error during describeTargetGroupCall",
-			fields: fields{
-				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
-					{
-						req: &elbv2sdk.DescribeTargetGroupsInput{
-							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
-						},
-						err: errors.New("targetGroup not found"),
-					},
-				},
-			},
-			args: args{
-				tgARN: "tg-1",
-			},
-			wantErr: errors.New("targetGroup not found"),
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-			elbv2Client := services.NewMockELBV2(ctrl)
-			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
-				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
-			}
-
-			m := &targetGroupBindingMutator{
-				elbv2Client: elbv2Client,
-				logger:      &log.NullLogger{},
-			}
-			got, err := m.obtainSDKTargetTypeFromAWS(context.Background(), tt.args.tgARN)
-			if tt.wantErr != nil {
-				assert.EqualError(t, err, tt.wantErr.Error())
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
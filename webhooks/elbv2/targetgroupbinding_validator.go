@@ -2,30 +2,55 @@ package elbv2
 
 import (
 	"context"
+	"reflect"
+	"strconv"
 	"strings"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-const apiPathValidateELBv2TargetGroupBinding = "/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding"
+const (
+	apiPathValidateELBv2TargetGroupBinding = "/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding"
 
-// NewTargetGroupBindingMutator returns a mutator for TargetGroupBinding CRD.
-func NewTargetGroupBindingValidator(logger logr.Logger) *targetGroupBindingValidator {
+	// healthCheckPortTrafficPort is the health check port that uses the port each target receives traffic on.
+	healthCheckPortTrafficPort = "traffic-port"
+)
+
+// NewTargetGroupBindingValidator returns a validator for TargetGroupBinding CRD.
+func NewTargetGroupBindingValidator(k8sClient client.Client, targetTypeResolver TargetTypeResolver, failClosedOnAWSErrors bool, logger logr.Logger) *targetGroupBindingValidator {
 	return &targetGroupBindingValidator{
-		logger: logger,
+		k8sClient:             k8sClient,
+		targetTypeResolver:    targetTypeResolver,
+		failClosedOnAWSErrors: failClosedOnAWSErrors,
+		logger:                logger,
 	}
 }
 
 var _ webhook.Validator = &targetGroupBindingValidator{}
 
 type targetGroupBindingValidator struct {
-	logger logr.Logger
+	k8sClient          client.Client
+	targetTypeResolver TargetTypeResolver
+	// whether to reject TargetGroupBindings when validations cannot be done due to AWS errors.
+	failClosedOnAWSErrors bool
+	logger                logr.Logger
 }
 
 func (v *targetGroupBindingValidator) Prototype(_ admission.Request) (runtime.Object, error) {
@@ -40,6 +65,33 @@ func (v *targetGroupBindingValidator) ValidateCreate(ctx context.Context, obj ru
 	if err := v.checkNodeSelector(tgb); err != nil {
 		return err
 	}
+	if err := v.checkTargetPort(tgb); err != nil {
+		return err
+	}
+	if err := v.checkPodSelector(tgb); err != nil {
+		return err
+	}
+	if err := v.checkPodReadinessConditionType(tgb); err != nil {
+		return err
+	}
+	if err := v.checkIPAddressType(tgb); err != nil {
+		return err
+	}
+	if err := v.checkAdditionalTargetGroups(ctx, tgb); err != nil {
+		return err
+	}
+	if err := v.checkHealthCheck(tgb); err != nil {
+		return err
+	}
+	if err := v.checkDeregistrationRateLimit(tgb); err != nil {
+		return err
+	}
+	if err := v.checkTargetGroupARNs(tgb); err != nil {
+		return err
+	}
+	if err := v.checkTargetGroupTargetTypes(ctx, tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -55,6 +107,33 @@ func (v *targetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj ru
 	if err := v.checkNodeSelector(tgb); err != nil {
 		return err
 	}
+	if err := v.checkTargetPort(tgb); err != nil {
+		return err
+	}
+	if err := v.checkPodSelector(tgb); err != nil {
+		return err
+	}
+	if err := v.checkPodReadinessConditionType(tgb); err != nil {
+		return err
+	}
+	if err := v.checkIPAddressType(tgb); err != nil {
+		return err
+	}
+	if err := v.checkAdditionalTargetGroups(ctx, tgb); err != nil {
+		return err
+	}
+	if err := v.checkHealthCheck(tgb); err != nil {
+		return err
+	}
+	if err := v.checkDeregistrationRateLimit(tgb); err != nil {
+		return err
+	}
+	if err := v.checkTargetGroupARNs(tgb); err != nil {
+		return err
+	}
+	if err := v.checkTargetGroupTargetTypes(ctx, tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -86,6 +165,9 @@ func (v *targetGroupBindingValidator) checkImmutableFields(tgb *elbv2api.TargetG
 	if tgb.Spec.TargetType != nil && oldTGB.Spec.TargetType != nil && (*tgb.Spec.TargetType) != (*oldTGB.Spec.TargetType) {
 		changedImmutableFields = append(changedImmutableFields, "spec.targetType")
 	}
+	if !reflect.DeepEqual(tgb.Spec.AdditionalTargetGroups, oldTGB.Spec.AdditionalTargetGroups) {
+		changedImmutableFields = append(changedImmutableFields, "spec.additionalTargetGroups")
+	}
 
 	if len(changedImmutableFields) != 0 {
 		return errors.Errorf("%s update may not change these fields: %s", "TargetGroupBinding", strings.Join(changedImmutableFields, ","))
@@ -101,6 +183,170 @@ func (v *targetGroupBindingValidator) checkNodeSelector(tgb *elbv2api.TargetGrou
 	return nil
 }
 
+// checkTargetPort ensures that TargetPort is only set when TargetType is ip
+func (v *targetGroupBindingValidator) checkTargetPort(tgb *elbv2api.TargetGroupBinding) error {
+	if (*tgb.Spec.TargetType == elbv2api.TargetTypeInstance) && (tgb.Spec.TargetPort != nil) {
+		return errors.Errorf("TargetGroupBinding cannot set TargetPort when TargetType is instance")
+	}
+	return nil
+}
+
+// checkPodSelector ensures that PodSelector is only set when TargetType is ip, and it's a valid label selector
+func (v *targetGroupBindingValidator) checkPodSelector(tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.PodSelector == nil {
+		return nil
+	}
+	if *tgb.Spec.TargetType == elbv2api.TargetTypeInstance {
+		return errors.Errorf("TargetGroupBinding cannot set PodSelector when TargetType is instance")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(tgb.Spec.PodSelector); err != nil {
+		return errors.Wrap(err, "invalid PodSelector")
+	}
+	return nil
+}
+
+// checkPodReadinessConditionType ensures that PodReadinessConditionType is only set when TargetType is ip, and it's a valid condition type
+func (v *targetGroupBindingValidator) checkPodReadinessConditionType(tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.PodReadinessConditionType == nil {
+		return nil
+	}
+	if *tgb.Spec.TargetType == elbv2api.TargetTypeInstance {
+		return errors.Errorf("TargetGroupBinding cannot set PodReadinessConditionType when TargetType is instance")
+	}
+	if errs := validation.IsQualifiedName(string(*tgb.Spec.PodReadinessConditionType)); len(errs) != 0 {
+		return errors.Errorf("invalid PodReadinessConditionType %q: %v", *tgb.Spec.PodReadinessConditionType, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// checkIPAddressType ensures that IPAddressType is only set when TargetType is ip
+func (v *targetGroupBindingValidator) checkIPAddressType(tgb *elbv2api.TargetGroupBinding) error {
+	if (*tgb.Spec.TargetType == elbv2api.TargetTypeInstance) && (tgb.Spec.IPAddressType != nil) {
+		return errors.Errorf("TargetGroupBinding cannot set IPAddressType when TargetType is instance")
+	}
+	return nil
+}
+
+// checkAdditionalTargetGroups ensures that each additional TargetGroup is specified, and TargetGroups and ServicePorts are not duplicated.
+func (v *targetGroupBindingValidator) checkAdditionalTargetGroups(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if len(tgb.Spec.AdditionalTargetGroups) == 0 {
+		return nil
+	}
+	svc, err := v.findService(ctx, tgb)
+	if err != nil {
+		return err
+	}
+	tgARNs := sets.NewString(tgb.Spec.TargetGroupARN)
+	servicePorts := sets.NewString(normalizeServicePort(svc, tgb.Spec.ServiceRef.Port))
+	for _, additionalTG := range tgb.Spec.AdditionalTargetGroups {
+		if additionalTG.TargetGroupARN == "" {
+			return errors.Errorf("TargetGroupBinding must specify targetGroupARN for additionalTargetGroups")
+		}
+		if tgARNs.Has(additionalTG.TargetGroupARN) {
+			return errors.Errorf("TargetGroupBinding cannot bind TargetGroup more than once: %v", additionalTG.TargetGroupARN)
+		}
+		servicePort := normalizeServicePort(svc, additionalTG.Port)
+		if servicePorts.Has(servicePort) {
+			return errors.Errorf("TargetGroupBinding cannot bind ServicePort more than once: %v", additionalTG.Port.String())
+		}
+		tgARNs.Insert(additionalTG.TargetGroupARN)
+		servicePorts.Insert(servicePort)
+	}
+	return nil
+}
+
+// findService returns the Service referenced by TargetGroupBinding, it's nil if the Service doesn't exist yet.
+func (v *targetGroupBindingValidator) findService(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (*corev1.Service, error) {
+	svc := &corev1.Service{}
+	svcKey := types.NamespacedName{Namespace: tgb.Namespace, Name: tgb.Spec.ServiceRef.Name}
+	if err := v.k8sClient.Get(ctx, svcKey, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return svc, nil
+}
+
+// normalizeServicePort normalizes port referencing ServicePort of svc, so that ports referencing the same ServicePort are equal.
+// port is resolved to the ServicePort's port number if it's found on svc, otherwise port numbers and numeric port names are equal.
+func normalizeServicePort(svc *corev1.Service, port intstr.IntOrString) string {
+	if svc != nil {
+		if servicePort, err := k8s.LookupServicePort(svc, port); err == nil {
+			return strconv.Itoa(int(servicePort.Port))
+		}
+	}
+	return port.String()
+}
+
+// checkHealthCheck ensures that healthCheck is specified when healthCheckReconcile is true, and it's a valid health check configuration.
+func (v *targetGroupBindingValidator) checkHealthCheck(tgb *elbv2api.TargetGroupBinding) error {
+	healthCheck := tgb.Spec.HealthCheck
+	if healthCheck == nil {
+		if awssdk.BoolValue(tgb.Spec.HealthCheckReconcile) {
+			return errors.Errorf("TargetGroupBinding must specify healthCheck when healthCheckReconcile is true")
+		}
+		return nil
+	}
+	if healthCheck.Port != nil && healthCheck.Port.Type == intstr.String && healthCheck.Port.StrVal != healthCheckPortTrafficPort {
+		return errors.Errorf("TargetGroupBinding has invalid healthCheck port: %v", healthCheck.Port.StrVal)
+	}
+	if healthCheck.Protocol != nil && *healthCheck.Protocol == elbv2api.TargetGroupHealthCheckProtocolTCP &&
+		(healthCheck.Path != nil || healthCheck.HTTPCode != nil) {
+		return errors.Errorf("TargetGroupBinding cannot set healthCheck path or httpCode when protocol is TCP")
+	}
+	return nil
+}
+
+// checkDeregistrationRateLimit ensures that deregistrationRateLimit is positive if specified.
+func (v *targetGroupBindingValidator) checkDeregistrationRateLimit(tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.DeregistrationRateLimit != nil && *tgb.Spec.DeregistrationRateLimit <= 0 {
+		return errors.Errorf("TargetGroupBinding deregistrationRateLimit must be greater than 0, got: %v", *tgb.Spec.DeregistrationRateLimit)
+	}
+	return nil
+}
+
+// checkTargetGroupARNs ensures that TargetGroup ARNs are valid ELBV2 TargetGroup ARNs.
+func (v *targetGroupBindingValidator) checkTargetGroupARNs(tgb *elbv2api.TargetGroupBinding) error {
+	for _, tgARN := range listTargetGroupARNs(tgb) {
+		parsedARN, err := arn.Parse(tgARN)
+		if err != nil || parsedARN.Service != "elasticloadbalancing" || !strings.HasPrefix(parsedARN.Resource, "targetgroup/") {
+			return errors.Errorf("TargetGroupBinding has invalid targetGroupARN: %v", tgARN)
+		}
+	}
+	return nil
+}
+
+// checkTargetGroupTargetTypes ensures that TargetType matches the actual TargetType of TargetGroups.
+// validation is skipped if TargetType cannot be resolved due to AWS errors, unless failClosedOnAWSErrors is set.
+func (v *targetGroupBindingValidator) checkTargetGroupTargetTypes(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	for _, tgARN := range listTargetGroupARNs(tgb) {
+		sdkTargetType, err := v.targetTypeResolver.Resolve(ctx, tgARN)
+		if err != nil {
+			if v.failClosedOnAWSErrors {
+				return errors.Wrapf(err, "couldn't determine TargetType of targetGroup %v", tgARN)
+			}
+			v.logger.Info("skipping TargetType validation due to AWS error",
+				"targetGroupBinding", k8s.NamespacedName(tgb), "targetGroupARN", tgARN, "error", err.Error())
+			continue
+		}
+		if sdkTargetType != string(*tgb.Spec.TargetType) {
+			return errors.Errorf("TargetGroupBinding targetType %v conflicts with targetGroup %v of TargetType %v",
+				*tgb.Spec.TargetType, tgARN, sdkTargetType)
+		}
+	}
+	return nil
+}
+
+// listTargetGroupARNs returns the ARNs of all TargetGroups bound by TargetGroupBinding.
+func listTargetGroupARNs(tgb *elbv2api.TargetGroupBinding) []string {
+	tgARNs := []string{tgb.Spec.TargetGroupARN}
+	for _, additionalTG := range tgb.Spec.AdditionalTargetGroups {
+		tgARNs = append(tgARNs, additionalTG.TargetGroupARN)
+	}
+	return tgARNs
+}
+
 // +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=create;update,versions=v1beta1,name=vtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *targetGroupBindingValidator) SetupWithManager(mgr ctrl.Manager) {
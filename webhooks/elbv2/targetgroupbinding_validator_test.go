@@ -4,21 +4,41 @@ import (
 	"context"
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func Test_targetGroupBindingValidator_ValidateCreate(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsAsListCalls []describeTargetGroupsAsListCall
+		failClosedOnAWSErrors           bool
+	}
 	type args struct {
 		obj *elbv2api.TargetGroupBinding
 	}
 	instanceTargetType := elbv2api.TargetTypeInstance
 	ipTargetType := elbv2api.TargetTypeIP
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
 	tests := []struct {
 		name    string
+		fields  fields
 		args    args
 		wantErr error
 	}{
@@ -27,7 +47,7 @@ func Test_targetGroupBindingValidator_ValidateCreate(t *testing.T) {
 			args: args{
 				obj: &elbv2api.TargetGroupBinding{
 					Spec: elbv2api.TargetGroupBindingSpec{
-						TargetGroupARN: "tg-2",
+						TargetGroupARN: tgARN,
 						TargetType:     nil,
 					},
 				},
@@ -36,10 +56,25 @@ func Test_targetGroupBindingValidator_ValidateCreate(t *testing.T) {
 		},
 		{
 			name: "targetType is set",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String(tgARN),
+								TargetType:     awssdk.String("instance"),
+							},
+						},
+					},
+				},
+			},
 			args: args{
 				obj: &elbv2api.TargetGroupBinding{
 					Spec: elbv2api.TargetGroupBindingSpec{
-						TargetGroupARN: "tg-2",
+						TargetGroupARN: tgARN,
 						TargetType:     &instanceTargetType,
 					},
 				},
@@ -51,18 +86,124 @@ func Test_targetGroupBindingValidator_ValidateCreate(t *testing.T) {
 			args: args{
 				obj: &elbv2api.TargetGroupBinding{
 					Spec: elbv2api.TargetGroupBindingSpec{
-						TargetType:   &ipTargetType,
-						NodeSelector: &v1.LabelSelector{},
+						TargetGroupARN: tgARN,
+						TargetType:     &ipTargetType,
+						NodeSelector:   &v1.LabelSelector{},
 					},
 				},
 			},
 			wantErr: errors.New("TargetGroupBinding cannot set NodeSelector when TargetType is ip"),
 		},
+		{
+			name: "[err] targetGroupARN is invalid",
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid targetGroupARN: tg-1"),
+		},
+		{
+			name: "[err] targetGroupARN is not a targetGroup",
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188",
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid targetGroupARN: arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"),
+		},
+		{
+			name: "[err] targetType conflicts with targetGroup",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String(tgARN),
+								TargetType:     awssdk.String("ip"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: tgARN,
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding targetType instance conflicts with targetGroup " + tgARN + " of TargetType ip"),
+		},
+		{
+			name: "[ok] AWS error with fail open",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+						},
+						err: errors.New("some AWS API error"),
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: tgARN,
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] AWS error with fail closed",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+						},
+						err: errors.New("some AWS API error"),
+					},
+				},
+				failClosedOnAWSErrors: true,
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: tgARN,
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("couldn't determine TargetType of targetGroup " + tgARN + ": some AWS API error"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
 			v := &targetGroupBindingValidator{
-				logger: &log.NullLogger{},
+				targetTypeResolver:    NewDefaultTargetTypeResolver(elbv2Client),
+				failClosedOnAWSErrors: tt.fields.failClosedOnAWSErrors,
+				logger:                &log.NullLogger{},
 			}
 			err := v.ValidateCreate(context.Background(), tt.args.obj)
 			if tt.wantErr != nil {
@@ -75,14 +216,24 @@ func Test_targetGroupBindingValidator_ValidateCreate(t *testing.T) {
 }
 
 func Test_targetGroupBindingValidator_ValidateUpdate(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsAsListCalls []describeTargetGroupsAsListCall
+	}
 	instanceTargetType := elbv2api.TargetTypeInstance
 	ipTargetType := elbv2api.TargetTypeIP
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
 	type args struct {
 		obj    *elbv2api.TargetGroupBinding
 		oldObj *elbv2api.TargetGroupBinding
 	}
 	tests := []struct {
 		name    string
+		fields  fields
 		args    args
 		wantErr error
 	}{
@@ -140,17 +291,85 @@ func Test_targetGroupBindingValidator_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: errors.New("TargetGroupBinding cannot set NodeSelector when TargetType is ip"),
 		},
+		{
+			name: "[err] invalid targetGroupARN",
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+					},
+				},
+				oldObj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid targetGroupARN: tg-1"),
+		},
+		{
+			name: "[err] targetType conflicts with targetGroup",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String(tgARN),
+								TargetType:     awssdk.String("instance"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: tgARN,
+						TargetType:     &ipTargetType,
+					},
+				},
+				oldObj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: tgARN,
+						TargetType:     &ipTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding targetType ip conflicts with targetGroup " + tgARN + " of TargetType instance"),
+		},
 		{
 			name: "[ok] no update to spec",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String(tgARN),
+								TargetType:     awssdk.String("ip"),
+							},
+						},
+					},
+				},
+			},
 			args: args{
 				obj: &elbv2api.TargetGroupBinding{
 					Spec: elbv2api.TargetGroupBindingSpec{
-						TargetType: &ipTargetType,
+						TargetGroupARN: tgARN,
+						TargetType:     &ipTargetType,
 					},
 				},
 				oldObj: &elbv2api.TargetGroupBinding{
 					Spec: elbv2api.TargetGroupBindingSpec{
-						TargetType: &ipTargetType,
+						TargetGroupARN: tgARN,
+						TargetType:     &ipTargetType,
 					},
 				},
 			},
@@ -159,8 +378,16 @@ func Test_targetGroupBindingValidator_ValidateUpdate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
 			v := &targetGroupBindingValidator{
-				logger: &log.NullLogger{},
+				targetTypeResolver: NewDefaultTargetTypeResolver(elbv2Client),
+				logger:             &log.NullLogger{},
 			}
 			err := v.ValidateUpdate(context.Background(), tt.args.obj, tt.args.oldObj)
 			if tt.wantErr != nil {
@@ -252,6 +479,36 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 			},
 			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.targetGroupARN"),
 		},
+		{
+			name: "additionalTargetGroups is changed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &instanceTargetType,
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-3",
+								Port:           intstr.FromInt(443),
+							},
+						},
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &instanceTargetType,
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromInt(443),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.additionalTargetGroups"),
+		},
 		{
 			name: "targetType is changed",
 			args: args{
@@ -431,3 +688,700 @@ func Test_targetGroupBindingValidator_checkNodeSelector(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupBindingValidator_checkTargetPort(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	targetPort := intstr.FromString("proxy")
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] targetType is ip, targetPort is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, targetPort is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+						TargetPort: &targetPort,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is instance, targetPort is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is instance, targetPort is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+						TargetPort: &targetPort,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set TargetPort when TargetType is instance"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkTargetPort(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkAdditionalTargetGroups(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name: "http",
+					Port: 80,
+				},
+				{
+					Name: "https",
+					Port: 443,
+				},
+			},
+		},
+	}
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+		svc *corev1.Service
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] no additionalTargetGroups",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] distinct additionalTargetGroups",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromInt(443),
+							},
+							{
+								TargetGroupARN: "tg-3",
+								Port:           intstr.FromString("metrics"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] additionalTargetGroups with distinct servicePorts on service",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Namespace: "awesome-ns",
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromString("http"),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromInt(443),
+							},
+						},
+					},
+				},
+				svc: svc,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] additionalTargetGroups duplicates servicePort number as string",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromString("80"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot bind ServicePort more than once: 80"),
+		},
+		{
+			name: "[err] additionalTargetGroups duplicates servicePort by name",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Namespace: "awesome-ns",
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(443),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromString("https"),
+							},
+						},
+					},
+				},
+				svc: svc,
+			},
+			wantErr: errors.New("TargetGroupBinding cannot bind ServicePort more than once: https"),
+		},
+		{
+			name: "[err] additionalTargetGroups without targetGroupARN",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								Port: intstr.FromInt(443),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding must specify targetGroupARN for additionalTargetGroups"),
+		},
+		{
+			name: "[err] additionalTargetGroups duplicates primary targetGroupARN",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-1",
+								Port:           intstr.FromInt(443),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot bind TargetGroup more than once: tg-1"),
+		},
+		{
+			name: "[err] additionalTargetGroups duplicates servicePort",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						ServiceRef: elbv2api.ServiceReference{
+							Name: "svc-1",
+							Port: intstr.FromInt(80),
+						},
+						AdditionalTargetGroups: []elbv2api.TargetGroupPortMapping{
+							{
+								TargetGroupARN: "tg-2",
+								Port:           intstr.FromInt(443),
+							},
+							{
+								TargetGroupARN: "tg-3",
+								Port:           intstr.FromInt(443),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot bind ServicePort more than once: 443"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			if tt.args.svc != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.args.svc.DeepCopy()))
+			}
+			v := &targetGroupBindingValidator{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+			err := v.checkAdditionalTargetGroups(ctx, tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkPodSelector(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] targetType is ip, podSelector is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, podSelector is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+						PodSelector: &v1.LabelSelector{
+							MatchLabels: map[string]string{"role": "web"},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is ip, podSelector is invalid",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+						PodSelector: &v1.LabelSelector{
+							MatchExpressions: []v1.LabelSelectorRequirement{
+								{
+									Key:      "role",
+									Operator: "Matches",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid PodSelector: \"Matches\" is not a valid pod selector operator"),
+		},
+		{
+			name: "[err] targetType is instance, podSelector is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+						PodSelector: &v1.LabelSelector{
+							MatchLabels: map[string]string{"role": "web"},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set PodSelector when TargetType is instance"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkPodSelector(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkPodReadinessConditionType(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	warmedUpConditionType := corev1.PodConditionType("example.com/warmed-up")
+	invalidConditionType := corev1.PodConditionType("warmed up")
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] targetType is ip, podReadinessConditionType is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, podReadinessConditionType is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:                &ipTargetType,
+						PodReadinessConditionType: &warmedUpConditionType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is ip, podReadinessConditionType is invalid",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:                &ipTargetType,
+						PodReadinessConditionType: &invalidConditionType,
+					},
+				},
+			},
+			wantErr: errors.New("invalid PodReadinessConditionType \"warmed up\": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+		},
+		{
+			name: "[err] targetType is instance, podReadinessConditionType is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:                &instanceTargetType,
+						PodReadinessConditionType: &warmedUpConditionType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set PodReadinessConditionType when TargetType is instance"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkPodReadinessConditionType(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkIPAddressType(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	dualStackIPAddressType := elbv2api.TargetGroupIPAddressTypeDualStack
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] targetType is ip, ipAddressType is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, ipAddressType is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:    &ipTargetType,
+						IPAddressType: &dualStackIPAddressType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is instance, ipAddressType is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is instance, ipAddressType is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:    &instanceTargetType,
+						IPAddressType: &dualStackIPAddressType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set IPAddressType when TargetType is instance"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkIPAddressType(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkHealthCheck(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	trafficPort := intstr.FromString("traffic-port")
+	invalidPort := intstr.FromString("http")
+	protocolHTTP := elbv2api.TargetGroupHealthCheckProtocolHTTP
+	protocolTCP := elbv2api.TargetGroupHealthCheckProtocolTCP
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] healthCheckReconcile and healthCheck are nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] healthCheckReconcile is true, healthCheck is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheckReconcile: awssdk.Bool(true),
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Port:     &trafficPort,
+							Protocol: &protocolHTTP,
+							Path:     awssdk.String("/healthz"),
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] healthCheckReconcile is true, healthCheck is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheckReconcile: awssdk.Bool(true),
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding must specify healthCheck when healthCheckReconcile is true"),
+		},
+		{
+			name: "[err] healthCheck port is named port",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Port: &invalidPort,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid healthCheck port: http"),
+		},
+		{
+			name: "[err] healthCheck protocol is TCP, path is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Protocol: &protocolTCP,
+							Path:     awssdk.String("/healthz"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set healthCheck path or httpCode when protocol is TCP"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkHealthCheck(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkDeregistrationRateLimit(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] deregistrationRateLimit is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] deregistrationRateLimit is positive",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						DeregistrationRateLimit: awssdk.Int64(5),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] deregistrationRateLimit is zero",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						DeregistrationRateLimit: awssdk.Int64(0),
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding deregistrationRateLimit must be greater than 0, got: 0"),
+		},
+		{
+			name: "[err] deregistrationRateLimit is negative",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						DeregistrationRateLimit: awssdk.Int64(-1),
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding deregistrationRateLimit must be greater than 0, got: -1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkDeregistrationRateLimit(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
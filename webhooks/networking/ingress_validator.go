@@ -134,12 +134,22 @@ func (v *ingressValidator) checkGroupNameAnnotationUsage(ing *networking.Ingress
 	return nil
 }
 
+// checkIngressClassUsage checks the usage of "ingressClassName" field.
+// the "group.name" annotation must not conflict with the group defined in associated IngressClassParams.
 func (v *ingressValidator) checkIngressClassUsage(ctx context.Context, ing *networking.Ingress) error {
 	if ing.Spec.IngressClassName != nil {
-		_, err := v.classLoader.Load(ctx, ing)
+		ingClassConfig, err := v.classLoader.Load(ctx, ing)
 		if err != nil {
 			return err
 		}
+		if ingClassConfig.IngClassParams != nil && ingClassConfig.IngClassParams.Spec.Group != nil {
+			classGroupName := ingClassConfig.IngClassParams.Spec.Group.Name
+			groupName := ""
+			if exists := v.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &groupName, ing.Annotations); exists && groupName != classGroupName {
+				return errors.Errorf("conflicting group name, `%s/%s` annotation: %v, IngressClassParams %v: %v",
+					annotations.AnnotationPrefixIngress, annotations.IngressSuffixGroupName, groupName, ingClassConfig.IngClassParams.Name, classGroupName)
+			}
+		}
 	}
 	return nil
 }
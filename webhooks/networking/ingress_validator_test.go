@@ -651,6 +651,100 @@ func Test_ingressValidator_checkIngressClassUsage(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "IngressClassParams group matches group.name annotation",
+			env: env{
+				ingClassList: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "awesome-class",
+						},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+							Parameters: &corev1.TypedLocalObjectReference{
+								APIGroup: awssdk.String("elbv2.k8s.aws"),
+								Kind:     "IngressClassParams",
+								Name:     "awesome-class-params",
+							},
+						},
+					},
+				},
+				ingClassParamsList: []*elbv2api.IngressClassParams{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "awesome-class-params",
+						},
+						Spec: elbv2api.IngressClassParamsSpec{
+							Group: &elbv2api.IngressGroup{
+								Name: "awesome-group",
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "awesome-group",
+						},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("awesome-class"),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "IngressClassParams group conflicts with group.name annotation",
+			env: env{
+				ingClassList: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "awesome-class",
+						},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+							Parameters: &corev1.TypedLocalObjectReference{
+								APIGroup: awssdk.String("elbv2.k8s.aws"),
+								Kind:     "IngressClassParams",
+								Name:     "awesome-class-params",
+							},
+						},
+					},
+				},
+				ingClassParamsList: []*elbv2api.IngressClassParams{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "awesome-class-params",
+						},
+						Spec: elbv2api.IngressClassParamsSpec{
+							Group: &elbv2api.IngressGroup{
+								Name: "awesome-group",
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "another-group",
+						},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("awesome-class"),
+					},
+				},
+			},
+			wantErr: errors.New("conflicting group name, `alb.ingress.kubernetes.io/group.name` annotation: another-group, IngressClassParams awesome-class-params: awesome-group"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -673,7 +767,8 @@ func Test_ingressValidator_checkIngressClassUsage(t *testing.T) {
 			}
 
 			v := &ingressValidator{
-				classLoader: ingress.NewDefaultClassLoader(k8sClient),
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:      ingress.NewDefaultClassLoader(k8sClient),
 			}
 			err := v.checkIngressClassUsage(ctx, tt.args.ing)
 			if tt.wantErr != nil {